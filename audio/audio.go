@@ -37,6 +37,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"runtime"
 	"sync"
 	"time"
@@ -72,6 +73,10 @@ type Context struct {
 
 	players map[*playerImpl]struct{}
 
+	underrunCallback func()
+	underruns        int
+	bufferedSize     int
+
 	m         sync.Mutex
 	semaphore chan struct{}
 }
@@ -89,6 +94,53 @@ var (
 //
 // NewContext panics when an audio context is already created.
 func NewContext(sampleRate int) *Context {
+	return NewContextWithOptions(sampleRate, nil)
+}
+
+// ContextOptions represents options for NewContextWithOptions.
+type ContextOptions struct {
+	// BufferSize specifies the target buffer size of the underlying audio device.
+	//
+	// A smaller buffer size reduces the latency, which is important for e.g. rhythm games,
+	// but the audio is more likely to glitch due to buffer underruns.
+	// A bigger buffer size makes the audio more stable against slow sources and GC pauses,
+	// but increases the latency.
+	//
+	// BufferSize is clamped to platform-safe bounds.
+	// The minimum is 5[ms] on desktops, 10[ms] on mobiles, and 20[ms] on browsers. The maximum is 1[s].
+	//
+	// The default (zero) value is 0, which means the platform's default buffer size is used.
+	BufferSize time.Duration
+
+	// HighPriority specifies whether the audio is given a higher priority where the platform allows it,
+	// so that the audio mixing is less likely to be starved under heavy load.
+	//
+	// The audio mixing runs on threads owned by the audio driver, and their priorities cannot be changed individually.
+	// Instead, HighPriority raises the priority of the whole process:
+	//
+	//   - On Windows, the timer resolution is set to 1[ms] by timeBeginPeriod, and the process priority class is set to ABOVE_NORMAL_PRIORITY_CLASS.
+	//   - On macOS, a latency-critical user-initiated activity is declared by NSProcessInfo, which disables App Nap and timer coalescing.
+	//   - On the other platforms, HighPriority does nothing.
+	//
+	// As a tradeoff, HighPriority increases the power consumption, and might make the other processes less responsive.
+	// A higher timer resolution on Windows affects the whole system on some Windows versions.
+	// The priority is kept until the process ends.
+	//
+	// The default (zero) value is false.
+	HighPriority bool
+}
+
+// NewContextWithOptions creates a new audio context with the given sample rate and options.
+//
+// If options is nil, NewContextWithOptions behaves the same as NewContext.
+//
+// NewContextWithOptions panics when an audio context is already created.
+func NewContextWithOptions(sampleRate int, options *ContextOptions) *Context {
+	var bufferSize time.Duration
+	if options != nil {
+		bufferSize = clampBufferSize(options.BufferSize)
+	}
+
 	theContextLock.Lock()
 	defer theContextLock.Unlock()
 
@@ -98,13 +150,19 @@ func NewContext(sampleRate int) *Context {
 
 	c := &Context{
 		sampleRate:    sampleRate,
-		playerFactory: newPlayerFactory(sampleRate),
+		playerFactory: newPlayerFactory(sampleRate, bufferSize),
 		players:       map[*playerImpl]struct{}{},
 		inited:        make(chan struct{}),
 		semaphore:     make(chan struct{}, 1),
 	}
 	theContext = c
 
+	if options != nil && options.HighPriority {
+		if err := raisePriority(); err != nil {
+			c.setError(err)
+		}
+	}
+
 	h := getHook()
 	h.OnSuspendAudio(func() error {
 		c.semaphore <- struct{}{}
@@ -145,6 +203,32 @@ func NewContext(sampleRate int) *Context {
 	return c
 }
 
+const maxBufferSize = time.Second
+
+func minBufferSize() time.Duration {
+	switch runtime.GOOS {
+	case "js":
+		return 20 * time.Millisecond
+	case "android", "ios":
+		return 10 * time.Millisecond
+	default:
+		return 5 * time.Millisecond
+	}
+}
+
+func clampBufferSize(bufferSize time.Duration) time.Duration {
+	if bufferSize <= 0 {
+		return 0
+	}
+	if m := minBufferSize(); bufferSize < m {
+		return m
+	}
+	if bufferSize > maxBufferSize {
+		return maxBufferSize
+	}
+	return bufferSize
+}
+
 // CurrentContext returns the current context or nil if there is no context.
 func CurrentContext() *Context {
 	theContextLock.Lock()
@@ -209,6 +293,8 @@ func (c *Context) gcPlayers() error {
 	c.m.Unlock()
 
 	var playersToRemove []*playerImpl
+	var underruns int
+	bufferedSize := -1
 
 	// Now reader players cannot call removePlayers from themselves in the current implementation.
 	// Underlying playering can be the pause state after fishing its playing,
@@ -218,20 +304,80 @@ func (c *Context) gcPlayers() error {
 		if err := p.Err(); err != nil {
 			return err
 		}
-		if !p.IsPlaying() {
+		size, underrun, playing := p.checkBuffer()
+		if !playing {
 			playersToRemove = append(playersToRemove, p)
+			continue
+		}
+		if underrun {
+			underruns++
+		}
+		if bufferedSize < 0 || size < bufferedSize {
+			bufferedSize = size
 		}
 	}
+	if bufferedSize < 0 {
+		bufferedSize = 0
+	}
 
 	c.m.Lock()
 	for _, p := range playersToRemove {
 		delete(c.players, p)
 	}
+	c.underruns += underruns
+	c.bufferedSize = bufferedSize
+	f := c.underrunCallback
 	c.m.Unlock()
 
+	if f != nil && underruns > 0 {
+		f()
+	}
+
 	return nil
 }
 
+// SetUnderrunCallback sets a callback function that is called when a buffer underrun is detected.
+//
+// A buffer underrun happens when a playing player's buffer becomes empty before its source reaches the end,
+// which is usually heard as a glitch.
+// A buffer underrun is often caused by a slow source or a GC pause.
+// In this case, increasing the buffer size by (*Player).SetBufferSize might help.
+//
+// Buffer underruns are checked once per tick, so f is called on the game's goroutine, not on the audio thread.
+// f is called at most once per tick even if multiple players have underruns.
+// If a player keeps starving, the underrun is counted and reported only once until the player recovers.
+//
+// If f is nil, the callback is unset.
+func (c *Context) SetUnderrunCallback(f func()) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.underrunCallback = f
+}
+
+// Stats represents statistics of an audio context.
+type Stats struct {
+	// Underruns is the total number of buffer underruns detected so far.
+	Underruns int
+
+	// BufferedDuration is the duration of the buffered data of the most starving playing player.
+	// BufferedDuration is 0 when there is no playing player.
+	BufferedDuration time.Duration
+}
+
+// Stats returns the statistics of the audio context.
+//
+// The statistics are updated once per tick.
+func (c *Context) Stats() Stats {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	samples := c.bufferedSize / bytesPerSampleInt16
+	return Stats{
+		Underruns:        c.underruns,
+		BufferedDuration: time.Duration(samples) * time.Second / time.Duration(c.sampleRate),
+	}
+}
+
 // IsReady returns a boolean value indicating whether the audio is ready or not.
 //
 // On some browsers, user interaction like click or pressing keys is required to start audio.
@@ -430,6 +576,73 @@ func (p *Player) SetVolume(volume float64) {
 	p.p.SetVolume(volume)
 }
 
+// VolumeDB returns the current volume of this player in decibels.
+//
+// VolumeDB returns 0 for the volume 1, and negative infinity for the volume 0.
+func (p *Player) VolumeDB() float64 {
+	v := p.Volume()
+	if v <= 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(v)
+}
+
+// SetVolumeDB sets the volume of this player in decibels.
+//
+// 0 dB is the original volume (the same as SetVolume(1)), and each -20 dB multiplies the linear volume by 0.1.
+// db is clamped to 0 or lower, as the volume cannot exceed 1.
+// Negative infinity makes the player silent.
+// SetVolumeDB panics if db is NaN.
+func (p *Player) SetVolumeDB(db float64) {
+	if math.IsNaN(db) {
+		panic("audio: db must not be NaN at SetVolumeDB")
+	}
+	if db > 0 {
+		db = 0
+	}
+	p.SetVolume(math.Pow(10, db/20))
+}
+
+// SetLowpassCutoff sets the cutoff frequency in Hz of the low-pass filter applied to this player.
+//
+// The filter is a second-order Butterworth filter applied to the PCM at the context's sample rate.
+// The cutoff frequency can be changed every frame smoothly, e.g. to sweep the cutoff frequency.
+// If hz is 0 or less, the low-pass filter is disabled and bypassed. The default value is 0.
+// hz is clamped below the Nyquist frequency.
+func (p *Player) SetLowpassCutoff(hz float64) {
+	p.p.SetLowpassCutoff(hz)
+}
+
+// SetHighpassCutoff sets the cutoff frequency in Hz of the high-pass filter applied to this player.
+//
+// The filter is a second-order Butterworth filter applied to the PCM at the context's sample rate.
+// The cutoff frequency can be changed every frame smoothly, e.g. to sweep the cutoff frequency.
+// If hz is 0 or less, the high-pass filter is disabled and bypassed. The default value is 0.
+// hz is clamped below the Nyquist frequency.
+func (p *Player) SetHighpassCutoff(hz float64) {
+	p.p.SetHighpassCutoff(hz)
+}
+
+// Pan returns the current stereo panning of this player in between -1 and 1.
+func (p *Player) Pan() float64 {
+	return p.p.Pan()
+}
+
+// SetPan sets the stereo panning of this player.
+// -1 is full left, 0 is the center, and 1 is full right. The default value is 0.
+// pan must be in between -1 and 1. SetPan panics otherwise.
+//
+// The panning uses the constant-power law so that the perceived loudness stays even.
+// At the center, the source is played as it is.
+// At full left or right, the nearer channel is boosted by +3 dB and the other channel is silent.
+// As the boosted samples are clamped, reduce the volume if the source is loud enough to clip.
+func (p *Player) SetPan(pan float64) {
+	if pan < -1 || pan > 1 || math.IsNaN(pan) {
+		panic(fmt.Sprintf("audio: pan must be in between -1 and 1 at SetPan but %f", pan))
+	}
+	p.p.SetPan(pan)
+}
+
 // SetBufferSize adjusts the buffer size of the player.
 // If 0 is specified, the default buffer size is used.
 // A small buffer size is useful if you want to play a real-time PCM for example.
@@ -16,6 +16,8 @@ package audio_test
 
 import (
 	"bytes"
+	"io"
+	"math"
 	"runtime"
 	"testing"
 	"time"
@@ -116,3 +118,161 @@ func TestPauseBeforeInit(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+type blockingReader struct {
+	ch chan struct{}
+}
+
+func (b *blockingReader) Read(buf []byte) (int, error) {
+	<-b.ch
+	return 0, io.EOF
+}
+
+func TestUnderrun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var called int
+	context.SetUnderrunCallback(func() {
+		called++
+	})
+
+	src := &blockingReader{ch: make(chan struct{})}
+	p, err := context.NewPlayer(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Play()
+
+	// The source blocks and the buffer is empty, so this is an underrun.
+	for i := 0; i < 3; i++ {
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The player keeps starving, so the underrun is counted only once.
+	if got, want := context.Stats().Underruns, 1; got != want {
+		t.Errorf("Underruns: got: %d, want: %d", got, want)
+	}
+	if got, want := called, 1; got != want {
+		t.Errorf("called: got: %d, want: %d", got, want)
+	}
+	if got, want := context.Stats().BufferedDuration, time.Duration(0); got != want {
+		t.Errorf("BufferedDuration: got: %v, want: %v", got, want)
+	}
+
+	close(src.ch)
+	p.Pause()
+}
+
+func TestClampBufferSize(t *testing.T) {
+	if got, want := audio.ClampBufferSizeForTesting(0), time.Duration(0); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := audio.ClampBufferSizeForTesting(100*time.Millisecond), 100*time.Millisecond; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := audio.ClampBufferSizeForTesting(time.Nanosecond), time.Nanosecond; got <= want {
+		t.Errorf("got: %v, want: > %v", got, want)
+	}
+	if got, want := audio.ClampBufferSizeForTesting(time.Minute), time.Second; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestVolumeDB(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, err := context.NewPlayer(bytes.NewReader(make([]byte, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		db     float64
+		volume float64
+		wantDB float64
+	}{
+		{db: 0, volume: 1, wantDB: 0},
+		{db: 6, volume: 1, wantDB: 0},
+		{db: -20, volume: 0.1, wantDB: -20},
+		{db: -60, volume: 0.001, wantDB: -60},
+		{db: math.Inf(-1), volume: 0, wantDB: math.Inf(-1)},
+	} {
+		p.SetVolumeDB(tc.db)
+		if got, want := p.Volume(), tc.volume; math.Abs(got-want) > 1e-9 {
+			t.Errorf("SetVolumeDB(%f): Volume(): got: %f, want: %f", tc.db, got, want)
+		}
+		if got, want := p.VolumeDB(), tc.wantDB; got != want && math.Abs(got-want) > 1e-9 {
+			t.Errorf("SetVolumeDB(%f): VolumeDB(): got: %f, want: %f", tc.db, got, want)
+		}
+	}
+}
+
+func TestPan(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, err := context.NewPlayer(bytes.NewReader(make([]byte, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := p.Pan(), 0.0; got != want {
+		t.Errorf("Pan(): got: %f, want: %f", got, want)
+	}
+	p.SetPan(-0.5)
+	if got, want := p.Pan(), -0.5; got != want {
+		t.Errorf("Pan(): got: %f, want: %f", got, want)
+	}
+
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("SetPan(2) must panic")
+		}
+	}()
+	p.SetPan(2)
+}
+
+func TestFilterWithSmallBuffer(t *testing.T) {
+	src := make([]byte, 4*256)
+	for i := 0; i < len(src)/2; i++ {
+		v := int16(math.Sin(float64(i)) * math.MaxInt16)
+		src[2*i] = byte(v)
+		src[2*i+1] = byte(v >> 8)
+	}
+
+	s0, err := audio.NewFilteredStreamForTesting(bytes.NewReader(src), 44100, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := io.ReadAll(s0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(want, src) {
+		t.Fatal("the filter must change the source")
+	}
+
+	// Read the stream one byte at a time. Every byte must be filtered.
+	s1, err := audio.NewFilteredStreamForTesting(bytes.NewReader(src), 44100, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := s1.Read(buf)
+		got = append(got, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("the bytes read with a small buffer didn't match")
+	}
+}
@@ -17,6 +17,7 @@ package audio
 import (
 	"io"
 	"sync"
+	"time"
 )
 
 type (
@@ -154,3 +155,17 @@ func ResetContextForTesting() {
 func (i *InfiniteLoop) SetNoBlendForTesting(value bool) {
 	i.noBlendForTesting = value
 }
+
+func ClampBufferSizeForTesting(bufferSize time.Duration) time.Duration {
+	return clampBufferSize(bufferSize)
+}
+
+// NewFilteredStreamForTesting returns a stream reading r with the low-pass filter of the given cutoff frequency.
+func NewFilteredStreamForTesting(r io.Reader, sampleRate int, lowpassCutoff float64) (io.Reader, error) {
+	s, err := newTimeStream(r, sampleRate)
+	if err != nil {
+		return nil, err
+	}
+	s.setLowpassCutoff(lowpassCutoff)
+	return s, nil
+}
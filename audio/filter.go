@@ -0,0 +1,174 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"math"
+)
+
+type biquadFilterType int
+
+const (
+	biquadFilterTypeLowpass biquadFilterType = iota
+	biquadFilterTypeHighpass
+)
+
+// biquadFilter is a second-order IIR filter for 16-bit little endian and 2 channels PCM.
+//
+// The coefficients are calculated based on Robert Bristow-Johnson's Audio EQ Cookbook.
+type biquadFilter struct {
+	typ        biquadFilterType
+	sampleRate int
+
+	// cutoff is the cutoff frequency in Hz. If cutoff is 0, the filter is disabled.
+	cutoff float64
+
+	// coeffs is the current coefficients b0, b1, b2, a1, and a2.
+	// When the cutoff frequency is changed, coeffs approaches target linearly for rampLength samples.
+	// As the set of stable coefficients (a1, a2) is convex, the interpolated filter is also stable.
+	coeffs    [5]float64
+	target    [5]float64
+	rampStep  [5]float64
+	rampCount int
+
+	x1, x2 [channelCount]float64
+	y1, y2 [channelCount]float64
+}
+
+func newBiquadFilter(typ biquadFilterType, sampleRate int) *biquadFilter {
+	return &biquadFilter{
+		typ:        typ,
+		sampleRate: sampleRate,
+	}
+}
+
+// rampLength returns the number of samples to change the coefficients in. This is about 10 milliseconds.
+func (f *biquadFilter) rampLength() int {
+	return f.sampleRate / 100
+}
+
+func (f *biquadFilter) enabled() bool {
+	return f.cutoff > 0
+}
+
+// setCutoff sets the cutoff frequency in Hz.
+//
+// The filter's state is kept and the coefficients are ramped so that the cutoff frequency can be swept without clicks.
+// Enabling or disabling the filter takes effect immediately.
+func (f *biquadFilter) setCutoff(hz float64) {
+	if hz <= 0 || math.IsNaN(hz) {
+		hz = 0
+	}
+	// The cutoff frequency must be lower than the Nyquist frequency.
+	if maxHz := float64(f.sampleRate) * 0.49; hz > maxHz {
+		hz = maxHz
+	}
+	if f.cutoff == hz {
+		return
+	}
+
+	wasEnabled := f.enabled()
+	if !wasEnabled {
+		f.reset()
+	}
+	f.cutoff = hz
+	if hz == 0 {
+		return
+	}
+
+	w0 := 2 * math.Pi * hz / float64(f.sampleRate)
+	cos := math.Cos(w0)
+	// Q is 1/sqrt(2), i.e. the Butterworth response.
+	alpha := math.Sin(w0) / math.Sqrt2
+
+	a0 := 1 + alpha
+	switch f.typ {
+	case biquadFilterTypeLowpass:
+		f.target[0] = (1 - cos) / 2 / a0
+		f.target[1] = (1 - cos) / a0
+		f.target[2] = (1 - cos) / 2 / a0
+	case biquadFilterTypeHighpass:
+		f.target[0] = (1 + cos) / 2 / a0
+		f.target[1] = -(1 + cos) / a0
+		f.target[2] = (1 + cos) / 2 / a0
+	}
+	f.target[3] = -2 * cos / a0
+	f.target[4] = (1 - alpha) / a0
+
+	n := f.rampLength()
+	if !wasEnabled || n == 0 {
+		f.coeffs = f.target
+		f.rampCount = 0
+		return
+	}
+	for i := range f.coeffs {
+		f.rampStep[i] = (f.target[i] - f.coeffs[i]) / float64(n)
+	}
+	f.rampCount = n
+}
+
+// stepCoeffs moves the coefficients toward the target by one sample.
+func (f *biquadFilter) stepCoeffs() {
+	if f.rampCount == 0 {
+		return
+	}
+	f.rampCount--
+	if f.rampCount == 0 {
+		f.coeffs = f.target
+		return
+	}
+	for i := range f.coeffs {
+		f.coeffs[i] += f.rampStep[i]
+	}
+}
+
+func (f *biquadFilter) reset() {
+	f.x1 = [channelCount]float64{}
+	f.x2 = [channelCount]float64{}
+	f.y1 = [channelCount]float64{}
+	f.y2 = [channelCount]float64{}
+}
+
+// process applies the filter to buf in place.
+// The length of buf must be a multiple of bytesPerSampleInt16.
+func (f *biquadFilter) process(buf []byte) {
+	if !f.enabled() {
+		return
+	}
+
+	for i := 0; i < len(buf)/bytesPerSampleInt16; i++ {
+		f.stepCoeffs()
+		b0, b1, b2, a1, a2 := f.coeffs[0], f.coeffs[1], f.coeffs[2], f.coeffs[3], f.coeffs[4]
+		for ch := 0; ch < channelCount; ch++ {
+			idx := bytesPerSampleInt16*i + bitDepthInBytesInt16*ch
+			x := float64(int16(buf[idx]) | int16(buf[idx+1])<<8)
+			y := b0*x + b1*f.x1[ch] + b2*f.x2[ch] - a1*f.y1[ch] - a2*f.y2[ch]
+			f.x2[ch] = f.x1[ch]
+			f.x1[ch] = x
+			f.y2[ch] = f.y1[ch]
+			f.y1[ch] = y
+
+			if y > math.MaxInt16 {
+				y = math.MaxInt16
+			}
+			if y < math.MinInt16 {
+				y = math.MinInt16
+			}
+			v := int16(y)
+			buf[idx] = byte(v)
+			buf[idx+1] = byte(v >> 8)
+		}
+	}
+}
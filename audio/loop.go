@@ -66,6 +66,18 @@ func NewInfiniteLoopWithIntro(src io.ReadSeeker, introLength int64, loopLength i
 	}
 }
 
+// NewInfiniteLoopWithLoopPoints creates a new infinite loop stream with loop points in samples.
+// NewInfiniteLoopWithLoopPoints accepts a source stream src, loopStart and loopLength in samples.
+// A sample here means a pair of left and right 16bit values, so one sample is 4 bytes.
+//
+// The part before loopStart is played only once as an intro, and then the part [loopStart, loopStart+loopLength) is looped.
+//
+// As with NewInfiniteLoopWithIntro, if src has data after the loop end, an InfiniteLoop uses part of the data to blend
+// with the loop start to make the loop joint smooth.
+func NewInfiniteLoopWithLoopPoints(src io.ReadSeeker, loopStart int64, loopLength int64) *InfiniteLoop {
+	return NewInfiniteLoopWithIntro(src, loopStart*bytesPerSampleInt16, loopLength*bytesPerSampleInt16)
+}
+
 func (i *InfiniteLoop) length() int64 {
 	return i.lstart + i.llength
 }
@@ -151,6 +151,42 @@ func TestInfiniteLoopWithIntro(t *testing.T) {
 	}
 }
 
+func TestInfiniteLoopWithLoopPoints(t *testing.T) {
+	const (
+		srcSamples  = 64
+		loopStart   = 13
+		loopLength  = 29
+		bytesPerSmp = 4
+	)
+
+	indexToByte := func(index int) byte {
+		return byte(math.Sin(float64(index)) * 256)
+	}
+	src := make([]byte, srcSamples*bytesPerSmp)
+	for i := range src {
+		src[i] = indexToByte(i)
+	}
+	l := audio.NewInfiniteLoopWithLoopPoints(bytes.NewReader(src), loopStart, loopLength)
+	l.SetNoBlendForTesting(true)
+
+	buf := make([]byte, len(src)*4)
+	if _, err := io.ReadFull(l, buf); err != nil {
+		t.Error(err)
+	}
+	for i, b := range buf {
+		got := b
+		want := byte(0)
+		if i < loopStart*bytesPerSmp {
+			want = indexToByte(i)
+		} else {
+			want = indexToByte((i-loopStart*bytesPerSmp)%(loopLength*bytesPerSmp) + loopStart*bytesPerSmp)
+		}
+		if got != want {
+			t.Errorf("index: %d, got: %v, want: %v", i, got, want)
+		}
+	}
+}
+
 func TestInfiniteLoopWithIncompleteSize(t *testing.T) {
 	// s1 should work as if 4092 is given.
 	s1 := audio.NewInfiniteLoop(bytes.NewReader(make([]byte, 4096)), 4095)
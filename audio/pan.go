@@ -0,0 +1,53 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"math"
+)
+
+// panGains returns the gains for the left and right channels with the constant-power panning law.
+// pan is in between -1 (left) and 1 (right).
+// The gains are normalized so that both are 1 when pan is 0.
+func panGains(pan float64) (float64, float64) {
+	theta := (pan + 1) * math.Pi / 4
+	return math.Cos(theta) * math.Sqrt2, math.Sin(theta) * math.Sqrt2
+}
+
+// applyPan applies the panning to buf in place.
+// The length of buf must be a multiple of bytesPerSampleInt16.
+func applyPan(buf []byte, pan float64) {
+	if pan == 0 {
+		return
+	}
+
+	l, r := panGains(pan)
+	gains := [channelCount]float64{l, r}
+	for i := 0; i < len(buf)/bytesPerSampleInt16; i++ {
+		for ch := 0; ch < channelCount; ch++ {
+			idx := bytesPerSampleInt16*i + bitDepthInBytesInt16*ch
+			v := float64(int16(buf[idx])|int16(buf[idx+1])<<8) * gains[ch]
+			if v > math.MaxInt16 {
+				v = math.MaxInt16
+			}
+			if v < math.MinInt16 {
+				v = math.MinInt16
+			}
+			s := int16(v)
+			buf[idx] = byte(s)
+			buf[idx+1] = byte(s >> 8)
+		}
+	}
+}
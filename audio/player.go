@@ -46,15 +46,17 @@ type context interface {
 type playerFactory struct {
 	context    context
 	sampleRate int
+	bufferSize time.Duration
 
 	m sync.Mutex
 }
 
 var driverForTesting context
 
-func newPlayerFactory(sampleRate int) *playerFactory {
+func newPlayerFactory(sampleRate int, bufferSize time.Duration) *playerFactory {
 	f := &playerFactory{
 		sampleRate: sampleRate,
+		bufferSize: bufferSize,
 	}
 	if driverForTesting != nil {
 		f.context = driverForTesting
@@ -69,7 +71,14 @@ type playerImpl struct {
 	stream         *timeStream
 	factory        *playerFactory
 	initBufferSize int
-	m              sync.Mutex
+	lowpassCutoff  float64
+	highpassCutoff float64
+	pan            float64
+
+	// starving represents whether the player was starving at the last check.
+	starving bool
+
+	m sync.Mutex
 }
 
 func (f *playerFactory) newPlayer(context *Context, src io.Reader) (*playerImpl, error) {
@@ -123,7 +132,7 @@ func (f *playerFactory) initContextIfNeeded() (<-chan struct{}, error) {
 		return nil, nil
 	}
 
-	c, ready, err := newContext(f.sampleRate)
+	c, ready, err := newContext(f.sampleRate, f.bufferSize)
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +162,9 @@ func (p *playerImpl) ensurePlayer() error {
 		if err != nil {
 			return err
 		}
+		s.setLowpassCutoff(p.lowpassCutoff)
+		s.setHighpassCutoff(p.highpassCutoff)
+		s.setPan(p.pan)
 		p.stream = s
 	}
 	if p.player == nil {
@@ -296,15 +308,89 @@ func (p *playerImpl) SetBufferSize(bufferSize time.Duration) {
 	p.player.SetBufferSize(bufferSizeInBytes)
 }
 
+func (p *playerImpl) SetLowpassCutoff(hz float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.lowpassCutoff = hz
+	if p.stream == nil {
+		return
+	}
+	p.stream.setLowpassCutoff(hz)
+}
+
+func (p *playerImpl) SetHighpassCutoff(hz float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.highpassCutoff = hz
+	if p.stream == nil {
+		return
+	}
+	p.stream.setHighpassCutoff(hz)
+}
+
+func (p *playerImpl) Pan() float64 {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return p.pan
+}
+
+func (p *playerImpl) SetPan(pan float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.pan = pan
+	if p.stream == nil {
+		return
+	}
+	p.stream.setPan(pan)
+}
+
 func (p *playerImpl) source() io.Reader {
 	return p.src
 }
 
+// checkBuffer returns the buffered size in bytes of the playing player.
+// checkBuffer also reports whether the player has newly started starving, i.e., the buffer is empty
+// though the source has not reached its end.
+//
+// checkBuffer returns false as playing if the player is not playing.
+func (p *playerImpl) checkBuffer() (bufferedSize int, underrun bool, playing bool) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.player == nil || !p.player.IsPlaying() {
+		p.starving = false
+		return 0, false, false
+	}
+
+	bufferedSize = p.player.BufferedSize()
+	starving := bufferedSize == 0 && !p.stream.isEOF()
+	underrun = starving && !p.starving
+	p.starving = starving
+	return bufferedSize, underrun, true
+}
+
 type timeStream struct {
 	r          io.Reader
 	sampleRate int
 	pos        int64
 
+	lowpass  *biquadFilter
+	highpass *biquadFilter
+	pan      float64
+
+	// pending is bytes that have been read from r but not returned yet, as they don't form a complete sample.
+	pending []byte
+
+	// processed is bytes that have been filtered but not returned yet, as the buffer given to Read was too small.
+	processed []byte
+
+	// eof represents whether r has reached its end.
+	eof bool
+
 	// m is a mutex for this stream.
 	// All the exported functions are protected by this mutex as Read can be read from a different goroutine than Seek.
 	m sync.Mutex
@@ -314,6 +400,8 @@ func newTimeStream(r io.Reader, sampleRate int) (*timeStream, error) {
 	s := &timeStream{
 		r:          r,
 		sampleRate: sampleRate,
+		lowpass:    newBiquadFilter(biquadFilterTypeLowpass, sampleRate),
+		highpass:   newBiquadFilter(biquadFilterTypeHighpass, sampleRate),
 	}
 	if seeker, ok := s.r.(io.Seeker); ok {
 		// Get the current position of the source.
@@ -330,9 +418,68 @@ func (s *timeStream) Read(buf []byte) (int, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	n, err := s.r.Read(buf)
-	s.pos += int64(n)
-	return n, err
+	if len(s.processed) > 0 {
+		n := copy(buf, s.processed)
+		s.processed = s.processed[n:]
+		s.pos += int64(n)
+		return n, nil
+	}
+
+	if !s.lowpass.enabled() && !s.highpass.enabled() && s.pan == 0 && len(s.pending) == 0 {
+		n, err := s.r.Read(buf)
+		s.pos += int64(n)
+		if err == io.EOF {
+			s.eof = true
+		}
+		return n, err
+	}
+
+	// The filters and the panning require complete samples.
+	// If buf is too small for a sample, process one sample and keep the rest bytes for the next Read.
+	if len(buf) < bytesPerSampleInt16 {
+		var sample [bytesPerSampleInt16]byte
+		n, err := s.readAndProcess(sample[:])
+		c := copy(buf, sample[:n])
+		s.processed = append(s.processed, sample[c:n]...)
+		s.pos -= int64(n - c)
+		if err == io.EOF && len(s.processed) > 0 {
+			// Return io.EOF after all the processed bytes are returned.
+			err = nil
+		}
+		return c, err
+	}
+	return s.readAndProcess(buf)
+}
+
+// readAndProcess reads bytes from r and applies the filters and the panning.
+// The rest bytes that don't form a complete sample are kept for the next call.
+// The length of buf must be bytesPerSampleInt16 or more.
+func (s *timeStream) readAndProcess(buf []byte) (int, error) {
+	p := copy(buf, s.pending)
+	s.pending = s.pending[:0]
+	n, err := s.r.Read(buf[p:])
+	n += p
+
+	if err != nil {
+		// Return all the bytes at the end of the stream even if the last sample is incomplete.
+		m := n / bytesPerSampleInt16 * bytesPerSampleInt16
+		s.lowpass.process(buf[:m])
+		s.highpass.process(buf[:m])
+		applyPan(buf[:m], s.pan)
+		s.pos += int64(n)
+		if err == io.EOF {
+			s.eof = true
+		}
+		return n, err
+	}
+
+	m := n / bytesPerSampleInt16 * bytesPerSampleInt16
+	s.pending = append(s.pending, buf[m:n]...)
+	s.lowpass.process(buf[:m])
+	s.highpass.process(buf[:m])
+	applyPan(buf[:m], s.pan)
+	s.pos += int64(m)
+	return m, nil
 }
 
 func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
@@ -350,9 +497,35 @@ func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	s.pos = pos
+	s.pending = s.pending[:0]
+	s.processed = s.processed[:0]
+	s.eof = false
+	s.lowpass.reset()
+	s.highpass.reset()
 	return pos, nil
 }
 
+func (s *timeStream) setLowpassCutoff(hz float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.lowpass.setCutoff(hz)
+}
+
+func (s *timeStream) setHighpassCutoff(hz float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.highpass.setCutoff(hz)
+}
+
+func (s *timeStream) setPan(pan float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.pan = pan
+}
+
 func (s *timeStream) timeDurationToPos(offset time.Duration) int64 {
 	s.m.Lock()
 	defer s.m.Unlock()
@@ -366,6 +539,13 @@ func (s *timeStream) timeDurationToPos(offset time.Duration) int64 {
 	return o
 }
 
+func (s *timeStream) isEOF() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.eof
+}
+
 func (s *timeStream) Current() int64 {
 	s.m.Lock()
 	defer s.m.Unlock()
@@ -0,0 +1,49 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build darwin && !ios
+
+package audio
+
+import (
+	"github.com/ebitengine/purego/objc"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/cocoa"
+)
+
+const (
+	_NSActivityUserInitiatedAllowingIdleSystemSleep = 0x00efffff
+	_NSActivityLatencyCritical                      = 0xff00000000
+)
+
+var (
+	sel_retain  = objc.RegisterName("retain")
+	sel_release = objc.RegisterName("release")
+)
+
+// priorityActivity is the token of the activity.
+// The activity continues while the token is retained, i.e., until the process ends.
+var priorityActivity objc.ID
+
+func raisePriority() error {
+	// The audio thread is owned by the audio driver, so declare a latency-critical activity for the whole process.
+	// This prevents App Nap and timer coalescing, and raises the QoS of the threads.
+	// The system is still allowed to sleep when idle.
+	reason := cocoa.NSString_alloc().InitWithUTF8String("Playing audio")
+	defer reason.Send(sel_release)
+
+	priorityActivity = cocoa.NSProcessInfo_processInfo().BeginActivityWithOptionsReason(_NSActivityUserInitiatedAllowingIdleSystemSleep|_NSActivityLatencyCritical, reason)
+	priorityActivity.Send(sel_retain)
+	return nil
+}
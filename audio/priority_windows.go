@@ -0,0 +1,42 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	winmm = windows.NewLazySystemDLL("winmm.dll")
+
+	procTimeBeginPeriod = winmm.NewProc("timeBeginPeriod")
+)
+
+const _TIMERR_NOERROR = 0
+
+func raisePriority() error {
+	// Make the timer resolution 1[ms] so that the audio thread wakes up on time.
+	if r, _, _ := procTimeBeginPeriod.Call(1); r != _TIMERR_NOERROR {
+		return fmt.Errorf("audio: timeBeginPeriod failed: %d", r)
+	}
+
+	// The audio thread is owned by the audio driver, so raise the priority class of the whole process.
+	if err := windows.SetPriorityClass(windows.CurrentProcess(), windows.ABOVE_NORMAL_PRIORITY_CLASS); err != nil {
+		return fmt.Errorf("audio: SetPriorityClass failed: %w", err)
+	}
+	return nil
+}
@@ -18,6 +18,8 @@ package vorbis
 import (
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/jfreymuth/oggvorbis"
 
@@ -29,6 +31,9 @@ import (
 type Stream struct {
 	decoded io.ReadSeeker
 	size    int64
+
+	loopStart  int64
+	loopLength int64
 }
 
 // Read is implementation of io.Reader's Read.
@@ -50,6 +55,79 @@ func (s *Stream) Length() int64 {
 	return s.size
 }
 
+// LoopPoints returns the loop start and the loop length in samples specified by the LOOPSTART and LOOPLENGTH
+// (or LOOPEND) comments of the Ogg/Vorbis data.
+// A sample here means a pair of left and right 16bit values of the decoded stream.
+// If the stream is resampled, the loop points are converted to the new sample rate.
+//
+// LoopPoints returns false as ok if the comments don't exist or are invalid.
+func (s *Stream) LoopPoints() (start, length int64, ok bool) {
+	if s.loopLength <= 0 {
+		return 0, 0, false
+	}
+	return s.loopStart, s.loopLength, true
+}
+
+// NewInfiniteLoop creates a new infinite loop stream from a decoded stream.
+//
+// If the stream has loop points specified by the LOOPSTART and LOOPLENGTH (or LOOPEND) comments,
+// the part before the loop start is played once as an intro, and then the loop body is looped.
+// Otherwise, the whole stream is looped.
+//
+// See also audio.NewInfiniteLoopWithLoopPoints.
+func NewInfiniteLoop(stream *Stream) *audio.InfiniteLoop {
+	start, length, ok := stream.LoopPoints()
+	if !ok {
+		return audio.NewInfiniteLoop(stream, stream.Length())
+	}
+	return audio.NewInfiniteLoopWithLoopPoints(stream, start, length)
+}
+
+// parseLoopPoints parses the loop points in samples from Vorbis comments.
+// parseLoopPoints returns 0 as length if the loop points are not specified or are invalid.
+func parseLoopPoints(comments []string, totalSamples int64) (start, length int64) {
+	var loopEnd int64 = -1
+	start = -1
+	length = -1
+	for _, c := range comments {
+		k, v, ok := strings.Cut(c, "=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			continue
+		}
+		// Field names are case-insensitive in Vorbis comments.
+		switch strings.ToUpper(strings.TrimSpace(k)) {
+		case "LOOPSTART":
+			start = n
+		case "LOOPLENGTH":
+			length = n
+		case "LOOPEND":
+			loopEnd = n
+		}
+	}
+	if start < 0 {
+		return 0, 0
+	}
+	if length < 0 {
+		switch {
+		case loopEnd > start:
+			length = loopEnd - start
+		case totalSamples > start:
+			length = totalSamples - start
+		}
+	}
+	if length <= 0 {
+		return 0, 0
+	}
+	if totalSamples > 0 && start+length > totalSamples {
+		return 0, 0
+	}
+	return start, length
+}
+
 type decoder interface {
 	Read([]float32) (int, error)
 	SetPosition(int64) error
@@ -63,6 +141,9 @@ type decoded struct {
 	posInBytes int
 	decoder    decoder
 	decoderr   io.Reader
+
+	loopStart  int64
+	loopLength int64
 }
 
 func (d *decoded) Read(b []byte) (int, error) {
@@ -132,6 +213,7 @@ func decode(in io.Reader) (*decoded, int, int, error) {
 		posInBytes: 0,
 		decoder:    r,
 	}
+	d.loopStart, d.loopLength = parseLoopPoints(r.CommentHeader().Comments, r.Length())
 	if _, ok := in.(io.Seeker); ok {
 		if _, err := d.Read(make([]byte, 65536)); err != nil && err != io.EOF {
 			return nil, 0, 0, err
@@ -166,8 +248,10 @@ func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
 		size *= 2
 	}
 	stream := &Stream{
-		decoded: s,
-		size:    size,
+		decoded:    s,
+		size:       size,
+		loopStart:  decoded.loopStart,
+		loopLength: decoded.loopLength,
 	}
 	return stream, nil
 }
@@ -196,12 +280,21 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 		s = convert.NewStereo16(s, true, false)
 		size *= 2
 	}
+	loopStart, loopLength := decoded.loopStart, decoded.loopLength
 	if origSampleRate != sampleRate {
 		r := convert.NewResampling(s, size, origSampleRate, sampleRate)
 		s = r
 		size = r.Length()
+		loopEnd := (loopStart + loopLength) * int64(sampleRate) / int64(origSampleRate)
+		loopStart = loopStart * int64(sampleRate) / int64(origSampleRate)
+		loopLength = loopEnd - loopStart
+	}
+	stream := &Stream{
+		decoded:    s,
+		size:       size,
+		loopStart:  loopStart,
+		loopLength: loopLength,
 	}
-	stream := &Stream{decoded: s, size: size}
 	return stream, nil
 }
 
@@ -99,3 +99,65 @@ func TestNonSeeker(t *testing.T) {
 		t.Errorf("s.Length(): got: %d, want: %d", got, want)
 	}
 }
+
+func TestParseLoopPoints(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Comments   []string
+		Total      int64
+		WantStart  int64
+		WantLength int64
+	}{
+		{
+			Name:       "no comments",
+			Comments:   nil,
+			Total:      1000,
+			WantStart:  0,
+			WantLength: 0,
+		},
+		{
+			Name:       "start and length",
+			Comments:   []string{"TITLE=foo", "LOOPSTART=100", "LOOPLENGTH=800"},
+			Total:      1000,
+			WantStart:  100,
+			WantLength: 800,
+		},
+		{
+			Name:       "start and end",
+			Comments:   []string{"loopstart=100", "loopend=600"},
+			Total:      1000,
+			WantStart:  100,
+			WantLength: 500,
+		},
+		{
+			Name:       "start only",
+			Comments:   []string{"LOOPSTART=100"},
+			Total:      1000,
+			WantStart:  100,
+			WantLength: 900,
+		},
+		{
+			Name:       "out of range",
+			Comments:   []string{"LOOPSTART=100", "LOOPLENGTH=1000"},
+			Total:      1000,
+			WantStart:  0,
+			WantLength: 0,
+		},
+		{
+			Name:       "invalid number",
+			Comments:   []string{"LOOPSTART=abc", "LOOPLENGTH=100"},
+			Total:      1000,
+			WantStart:  0,
+			WantLength: 0,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			start, length := vorbis.ParseLoopPoints(c.Comments, c.Total)
+			if start != c.WantStart || length != c.WantLength {
+				t.Errorf("got: (%d, %d), want: (%d, %d)", start, length, c.WantStart, c.WantLength)
+			}
+		})
+	}
+}
@@ -407,4 +407,49 @@ var (
 		BlendOperationRGB:           BlendOperationAdd,
 		BlendOperationAlpha:         BlendOperationAdd,
 	}
+
+	// BlendMultiply is a preset Blend for 'multiply'.
+	// The result is the same as CSS's 'multiply' when the destination is opaque.
+	//
+	//     c_out = c_src × c_dst + c_dst × (1 - α_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendMultiply = Blend{
+		BlendFactorSourceRGB:        BlendFactorDestinationColor,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOneMinusSourceAlpha,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationAdd,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
+
+	// BlendScreen is a preset Blend for 'screen'.
+	//
+	//     c_out = c_src + c_dst × (1 - c_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendScreen = Blend{
+		BlendFactorSourceRGB:        BlendFactorOne,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOneMinusSourceColor,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationAdd,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
+
+	// BlendSubtract is a preset Blend to subtract the source color from the destination color.
+	// The destination alpha is kept.
+	//
+	//     c_out = c_dst - c_src
+	//     α_out = α_dst
+	BlendSubtract = Blend{
+		BlendFactorSourceRGB:        BlendFactorOne,
+		BlendFactorSourceAlpha:      BlendFactorZero,
+		BlendFactorDestinationRGB:   BlendFactorOne,
+		BlendFactorDestinationAlpha: BlendFactorOne,
+		BlendOperationRGB:           BlendOperationReverseSubtract,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
+
+	// There is no preset for 'overlay' as it chooses multiply or screen per pixel based on the destination color,
+	// which cannot be expressed by blend factors. Use a shader reading the destination as a source image instead.
+	// For 'additive' blending of premultiplied colors, use BlendLighter.
 )
@@ -0,0 +1,71 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// ClipboardText returns the text in the system clipboard.
+//
+// ClipboardText returns an empty string if the clipboard doesn't have a text,
+// or if the clipboard is not available.
+//
+// ClipboardText works only while the game is running.
+// On browsers, ClipboardText works only in a secure context, and might block until the user permits reading the clipboard.
+// On mobiles, ClipboardText always returns an empty string.
+//
+// ClipboardText is concurrent-safe.
+func ClipboardText() string {
+	return ui.Get().ClipboardText()
+}
+
+// SetClipboardText sets the text to the system clipboard.
+//
+// SetClipboardText works only while the game is running.
+// On browsers, SetClipboardText works only in a secure context, and the text is written asynchronously.
+// On mobiles, SetClipboardText does nothing.
+//
+// SetClipboardText is concurrent-safe.
+func SetClipboardText(text string) {
+	ui.Get().SetClipboardText(text)
+}
+
+// ClipboardImage returns the image in the system clipboard.
+//
+// ClipboardImage returns nil if the clipboard doesn't have an image,
+// or if the clipboard is not available.
+//
+// ClipboardImage works only on Windows and macOS while the game is running.
+// On the other environments, ClipboardImage always returns nil.
+//
+// ClipboardImage is concurrent-safe.
+func ClipboardImage() image.Image {
+	return ui.Get().ClipboardImage()
+}
+
+// SetClipboardImage sets the image to the system clipboard.
+//
+// If img is nil or empty, SetClipboardImage does nothing.
+//
+// SetClipboardImage works only on Windows and macOS while the game is running.
+// On the other environments, SetClipboardImage does nothing.
+//
+// SetClipboardImage is concurrent-safe.
+func SetClipboardImage(img image.Image) {
+	ui.Get().SetClipboardImage(img)
+}
@@ -155,7 +155,8 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
             int x = (int)e.getX(i);
             int y = (int)e.getY(i);
             int action = (i == touchIndex) ? e.getActionMasked() : MotionEvent.ACTION_MOVE;
-            Ebitenmobileview.updateTouchesOnAndroid(action, id, (int)pxToDp(x), (int)pxToDp(y));
+            // getTouchMajor returns the length of the major axis of the contact ellipse in pixels.
+            Ebitenmobileview.updateTouchesOnAndroid(action, id, (int)pxToDp(x), (int)pxToDp(y), e.getPressure(i), pxToDp(e.getTouchMajor(i)) / 2);
         }
         return true;
     }
@@ -286,7 +286,12 @@
       }
     }
     CGPoint location = [touch locationInView:touch.view];
-    EbitenmobileviewUpdateTouchesOnIOS(touch.phase, (uintptr_t)touch, location.x, location.y);
+    // maximumPossibleForce is 0 when 3D Touch is not available.
+    double force = -1;
+    if (touch.maximumPossibleForce > 0) {
+      force = touch.force / touch.maximumPossibleForce;
+    }
+    EbitenmobileviewUpdateTouchesOnIOS(touch.phase, (uintptr_t)touch, location.x, location.y, force, touch.majorRadius);
   }
 }
 
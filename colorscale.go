@@ -120,6 +120,23 @@ func (c *ColorScale) ScaleWithColorScale(colorScale ColorScale) {
 	c.a_1 = (c.a_1+1)*(colorScale.a_1+1) - 1
 }
 
+// premultiplied returns a color scale treating c as a straight-alpha color scale.
+func (c *ColorScale) premultiplied() ColorScale {
+	r, g, b, a := c.elements()
+	var cs ColorScale
+	cs.Scale(r*a, g*a, b*a, a)
+	return cs
+}
+
+// withMode returns the premultiplied-alpha color scale of c interpreted with mode.
+// If modeSet is false, c is treated as a premultiplied-alpha color scale.
+func (c *ColorScale) withMode(mode ColorScaleMode, modeSet bool) ColorScale {
+	if modeSet && mode == ColorScaleModeStraightAlpha {
+		return c.premultiplied()
+	}
+	return *c
+}
+
 func (c *ColorScale) apply(r, g, b, a float32) (float32, float32, float32, float32) {
 	return (c.r_1 + 1) * r, (c.g_1 + 1) * g, (c.b_1 + 1) * b, (c.a_1 + 1) * a
 }
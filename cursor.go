@@ -43,4 +43,6 @@ const (
 	CursorShapeNWSEResize CursorShapeType = CursorShapeType(ui.CursorShapeNWSEResize)
 	CursorShapeMove       CursorShapeType = CursorShapeType(ui.CursorShapeMove)
 	CursorShapeNotAllowed CursorShapeType = CursorShapeType(ui.CursorShapeNotAllowed)
+	CursorShapeGrab       CursorShapeType = CursorShapeType(ui.CursorShapeGrab)
+	CursorShapeGrabbing   CursorShapeType = CursorShapeType(ui.CursorShapeGrabbing)
 )
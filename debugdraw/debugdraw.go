@@ -0,0 +1,268 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debugdraw provides immediate-mode functions to draw debug primitives.
+//
+// The functions like Line and Rect can be called anywhere in Game.Update.
+// The primitives are accumulated, and are drawn in one batch when Flush is called in Game.Draw, typically at its end.
+//
+// The accumulated primitives are cleared right before every Update, so the primitives are drawn by every Draw until the next Update.
+// If the functions are called in Draw, the primitives are also drawn until the next Update.
+// Note that this might draw the same primitives multiple times when Draw is called multiple times without Update.
+package debugdraw
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var (
+	whiteImage    = ebiten.NewImage(3, 3)
+	whiteSubImage = whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+)
+
+func init() {
+	b := whiteImage.Bounds()
+	pix := make([]byte, 4*b.Dx()*b.Dy())
+	for i := range pix {
+		pix[i] = 0xff
+	}
+	// This is hacky, but WritePixels is better than Fill in term of automatic texture packing.
+	whiteImage.WritePixels(pix)
+
+	hook.AppendHookOnBeforeUpdate(func() error {
+		theBatch.clear()
+		return nil
+	})
+}
+
+type shapeType int
+
+const (
+	shapeTypeStroke shapeType = iota
+	shapeTypeFill
+	shapeTypeCircle
+)
+
+type shape struct {
+	typ shapeType
+
+	// points are the positions in the screen coordinate.
+	// For shapeTypeCircle, points is only the center.
+	points []float32
+	radius float32
+	clr    color.Color
+}
+
+type text struct {
+	str string
+	x   float32
+	y   float32
+}
+
+type batch struct {
+	geoM   ebiten.GeoM
+	shapes []shape
+	texts  []text
+
+	// vertices and indices are reused to reduce allocations.
+	vertices []ebiten.Vertex
+	indices  []uint16
+
+	m sync.Mutex
+}
+
+var theBatch batch
+
+func (b *batch) clear() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.shapes = b.shapes[:0]
+	b.texts = b.texts[:0]
+}
+
+func (b *batch) appendShape(typ shapeType, clr color.Color, radius float32, xys ...float32) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	pts := make([]float32, len(xys))
+	for i := 0; i < len(xys); i += 2 {
+		x, y := b.geoM.Apply(float64(xys[i]), float64(xys[i+1]))
+		pts[i] = float32(x)
+		pts[i+1] = float32(y)
+	}
+	if typ == shapeTypeCircle {
+		// A circle is kept as a circle, so the scale is approximated by the area ratio.
+		radius *= float32(math.Sqrt(math.Abs(b.geoM.Element(0, 0)*b.geoM.Element(1, 1) - b.geoM.Element(0, 1)*b.geoM.Element(1, 0))))
+	}
+	b.shapes = append(b.shapes, shape{
+		typ:    typ,
+		points: pts,
+		radius: radius,
+		clr:    clr,
+	})
+}
+
+// SetTransform sets the transform from the world coordinate to the screen coordinate.
+// The transform is applied to the positions of the primitives added after SetTransform is called.
+//
+// The stroke width and the text size are not affected by the transform.
+// A circle is kept a circle even when the transform is not uniform.
+//
+// The transform is not reset automatically.
+// The default transform is the identity.
+//
+// SetTransform is concurrent-safe.
+func SetTransform(geoM ebiten.GeoM) {
+	theBatch.m.Lock()
+	defer theBatch.m.Unlock()
+	theBatch.geoM = geoM
+}
+
+// Line adds a line (x0, y0)-(x1, y1) with the color clr.
+//
+// Line is concurrent-safe.
+func Line(x0, y0, x1, y1 float32, clr color.Color) {
+	theBatch.appendShape(shapeTypeStroke, clr, 0, x0, y0, x1, y1)
+}
+
+// Rect adds an outline of a rectangle with the color clr.
+//
+// Rect is concurrent-safe.
+func Rect(x, y, width, height float32, clr color.Color) {
+	theBatch.appendShape(shapeTypeStroke, clr, 0, x, y, x+width, y, x+width, y+height, x, y+height, x, y)
+}
+
+// FilledRect adds a filled rectangle with the color clr.
+//
+// FilledRect is concurrent-safe.
+func FilledRect(x, y, width, height float32, clr color.Color) {
+	theBatch.appendShape(shapeTypeFill, clr, 0, x, y, x+width, y, x+width, y+height, x, y+height)
+}
+
+// Circle adds an outline of a circle with the center (cx, cy) and the radius r with the color clr.
+//
+// Circle is concurrent-safe.
+func Circle(cx, cy, r float32, clr color.Color) {
+	theBatch.appendShape(shapeTypeCircle, clr, r, cx, cy)
+}
+
+// Text adds a debug text at (x, y).
+// The text is rendered in the same way as ebitenutil.DebugPrintAt.
+//
+// Text is concurrent-safe.
+func Text(str string, x, y float32) {
+	theBatch.m.Lock()
+	defer theBatch.m.Unlock()
+
+	tx, ty := theBatch.geoM.Apply(float64(x), float64(y))
+	theBatch.texts = append(theBatch.texts, text{
+		str: str,
+		x:   float32(tx),
+		y:   float32(ty),
+	})
+}
+
+// Flush draws the accumulated primitives onto the screen.
+// The shapes are drawn in one batch as much as possible, and then the texts are drawn over them.
+//
+// Flush doesn't clear the accumulated primitives.
+// They are cleared right before the next Update.
+//
+// Flush is concurrent-safe.
+func Flush(screen *ebiten.Image) {
+	theBatch.flush(screen)
+}
+
+func (b *batch) flush(screen *ebiten.Image) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	// maxVertexCount is a threshold to draw the batch so far, as the indices are uint16.
+	const maxVertexCount = math.MaxUint16 / 2
+
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+
+	strokeOp := &vector.StrokeOptions{}
+	strokeOp.Width = 1
+
+	for _, s := range b.shapes {
+		var path vector.Path
+		switch s.typ {
+		case shapeTypeStroke, shapeTypeFill:
+			path.MoveTo(s.points[0], s.points[1])
+			for i := 2; i < len(s.points); i += 2 {
+				path.LineTo(s.points[i], s.points[i+1])
+			}
+		case shapeTypeCircle:
+			path.Arc(s.points[0], s.points[1], s.radius, 0, 2*math.Pi, vector.Clockwise)
+			path.Close()
+		}
+
+		start := len(b.vertices)
+		if s.typ == shapeTypeFill {
+			// A filled rectangle is convex, so its vertices can be rendered with FillAll.
+			idx := uint16(start)
+			for i := 0; i < len(s.points); i += 2 {
+				b.vertices = append(b.vertices, ebiten.Vertex{
+					DstX: s.points[i],
+					DstY: s.points[i+1],
+				})
+			}
+			b.indices = append(b.indices, idx, idx+1, idx+2, idx, idx+2, idx+3)
+		} else {
+			b.vertices, b.indices = path.AppendVerticesAndIndicesForStroke(b.vertices, b.indices, strokeOp)
+		}
+
+		cr, cg, cb, ca := s.clr.RGBA()
+		for i := start; i < len(b.vertices); i++ {
+			b.vertices[i].SrcX = 1
+			b.vertices[i].SrcY = 1
+			b.vertices[i].ColorR = float32(cr) / 0xffff
+			b.vertices[i].ColorG = float32(cg) / 0xffff
+			b.vertices[i].ColorB = float32(cb) / 0xffff
+			b.vertices[i].ColorA = float32(ca) / 0xffff
+		}
+
+		if len(b.vertices) >= maxVertexCount {
+			b.drawVertices(screen)
+		}
+	}
+	b.drawVertices(screen)
+
+	for _, t := range b.texts {
+		ebitenutil.DebugPrintAt(screen, t.str, int(t.x), int(t.y))
+	}
+}
+
+func (b *batch) drawVertices(screen *ebiten.Image) {
+	if len(b.indices) == 0 {
+		return
+	}
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.AntiAlias = true
+	screen.DrawTriangles(b.vertices, b.indices, whiteSubImage, op)
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugdraw_test
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/debugdraw"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+func TestAccumulate(t *testing.T) {
+	debugdraw.ResetForTesting()
+	defer debugdraw.ResetForTesting()
+
+	debugdraw.Line(0, 0, 1, 1, color.White)
+	debugdraw.Rect(0, 0, 1, 1, color.White)
+	debugdraw.FilledRect(0, 0, 1, 1, color.White)
+	debugdraw.Circle(0, 0, 1, color.White)
+	debugdraw.Text("foo", 0, 0)
+	debugdraw.Text("bar", 0, 0)
+
+	if got, want := debugdraw.ShapeCountForTesting(), 4; got != want {
+		t.Errorf("debugdraw.ShapeCountForTesting(): got: %d, want: %d", got, want)
+	}
+	if got, want := debugdraw.TextCountForTesting(), 2; got != want {
+		t.Errorf("debugdraw.TextCountForTesting(): got: %d, want: %d", got, want)
+	}
+
+	// Flush doesn't clear the accumulated primitives.
+	dst := ebiten.NewImage(16, 16)
+	debugdraw.Flush(dst)
+	if got, want := debugdraw.ShapeCountForTesting(), 4; got != want {
+		t.Errorf("debugdraw.ShapeCountForTesting() after Flush: got: %d, want: %d", got, want)
+	}
+	if got, want := debugdraw.TextCountForTesting(), 2; got != want {
+		t.Errorf("debugdraw.TextCountForTesting() after Flush: got: %d, want: %d", got, want)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	debugdraw.ResetForTesting()
+	defer debugdraw.ResetForTesting()
+
+	var geoM ebiten.GeoM
+	geoM.Scale(2, 8)
+	geoM.Translate(10, 20)
+	debugdraw.SetTransform(geoM)
+
+	debugdraw.Line(1, 1, 2, 3, color.White)
+	debugdraw.Circle(1, 2, 3, color.White)
+	debugdraw.Text("foo", 1, 2)
+
+	if got, want := debugdraw.ShapePointsForTesting(0), []float32{12, 28, 14, 44}; !reflect.DeepEqual(got, want) {
+		t.Errorf("line points: got: %v, want: %v", got, want)
+	}
+	if got, want := debugdraw.ShapePointsForTesting(1), []float32{12, 36}; !reflect.DeepEqual(got, want) {
+		t.Errorf("circle center: got: %v, want: %v", got, want)
+	}
+	// The radius is scaled by the square root of the area ratio, i.e. sqrt(2*8) = 4.
+	if got, want := debugdraw.ShapeRadiusForTesting(1), float32(12); got != want {
+		t.Errorf("circle radius: got: %v, want: %v", got, want)
+	}
+	if x, y := debugdraw.TextPositionForTesting(0); x != 12 || y != 36 {
+		t.Errorf("text position: got: (%v, %v), want: (12, 36)", x, y)
+	}
+
+	// The transform is applied only to the primitives added after SetTransform.
+	debugdraw.SetTransform(ebiten.GeoM{})
+	debugdraw.Line(1, 1, 2, 3, color.White)
+	if got, want := debugdraw.ShapePointsForTesting(2), []float32{1, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("line points: got: %v, want: %v", got, want)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	debugdraw.ResetForTesting()
+	defer debugdraw.ResetForTesting()
+
+	var geoM ebiten.GeoM
+	geoM.Scale(2, 2)
+	geoM.Translate(4, 4)
+	debugdraw.SetTransform(geoM)
+
+	// The rectangle is (4, 4)-(8, 8) in the screen coordinate.
+	clr := color.RGBA{0xff, 0, 0, 0xff}
+	debugdraw.FilledRect(0, 0, 2, 2, clr)
+
+	dst := ebiten.NewImage(16, 16)
+	debugdraw.Flush(dst)
+	for j := 0; j < 16; j++ {
+		for i := 0; i < 16; i++ {
+			got := dst.At(i, j)
+			want := color.RGBA{}
+			if 4 <= i && i < 8 && 4 <= j && j < 8 {
+				want = clr
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// The same primitives are drawn again until the next Update.
+	dst2 := ebiten.NewImage(16, 16)
+	debugdraw.Flush(dst2)
+	if got, want := dst2.At(5, 5), clr; got != want {
+		t.Errorf("dst2.At(5, 5): got: %v, want: %v", got, want)
+	}
+
+	// The primitives are cleared before the next Update.
+	if err := hook.RunBeforeUpdateHooks(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := debugdraw.ShapeCountForTesting(), 0; got != want {
+		t.Errorf("debugdraw.ShapeCountForTesting() after an update: got: %d, want: %d", got, want)
+	}
+	dst3 := ebiten.NewImage(16, 16)
+	debugdraw.Flush(dst3)
+	if got, want := dst3.At(5, 5), (color.RGBA{}); got != want {
+		t.Errorf("dst3.At(5, 5): got: %v, want: %v", got, want)
+	}
+}
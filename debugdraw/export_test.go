@@ -0,0 +1,54 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugdraw
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func ResetForTesting() {
+	theBatch.clear()
+	SetTransform(ebiten.GeoM{})
+}
+
+func ShapeCountForTesting() int {
+	theBatch.m.Lock()
+	defer theBatch.m.Unlock()
+	return len(theBatch.shapes)
+}
+
+func TextCountForTesting() int {
+	theBatch.m.Lock()
+	defer theBatch.m.Unlock()
+	return len(theBatch.texts)
+}
+
+func ShapePointsForTesting(index int) []float32 {
+	theBatch.m.Lock()
+	defer theBatch.m.Unlock()
+	return append([]float32{}, theBatch.shapes[index].points...)
+}
+
+func ShapeRadiusForTesting(index int) float32 {
+	theBatch.m.Lock()
+	defer theBatch.m.Unlock()
+	return theBatch.shapes[index].radius
+}
+
+func TextPositionForTesting(index int) (float32, float32) {
+	theBatch.m.Lock()
+	defer theBatch.m.Unlock()
+	return theBatch.texts[index].x, theBatch.texts[index].y
+}
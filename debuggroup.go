@@ -0,0 +1,64 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// PushDebugGroup pushes a named debug group.
+// The rendering commands issued until the corresponding PopDebugGroup call belong to the group,
+// and the groups are shown in GPU debugging tools like RenderDoc or Xcode's frame capture.
+//
+// Debug groups can be nested. Every PushDebugGroup call should have a corresponding PopDebugGroup call in the same frame.
+//
+// Debug groups are available with OpenGL (when the OpenGL version supports KHR_debug) and Metal.
+// With other graphics libraries, PushDebugGroup does nothing.
+//
+// PushDebugGroup is not concurrent-safe.
+func PushDebugGroup(name string) {
+	ui.Get().PushDebugGroup(name)
+}
+
+// PopDebugGroup pops the current debug group pushed by PushDebugGroup.
+//
+// If there is no debug group pushed, PopDebugGroup does nothing.
+//
+// PopDebugGroup is not concurrent-safe.
+func PopDebugGroup() {
+	ui.Get().PopDebugGroup()
+}
+
+// SetDebugLabel sets a label to the image, which is shown in GPU debugging tools.
+//
+// The label is applied only when the image has its own texture, e.g. an unmanaged image or a big image.
+// An image sharing a texture with other images is not labeled.
+// Debug labels are available with OpenGL (when the OpenGL version supports KHR_debug) and Metal.
+// With other graphics libraries, SetDebugLabel does nothing.
+//
+// If the image is a sub-image, SetDebugLabel does nothing.
+//
+// If the image is disposed, SetDebugLabel does nothing.
+func (i *Image) SetDebugLabel(label string) {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+	if i.isSubImage() {
+		return
+	}
+	i.image.SetDebugLabel(label)
+}
@@ -75,6 +75,7 @@
 //	"directx":      DirectX. This works only on Windows.
 //	"metal":        Metal. This works only on macOS or iOS.
 //	"playstation5": PlayStation 5. This works only on PlayStation 5.
+//	"software":     The software renderer. This is slow but works without GPUs.
 //
 // `EBITENGINE_DIRECTX` environment variable specifies various parameters for DirectX.
 // You can specify multiple values separated by a comma. The default value is empty (i.e. no parameters).
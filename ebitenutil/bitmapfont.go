@@ -0,0 +1,147 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// BitmapFont is a fixed-width bitmap font whose glyphs are laid out in cells of an atlas image.
+//
+// BitmapFont is lighter than the package text/v2, as BitmapFont doesn't cache any glyphs.
+// This is useful for debug overlays and retro-style pixel fonts.
+type BitmapFont struct {
+	image      *ebiten.Image
+	cellWidth  int
+	cellHeight int
+	indices    map[rune]int
+
+	vertices []ebiten.Vertex
+	indexBuf []uint16
+}
+
+// NewBitmapFont creates a new BitmapFont.
+//
+// img is an atlas image of glyphs. Each glyph is in a cell of cellWidth x cellHeight,
+// and the cells are laid out from the left to the right, and from the top to the bottom.
+// charset is the runes of the glyphs in the same order as the cells.
+//
+// NewBitmapFont panics if cellWidth or cellHeight is not positive.
+func NewBitmapFont(img *ebiten.Image, cellWidth, cellHeight int, charset []rune) *BitmapFont {
+	if cellWidth <= 0 || cellHeight <= 0 {
+		panic(fmt.Sprintf("ebitenutil: cellWidth and cellHeight must be positive but (%d, %d)", cellWidth, cellHeight))
+	}
+	indices := make(map[rune]int, len(charset))
+	for i, r := range charset {
+		if _, ok := indices[r]; ok {
+			continue
+		}
+		indices[r] = i
+	}
+	return &BitmapFont{
+		image:      img,
+		cellWidth:  cellWidth,
+		cellHeight: cellHeight,
+		indices:    indices,
+	}
+}
+
+// CellSize returns the size of a glyph cell.
+func (b *BitmapFont) CellSize() (width, height int) {
+	return b.cellWidth, b.cellHeight
+}
+
+// Draw draws str on dst with one DrawTriangles call.
+//
+// A '\n' starts a new line. Runes not in the charset are rendered as spaces.
+//
+// options's GeoM, ColorScale, Blend, and BlendSet are used. options can be nil.
+// The glyphs are always drawn with FilterNearest, as a linear filter would sample the texels of the neighboring cells
+// and make the edges of the glyphs bleed.
+//
+// Draw is not concurrent-safe for the same BitmapFont.
+func (b *BitmapFont) Draw(dst *ebiten.Image, str string, options *ebiten.DrawImageOptions) {
+	if options == nil {
+		options = &ebiten.DrawImageOptions{}
+	}
+
+	bounds := b.image.Bounds()
+	cols := bounds.Dx() / b.cellWidth
+	if cols == 0 {
+		return
+	}
+
+	cr := options.ColorScale.R()
+	cg := options.ColorScale.G()
+	cb := options.ColorScale.B()
+	ca := options.ColorScale.A()
+
+	b.vertices = b.vertices[:0]
+	b.indexBuf = b.indexBuf[:0]
+	var x, y int
+	for _, r := range str {
+		if r == '\n' {
+			x = 0
+			y += b.cellHeight
+			continue
+		}
+		idx, ok := b.indices[r]
+		if !ok {
+			x += b.cellWidth
+			continue
+		}
+
+		sx := float32(bounds.Min.X + (idx%cols)*b.cellWidth)
+		sy := float32(bounds.Min.Y + (idx/cols)*b.cellHeight)
+		sw, sh := float32(b.cellWidth), float32(b.cellHeight)
+		dx0, dy0 := options.GeoM.Apply(float64(x), float64(y))
+		dx1, dy1 := options.GeoM.Apply(float64(x+b.cellWidth), float64(y))
+		dx2, dy2 := options.GeoM.Apply(float64(x), float64(y+b.cellHeight))
+		dx3, dy3 := options.GeoM.Apply(float64(x+b.cellWidth), float64(y+b.cellHeight))
+
+		n := uint16(len(b.vertices))
+		b.vertices = append(b.vertices,
+			ebiten.Vertex{DstX: float32(dx0), DstY: float32(dy0), SrcX: sx, SrcY: sy, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+			ebiten.Vertex{DstX: float32(dx1), DstY: float32(dy1), SrcX: sx + sw, SrcY: sy, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+			ebiten.Vertex{DstX: float32(dx2), DstY: float32(dy2), SrcX: sx, SrcY: sy + sh, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+			ebiten.Vertex{DstX: float32(dx3), DstY: float32(dy3), SrcX: sx + sw, SrcY: sy + sh, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		)
+		b.indexBuf = append(b.indexBuf, n, n+1, n+2, n+1, n+2, n+3)
+		x += b.cellWidth
+
+		// Flush before the indices overflow.
+		if len(b.vertices) > 65536-4 {
+			b.flush(dst, options)
+		}
+	}
+	b.flush(dst, options)
+}
+
+func (b *BitmapFont) flush(dst *ebiten.Image, options *ebiten.DrawImageOptions) {
+	if len(b.indexBuf) == 0 {
+		return
+	}
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.Blend = options.Blend
+	op.BlendSet = options.BlendSet
+	op.Filter = ebiten.FilterNearest
+	op.FilterSet = true
+	dst.DrawTriangles(b.vertices, b.indexBuf, b.image, op)
+	b.vertices = b.vertices[:0]
+	b.indexBuf = b.indexBuf[:0]
+}
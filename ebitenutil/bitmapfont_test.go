@@ -0,0 +1,115 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+var (
+	bitmapFontRed   = color.RGBA{R: 0xff, A: 0xff}
+	bitmapFontGreen = color.RGBA{G: 0xff, A: 0xff}
+	bitmapFontBlue  = color.RGBA{B: 0xff, A: 0xff}
+)
+
+// newBitmapFontForTesting returns a font with 4x4 cells of 'A' in red, 'B' in green, and 'C' in blue.
+// The cells are laid out in 2 columns, so 'C' is in the second row.
+func newBitmapFontForTesting() *ebitenutil.BitmapFont {
+	const cw, ch = 4, 4
+	img := ebiten.NewImage(2*cw, 2*ch)
+	img.SubImage(image.Rect(0, 0, cw, ch)).(*ebiten.Image).Fill(bitmapFontRed)
+	img.SubImage(image.Rect(cw, 0, 2*cw, ch)).(*ebiten.Image).Fill(bitmapFontGreen)
+	img.SubImage(image.Rect(0, ch, cw, 2*ch)).(*ebiten.Image).Fill(bitmapFontBlue)
+	return ebitenutil.NewBitmapFont(img, cw, ch, []rune("ABC"))
+}
+
+func TestBitmapFontDraw(t *testing.T) {
+	f := newBitmapFontForTesting()
+
+	const w, h = 16, 8
+	dst := ebiten.NewImage(w, h)
+	// '?' is not in the charset, and is rendered as a space.
+	f.Draw(dst, "A?B\nC", nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			var want color.RGBA
+			switch {
+			case j < 4 && i < 4:
+				want = bitmapFontRed
+			case j < 4 && 8 <= i && i < 12:
+				want = bitmapFontGreen
+			case 4 <= j && i < 4:
+				want = bitmapFontBlue
+			}
+			if got := dst.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestBitmapFontDrawWithoutBleeding(t *testing.T) {
+	f := newBitmapFontForTesting()
+
+	// Scaling with a linear filter must not sample the neighboring cells.
+	const scale = 3.5
+	const w, h = 28, 14
+	dst := ebiten.NewImage(w, h)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.Filter = ebiten.FilterLinear
+	f.Draw(dst, "AB", op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			want := bitmapFontRed
+			if i >= 14 {
+				want = bitmapFontGreen
+			}
+			if got := dst.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestBitmapFontDrawColorScale(t *testing.T) {
+	f := newBitmapFontForTesting()
+
+	dst := ebiten.NewImage(4, 4)
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleAlpha(0.5)
+	f.Draw(dst, "A", op)
+
+	got := dst.At(0, 0).(color.RGBA)
+	want := color.RGBA{R: 0x80, A: 0x80}
+	near := func(a, b uint8) bool {
+		return a-b <= 1 || b-a <= 1
+	}
+	if !near(got.R, want.R) || got.G != 0 || got.B != 0 || !near(got.A, want.A) {
+		t.Errorf("dst.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
@@ -28,17 +28,18 @@ import (
 //go:embed text.png
 var text_png []byte
 
-var (
-	debugPrintTextImage     *ebiten.Image
-	debugPrintTextSubImages = map[rune]*ebiten.Image{}
-)
+var debugPrintFont *BitmapFont
 
 func init() {
 	img, _, err := image.Decode(bytes.NewReader(text_png))
 	if err != nil {
 		panic(err)
 	}
-	debugPrintTextImage = ebiten.NewImageFromImage(img)
+	charset := make([]rune, 0x100)
+	for i := range charset {
+		charset[i] = rune(i)
+	}
+	debugPrintFont = NewBitmapFont(ebiten.NewImageFromImage(img), 6, 16, charset)
 }
 
 // DebugPrint draws the string str on the image on left top corner.
@@ -57,31 +58,6 @@ func DebugPrintAt(image *ebiten.Image, str string, x, y int) {
 
 func drawDebugText(rt *ebiten.Image, str string, ox, oy int) {
 	op := &ebiten.DrawImageOptions{}
-	x := 0
-	y := 0
-	w := debugPrintTextImage.Bounds().Dx()
-	for _, c := range str {
-		const (
-			cw = 6
-			ch = 16
-		)
-		if c == '\n' {
-			x = 0
-			y += ch
-			continue
-		}
-		s, ok := debugPrintTextSubImages[c]
-		if !ok {
-			n := w / cw
-			sx := (int(c) % n) * cw
-			sy := (int(c) / n) * ch
-			s = debugPrintTextImage.SubImage(image.Rect(sx, sy, sx+cw, sy+ch)).(*ebiten.Image)
-			debugPrintTextSubImages[c] = s
-		}
-		op.GeoM.Reset()
-		op.GeoM.Translate(float64(x), float64(y))
-		op.GeoM.Translate(float64(ox+1), float64(oy))
-		rt.DrawImage(s, op)
-		x += cw
-	}
+	op.GeoM.Translate(float64(ox+1), float64(oy))
+	debugPrintFont.Draw(rt, str, op)
 }
@@ -90,6 +90,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		ebitenutil.DebugPrint(screen, "CursorShape: Move")
 	case ebiten.CursorShapeNotAllowed:
 		ebitenutil.DebugPrint(screen, "CursorShape: Not Allowed")
+	case ebiten.CursorShapeGrab:
+		ebitenutil.DebugPrint(screen, "CursorShape: Grab")
+	case ebiten.CursorShapeGrabbing:
+		ebitenutil.DebugPrint(screen, "CursorShape: Grabbing")
 	}
 }
 
@@ -110,6 +114,8 @@ func main() {
 			image.Rect(400, 200, 500, 300): ebiten.CursorShapeNWSEResize,
 			image.Rect(100, 300, 200, 400): ebiten.CursorShapeMove,
 			image.Rect(200, 300, 300, 400): ebiten.CursorShapeNotAllowed,
+			image.Rect(300, 300, 400, 400): ebiten.CursorShapeGrab,
+			image.Rect(400, 300, 500, 400): ebiten.CursorShapeGrabbing,
 		},
 		gridColors: map[image.Rectangle]color.Color{},
 	}
@@ -26,6 +26,8 @@ import (
 type Game struct {
 	highDPIImageCh chan *ebiten.Image
 	highDPIImage   *ebiten.Image
+
+	deviceScaleFactor float64
 }
 
 func NewGame() *Game {
@@ -84,7 +86,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	// Scale the image by the device ratio so that the rendering result can be same
 	// on various (different-DPI) environments.
-	scale := ebiten.DeviceScaleFactor()
+	scale := g.deviceScaleFactor
 	op.GeoM.Scale(scale, scale)
 
 	// Move the image's center to the screen's center.
@@ -93,16 +95,26 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	op.Filter = ebiten.FilterLinear
 	screen.DrawImage(g.highDPIImage, op)
 
-	ebitenutil.DebugPrint(screen, fmt.Sprintf("(Init) Device Scale Ratio: %0.2f", scale))
+	ebitenutil.DebugPrint(screen, fmt.Sprintf("Device Scale Ratio: %0.2f", scale))
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	// As Game implements the interface DevicePixelLayouter, Layout is not called and LayoutDevicePixels is called instead.
+	// Layout is still implemented in the same way in case LayoutDevicePixels is not available.
 	// The unit of outsideWidth/Height is device-independent pixels.
 	// By multiplying them by the device scale factor, we can get a hi-DPI screen size.
 	s := ebiten.DeviceScaleFactor()
+	g.deviceScaleFactor = s
 	return int(float64(outsideWidth) * s), int(float64(outsideHeight) * s)
 }
 
+func (g *Game) LayoutDevicePixels(outsideWidth, outsideHeight float64, deviceScaleFactor float64) (float64, float64) {
+	// The unit of outsideWidth/Height is device pixels.
+	// By returning them as they are, the screen has the same size as the framebuffer and is rendered without scaling.
+	g.deviceScaleFactor = deviceScaleFactor
+	return outsideWidth, outsideHeight
+}
+
 func main() {
 	ebiten.SetWindowSize(640, 480)
 	ebiten.SetWindowTitle("High DPI (Ebitengine Demo)")
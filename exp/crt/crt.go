@@ -0,0 +1,155 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package crt provides a post-processing shader to emulate a CRT display, with scanlines, curvature, bloom, and an aperture grille mask.
+// This package is experimental and the API might be changed in the future.
+package crt
+
+import (
+	_ "embed"
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed crt.kage
+var shaderSrc []byte
+
+var (
+	theShader     *ebiten.Shader
+	theShaderOnce sync.Once
+)
+
+// Shader returns the Kage shader to emulate a CRT display.
+//
+// The shader has these uniform variables:
+//
+//   - Scanline (float): the intensity of the scanlines in [0, 1].
+//   - Curvature (float): the strength of the barrel distortion.
+//   - Bloom (float): the strength of the glow around bright pixels.
+//   - Mask (float): the intensity of the RGB aperture grille mask in [0, 1].
+//
+// The unit of the shader is pixels, and the source image is the first image (imageSrc0).
+// The scanlines are rendered for each pixel row of the source image,
+// and the mask is rendered for each pixel column of the destination image.
+//
+// Usually Draw is enough, but you can use the shader directly e.g. with DrawTrianglesShader.
+func Shader() *ebiten.Shader {
+	theShaderOnce.Do(func() {
+		s, err := ebiten.NewShader(shaderSrc)
+		if err != nil {
+			panic(fmt.Sprintf("crt: NewShader failed: %v", err))
+		}
+		theShader = s
+	})
+	return theShader
+}
+
+// Options represents options for Draw.
+type Options struct {
+	// Scanline is the intensity of the scanlines in [0, 1].
+	// 0 means no scanlines.
+	Scanline float64
+
+	// Curvature is the strength of the barrel distortion of the screen.
+	// 0 means a flat screen.
+	Curvature float64
+
+	// Bloom is the strength of the glow around bright pixels.
+	// 0 means no glow.
+	Bloom float64
+
+	// Mask is the intensity of the RGB aperture grille mask in [0, 1].
+	// 0 means no mask.
+	Mask float64
+}
+
+// DefaultOptions returns the recommended options.
+func DefaultOptions() *Options {
+	return &Options{
+		Scanline:  0.5,
+		Curvature: 0.1,
+		Bloom:     0.2,
+		Mask:      0.3,
+	}
+}
+
+// Destination is a destination image for Draw.
+//
+// Both *ebiten.Image and ebiten.FinalScreen implement Destination.
+type Destination interface {
+	Bounds() image.Rectangle
+	DrawTrianglesShader(vertices []ebiten.Vertex, indices []uint16, shader *ebiten.Shader, options *ebiten.DrawTrianglesShaderOptions)
+}
+
+// Draw draws src onto the whole region of dst with the CRT effect as a full-screen pass.
+// src is stretched to fit dst.
+//
+// A typical usage is to render the game at a low resolution onto an offscreen image,
+// and call Draw at DrawFinalScreen of ebiten.FinalScreenDrawer with the offscreen as src.
+//
+// If options is nil, DefaultOptions() is used.
+func Draw(dst Destination, src *ebiten.Image, options *Options) {
+	if options == nil {
+		options = DefaultOptions()
+	}
+
+	db := dst.Bounds()
+	sb := src.Bounds()
+	vs := []ebiten.Vertex{
+		{
+			DstX: float32(db.Min.X),
+			DstY: float32(db.Min.Y),
+			SrcX: float32(sb.Min.X),
+			SrcY: float32(sb.Min.Y),
+		},
+		{
+			DstX: float32(db.Max.X),
+			DstY: float32(db.Min.Y),
+			SrcX: float32(sb.Max.X),
+			SrcY: float32(sb.Min.Y),
+		},
+		{
+			DstX: float32(db.Min.X),
+			DstY: float32(db.Max.Y),
+			SrcX: float32(sb.Min.X),
+			SrcY: float32(sb.Max.Y),
+		},
+		{
+			DstX: float32(db.Max.X),
+			DstY: float32(db.Max.Y),
+			SrcX: float32(sb.Max.X),
+			SrcY: float32(sb.Max.Y),
+		},
+	}
+	for i := range vs {
+		vs[i].ColorR = 1
+		vs[i].ColorG = 1
+		vs[i].ColorB = 1
+		vs[i].ColorA = 1
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = map[string]any{
+		"Scanline":  float32(options.Scanline),
+		"Curvature": float32(options.Curvature),
+		"Bloom":     float32(options.Bloom),
+		"Mask":      float32(options.Mask),
+	}
+	dst.DrawTrianglesShader(vs, is, Shader(), op)
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crt
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+)
+
+func TestCompileShader(t *testing.T) {
+	p, err := graphics.CompileShader(shaderSrc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string]struct{}{}
+	for _, n := range p.UniformNames {
+		names[n] = struct{}{}
+	}
+	for _, n := range []string{"Scanline", "Curvature", "Bloom", "Mask"} {
+		if _, ok := names[n]; !ok {
+			t.Errorf("uniform %q must exist", n)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gesture
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type TouchInputForTesting struct {
+	ID ebiten.TouchID
+	X  float64
+	Y  float64
+}
+
+func (r *Recognizer) UpdateForTesting(inputs []TouchInputForTesting, now time.Time) {
+	r.inputsBuf = r.inputsBuf[:0]
+	for _, in := range inputs {
+		r.inputsBuf = append(r.inputsBuf, touchInput{
+			id: in.ID,
+			x:  in.X,
+			y:  in.Y,
+		})
+	}
+	r.update(r.inputsBuf, now)
+}
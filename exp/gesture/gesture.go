@@ -0,0 +1,428 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gesture provides gesture recognizers like tap, swipe, and pinch based on touch input.
+// This package is experimental and the API might be changed in the future.
+package gesture
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Kind represents a kind of gestures.
+type Kind int
+
+const (
+	// KindTap is a short touch without moving.
+	KindTap Kind = iota
+
+	// KindDoubleTap is a tap soon after another tap at a near position.
+	// The second tap is reported as KindDoubleTap instead of KindTap.
+	KindDoubleTap
+
+	// KindLongPress is a long touch without moving.
+	// KindLongPress is reported while the touch is still held.
+	KindLongPress
+
+	// KindSwipe is a quick move of a touch.
+	// KindSwipe is reported when the touch is released.
+	KindSwipe
+
+	// KindPinch is a move of two touches.
+	// KindPinch is reported at every update while the two touches move.
+	KindPinch
+)
+
+// Direction represents a direction of a swipe.
+type Direction int
+
+const (
+	DirectionNone Direction = iota
+	DirectionLeft
+	DirectionRight
+	DirectionUp
+	DirectionDown
+)
+
+// Event represents a recognized gesture.
+type Event struct {
+	// Kind is the kind of the gesture.
+	Kind Kind
+
+	// X and Y represent the position of the gesture in logical pixels.
+	// For a tap, a double tap, and a long press, this is the touch position.
+	// For a swipe, this is the position where the touch started.
+	// For a pinch, this is the center of the two touches.
+	X float64
+	Y float64
+
+	// Direction is the direction of a swipe.
+	// Direction is DirectionNone for the other kinds.
+	Direction Direction
+
+	// VelocityX and VelocityY are the average velocity of a swipe in logical pixels per second.
+	VelocityX float64
+	VelocityY float64
+
+	// Scale is the ratio of the current distance to the initial distance of the two touches of a pinch.
+	Scale float64
+
+	// Rotation is the rotation angle in radian of the two touches of a pinch from the initial angle.
+	// Rotation is in [-π, π].
+	Rotation float64
+
+	// Time is the time when the gesture is recognized.
+	Time time.Time
+}
+
+// Options represents options for a Recognizer.
+//
+// For a zero value of an option, the default value is used.
+type Options struct {
+	// TapMaxDuration is the maximum duration of a tap.
+	// The default value is 300[ms].
+	TapMaxDuration time.Duration
+
+	// TapMaxDistance is the maximum distance in logical pixels that a touch can move in a tap and a long press.
+	// The default value is 10.
+	TapMaxDistance float64
+
+	// DoubleTapInterval is the maximum interval between two taps of a double tap.
+	// The default value is 300[ms].
+	DoubleTapInterval time.Duration
+
+	// LongPressDuration is the minimum duration of a long press.
+	// The default value is 500[ms].
+	LongPressDuration time.Duration
+
+	// SwipeMinDistance is the minimum distance in logical pixels of a swipe.
+	// The default value is 30.
+	SwipeMinDistance float64
+
+	// SwipeMinVelocity is the minimum velocity in logical pixels per second of a swipe.
+	// The default value is 200.
+	SwipeMinVelocity float64
+}
+
+type touch struct {
+	id ebiten.TouchID
+
+	startX    float64
+	startY    float64
+	startTime time.Time
+
+	x float64
+	y float64
+
+	moved       bool
+	longPressed bool
+	pinched     bool
+}
+
+type touchInput struct {
+	id ebiten.TouchID
+	x  float64
+	y  float64
+}
+
+type pinch struct {
+	id0      ebiten.TouchID
+	id1      ebiten.TouchID
+	distance float64
+	angle    float64
+
+	lastScale    float64
+	lastRotation float64
+}
+
+// Recognizer recognizes gestures from touch input.
+type Recognizer struct {
+	options Options
+
+	touches []*touch
+	pinch   *pinch
+
+	lastTapX    float64
+	lastTapY    float64
+	lastTapTime time.Time
+
+	events []Event
+
+	touchIDsBuf []ebiten.TouchID
+	inputsBuf   []touchInput
+}
+
+// NewRecognizer creates a new Recognizer.
+//
+// If options is nil, the default options are used.
+func NewRecognizer(options *Options) *Recognizer {
+	r := &Recognizer{}
+	if options != nil {
+		r.options = *options
+	}
+	if r.options.TapMaxDuration == 0 {
+		r.options.TapMaxDuration = 300 * time.Millisecond
+	}
+	if r.options.TapMaxDistance == 0 {
+		r.options.TapMaxDistance = 10
+	}
+	if r.options.DoubleTapInterval == 0 {
+		r.options.DoubleTapInterval = 300 * time.Millisecond
+	}
+	if r.options.LongPressDuration == 0 {
+		r.options.LongPressDuration = 500 * time.Millisecond
+	}
+	if r.options.SwipeMinDistance == 0 {
+		r.options.SwipeMinDistance = 30
+	}
+	if r.options.SwipeMinVelocity == 0 {
+		r.options.SwipeMinVelocity = 200
+	}
+	return r
+}
+
+// Update updates the recognizer with the current touch input.
+//
+// Update must be called once in every Update of a game, and before AppendEvents is called.
+func (r *Recognizer) Update() {
+	r.touchIDsBuf = ebiten.AppendTouchIDs(r.touchIDsBuf[:0])
+	r.inputsBuf = r.inputsBuf[:0]
+	for _, id := range r.touchIDsBuf {
+		x, y := ebiten.TouchPosition(id)
+		r.inputsBuf = append(r.inputsBuf, touchInput{
+			id: id,
+			x:  float64(x),
+			y:  float64(y),
+		})
+	}
+	r.update(r.inputsBuf, time.Now())
+}
+
+// AppendEvents appends the gestures recognized at the last Update to events, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+func (r *Recognizer) AppendEvents(events []Event) []Event {
+	return append(events, r.events...)
+}
+
+func (r *Recognizer) update(inputs []touchInput, now time.Time) {
+	r.events = r.events[:0]
+
+	// Update the existing touches, and handle the released touches.
+	var idx int
+	for _, t := range r.touches {
+		in, ok := findTouchInput(inputs, t.id)
+		if !ok {
+			r.release(t, now)
+			continue
+		}
+		t.x = in.x
+		t.y = in.y
+		if math.Hypot(t.x-t.startX, t.y-t.startY) > r.options.TapMaxDistance {
+			t.moved = true
+		}
+		r.touches[idx] = t
+		idx++
+	}
+	for i := idx; i < len(r.touches); i++ {
+		r.touches[i] = nil
+	}
+	r.touches = r.touches[:idx]
+
+	// Add the new touches.
+	for _, in := range inputs {
+		if r.findTouch(in.id) != nil {
+			continue
+		}
+		r.touches = append(r.touches, &touch{
+			id:        in.id,
+			startX:    in.x,
+			startY:    in.y,
+			startTime: now,
+			x:         in.x,
+			y:         in.y,
+		})
+	}
+
+	r.updatePinch(now)
+
+	// Long presses.
+	for _, t := range r.touches {
+		if t.moved || t.longPressed || t.pinched {
+			continue
+		}
+		if now.Sub(t.startTime) < r.options.LongPressDuration {
+			continue
+		}
+		t.longPressed = true
+		r.events = append(r.events, Event{
+			Kind: KindLongPress,
+			X:    t.x,
+			Y:    t.y,
+			Time: now,
+		})
+	}
+}
+
+func (r *Recognizer) release(t *touch, now time.Time) {
+	if t.pinched || t.longPressed {
+		return
+	}
+
+	d := now.Sub(t.startTime)
+	if !t.moved {
+		if d > r.options.TapMaxDuration {
+			return
+		}
+		if !r.lastTapTime.IsZero() && now.Sub(r.lastTapTime) <= r.options.DoubleTapInterval &&
+			math.Hypot(t.x-r.lastTapX, t.y-r.lastTapY) <= 2*r.options.TapMaxDistance {
+			r.lastTapTime = time.Time{}
+			r.events = append(r.events, Event{
+				Kind: KindDoubleTap,
+				X:    t.x,
+				Y:    t.y,
+				Time: now,
+			})
+			return
+		}
+		r.lastTapX = t.x
+		r.lastTapY = t.y
+		r.lastTapTime = now
+		r.events = append(r.events, Event{
+			Kind: KindTap,
+			X:    t.x,
+			Y:    t.y,
+			Time: now,
+		})
+		return
+	}
+
+	dx := t.x - t.startX
+	dy := t.y - t.startY
+	if math.Hypot(dx, dy) < r.options.SwipeMinDistance {
+		return
+	}
+	sec := d.Seconds()
+	if sec <= 0 {
+		return
+	}
+	vx := dx / sec
+	vy := dy / sec
+	if math.Hypot(vx, vy) < r.options.SwipeMinVelocity {
+		return
+	}
+
+	var dir Direction
+	if math.Abs(dx) >= math.Abs(dy) {
+		if dx < 0 {
+			dir = DirectionLeft
+		} else {
+			dir = DirectionRight
+		}
+	} else {
+		if dy < 0 {
+			dir = DirectionUp
+		} else {
+			dir = DirectionDown
+		}
+	}
+	r.events = append(r.events, Event{
+		Kind:      KindSwipe,
+		X:         t.startX,
+		Y:         t.startY,
+		Direction: dir,
+		VelocityX: vx,
+		VelocityY: vy,
+		Time:      now,
+	})
+}
+
+func (r *Recognizer) updatePinch(now time.Time) {
+	if r.pinch != nil {
+		t0 := r.findTouch(r.pinch.id0)
+		t1 := r.findTouch(r.pinch.id1)
+		if t0 == nil || t1 == nil {
+			r.pinch = nil
+			return
+		}
+
+		distance := math.Hypot(t1.x-t0.x, t1.y-t0.y)
+		angle := math.Atan2(t1.y-t0.y, t1.x-t0.x)
+		scale := 1.0
+		if r.pinch.distance > 0 {
+			scale = distance / r.pinch.distance
+		}
+		rotation := normalizeAngle(angle - r.pinch.angle)
+		if scale == r.pinch.lastScale && rotation == r.pinch.lastRotation {
+			return
+		}
+		r.pinch.lastScale = scale
+		r.pinch.lastRotation = rotation
+		r.events = append(r.events, Event{
+			Kind:     KindPinch,
+			X:        (t0.x + t1.x) / 2,
+			Y:        (t0.y + t1.y) / 2,
+			Scale:    scale,
+			Rotation: rotation,
+			Time:     now,
+		})
+		return
+	}
+
+	if len(r.touches) < 2 {
+		return
+	}
+	t0 := r.touches[0]
+	t1 := r.touches[1]
+	t0.pinched = true
+	t1.pinched = true
+	r.pinch = &pinch{
+		id0:          t0.id,
+		id1:          t1.id,
+		distance:     math.Hypot(t1.x-t0.x, t1.y-t0.y),
+		angle:        math.Atan2(t1.y-t0.y, t1.x-t0.x),
+		lastScale:    1,
+		lastRotation: 0,
+	}
+}
+
+func (r *Recognizer) findTouch(id ebiten.TouchID) *touch {
+	for _, t := range r.touches {
+		if t.id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func findTouchInput(inputs []touchInput, id ebiten.TouchID) (touchInput, bool) {
+	for _, in := range inputs {
+		if in.id == id {
+			return in, true
+		}
+	}
+	return touchInput{}, false
+}
+
+func normalizeAngle(a float64) float64 {
+	for a > math.Pi {
+		a -= 2 * math.Pi
+	}
+	for a < -math.Pi {
+		a += 2 * math.Pi
+	}
+	return a
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gesture_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/gesture"
+)
+
+type touch = gesture.TouchInputForTesting
+
+func kinds(events []gesture.Event) []gesture.Kind {
+	var ks []gesture.Kind
+	for _, e := range events {
+		ks = append(ks, e.Kind)
+	}
+	return ks
+}
+
+func TestTapAndDoubleTap(t *testing.T) {
+	r := gesture.NewRecognizer(nil)
+	now := time.Unix(0, 0)
+
+	r.UpdateForTesting([]touch{{ID: 1, X: 10, Y: 10}}, now)
+	now = now.Add(50 * time.Millisecond)
+	r.UpdateForTesting(nil, now)
+	events := r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Kind != gesture.KindTap {
+		t.Fatalf("got: %v, want: [KindTap]", kinds(events))
+	}
+	if events[0].X != 10 || events[0].Y != 10 {
+		t.Errorf("position: got: (%v, %v), want: (10, 10)", events[0].X, events[0].Y)
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	r.UpdateForTesting([]touch{{ID: 2, X: 12, Y: 11}}, now)
+	now = now.Add(50 * time.Millisecond)
+	r.UpdateForTesting(nil, now)
+	events = r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Kind != gesture.KindDoubleTap {
+		t.Fatalf("got: %v, want: [KindDoubleTap]", kinds(events))
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	r := gesture.NewRecognizer(nil)
+	now := time.Unix(0, 0)
+
+	r.UpdateForTesting([]touch{{ID: 1, X: 10, Y: 10}}, now)
+	now = now.Add(600 * time.Millisecond)
+	r.UpdateForTesting([]touch{{ID: 1, X: 11, Y: 10}}, now)
+	events := r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Kind != gesture.KindLongPress {
+		t.Fatalf("got: %v, want: [KindLongPress]", kinds(events))
+	}
+
+	// Releasing a long press is not a tap.
+	now = now.Add(16 * time.Millisecond)
+	r.UpdateForTesting(nil, now)
+	if events := r.AppendEvents(nil); len(events) != 0 {
+		t.Errorf("got: %v, want: []", kinds(events))
+	}
+}
+
+func TestSwipe(t *testing.T) {
+	r := gesture.NewRecognizer(nil)
+	now := time.Unix(0, 0)
+
+	r.UpdateForTesting([]touch{{ID: 1, X: 100, Y: 100}}, now)
+	now = now.Add(50 * time.Millisecond)
+	r.UpdateForTesting([]touch{{ID: 1, X: 70, Y: 102}}, now)
+	now = now.Add(50 * time.Millisecond)
+	r.UpdateForTesting([]touch{{ID: 1, X: 40, Y: 104}}, now)
+	r.UpdateForTesting(nil, now)
+
+	events := r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Kind != gesture.KindSwipe {
+		t.Fatalf("got: %v, want: [KindSwipe]", kinds(events))
+	}
+	e := events[0]
+	if got, want := e.Direction, gesture.DirectionLeft; got != want {
+		t.Errorf("Direction: got: %v, want: %v", got, want)
+	}
+	if got, want := e.VelocityX, -600.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("VelocityX: got: %v, want: %v", got, want)
+	}
+}
+
+func TestPinch(t *testing.T) {
+	r := gesture.NewRecognizer(nil)
+	now := time.Unix(0, 0)
+
+	r.UpdateForTesting([]touch{{ID: 1, X: 100, Y: 100}, {ID: 2, X: 200, Y: 100}}, now)
+	if events := r.AppendEvents(nil); len(events) != 0 {
+		t.Fatalf("got: %v, want: []", kinds(events))
+	}
+
+	now = now.Add(16 * time.Millisecond)
+	r.UpdateForTesting([]touch{{ID: 1, X: 50, Y: 100}, {ID: 2, X: 250, Y: 100}}, now)
+	events := r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Kind != gesture.KindPinch {
+		t.Fatalf("got: %v, want: [KindPinch]", kinds(events))
+	}
+	e := events[0]
+	if got, want := e.Scale, 2.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Scale: got: %v, want: %v", got, want)
+	}
+	if got, want := e.Rotation, 0.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Rotation: got: %v, want: %v", got, want)
+	}
+	if e.X != 150 || e.Y != 100 {
+		t.Errorf("position: got: (%v, %v), want: (150, 100)", e.X, e.Y)
+	}
+
+	now = now.Add(16 * time.Millisecond)
+	r.UpdateForTesting([]touch{{ID: 1, X: 150, Y: 50}, {ID: 2, X: 150, Y: 150}}, now)
+	events = r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Kind != gesture.KindPinch {
+		t.Fatalf("got: %v, want: [KindPinch]", kinds(events))
+	}
+	if got, want := events[0].Rotation, math.Pi/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Rotation: got: %v, want: %v", got, want)
+	}
+
+	// Releasing the touches of a pinch doesn't cause taps.
+	now = now.Add(16 * time.Millisecond)
+	r.UpdateForTesting(nil, now)
+	if events := r.AppendEvents(nil); len(events) != 0 {
+		t.Errorf("got: %v, want: []", kinds(events))
+	}
+}
@@ -48,6 +48,19 @@ func Start(x, y int) (states chan State, close func()) {
 	return theTextInput.Start(int(cx), int(cy))
 }
 
+// SetRect sets the rectangle of the text being input, e.g. a text field or the caret, in the logical coordinate.
+// The rectangle is used to position the OS's IME candidate window near the text.
+//
+// SetRect can be called during text inputting, e.g. when the caret moves.
+// Start resets the rectangle to the given position.
+//
+// SetRect does nothing if the current environment doesn't support this package.
+func SetRect(x, y, width, height int) {
+	cx0, cy0 := ui.Get().LogicalPositionToClientPosition(float64(x), float64(y))
+	cx1, cy1 := ui.Get().LogicalPositionToClientPosition(float64(x+width), float64(y+height))
+	theTextInput.SetRect(int(cx0), int(cy0), int(cx1-cx0), int(cy1-cy0))
+}
+
 func convertUTF16CountToByteCount(text string, c int) int {
 	return len(string(utf16.Decode(utf16.Encode([]rune(text))[:c])))
 }
@@ -43,6 +43,21 @@ package textinput
 //   y = [[window contentView] frame].size.height - y - 4;
 //   [textInputClient setFrame:NSMakeRect(x, y, 1, 1)];
 // }
+//
+// static void setRect(int x, int y, int width, int height) {
+//   TextInputClient* textInputClient = getTextInputClient();
+//   NSWindow* window = [[NSApplication sharedApplication] mainWindow];
+//
+//   if (width < 1) {
+//     width = 1;
+//   }
+//   if (height < 1) {
+//     height = 1;
+//   }
+//   y = [[window contentView] frame].size.height - y - height;
+//   [textInputClient setFrame:NSMakeRect(x, y, width, height)];
+//   [[textInputClient inputContext] invalidateCharacterCoordinates];
+// }
 import "C"
 
 import (
@@ -70,6 +85,12 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 	return session.ch, session.end
 }
 
+func (t *textInput) SetRect(x, y, width, height int) {
+	ui.Get().RunOnMainThread(func() {
+		C.setRect(C.int(x), C.int(y), C.int(width), C.int(height))
+	})
+}
+
 //export ebitengine_textinput_update
 func ebitengine_textinput_update(text *C.char, start, end C.int, committed C.int) {
 	theTextInput.update(C.GoString(text), int(start), int(end), committed != 0)
@@ -123,6 +123,24 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 	return s.ch, s.end
 }
 
+func (t *textInput) SetRect(x, y, width, height int) {
+	if !t.textareaElement.Truthy() {
+		return
+	}
+
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	style := t.textareaElement.Get("style")
+	style.Set("left", fmt.Sprintf("%dpx", x))
+	style.Set("top", fmt.Sprintf("%dpx", y))
+	style.Set("width", fmt.Sprintf("%dpx", width))
+	style.Set("height", fmt.Sprintf("%dpx", height))
+}
+
 func (t *textInput) trySend(committed bool) {
 	if t.session == nil {
 		return
@@ -23,3 +23,6 @@ var theTextInput textInput
 func (t *textInput) Start(x, y int) (chan State, func()) {
 	return nil, nil
 }
+
+func (t *textInput) SetRect(x, y, width, height int) {
+}
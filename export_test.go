@@ -17,3 +17,22 @@ package ebiten
 var (
 	ImageToBytes = imageToBytes
 )
+
+const ImageLoaderBytesPerTick = imageLoaderBytesPerTick
+
+func UpdateImageLoaderForTesting() {
+	theImageLoader.update()
+}
+
+func ImageLoaderDecodedJobCountForTesting() int {
+	theImageLoader.m.Lock()
+	defer theImageLoader.m.Unlock()
+
+	var n int
+	for _, job := range theImageLoader.jobs {
+		if job.decoded {
+			n++
+		}
+	}
+	return n
+}
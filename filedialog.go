@@ -0,0 +1,94 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// FileDialogFilter represents a filter of files shown in a file dialog.
+type FileDialogFilter struct {
+	// Name is a human-readable name of the filter, e.g. "Images".
+	Name string
+
+	// Extensions is the file extensions of the filter, e.g. "png" and "jpg".
+	// A leading dot is optional.
+	Extensions []string
+}
+
+// FileDialogOptions represents options for OpenFileDialog and SaveFileDialog.
+type FileDialogOptions struct {
+	// Title is the title of the dialog.
+	// If Title is empty, the platform's default title is used.
+	Title string
+
+	// Directory is the initial directory of the dialog.
+	// If Directory is empty, the platform's default directory is used.
+	Directory string
+
+	// FileName is the initial file name of the dialog.
+	// On macOS, FileName is used only by SaveFileDialog.
+	FileName string
+
+	// Filters is the filters of files.
+	// On macOS, the filters are merged into one list of allowed extensions.
+	// If Filters is empty, all the files are shown.
+	Filters []FileDialogFilter
+}
+
+func (f *FileDialogOptions) toUI() *ui.FileDialogOptions {
+	if f == nil {
+		return nil
+	}
+	op := &ui.FileDialogOptions{
+		Title:     f.Title,
+		Directory: f.Directory,
+		FileName:  f.FileName,
+	}
+	for _, filter := range f.Filters {
+		op.Filters = append(op.Filters, ui.FileDialogFilter{
+			Name:       filter.Name,
+			Extensions: filter.Extensions,
+		})
+	}
+	return op
+}
+
+// OpenFileDialog shows a native dialog to choose a file to open, and returns the chosen file path.
+//
+// OpenFileDialog blocks until the dialog is closed.
+// If the dialog is canceled, OpenFileDialog returns an empty string without an error.
+//
+// OpenFileDialog is available only on Windows and macOS while the game is running, e.g. in Update.
+// Otherwise, OpenFileDialog returns an error.
+//
+// options can be nil.
+func OpenFileDialog(options *FileDialogOptions) (string, error) {
+	return ui.Get().ShowFileDialog(options.toUI(), false)
+}
+
+// SaveFileDialog shows a native dialog to choose a file path to save, and returns the chosen file path.
+//
+// SaveFileDialog blocks until the dialog is closed.
+// If the dialog is canceled, SaveFileDialog returns an empty string without an error.
+// SaveFileDialog doesn't create the file.
+//
+// SaveFileDialog is available only on Windows and macOS while the game is running, e.g. in Update.
+// Otherwise, SaveFileDialog returns an error.
+//
+// options can be nil.
+func SaveFileDialog(options *FileDialogOptions) (string, error) {
+	return ui.Get().ShowFileDialog(options.toUI(), true)
+}
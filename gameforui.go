@@ -17,7 +17,9 @@ package ebiten
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"math"
+	"sync"
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
@@ -61,6 +63,33 @@ func setScreenFilterEnabled(enabled bool) {
 	atomic.StoreInt32(&screenFilterEnabled, v)
 }
 
+// tick is the number of completed Update calls.
+var tick int64
+
+var (
+	screenFillColor color.Color
+	screenFillImage *Image
+	screenFillM     sync.Mutex
+)
+
+func getScreenFill() (color.Color, *Image) {
+	screenFillM.Lock()
+	defer screenFillM.Unlock()
+	return screenFillColor, screenFillImage
+}
+
+func setScreenFillColor(clr color.Color) {
+	screenFillM.Lock()
+	defer screenFillM.Unlock()
+	screenFillColor = clr
+}
+
+func setScreenFillImage(img *Image) {
+	screenFillM.Lock()
+	defer screenFillM.Unlock()
+	screenFillImage = img
+}
+
 type gameForUI struct {
 	game         Game
 	offscreen    *Image
@@ -68,12 +97,14 @@ type gameForUI struct {
 	screenShader *Shader
 	imageDumper  imageDumper
 	transparent  bool
+	antialias    bool
 }
 
-func newGameForUI(game Game, transparent bool) *gameForUI {
+func newGameForUI(game Game, transparent bool, antialias bool) *gameForUI {
 	g := &gameForUI{
 		game:        game,
 		transparent: transparent,
+		antialias:   antialias,
 	}
 
 	s, err := NewShader([]byte(screenShaderSrc))
@@ -86,10 +117,8 @@ func newGameForUI(game Game, transparent bool) *gameForUI {
 }
 
 func (g *gameForUI) NewOffscreenImage(width, height int) *ui.Image {
-	if g.offscreen != nil {
-		g.offscreen.Deallocate()
-		g.offscreen = nil
-	}
+	old := g.offscreen
+	g.offscreen = nil
 
 	// Keep the offscreen an unmanaged image that is always isolated from an atlas (#1938).
 	// The shader program for the screen is special and doesn't work well with an image on an atlas.
@@ -101,6 +130,21 @@ func (g *gameForUI) NewOffscreenImage(width, height int) *ui.Image {
 		imageType = atlas.ImageTypeVolatile
 	}
 	g.offscreen = newImage(image.Rect(0, 0, width, height), imageType)
+	if g.antialias {
+		g.offscreen.image.SetAntiAlias(true)
+	}
+
+	if old != nil {
+		// When only the image type is changed, e.g. by SetScreenClearedEveryFrame(false), keep the content
+		// so that the change doesn't cause a visible hitch.
+		if !old.isDisposed() && old.Bounds().Dx() == width && old.Bounds().Dy() == height {
+			op := &DrawImageOptions{}
+			op.Blend = BlendCopy
+			g.offscreen.DrawImage(old, op)
+		}
+		old.Deallocate()
+	}
+
 	return g.offscreen.image
 }
 
@@ -114,7 +158,10 @@ func (g *gameForUI) NewScreenImage(width, height int) *ui.Image {
 	return g.screen.image
 }
 
-func (g *gameForUI) Layout(outsideWidth, outsideHeight float64) (float64, float64) {
+func (g *gameForUI) Layout(outsideWidth, outsideHeight float64, deviceScaleFactor float64) (float64, float64) {
+	if l, ok := g.game.(DevicePixelLayouter); ok {
+		return l.LayoutDevicePixels(outsideWidth*deviceScaleFactor, outsideHeight*deviceScaleFactor, deviceScaleFactor)
+	}
 	if l, ok := g.game.(LayoutFer); ok {
 		return l.LayoutF(outsideWidth, outsideHeight)
 	}
@@ -138,10 +185,31 @@ func (g *gameForUI) UpdateInputState(fn func(*ui.InputState)) {
 	theInputState.update(fn)
 }
 
+func (g *gameForUI) SetUpdatePaused(paused bool) {
+	l, ok := g.game.(PauseListener)
+	if !ok {
+		return
+	}
+	if paused {
+		l.OnPause()
+	} else {
+		l.OnResume()
+	}
+}
+
+func (g *gameForUI) SetFocused(focused bool) {
+	l, ok := g.game.(FocusListener)
+	if !ok {
+		return
+	}
+	l.OnFocusChanged(focused)
+}
+
 func (g *gameForUI) Update() error {
 	if err := g.game.Update(); err != nil {
 		return err
 	}
+	atomic.AddInt64(&tick, 1)
 	if err := g.imageDumper.update(); err != nil {
 		return err
 	}
@@ -149,20 +217,33 @@ func (g *gameForUI) Update() error {
 }
 
 func (g *gameForUI) DrawOffscreen() error {
+	runFrameBudgetCallbackIfNeeded()
 	g.game.Draw(g.offscreen)
 	if err := g.imageDumper.dump(g.offscreen, g.transparent); err != nil {
 		return err
 	}
+	if err := theRecorder.record(g.offscreen); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
+func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64, region image.Rectangle) {
 	var geoM GeoM
 	geoM.Scale(scale, scale)
 	geoM.Translate(offsetX, offsetY)
 
+	screen := g.screen
+	if region != screen.Bounds() {
+		// Only the dirty region is rendered. The sub-image clips rendering results to the region.
+		screen = screen.SubImage(region).(*Image)
+		screen.Clear()
+	}
+
+	g.fillScreenBars(screen, scale, offsetX, offsetY)
+
 	if d, ok := g.game.(FinalScreenDrawer); ok {
-		d.DrawFinalScreen(g.screen, g.offscreen, geoM)
+		d.DrawFinalScreen(screen, g.offscreen, geoM)
 		return
 	}
 
@@ -170,17 +251,76 @@ func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
 	case !isScreenFilterEnabled(), math.Floor(scale) == scale:
 		op := &DrawImageOptions{}
 		op.GeoM = geoM
-		g.screen.DrawImage(g.offscreen, op)
+		screen.DrawImage(g.offscreen, op)
 	case scale < 1:
 		op := &DrawImageOptions{}
 		op.GeoM = geoM
 		op.Filter = FilterLinear
-		g.screen.DrawImage(g.offscreen, op)
+		screen.DrawImage(g.offscreen, op)
 	default:
 		op := &DrawRectShaderOptions{}
 		op.Images[0] = g.offscreen
 		op.GeoM = geoM
 		w, h := g.offscreen.Bounds().Dx(), g.offscreen.Bounds().Dy()
-		g.screen.DrawRectShader(w, h, g.screenShader, op)
+		screen.DrawRectShader(w, h, g.screenShader, op)
+	}
+}
+
+// fillScreenBars fills the letterbox or pillarbox bars, which are the regions of the screen not covered with the offscreen.
+func (g *gameForUI) fillScreenBars(screen *Image, scale, offsetX, offsetY float64) {
+	clr, img := getScreenFill()
+	if img != nil && img.isDisposed() {
+		img = nil
+	}
+	if clr != nil {
+		if _, _, _, a := clr.RGBA(); a == 0 {
+			clr = nil
+		}
+	}
+	if clr == nil && img == nil {
+		return
+	}
+
+	sb := g.screen.Bounds()
+	ob := g.offscreen.Bounds()
+	x0 := int(math.Floor(offsetX))
+	y0 := int(math.Floor(offsetY))
+	x1 := int(math.Ceil(offsetX + float64(ob.Dx())*scale))
+	y1 := int(math.Ceil(offsetY + float64(ob.Dy())*scale))
+	bars := [...]image.Rectangle{
+		image.Rect(sb.Min.X, sb.Min.Y, sb.Max.X, y0),
+		image.Rect(sb.Min.X, y1, sb.Max.X, sb.Max.Y),
+		image.Rect(sb.Min.X, y0, x0, y1),
+		image.Rect(x1, y0, sb.Max.X, y1),
+	}
+
+	for _, r := range bars {
+		r = r.Intersect(screen.Bounds())
+		if r.Empty() {
+			continue
+		}
+		dst := screen.SubImage(r).(*Image)
+		if clr != nil {
+			dst.Fill(clr)
+		}
+		if img == nil {
+			continue
+		}
+
+		// Tile the image in the same scale as the offscreen, from the upper-left corner of the screen.
+		ib := img.Bounds()
+		sx0 := float32(ib.Min.X) + float32(float64(r.Min.X-sb.Min.X)/scale)
+		sy0 := float32(ib.Min.Y) + float32(float64(r.Min.Y-sb.Min.Y)/scale)
+		sx1 := float32(ib.Min.X) + float32(float64(r.Max.X-sb.Min.X)/scale)
+		sy1 := float32(ib.Min.Y) + float32(float64(r.Max.Y-sb.Min.Y)/scale)
+		vs := []Vertex{
+			{DstX: float32(r.Min.X), DstY: float32(r.Min.Y), SrcX: sx0, SrcY: sy0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: float32(r.Max.X), DstY: float32(r.Min.Y), SrcX: sx1, SrcY: sy0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: float32(r.Min.X), DstY: float32(r.Max.Y), SrcX: sx0, SrcY: sy1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: float32(r.Max.X), DstY: float32(r.Max.Y), SrcX: sx1, SrcY: sy1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		}
+		op := &DrawTrianglesOptions{}
+		op.Address = AddressRepeat
+		dst.DrawTriangles(vs, []uint16{0, 1, 2, 1, 2, 3}, img, op)
 	}
 }
@@ -60,6 +60,16 @@ func (g *GeoM) elements32() (a, b, c, d, tx, ty float32) {
 	return float32(g.a_1) + 1, float32(g.b), float32(g.c), float32(g.d_1) + 1, float32(g.tx), float32(g.ty)
 }
 
+// applyGeoMF32 applies g to (x, y) in float32.
+// applyGeoMF32 returns (x, y) as it is if g is identity.
+func applyGeoMF32(g *GeoM, x, y float32) (float32, float32) {
+	if *g == (GeoM{}) {
+		return x, y
+	}
+	a, b, c, d, tx, ty := g.elements32()
+	return a*x + b*y + tx, c*x + d*y + ty
+}
+
 // Element returns a value of a matrix at (i, j).
 func (g *GeoM) Element(i, j int) float64 {
 	switch {
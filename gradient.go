@@ -0,0 +1,114 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// srcPos in the gradient shaders is a position in the destination image's bounds.
+// The colors are premultiplied alpha values and are interpolated as they are.
+
+const linearGradientShaderSrc = `//kage:unit pixels
+
+package main
+
+var P0 vec2
+var P1 vec2
+var Color0 vec4
+var Color1 vec4
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	d := P1 - P0
+	t := clamp(dot(srcPos-P0, d)/dot(d, d), 0, 1)
+	return mix(Color0, Color1, t)
+}
+`
+
+const radialGradientShaderSrc = `//kage:unit pixels
+
+package main
+
+var Center vec2
+var Radius float
+var Color0 vec4
+var Color1 vec4
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	t := clamp(length(srcPos-Center)/Radius, 0, 1)
+	return mix(Color0, Color1, t)
+}
+`
+
+var (
+	linearGradientShader     *Shader
+	linearGradientShaderOnce sync.Once
+	radialGradientShader     *Shader
+	radialGradientShaderOnce sync.Once
+)
+
+func mustCompileInternalShader(src string) *Shader {
+	s, err := NewShader([]byte(src))
+	if err != nil {
+		panic(fmt.Sprintf("ebiten: NewShader for an internal shader failed: %v", err))
+	}
+	return s
+}
+
+func premultipliedColorToFloats(clr color.Color) []float32 {
+	r, g, b, a := clr.RGBA()
+	return []float32{float32(r) / 0xffff, float32(g) / 0xffff, float32(b) / 0xffff, float32(a) / 0xffff}
+}
+
+// fillWithShader fills the whole bounds of i with the given shader, replacing the existing pixels.
+// srcPos values in the shader are positions in i's bounds.
+func (i *Image) fillWithShader(shader *Shader, uniforms map[string]any) {
+	b := i.Bounds()
+	dx0, dy0 := i.adjustPositionF32(float32(b.Min.X), float32(b.Min.Y))
+	dx1, dy1 := i.adjustPositionF32(float32(b.Max.X), float32(b.Max.Y))
+	sx0, sy0, sx1, sy1 := float32(b.Min.X), float32(b.Min.Y), float32(b.Max.X), float32(b.Max.Y)
+
+	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
+	for j, v := range [4][4]float32{
+		{dx0, dy0, sx0, sy0},
+		{dx1, dy0, sx1, sy0},
+		{dx0, dy1, sx0, sy1},
+		{dx1, dy1, sx1, sy1},
+	} {
+		vs[j*graphics.VertexFloatCount] = v[0]
+		vs[j*graphics.VertexFloatCount+1] = v[1]
+		vs[j*graphics.VertexFloatCount+2] = v[2]
+		vs[j*graphics.VertexFloatCount+3] = v[3]
+		vs[j*graphics.VertexFloatCount+4] = 1
+		vs[j*graphics.VertexFloatCount+5] = 1
+		vs[j*graphics.VertexFloatCount+6] = 1
+		vs[j*graphics.VertexFloatCount+7] = 1
+	}
+	is := graphics.QuadIndices()
+
+	i.tmpUniforms = i.tmpUniforms[:0]
+	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, uniforms)
+
+	var srcs [graphics.ShaderImageCount]*ui.Image
+	var srcRegions [graphics.ShaderImageCount]image.Rectangle
+	i.image.DrawTriangles(srcs, vs, is, BlendCopy.internalBlend(), i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, true, false)
+}
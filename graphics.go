@@ -52,6 +52,13 @@ const (
 
 	// GraphicsLibraryMetal represents the graphics library PlayStation 5.
 	GraphicsLibraryPlayStation5 GraphicsLibrary = GraphicsLibrary(ui.GraphicsLibraryPlayStation5)
+
+	// GraphicsLibrarySoftware represents the software renderer, which renders images with CPUs.
+	//
+	// The software renderer is slow, but its results are deterministic and don't depend on GPUs.
+	// This is useful for testing the rendering without GPUs, e.g., on CI.
+	// The screen is not presented to the window, but can be read by e.g. ReadPixels.
+	GraphicsLibrarySoftware GraphicsLibrary = GraphicsLibrary(ui.GraphicsLibrarySoftware)
 )
 
 // String returns a string representing the graphics library.
@@ -72,3 +79,85 @@ type DebugInfo struct {
 func ReadDebugInfo(d *DebugInfo) {
 	d.GraphicsLibrary = GraphicsLibrary(ui.Get().GraphicsLibrary())
 }
+
+// GraphicsLibraryCapabilities is a struct to store the capabilities of the graphics library currently in use.
+type GraphicsLibraryCapabilities struct {
+	// GraphicsLibrary represents the graphics library currently in use.
+	GraphicsLibrary GraphicsLibrary
+
+	// MaxImageSize is the maximum width and height of an image in pixels.
+	// MaxImageSize is 0 if the graphics library is not initialized yet.
+	MaxImageSize int
+
+	// SRGBFramebuffer reports whether the screen framebuffer and the textures are in the sRGB color space.
+	// SRGBFramebuffer is true only when RunGameOptions.LinearBlending is specified and the graphics library supports it.
+	SRGBFramebuffer bool
+
+	// MaxRenderTargets is the maximum number of images that can be rendered at one draw call.
+	// Ebitengine renders to one destination image at a time with any graphics library, and this is always 1.
+	MaxRenderTargets int
+
+	// MaxShaderImages is the maximum number of source images of a shader,
+	// which is the number of Images and ExtraImages in DrawTrianglesShaderOptions and DrawRectShaderOptions.
+	// MaxShaderImages is limited by the number of texture units of the graphics library, and is at most 8.
+	MaxShaderImages int
+}
+
+// GraphicsLibraryInfo returns the capabilities of the graphics library currently in use.
+//
+// The graphics library is initialized when the game starts.
+// Before the game starts, GraphicsLibrary is GraphicsLibraryUnknown and MaxImageSize is 0.
+//
+// Kage shaders are compiled for every graphics library and have the same features regardless of the graphics library,
+// so there are no feature flags for shaders.
+func GraphicsLibraryInfo() GraphicsLibraryCapabilities {
+	return GraphicsLibraryCapabilities{
+		GraphicsLibrary:  GraphicsLibrary(ui.Get().GraphicsLibrary()),
+		MaxImageSize:     ui.Get().MaxImageSize(),
+		SRGBFramebuffer:  ui.Get().IsSRGBFramebuffer(),
+		MaxRenderTargets: 1,
+		MaxShaderImages:  ui.Get().MaxShaderImages(),
+	}
+}
+
+// ReleaseUnusedGraphicsResources runs a garbage collection and disposes the graphics resources like images and shaders
+// that are no longer referenced.
+//
+// Ebitengine disposes unreferenced images lazily when they are finalized by the garbage collector.
+// ReleaseUnusedGraphicsResources is useful to release the memory in a deterministic way, e.g., at a scene transition.
+// The actual textures are released by the end of the current frame, or the next frame if this is called outside of the game's
+// Update or Draw. Texture atlases that no longer have images are also released.
+//
+// Images in use are not moved, so a texture atlas that is not empty is not shrunk.
+//
+// ReleaseUnusedGraphicsResources is concurrent-safe.
+func ReleaseUnusedGraphicsResources() {
+	ui.Get().ReleaseUnusedGraphicsResources()
+}
+
+// FlushGraphicsOptions represents options for FlushGraphics.
+type FlushGraphicsOptions struct {
+	// Wait specifies whether FlushGraphics waits until the flushed commands are sent to the graphics library.
+	// The default (zero) value is false.
+	Wait bool
+}
+
+// FlushGraphics flushes all the pending rendering commands of Ebitengine, and sends them to the graphics library.
+//
+// Ebitengine buffers rendering commands and sends them to the graphics library lazily.
+// FlushGraphics is useful to order Ebitengine's rendering and rendering with the graphics library directly,
+// e.g. for an image created by NewImageFromNativeTexture.
+// Call FlushGraphics with Wait true before using native textures that Ebitengine renders to.
+//
+// Note that FlushGraphics doesn't wait for the completion of the commands on GPU.
+// The commands are executed on GPU in the order sent to the graphics library.
+//
+// FlushGraphics works only in the game's Update or Draw. Otherwise, FlushGraphics does nothing.
+//
+// If options is nil, the default options are used.
+func FlushGraphics(options *FlushGraphicsOptions) {
+	if options == nil {
+		options = &FlushGraphicsOptions{}
+	}
+	ui.Get().FlushGraphics(options.Wait)
+}
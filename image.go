@@ -15,9 +15,12 @@
 package ebiten
 
 import (
+	"encoding/binary"
 	"fmt"
 	"image"
 	"image/color"
+	"image/png"
+	"io"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/affine"
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
@@ -46,6 +49,10 @@ type Image struct {
 	// tmpUniforms must not be reused until ui.Image.Draw* is called.
 	tmpUniforms []uint32
 
+	// defaultBlend and defaultFilter are used when the image is drawn as a source with the zero Blend and Filter.
+	defaultBlend  Blend
+	defaultFilter Filter
+
 	// Do not add a 'buffering' member that are resolved lazily.
 	// This tends to forget resolving the buffer easily (#2362).
 }
@@ -56,6 +63,49 @@ func (i *Image) copyCheck() {
 	}
 }
 
+// WithOrigin returns an image that shares the pixels with the image i, and whose bounds are translated
+// so that the upper-left position is origin.
+//
+// WithOrigin is useful to align the coordinate system of an image with a larger virtual canvas.
+// For example, if i's bounds are (0, 0)-(100, 100), i.WithOrigin(image.Pt(200, 300)) returns an image with
+// the bounds (200, 300)-(300, 400), and its pixel at (200, 300) is the pixel of i at (0, 0).
+// All the functions of the returned image like DrawImage, Set, At, and SubImage work in the translated coordinate.
+// As a source of DrawImage, the returned image works in the same way as i, since the upper-left position of
+// the source bounds is always rendered at the origin of the GeoM.
+//
+// The returned image is treated as a sub-image. Dispose and Deallocate for the returned image do nothing.
+//
+// If the image is disposed, WithOrigin returns nil.
+func (i *Image) WithOrigin(origin image.Point) *Image {
+	i.copyCheck()
+	if i.isDisposed() {
+		return nil
+	}
+
+	orig := i
+	if i.isSubImage() {
+		orig = i.original
+	}
+
+	delta := origin.Sub(i.Bounds().Min)
+	// The translated original image is not exposed, and is used only for the coordinate conversions.
+	translated := &Image{
+		image:  orig.image,
+		bounds: orig.Bounds().Add(delta),
+	}
+	translated.addr = translated
+
+	img := &Image{
+		image:         i.image,
+		bounds:        i.Bounds().Add(delta),
+		original:      translated,
+		defaultBlend:  i.defaultBlend,
+		defaultFilter: i.defaultFilter,
+	}
+	img.addr = img
+	return img
+}
+
 // Size returns the size of the image.
 //
 // Deprecated: as of v2.5. Use Bounds().Dx() and Bounds().Dy() or Bounds().Size() instead.
@@ -74,9 +124,16 @@ func (i *Image) isSubImage() bool {
 
 // Clear resets the pixels of the image into 0.
 //
+// Clear is cheaper than Fill(color.Transparent).
+// Clearing an image that has never been drawn costs nothing.
+//
 // When the image is disposed, Clear does nothing.
 func (i *Image) Clear() {
-	i.Fill(color.Transparent)
+	i.copyCheck()
+	if i.isDisposed() {
+		return
+	}
+	i.image.Clear(i.adjustedBounds())
 }
 
 // Fill fills the image with a solid color.
@@ -97,6 +154,70 @@ func (i *Image) Fill(clr color.Color) {
 	i.image.Fill(crf, cgf, cbf, caf, i.adjustedBounds())
 }
 
+// FillLinearGradient fills the image with a linear gradient from (x0, y0) with the color c0 to (x1, y1) with the color c1.
+// The positions are in the image's bounds coordinate.
+//
+// Pixels before (x0, y0) have c0, and pixels after (x1, y1) have c1 along the gradient's direction.
+// The colors are interpolated in premultiplied alpha.
+// If (x0, y0) and (x1, y1) are the same, the image is filled with c1.
+//
+// Like Fill, FillLinearGradient replaces the pixels of the image without blending.
+//
+// When the image is disposed, FillLinearGradient does nothing.
+func (i *Image) FillLinearGradient(x0, y0, x1, y1 float32, c0, c1 color.Color) {
+	i.copyCheck()
+	if i.isDisposed() {
+		return
+	}
+
+	if x0 == x1 && y0 == y1 {
+		i.Fill(c1)
+		return
+	}
+
+	linearGradientShaderOnce.Do(func() {
+		linearGradientShader = mustCompileInternalShader(linearGradientShaderSrc)
+	})
+	i.fillWithShader(linearGradientShader, map[string]any{
+		"P0":     []float32{x0, y0},
+		"P1":     []float32{x1, y1},
+		"Color0": premultipliedColorToFloats(c0),
+		"Color1": premultipliedColorToFloats(c1),
+	})
+}
+
+// FillRadialGradient fills the image with a radial gradient centered at (cx, cy) with the given radius.
+// The color at the center is inner, and the color at the radius and beyond is outer.
+// The positions are in the image's bounds coordinate.
+//
+// The colors are interpolated in premultiplied alpha.
+// If radius is not positive, the image is filled with outer.
+//
+// Like Fill, FillRadialGradient replaces the pixels of the image without blending.
+//
+// When the image is disposed, FillRadialGradient does nothing.
+func (i *Image) FillRadialGradient(cx, cy, radius float32, inner, outer color.Color) {
+	i.copyCheck()
+	if i.isDisposed() {
+		return
+	}
+
+	if radius <= 0 {
+		i.Fill(outer)
+		return
+	}
+
+	radialGradientShaderOnce.Do(func() {
+		radialGradientShader = mustCompileInternalShader(radialGradientShaderSrc)
+	})
+	i.fillWithShader(radialGradientShader, map[string]any{
+		"Center": []float32{cx, cy},
+		"Radius": radius,
+		"Color0": premultipliedColorToFloats(inner),
+		"Color1": premultipliedColorToFloats(outer),
+	})
+}
+
 func canSkipMipmap(geom GeoM, filter builtinshader.Filter) bool {
 	if filter != builtinshader.FilterLinear {
 		return true
@@ -104,6 +225,62 @@ func canSkipMipmap(geom GeoM, filter builtinshader.Filter) bool {
 	return geom.det2x2() >= 0.999
 }
 
+// SetDefaultBlend sets the blend used when the image is drawn as a source image by DrawImage or DrawTriangles
+// and the blend of the options is not specified, i.e. Blend is the zero value and BlendSet is false.
+//
+// This is useful for an image that is always drawn with the same blend, e.g. a particle drawn with BlendLighter.
+//
+// A sub-image created by SubImage or WithOrigin copies the default blend when the sub-image is created.
+// Changing the default blend of the image later doesn't affect the existing sub-images.
+func (i *Image) SetDefaultBlend(blend Blend) {
+	i.copyCheck()
+	i.defaultBlend = blend
+}
+
+// DefaultBlend returns the default blend set by SetDefaultBlend.
+// The default (zero) value is the zero value of Blend, which means the regular alpha blending.
+func (i *Image) DefaultBlend() Blend {
+	i.copyCheck()
+	return i.defaultBlend
+}
+
+// SetDefaultFilter sets the filter used when the image is drawn as a source image by DrawImage or DrawTriangles
+// and the filter of the options is not specified, i.e. Filter is the zero value and FilterSet is false.
+//
+// This is useful for an image that is always drawn with the same filter, e.g. a smooth illustration drawn with FilterLinear.
+//
+// A sub-image created by SubImage or WithOrigin copies the default filter when the sub-image is created.
+// Changing the default filter of the image later doesn't affect the existing sub-images.
+func (i *Image) SetDefaultFilter(filter Filter) {
+	i.copyCheck()
+	i.defaultFilter = filter
+}
+
+// DefaultFilter returns the default filter set by SetDefaultFilter.
+// The default (zero) value is FilterNearest.
+func (i *Image) DefaultFilter() Filter {
+	i.copyCheck()
+	return i.defaultFilter
+}
+
+// blendOrDefault returns blend, or the default blend of the image if blend is not specified.
+// i can be nil.
+func (i *Image) blendOrDefault(blend Blend, set bool) Blend {
+	if i == nil || set || blend != (Blend{}) {
+		return blend
+	}
+	return i.defaultBlend
+}
+
+// filterOrDefault returns filter, or the default filter of the image if filter is not specified.
+// i can be nil.
+func (i *Image) filterOrDefault(filter Filter, set bool) Filter {
+	if i == nil || set || filter != FilterNearest {
+		return filter
+	}
+	return i.defaultFilter
+}
+
 // DrawImageOptions represents options for DrawImage.
 type DrawImageOptions struct {
 	// GeoM is a geometry matrix to draw.
@@ -119,6 +296,21 @@ type DrawImageOptions struct {
 	// The default (zero) value is identity, which is (1, 1, 1, 1).
 	ColorScale ColorScale
 
+	// ColorScaleMode is the mode of ColorScale.
+	// With ColorScaleModeStraightAlpha, the RGB values of ColorScale are multiplied by its alpha value before being applied.
+	// For example, ColorScale.Scale(1, 0.5, 0.5, 0.5) tints an image with a translucent red
+	// in the same way as ColorM.Scale(1, 0.5, 0.5, 0.5).
+	//
+	// ColorScaleMode is used only when ColorScaleModeSet is true.
+	// Otherwise, ColorScale is treated as ColorScaleModePremultipliedAlpha for compatibility.
+	ColorScaleMode ColorScaleMode
+
+	// ColorScaleModeSet indicates whether ColorScaleMode is specified explicitly
+	// even when ColorScaleMode is the zero value, ColorScaleModeStraightAlpha.
+	//
+	// The default (zero) value is false.
+	ColorScaleModeSet bool
+
 	// ColorM is a color matrix to draw.
 	// The default (zero) value is identity, which doesn't change any color.
 	//
@@ -133,12 +325,46 @@ type DrawImageOptions struct {
 
 	// Blend is a blending way of the source color and the destination color.
 	// Blend is used only when CompositeMode is CompositeModeCustom.
-	// The default (zero) value is the regular alpha blending.
+	// The default (zero) value is the source image's default blend set by SetDefaultBlend,
+	// which is the regular alpha blending unless specified.
 	Blend Blend
 
+	// BlendSet indicates whether Blend is specified explicitly even when Blend is the zero value.
+	// If BlendSet is true, Blend is used instead of the source image's default blend.
+	//
+	// The default (zero) value is false.
+	BlendSet bool
+
 	// Filter is a type of texture filter.
-	// The default (zero) value is FilterNearest.
+	// The default (zero) value is the source image's default filter set by SetDefaultFilter,
+	// which is FilterNearest unless specified.
 	Filter Filter
+
+	// FilterSet indicates whether Filter is specified explicitly even when Filter is the zero value, FilterNearest.
+	// If FilterSet is true, Filter is used instead of the source image's default filter.
+	//
+	// The default (zero) value is false.
+	FilterSet bool
+
+	// SourceRect is the region of the source image to draw, in the source image's coordinate.
+	// The image is drawn as if the source image had the size of SourceRect, i.e. the upper-left corner of SourceRect
+	// is rendered at (0, 0) before GeoM is applied.
+	//
+	// SourceRect can exceed the bounds of the source image.
+	// The texture coordinates out of the bounds are handled by Address.
+	// For example, specifying a large SourceRect with AddressRepeat tiles the source image with one DrawImage call.
+	//
+	// The default (zero) value means the bounds of the source image.
+	SourceRect image.Rectangle
+
+	// Address is a sampler address mode for the texture coordinates out of the bounds of the source image.
+	//
+	// Address is applied to the bounds of the source image, so Address works correctly even when the source image is
+	// a sub-image or shares a texture atlas with other images.
+	//
+	// The default (zero) value is AddressUnsafe.
+	// With AddressUnsafe, the rendering result is undefined if SourceRect exceeds the bounds of the source image.
+	Address Address
 }
 
 // adjustPosition converts the position in the *ebiten.Image coordinate to the *ui.Image coordinate.
@@ -197,6 +423,7 @@ func (i *Image) adjustedBounds() image.Rectangle {
 //   - All render targets are the same (A in A.DrawImage(B, op))
 //   - All Blend values are the same
 //   - All Filter values are the same
+//   - All Address values are the same
 //
 // A whole image and its sub-image are considered to be the same, but some
 // environments like browsers might not work efficiently (#2471).
@@ -226,11 +453,12 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 
 	var blend graphicsdriver.Blend
 	if options.CompositeMode == CompositeModeCustom {
-		blend = options.Blend.internalBlend()
+		blend = img.blendOrDefault(options.Blend, options.BlendSet).internalBlend()
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
-	filter := builtinshader.Filter(options.Filter)
+	filter := builtinshader.Filter(img.filterOrDefault(options.Filter, options.FilterSet))
+	address := builtinshader.Address(options.Address)
 
 	geoM := options.GeoM
 	if offsetX, offsetY := i.adjustPosition(0, 0); offsetX != 0 || offsetY != 0 {
@@ -239,10 +467,14 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	a, b, c, d, tx, ty := geoM.elements32()
 
 	bounds := img.Bounds()
+	if options.SourceRect != (image.Rectangle{}) {
+		bounds = options.SourceRect
+	}
 	sx0, sy0 := img.adjustPosition(bounds.Min.X, bounds.Min.Y)
 	sx1, sy1 := img.adjustPosition(bounds.Max.X, bounds.Max.Y)
 	colorm, cr, cg, cb, ca := colorMToScale(options.ColorM.affineColorM())
-	cr, cg, cb, ca = options.ColorScale.apply(cr, cg, cb, ca)
+	colorScale := options.ColorScale.withMode(options.ColorScaleMode, options.ColorScaleModeSet)
+	cr, cg, cb, ca = colorScale.apply(cr, cg, cb, ca)
 	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
 	graphics.QuadVertices(vs, float32(sx0), float32(sy0), float32(sx1), float32(sy1), a, b, c, d, tx, ty, cr, cg, cb, ca)
 	is := graphics.QuadIndices()
@@ -250,7 +482,7 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	srcs := [graphics.ShaderImageCount]*ui.Image{img.image}
 
 	useColorM := !colorm.IsIdentity()
-	shader := builtinShader(filter, builtinshader.AddressUnsafe, useColorM)
+	shader := builtinShader(filter, address, useColorM)
 	i.tmpUniforms = i.tmpUniforms[:0]
 	if useColorM {
 		var body [16]float32
@@ -265,6 +497,45 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, canSkipMipmap(geoM, filter), false)
 }
 
+// CopyFrom copies the pixels in the region srcRect of src to the image i at dstPoint, without blending.
+//
+// The destination pixels are replaced with the source pixels including their alpha values.
+// CopyFrom is a convenience wrapper of DrawImage with BlendCopy, a translation GeoM, and SourceRect,
+// and is not faster than calling DrawImage in that way.
+//
+// srcRect is in src's coordinate, and dstPoint is in i's coordinate.
+// srcRect is clipped by src's bounds, and the region out of i's bounds is not copied.
+//
+// When the image i is disposed, CopyFrom does nothing.
+// When src is disposed, CopyFrom panics.
+//
+// When src and i share the same pixels, e.g. src is a sub-image of i, CopyFrom panics.
+func (i *Image) CopyFrom(src *Image, srcRect image.Rectangle, dstPoint image.Point) {
+	i.copyCheck()
+
+	if src.isDisposed() {
+		panic("ebiten: the given image to CopyFrom must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+	if i.image == src.image {
+		panic("ebiten: the given image to CopyFrom must not share the pixels with the receiver image")
+	}
+
+	r := srcRect.Intersect(src.Bounds())
+	if r.Empty() {
+		return
+	}
+	dstPoint = dstPoint.Add(r.Min.Sub(srcRect.Min))
+
+	op := &DrawImageOptions{}
+	op.GeoM.Translate(float64(dstPoint.X), float64(dstPoint.Y))
+	op.Blend = BlendCopy
+	op.SourceRect = r
+	i.DrawImage(src, op)
+}
+
 // Vertex represents a vertex passed to DrawTriangles.
 type Vertex struct {
 	// DstX and DstY represents a point on a destination image.
@@ -305,6 +576,12 @@ const (
 
 	// AddressRepeat means that texture coordinates wrap to the other side of the texture.
 	AddressRepeat Address = Address(builtinshader.AddressRepeat)
+
+	// AddressClampToEdge means that out-of-range texture coordinates return the color at the nearest edge of the texture.
+	AddressClampToEdge Address = Address(builtinshader.AddressClampToEdge)
+
+	// AddressMirror means that texture coordinates wrap to the other side of the texture, flipping at every repetition.
+	AddressMirror Address = Address(builtinshader.AddressMirror)
 )
 
 // FillRule is the rule whether an overlapped region is rendered with DrawTriangles(Shader).
@@ -323,21 +600,32 @@ const (
 	EvenOdd FillRule = FillRule(graphicsdriver.EvenOdd)
 )
 
-// ColorScaleMode is the mode of color scales in vertices.
+// ColorScaleMode is the mode of color scales in vertices or ColorScale values.
 type ColorScaleMode int
 
 const (
-	// ColorScaleModeStraightAlpha indicates color scales in vertices are
+	// ColorScaleModeStraightAlpha indicates color scales are
 	// straight-alpha encoded color multiplier.
+	//
+	// For example, (ColorR, ColorG, ColorB, ColorA) = (1, 0, 0, 0.5) is a half-transparent red.
+	// The color scales are premultiplied for each vertex before they are interpolated,
+	// so a translucent gradient between vertices doesn't get darker.
 	ColorScaleModeStraightAlpha ColorScaleMode = iota
 
-	// ColorScaleModePremultipliedAlpha indicates color scales in vertices are
+	// ColorScaleModePremultipliedAlpha indicates color scales are
 	// premultiplied-alpha encoded color multiplier.
+	//
+	// For example, (ColorR, ColorG, ColorB, ColorA) = (0.5, 0, 0, 0.5) is a half-transparent red.
+	// Straight-alpha values with ColorScaleModePremultipliedAlpha make translucent colors too bright.
 	ColorScaleModePremultipliedAlpha
 )
 
 // DrawTrianglesOptions represents options for DrawTriangles.
 type DrawTrianglesOptions struct {
+	// GeoM is a geometry matrix applied to the destination positions of the vertices.
+	// The default (zero) value is identity, which doesn't change the positions.
+	GeoM GeoM
+
 	// ColorM is a color matrix to draw.
 	// The default (zero) value is identity, which doesn't change any color.
 	// ColorM is applied before vertex color scale is applied.
@@ -347,7 +635,7 @@ type DrawTrianglesOptions struct {
 
 	// ColorScaleMode is the mode of color scales in vertices.
 	// ColorScaleMode affects the color calculation with vertex colors, but doesn't affect with a color matrix.
-	// The default (zero) value is ColorScaleModeStraightAlpha.
+	// The default (zero) value is ColorScaleModeStraightAlpha, so straight-alpha vertex colors work as they are.
 	ColorScaleMode ColorScaleMode
 
 	// CompositeMode is a composite mode to draw.
@@ -358,17 +646,45 @@ type DrawTrianglesOptions struct {
 
 	// Blend is a blending way of the source color and the destination color.
 	// Blend is used only when CompositeMode is CompositeModeCustom.
-	// The default (zero) value is the regular alpha blending.
+	// The default (zero) value is the source image's default blend set by SetDefaultBlend,
+	// which is the regular alpha blending unless specified.
 	Blend Blend
 
+	// BlendSet indicates whether Blend is specified explicitly even when Blend is the zero value.
+	// If BlendSet is true, Blend is used instead of the source image's default blend.
+	//
+	// The default (zero) value is false.
+	BlendSet bool
+
 	// Filter is a type of texture filter.
-	// The default (zero) value is FilterNearest.
+	// The default (zero) value is the source image's default filter set by SetDefaultFilter,
+	// which is FilterNearest unless specified.
 	Filter Filter
 
+	// FilterSet indicates whether Filter is specified explicitly even when Filter is the zero value, FilterNearest.
+	// If FilterSet is true, Filter is used instead of the source image's default filter.
+	//
+	// The default (zero) value is false.
+	FilterSet bool
+
 	// Address is a sampler address mode.
 	// The default (zero) value is AddressUnsafe.
 	Address Address
 
+	// MaxAnisotropy is the maximum number of samples for anisotropic filtering.
+	//
+	// Anisotropic filtering takes multiple linear samples along the longer axis of each pixel's footprint in the source image,
+	// and sharpens textures viewed at oblique angles, e.g. ground planes in pseudo-3D games.
+	// The number of samples for each pixel is determined by the ratio of the footprint's axes, and is limited by MaxAnisotropy.
+	//
+	// MaxAnisotropy is used only when Filter is FilterLinear.
+	// MaxAnisotropy is clamped to [1, MaxAnisotropyLimit].
+	// Anisotropic filtering might affect performance as it increases the number of texture samples.
+	//
+	// The default (zero) value is 0, which means anisotropic filtering is disabled.
+	// 1 also means anisotropic filtering is disabled.
+	MaxAnisotropy int
+
 	// FillRule indicates the rule how an overlapped region is rendered.
 	//
 	// The rules NonZero and EvenOdd are useful when you want to render a complex polygon.
@@ -388,6 +704,9 @@ type DrawTrianglesOptions struct {
 	AntiAlias bool
 }
 
+// MaxAnisotropyLimit is the maximum value of DrawTrianglesOptions.MaxAnisotropy.
+const MaxAnisotropyLimit = builtinshader.MaxAnisotropy
+
 // MaxIndicesCount is the maximum number of indices for DrawTriangles and DrawTrianglesShader.
 //
 // Deprecated: as of v2.6. This constant is no longer used.
@@ -437,6 +756,46 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 		return
 	}
 
+	is := make([]uint32, len(indices))
+	for i := range is {
+		is[i] = uint32(indices[i])
+	}
+	i.drawTriangles(vertices, is, img, options)
+}
+
+// DrawTriangles32 draws triangles with the specified vertices and their indices.
+//
+// DrawTriangles32 is the same as DrawTriangles except that the indices are uint32 values.
+// DrawTriangles32 doesn't allocate any memory for the indices, and neither vertices nor indices are modified.
+// Then, you can prepare vertices and indices once, e.g. for static geometry like a tile map, and reuse them
+// every frame without any garbage. Use the option's GeoM to change the transform of the geometry.
+//
+// Note that the vertices are still converted into an internal buffer at every call,
+// so there is no need to specify which part of vertices is modified.
+//
+// If len(vertices) is more than MaxVertexCount, the exceeding part is ignored.
+//
+// If len(indices) is not multiple of 3, DrawTriangles32 panics.
+//
+// If a value in indices is out of range of vertices, or not less than MaxVertexCount, DrawTriangles32 panics.
+//
+// When the given image is disposed, DrawTriangles32 panics.
+//
+// When the image i is disposed, DrawTriangles32 does nothing.
+func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image, options *DrawTrianglesOptions) {
+	i.copyCheck()
+
+	if img != nil && img.isDisposed() {
+		panic("ebiten: the given image to DrawTriangles32 must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	i.drawTriangles(vertices, indices, img, options)
+}
+
+func (i *Image) drawTriangles(vertices []Vertex, indices []uint32, img *Image, options *DrawTrianglesOptions) {
 	if len(vertices) > graphicscommand.MaxVertexCount {
 		// The last part cannot be specified by indices. Just omit them.
 		vertices = vertices[:graphicscommand.MaxVertexCount]
@@ -456,21 +815,29 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 
 	var blend graphicsdriver.Blend
 	if options.CompositeMode == CompositeModeCustom {
-		blend = options.Blend.internalBlend()
+		blend = img.blendOrDefault(options.Blend, options.BlendSet).internalBlend()
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
 
 	address := builtinshader.Address(options.Address)
-	filter := builtinshader.Filter(options.Filter)
+	filter := builtinshader.Filter(img.filterOrDefault(options.Filter, options.FilterSet))
+	maxAnisotropy := options.MaxAnisotropy
+	if maxAnisotropy > MaxAnisotropyLimit {
+		maxAnisotropy = MaxAnisotropyLimit
+	}
+	if filter == builtinshader.FilterLinear && maxAnisotropy > 1 {
+		filter = builtinshader.FilterAnisotropic
+	}
 
 	colorm, cr, cg, cb, ca := colorMToScale(options.ColorM.affineColorM())
 
 	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
 	dst := i
+	geoM := &options.GeoM
 	if options.ColorScaleMode == ColorScaleModeStraightAlpha {
 		for i, v := range vertices {
-			dx, dy := dst.adjustPositionF32(v.DstX, v.DstY)
+			dx, dy := dst.adjustPositionF32(applyGeoMF32(geoM, v.DstX, v.DstY))
 			vs[i*graphics.VertexFloatCount] = dx
 			vs[i*graphics.VertexFloatCount+1] = dy
 			sx, sy := img.adjustPositionF32(v.SrcX, v.SrcY)
@@ -483,7 +850,7 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 		}
 	} else {
 		for i, v := range vertices {
-			dx, dy := dst.adjustPositionF32(v.DstX, v.DstY)
+			dx, dy := dst.adjustPositionF32(applyGeoMF32(geoM, v.DstX, v.DstY))
 			vs[i*graphics.VertexFloatCount] = dx
 			vs[i*graphics.VertexFloatCount+1] = dy
 			sx, sy := img.adjustPositionF32(v.SrcX, v.SrcY)
@@ -495,31 +862,35 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 			vs[i*graphics.VertexFloatCount+7] = v.ColorA * ca
 		}
 	}
-	is := make([]uint32, len(indices))
-	for i := range is {
-		is[i] = uint32(indices[i])
-	}
-
 	srcs := [graphics.ShaderImageCount]*ui.Image{img.image}
 
 	useColorM := !colorm.IsIdentity()
 	shader := builtinShader(filter, address, useColorM)
 	i.tmpUniforms = i.tmpUniforms[:0]
-	if useColorM {
-		var body [16]float32
-		var translation [4]float32
-		colorm.Elements(body[:], translation[:])
-		i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, map[string]any{
-			builtinshader.UniformColorMBody:        body[:],
-			builtinshader.UniformColorMTranslation: translation[:],
-		})
+	if useColorM || filter == builtinshader.FilterAnisotropic {
+		uniforms := map[string]any{}
+		if useColorM {
+			var body [16]float32
+			var translation [4]float32
+			colorm.Elements(body[:], translation[:])
+			uniforms[builtinshader.UniformColorMBody] = body[:]
+			uniforms[builtinshader.UniformColorMTranslation] = translation[:]
+		}
+		if filter == builtinshader.FilterAnisotropic {
+			uniforms[builtinshader.UniformMaxAnisotropy] = float32(maxAnisotropy)
+		}
+		i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, uniforms)
 	}
 
-	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), filter != builtinshader.FilterLinear, options.AntiAlias)
+	i.image.DrawTriangles(srcs, vs, indices, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), filter == builtinshader.FilterNearest, options.AntiAlias)
 }
 
 // DrawTrianglesShaderOptions represents options for DrawTrianglesShader.
 type DrawTrianglesShaderOptions struct {
+	// GeoM is a geometry matrix applied to the destination positions of the vertices.
+	// The default (zero) value is identity, which doesn't change the positions.
+	GeoM GeoM
+
 	// CompositeMode is a composite mode to draw.
 	// The default (zero) value is CompositeModeCustom (Blend is used).
 	//
@@ -545,6 +916,16 @@ type DrawTrianglesShaderOptions struct {
 	// All the images' sizes must be the same.
 	Images [4]*Image
 
+	// ExtraImages is a set of the additional source images following Images.
+	// The i-th image of ExtraImages is available as imageSrc{4+i}At and so on in a shader.
+	// All the images' sizes must be the same as Images.
+	//
+	// The number of all the source images is limited by GraphicsLibraryInfo().MaxShaderImages.
+	// If an image beyond the limit is given, DrawTrianglesShader panics.
+	//
+	// The default (zero) value is nil.
+	ExtraImages []*Image
+
 	// FillRule indicates the rule how an overlapped region is rendered.
 	//
 	// The rules NonZero and EvenOdd are useful when you want to render a complex polygon.
@@ -565,7 +946,10 @@ type DrawTrianglesShaderOptions struct {
 }
 
 // Check the number of images.
-var _ [len(DrawTrianglesShaderOptions{}.Images) - graphics.ShaderImageCount]struct{} = [0]struct{}{}
+var _ [len(DrawTrianglesShaderOptions{}.Images) + maxExtraShaderImages - graphics.ShaderImageCount]struct{} = [0]struct{}{}
+
+// maxExtraShaderImages is the maximum number of ExtraImages.
+const maxExtraShaderImages = 4
 
 // DrawTrianglesShader draws triangles with the specified vertices and their indices with the specified shader.
 //
@@ -599,6 +983,35 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 		panic("ebiten: the given shader to DrawTrianglesShader must not be disposed")
 	}
 
+	is := make([]uint32, len(indices))
+	for i := range is {
+		is[i] = uint32(indices[i])
+	}
+	i.drawTrianglesShader(vertices, is, shader, options)
+}
+
+// DrawTrianglesShader32 draws triangles with the specified vertices and their indices with the specified shader.
+//
+// DrawTrianglesShader32 is the same as DrawTrianglesShader except that the indices are uint32 values.
+// As with DrawTriangles32, DrawTrianglesShader32 doesn't allocate any memory for the indices,
+// and neither vertices nor indices are modified.
+//
+// When the image i is disposed, DrawTrianglesShader32 does nothing.
+func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shader *Shader, options *DrawTrianglesShaderOptions) {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+
+	if shader.isDisposed() {
+		panic("ebiten: the given shader to DrawTrianglesShader32 must not be disposed")
+	}
+
+	i.drawTrianglesShader(vertices, indices, shader, options)
+}
+
+func (i *Image) drawTrianglesShader(vertices []Vertex, indices []uint32, shader *Shader, options *DrawTrianglesShaderOptions) {
 	if len(vertices) > graphicscommand.MaxVertexCount {
 		// The last part cannot be specified by indices. Just omit them.
 		vertices = vertices[:graphicscommand.MaxVertexCount]
@@ -623,11 +1036,14 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 		blend = options.CompositeMode.blend().internalBlend()
 	}
 
+	srcImages := shaderImages(&options.Images, options.ExtraImages, "DrawTrianglesShader")
+
 	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
 	dst := i
-	src := options.Images[0]
+	src := srcImages[0]
+	geoM := &options.GeoM
 	for i, v := range vertices {
-		dx, dy := dst.adjustPositionF32(v.DstX, v.DstY)
+		dx, dy := dst.adjustPositionF32(applyGeoMF32(geoM, v.DstX, v.DstY))
 		vs[i*graphics.VertexFloatCount] = dx
 		vs[i*graphics.VertexFloatCount+1] = dy
 		sx, sy := v.SrcX, v.SrcY
@@ -642,14 +1058,9 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 		vs[i*graphics.VertexFloatCount+7] = v.ColorA
 	}
 
-	is := make([]uint32, len(indices))
-	for i := range is {
-		is[i] = uint32(indices[i])
-	}
-
 	var imgs [graphics.ShaderImageCount]*ui.Image
 	var imgSize image.Point
-	for i, img := range options.Images {
+	for i, img := range srcImages {
 		if img == nil {
 			continue
 		}
@@ -670,7 +1081,7 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 	}
 
 	var srcRegions [graphics.ShaderImageCount]image.Rectangle
-	for i, img := range options.Images {
+	for i, img := range srcImages {
 		if img == nil {
 			continue
 		}
@@ -680,7 +1091,27 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 	i.tmpUniforms = i.tmpUniforms[:0]
 	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, options.Uniforms)
 
-	i.image.DrawTriangles(imgs, vs, is, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias)
+	i.image.DrawTriangles(imgs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias)
+}
+
+// shaderImages returns the source images of a shader given as images and extraImages.
+// shaderImages panics if an image beyond the limit of the graphics library is given.
+func shaderImages(images *[4]*Image, extraImages []*Image, funcName string) [graphics.ShaderImageCount]*Image {
+	if len(extraImages) > maxExtraShaderImages {
+		panic(fmt.Sprintf("ebiten: the number of ExtraImages at %s must be at most %d but %d", funcName, maxExtraShaderImages, len(extraImages)))
+	}
+
+	var imgs [graphics.ShaderImageCount]*Image
+	copy(imgs[:], images[:])
+	copy(imgs[len(images):], extraImages)
+
+	n := ui.Get().MaxShaderImages()
+	for i := n; i < len(imgs); i++ {
+		if imgs[i] != nil {
+			panic(fmt.Sprintf("ebiten: the graphics library supports at most %d source images but the image at %d is given to %s", n, i, funcName))
+		}
+	}
+	return imgs
 }
 
 // DrawRectShaderOptions represents options for DrawRectShader.
@@ -694,6 +1125,19 @@ type DrawRectShaderOptions struct {
 	// The default (zero) value is identity, which is (1, 1, 1, 1).
 	ColorScale ColorScale
 
+	// ColorScaleMode is the mode of ColorScale.
+	// With ColorScaleModeStraightAlpha, the RGB values of ColorScale are multiplied by its alpha value before being passed.
+	//
+	// ColorScaleMode is used only when ColorScaleModeSet is true.
+	// Otherwise, ColorScale is treated as ColorScaleModePremultipliedAlpha for compatibility.
+	ColorScaleMode ColorScaleMode
+
+	// ColorScaleModeSet indicates whether ColorScaleMode is specified explicitly
+	// even when ColorScaleMode is the zero value, ColorScaleModeStraightAlpha.
+	//
+	// The default (zero) value is false.
+	ColorScaleModeSet bool
+
 	// CompositeMode is a composite mode to draw.
 	// The default (zero) value is CompositeModeCustom (Blend is used).
 	//
@@ -718,10 +1162,20 @@ type DrawRectShaderOptions struct {
 	// Images is a set of the source images.
 	// All the images' sizes must be the same.
 	Images [4]*Image
+
+	// ExtraImages is a set of the additional source images following Images.
+	// The i-th image of ExtraImages is available as imageSrc{4+i}At and so on in a shader.
+	// All the images' sizes must be the same as Images.
+	//
+	// The number of all the source images is limited by GraphicsLibraryInfo().MaxShaderImages.
+	// If an image beyond the limit is given, DrawRectShader panics.
+	//
+	// The default (zero) value is nil.
+	ExtraImages []*Image
 }
 
 // Check the number of images.
-var _ [len(DrawRectShaderOptions{}.Images)]struct{} = [graphics.ShaderImageCount]struct{}{}
+var _ [len(DrawRectShaderOptions{}.Images) + maxExtraShaderImages]struct{} = [graphics.ShaderImageCount]struct{}{}
 
 // DrawRectShader draws a rectangle with the specified width and height with the specified shader.
 //
@@ -765,8 +1219,10 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 		blend = options.CompositeMode.blend().internalBlend()
 	}
 
+	srcImages := shaderImages(&options.Images, options.ExtraImages, "DrawRectShader")
+
 	var imgs [graphics.ShaderImageCount]*ui.Image
-	for i, img := range options.Images {
+	for i, img := range srcImages {
 		if img == nil {
 			continue
 		}
@@ -780,7 +1236,7 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 	}
 
 	var srcRegions [graphics.ShaderImageCount]image.Rectangle
-	for i, img := range options.Images {
+	for i, img := range srcImages {
 		if img == nil {
 			if shader.unit == shaderir.Pixels && i == 0 {
 				// Give the source size as pixels only when the unit is pixels so that users can get the source size via imageSrc0Size (#2166).
@@ -797,7 +1253,8 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 		geoM.Translate(float64(offsetX), float64(offsetY))
 	}
 	a, b, c, d, tx, ty := geoM.elements32()
-	cr, cg, cb, ca := options.ColorScale.elements()
+	colorScale := options.ColorScale.withMode(options.ColorScaleMode, options.ColorScaleModeSet)
+	cr, cg, cb, ca := colorScale.elements()
 	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
 
 	// Do not use srcRegions[0].Dx() and srcRegions[0].Dy() as these might be empty.
@@ -844,9 +1301,11 @@ func (i *Image) SubImage(r image.Rectangle) image.Image {
 	}
 
 	img := &Image{
-		image:    i.image,
-		bounds:   r,
-		original: orig,
+		image:         i.image,
+		bounds:        r,
+		original:      orig,
+		defaultBlend:  i.defaultBlend,
+		defaultFilter: i.defaultFilter,
 	}
 	img.addr = img
 
@@ -903,6 +1362,40 @@ func (i *Image) ReadPixels(pixels []byte) {
 	i.image.ReadPixels(pixels, i.adjustedBounds())
 }
 
+// WritePNG encodes the image's pixels as PNG and writes it to w.
+//
+// The pixels are converted from premultiplied alpha to straight alpha, as PNG requires straight alpha values.
+//
+// WritePNG loads pixels from GPU to system memory by ReadPixels, which means that WritePNG can be slow.
+//
+// WritePNG also works on a sub-image.
+//
+// WritePNG can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) WritePNG(w io.Writer) error {
+	b := i.Bounds()
+	img := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	i.ReadPixels(img.Pix)
+
+	for j := 0; j < len(img.Pix); j += 4 {
+		a := uint32(img.Pix[j+3])
+		if a == 0 || a == 0xff {
+			continue
+		}
+		for k := 0; k < 3; k++ {
+			c := (uint32(img.Pix[j+k])*0xff + a/2) / a
+			if c > 0xff {
+				c = 0xff
+			}
+			img.Pix[j+k] = uint8(c)
+		}
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("ebiten: png.Encode failed: %w", err)
+	}
+	return nil
+}
+
 // At returns the color of the image at (x, y).
 //
 // At implements the standard image.Image's At.
@@ -950,6 +1443,31 @@ func (i *Image) at(x, y int) (r, g, b, a byte) {
 	return pix[0], pix[1], pix[2], pix[3]
 }
 
+// ReadPixel returns the color of the image at (x, y) in the premultiplied-alpha format.
+//
+// ReadPixel is useful to read a pixel of an image that is rendered every frame, e.g. for color picking with an ID buffer.
+// While At and ReadPixels load all the pixels of the image from GPU and cache them,
+// ReadPixel loads only the pixel at (x, y) without caching.
+// ReadPixel still waits for the GPU to finish the rendering, so ReadPixel should not be called many times in one frame.
+// To read many pixels, use ReadPixels or At instead.
+//
+// ReadPixel always returns a transparent color if the image is disposed or (x, y) is out of the image's bounds.
+//
+// ReadPixel can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) ReadPixel(x, y int) color.RGBA {
+	if i.isDisposed() {
+		return color.RGBA{}
+	}
+	if !image.Pt(x, y).In(i.Bounds()) {
+		return color.RGBA{}
+	}
+
+	x, y = i.adjustPosition(x, y)
+	var pix [4]byte
+	i.image.ReadPixelsWithoutCache(pix[:], image.Rect(x, y, x+1, y+1))
+	return color.RGBA{R: pix[0], G: pix[1], B: pix[2], A: pix[3]}
+}
+
 // Set sets the color at (x, y).
 //
 // Set implements the standard draw.Image's Set.
@@ -1071,6 +1589,16 @@ type NewImageOptions struct {
 	// A regular image is a part of an internal texture atlas, and locating them is done automatically in Ebitengine.
 	// Unmanaged is useful when you want finer controls over the image for performance and memory reasons.
 	Unmanaged bool
+
+	// Supersampling represents whether all the rendering onto the image is done with 2x2 supersampling.
+	// With Supersampling, the image is rendered as if DrawTrianglesOptions.AntiAlias and DrawTrianglesShaderOptions.AntiAlias are always true,
+	// and DrawImage and DrawRectShader are also rendered with anti-alias.
+	// Fill and Clear are not affected.
+	//
+	// Supersampling might affect performance, as the rendering is done onto an internal double-sized image.
+	//
+	// The default (zero) value is false.
+	Supersampling bool
 }
 
 // NewImageWithOptions returns an empty image with the given bounds and the options.
@@ -1093,7 +1621,11 @@ func NewImageWithOptions(bounds image.Rectangle, options *NewImageOptions) *Imag
 	if options != nil && options.Unmanaged {
 		imageType = atlas.ImageTypeUnmanaged
 	}
-	return newImage(bounds, imageType)
+	img := newImage(bounds, imageType)
+	if options != nil && options.Supersampling {
+		img.image.SetAntiAlias(true)
+	}
+	return img
 }
 
 func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
@@ -1190,6 +1722,273 @@ func NewImageFromImageWithOptions(source image.Image, options *NewImageFromImage
 	return i
 }
 
+// NewImageFromNativeTexture creates a new image that wraps an existing texture of the graphics library currently in use.
+//
+// handle is a native texture handle for the graphics library:
+//
+//   - OpenGL: the texture name (GLuint). The texture must be a GL_TEXTURE_2D texture in the GL_RGBA format.
+//     The texture's filter and wrap parameters are changed to GL_NEAREST and GL_CLAMP_TO_EDGE.
+//   - Metal: the pointer to id<MTLTexture>. The texture must be in MTLPixelFormatRGBA8Unorm and its usage must include
+//     MTLTextureUsageShaderRead and MTLTextureUsageRenderTarget.
+//   - DirectX 11: the pointer to ID3D11Texture2D. The texture must be in DXGI_FORMAT_R8G8B8A8_UNORM and its bind flags must include
+//     D3D11_BIND_SHADER_RESOURCE and D3D11_BIND_RENDER_TARGET.
+//
+// The other graphics libraries including OpenGL on browsers are not supported, and NewImageFromNativeTexture returns an error.
+// NewImageFromNativeTexture also returns an error if the game is not running yet, since the graphics library is not determined.
+//
+// The pixels of the texture must be in the premultiplied-alpha format, and the texture must be the same size as the given width and height.
+// The returned image is an unmanaged image, and its bounds are (0, 0) to (width, height).
+//
+// The caller still owns the native texture.
+// Ebitengine never deletes or releases the native texture, even when the returned image is disposed or deallocated.
+// The caller must keep the native texture valid until the returned image is disposed, and must not delete it before that.
+// After Deallocate is called, the image no longer wraps the native texture and works as a new cleared image.
+//
+// When the native texture's contents are modified outside of Ebitengine, the modification must be synchronized by the caller
+// before drawing the image in Ebitengine.
+// Also, the results of ReadPixels and At might be cached and not reflect the modification outside of Ebitengine.
+func NewImageFromNativeTexture(handle uintptr, width, height int) (*Image, error) {
+	if isRunGameEnded() {
+		panic(fmt.Sprintf("ebiten: NewImageFromNativeTexture cannot be called after RunGame finishes"))
+	}
+	if width <= 0 {
+		panic(fmt.Sprintf("ebiten: width at NewImageFromNativeTexture must be positive but %d", width))
+	}
+	if height <= 0 {
+		panic(fmt.Sprintf("ebiten: height at NewImageFromNativeTexture must be positive but %d", height))
+	}
+
+	img, err := ui.Get().NewImageFromNativeTexture(handle, width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := &Image{
+		image:  img,
+		bounds: image.Rect(0, 0, width, height),
+	}
+	i.addr = i
+	return i, nil
+}
+
+// CompressedTextureFormat represents a format of GPU-compressed texture data.
+//
+// All the formats are 4x4 block formats.
+type CompressedTextureFormat int
+
+const (
+	// CompressedTextureFormatBC1 represents BC1 (DXT1), 8 bytes per block.
+	CompressedTextureFormatBC1 CompressedTextureFormat = CompressedTextureFormat(graphicsdriver.CompressedTextureFormatBC1)
+
+	// CompressedTextureFormatBC3 represents BC3 (DXT5), 16 bytes per block.
+	CompressedTextureFormatBC3 CompressedTextureFormat = CompressedTextureFormat(graphicsdriver.CompressedTextureFormatBC3)
+
+	// CompressedTextureFormatBC7 represents BC7 (BPTC), 16 bytes per block.
+	CompressedTextureFormatBC7 CompressedTextureFormat = CompressedTextureFormat(graphicsdriver.CompressedTextureFormatBC7)
+
+	// CompressedTextureFormatETC2RGB8 represents ETC2 RGB without alpha, 8 bytes per block.
+	CompressedTextureFormatETC2RGB8 CompressedTextureFormat = CompressedTextureFormat(graphicsdriver.CompressedTextureFormatETC2RGB8)
+
+	// CompressedTextureFormatETC2RGBA8 represents ETC2 RGBA with EAC alpha, 16 bytes per block.
+	CompressedTextureFormatETC2RGBA8 CompressedTextureFormat = CompressedTextureFormat(graphicsdriver.CompressedTextureFormatETC2RGBA8)
+
+	// CompressedTextureFormatASTC4x4 represents ASTC with 4x4 blocks, 16 bytes per block.
+	CompressedTextureFormatASTC4x4 CompressedTextureFormat = CompressedTextureFormat(graphicsdriver.CompressedTextureFormatASTC4x4)
+)
+
+// IsCompressedTextureFormatSupported reports whether NewImageFromCompressedData can create an image
+// with the given format in the graphics library currently in use.
+//
+// IsCompressedTextureFormatSupported returns false if the game is not running yet, since the graphics library is not determined.
+func IsCompressedTextureFormatSupported(format CompressedTextureFormat) bool {
+	return ui.Get().IsCompressedTextureFormatSupported(graphicsdriver.CompressedTextureFormat(format))
+}
+
+// NewImageFromCompressedData creates a new image with GPU-compressed texture data like BC, ETC2, and ASTC.
+//
+// The data is uploaded to GPU as it is without being decompressed, which saves GPU memory compared to an RGBA image.
+// data must be the compressed blocks of the mip level 0 without any headers, and its length must be
+// ceil(width/4) * ceil(height/4) * (bytes per block).
+// The color values must be in the premultiplied-alpha format.
+//
+// The available formats depend on the graphics library and the GPU:
+// typically BC on desktops, and ETC2 and ASTC on mobiles.
+// Use IsCompressedTextureFormatSupported to check whether the format is available.
+// Currently only OpenGL and OpenGL ES are supported.
+// NewImageFromCompressedData returns an error if the format is not supported or the data's length is wrong.
+// NewImageFromCompressedData also returns an error if the game is not running yet, since the graphics library is not determined.
+//
+// The returned image is an unmanaged image on a dedicated texture, as compressed blocks cannot be put on an atlas.
+// The returned image can be used only as a rendering source.
+// Drawing on it, WritePixels, Clear, Fill, and reading pixels like ReadPixels and At panic.
+// The bounds of the image are (0, 0) to (width, height).
+//
+// After Deallocate is called, the image no longer has the compressed data and works as a new cleared image.
+func NewImageFromCompressedData(format CompressedTextureFormat, data []byte, width, height int) (*Image, error) {
+	if isRunGameEnded() {
+		panic(fmt.Sprintf("ebiten: NewImageFromCompressedData cannot be called after RunGame finishes"))
+	}
+	if width <= 0 {
+		panic(fmt.Sprintf("ebiten: width at NewImageFromCompressedData must be positive but %d", width))
+	}
+	if height <= 0 {
+		panic(fmt.Sprintf("ebiten: height at NewImageFromCompressedData must be positive but %d", height))
+	}
+
+	img, err := ui.Get().NewImageFromCompressedData(graphicsdriver.CompressedTextureFormat(format), data, width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := &Image{
+		image:  img,
+		bounds: image.Rect(0, 0, width, height),
+	}
+	i.addr = i
+	return i, nil
+}
+
+// IsHighPrecisionImageSupported reports whether NewHighPrecisionImage can create an image
+// in the graphics library currently in use.
+//
+// IsHighPrecisionImageSupported returns false if the game is not running yet, since the graphics library is not determined.
+func IsHighPrecisionImageSupported() bool {
+	return ui.Get().IsHighPrecisionImageSupported()
+}
+
+// NewHighPrecisionImage creates a new empty image that has a 16-bit floating point number per channel.
+//
+// A high-precision image is useful for the use cases that require more than 8 bits per channel,
+// e.g. height maps, normal maps, and accumulation buffers.
+// Rendering onto a high-precision image keeps the precision, and the results are not clamped to [0, 1] unless the shader clamps them.
+// Use WritePixelsFloat32 and ReadPixelsFloat32 to access the pixels without losing the precision.
+// The other functions to access the pixels like WritePixels, ReadPixels, Set, and At still work with 8-bit values.
+// In this case, the values are clamped to [0, 1].
+//
+// Currently only OpenGL and OpenGL ES are supported, and browsers are not supported.
+// Use IsHighPrecisionImageSupported to check whether a high-precision image is available.
+// NewHighPrecisionImage returns an error if a high-precision image is not supported.
+// NewHighPrecisionImage also returns an error if the game is not running yet, since the graphics library is not determined.
+//
+// The returned image is an unmanaged image on a dedicated texture, as an atlas consists of 8-bit images.
+// When a high-precision image is rendered with a smaller scale and FilterLinear, the mipmaps might be 8-bit images.
+//
+// If width or height is less than 1, NewHighPrecisionImage panics.
+func NewHighPrecisionImage(width, height int) (*Image, error) {
+	if isRunGameEnded() {
+		panic(fmt.Sprintf("ebiten: NewHighPrecisionImage cannot be called after RunGame finishes"))
+	}
+	if width <= 0 {
+		panic(fmt.Sprintf("ebiten: width at NewHighPrecisionImage must be positive but %d", width))
+	}
+	if height <= 0 {
+		panic(fmt.Sprintf("ebiten: height at NewHighPrecisionImage must be positive but %d", height))
+	}
+
+	img, err := ui.Get().NewHighPrecisionImage(width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := &Image{
+		image:  img,
+		bounds: image.Rect(0, 0, width, height),
+	}
+	i.addr = i
+	return i, nil
+}
+
+// WritePixelsFloat32 replaces the pixels of the image with floating point values.
+//
+// The given pixels are treated as RGBA floating point values in the premultiplied-alpha format, where 1 is the maximum intensity.
+// The length of pixels must be 4 * (bounds width) * (bounds height).
+// If len(pixels) is not correct, WritePixelsFloat32 panics.
+//
+// For a high-precision image created by NewHighPrecisionImage, the values are stored as 16-bit floating point numbers.
+// For the other images, the values are clamped to [0, 1] and stored as 8-bit values.
+//
+// WritePixelsFloat32 also works on a sub-image.
+//
+// When the image is disposed, WritePixelsFloat32 does nothing.
+func (i *Image) WritePixelsFloat32(pixels []float32) {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+
+	b := i.Bounds()
+	if got, want := len(pixels), 4*b.Dx()*b.Dy(); got != want {
+		panic(fmt.Sprintf("ebiten: len(pixels) must be %d but %d at WritePixelsFloat32", want, got))
+	}
+
+	if !i.image.IsHighPrecision() {
+		pix := make([]byte, len(pixels))
+		for j, v := range pixels {
+			pix[j] = float32ToByte(v)
+		}
+		i.image.WritePixels(pix, i.adjustedBounds())
+		return
+	}
+
+	pix := make([]byte, 2*len(pixels))
+	for j, v := range pixels {
+		binary.LittleEndian.PutUint16(pix[2*j:], graphics.Float32ToFloat16(v))
+	}
+	i.image.WritePixelsHighPrecision(pix, i.adjustedBounds())
+}
+
+// ReadPixelsFloat32 reads the image's pixels as floating point values.
+//
+// ReadPixelsFloat32 returns RGBA floating point values in the premultiplied-alpha format, where 1 is the maximum intensity.
+// The length of pixels must be 4 * (bounds width) * (bounds height).
+// If len(pixels) is not correct, ReadPixelsFloat32 panics.
+//
+// For a high-precision image created by NewHighPrecisionImage, the values are not clamped to [0, 1].
+//
+// ReadPixelsFloat32 loads pixels from GPU to system memory if necessary, which means that ReadPixelsFloat32 can be slow.
+//
+// ReadPixelsFloat32 always returns transparent colors if the image is disposed.
+//
+// ReadPixelsFloat32 also works on a sub-image.
+//
+// ReadPixelsFloat32 can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) ReadPixelsFloat32(pixels []float32) {
+	b := i.Bounds()
+	if got, want := len(pixels), 4*b.Dx()*b.Dy(); got != want {
+		panic(fmt.Sprintf("ebiten: len(pixels) must be %d but %d at ReadPixelsFloat32", want, got))
+	}
+
+	if i.isDisposed() {
+		for i := range pixels {
+			pixels[i] = 0
+		}
+		return
+	}
+
+	if !i.image.IsHighPrecision() {
+		pix := make([]byte, len(pixels))
+		i.image.ReadPixels(pix, i.adjustedBounds())
+		for j, v := range pix {
+			pixels[j] = float32(v) / 0xff
+		}
+		return
+	}
+
+	pix := make([]byte, 2*len(pixels))
+	i.image.ReadPixelsHighPrecision(pix, i.adjustedBounds())
+	for j := range pixels {
+		pixels[j] = graphics.Float16ToFloat32(binary.LittleEndian.Uint16(pix[2*j:]))
+	}
+}
+
+func float32ToByte(v float32) byte {
+	if !(v > 0) {
+		return 0
+	}
+	if v >= 1 {
+		return 0xff
+	}
+	return byte(v*0xff + 0.5)
+}
+
 // colorMToScale returns a new color matrix and color scales that equal to the given matrix in terms of the effect.
 //
 // If the given matrix is merely a scaling matrix, colorMToScale returns
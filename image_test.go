@@ -396,6 +396,67 @@ func TestImageBlendLighter(t *testing.T) {
 	}
 }
 
+func TestImageBlendPresets(t *testing.T) {
+	dstClr := color.RGBA{R: 0x80, G: 0x40, B: 0xff, A: 0xff}
+	srcClr := color.RGBA{R: 0x40, G: 0x20, B: 0x80, A: 0x80}
+	mul := func(x, y uint8) int {
+		return int(x) * int(y) / 0xff
+	}
+
+	for _, tc := range []struct {
+		name  string
+		blend ebiten.Blend
+		want  func(s, d uint8, sa uint8) int
+		wantA int
+	}{
+		{
+			name:  "multiply",
+			blend: ebiten.BlendMultiply,
+			want: func(s, d uint8, sa uint8) int {
+				return mul(s, d) + mul(d, 0xff-sa)
+			},
+			wantA: 0xff,
+		},
+		{
+			name:  "screen",
+			blend: ebiten.BlendScreen,
+			want: func(s, d uint8, sa uint8) int {
+				return int(s) + mul(d, 0xff-s)
+			},
+			wantA: 0xff,
+		},
+		{
+			name:  "subtract",
+			blend: ebiten.BlendSubtract,
+			want: func(s, d uint8, sa uint8) int {
+				return max(0, int(d)-int(s))
+			},
+			wantA: 0xff,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := ebiten.NewImage(1, 1)
+			dst.Fill(dstClr)
+			src := ebiten.NewImage(1, 1)
+			src.Fill(srcClr)
+			op := &ebiten.DrawImageOptions{}
+			op.Blend = tc.blend
+			dst.DrawImage(src, op)
+
+			got := dst.At(0, 0).(color.RGBA)
+			want := color.RGBA{
+				R: uint8(tc.want(srcClr.R, dstClr.R, srcClr.A)),
+				G: uint8(tc.want(srcClr.G, dstClr.G, srcClr.A)),
+				B: uint8(tc.want(srcClr.B, dstClr.B, srcClr.A)),
+				A: uint8(tc.wantA),
+			}
+			if !sameColors(got, want, 2) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestNewImageFromEbitenImage(t *testing.T) {
 	img, _, err := openEbitenImage()
 	if err != nil {
@@ -484,6 +545,25 @@ func TestImageClear(t *testing.T) {
 	}
 }
 
+func TestImageSubImageClear(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.Fill(color.White)
+	img.SubImage(image.Rect(4, 4, 12, 12)).(*ebiten.Image).Clear()
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j)
+			want := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			if image.Pt(i, j).In(image.Rect(4, 4, 12, 12)) {
+				want = color.RGBA{}
+			}
+			if got != want {
+				t.Errorf("img At(%d, %d): got %v; want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
 // Issue #317, #558, #724
 func TestImageEdge(t *testing.T) {
 	// TODO: This test is not so meaningful after #1218. Do we remove this?
@@ -1358,6 +1438,103 @@ func TestImageAddressRepeat(t *testing.T) {
 	}
 }
 
+func TestImageDrawImageSourceRectAddress(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			if 4 <= i && i < 8 && 4 <= j && j < 8 {
+				pix[idx] = byte(i-4) * 0x10
+				pix[idx+1] = byte(j-4) * 0x10
+				pix[idx+2] = 0
+				pix[idx+3] = 0xff
+			} else {
+				pix[idx] = 0
+				pix[idx+1] = 0
+				pix[idx+2] = 0xff
+				pix[idx+3] = 0xff
+			}
+		}
+	}
+	src.WritePixels(pix)
+	subImage := src.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image)
+
+	testCases := []struct {
+		Name       string
+		Address    ebiten.Address
+		SourceRect image.Rectangle
+		// Texel returns the expected texel index of the source sub-image at the coordinate x relative to the sub-image.
+		Texel func(x int) int
+	}{
+		{
+			Name:       "repeat",
+			Address:    ebiten.AddressRepeat,
+			SourceRect: image.Rect(4, 4, 4+w, 4+h),
+			Texel: func(x int) int {
+				return x % 4
+			},
+		},
+		{
+			Name:       "repeat with an offset",
+			Address:    ebiten.AddressRepeat,
+			SourceRect: image.Rect(5, 6, 5+w, 6+h),
+			Texel: func(x int) int {
+				return x % 4
+			},
+		},
+		{
+			Name:       "mirror",
+			Address:    ebiten.AddressMirror,
+			SourceRect: image.Rect(4, 4, 4+w, 4+h),
+			Texel: func(x int) int {
+				q := x % 8
+				if q < 4 {
+					return q
+				}
+				return 7 - q
+			},
+		},
+		{
+			Name:       "clamp to edge",
+			Address:    ebiten.AddressClampToEdge,
+			SourceRect: image.Rect(2, 2, 2+w, 2+h),
+			Texel: func(x int) int {
+				if x < 0 {
+					return 0
+				}
+				if x > 3 {
+					return 3
+				}
+				return x
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			dst := ebiten.NewImage(w, h)
+			op := &ebiten.DrawImageOptions{}
+			op.Address = tc.Address
+			op.SourceRect = tc.SourceRect
+			dst.DrawImage(subImage, op)
+
+			offsetX := tc.SourceRect.Min.X - subImage.Bounds().Min.X
+			offsetY := tc.SourceRect.Min.Y - subImage.Bounds().Min.Y
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					got := dst.At(i, j).(color.RGBA)
+					want := color.RGBA{R: byte(tc.Texel(i+offsetX)) * 0x10, G: byte(tc.Texel(j+offsetY)) * 0x10, A: 0xff}
+					if !sameColors(got, want, 1) {
+						t.Errorf("dst.At(%d, %d): got %v, want: %v", i, j, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestImageAddressRepeatNegativePosition(t *testing.T) {
 	const w, h = 16, 16
 	src := ebiten.NewImage(w, h)
@@ -4427,6 +4604,30 @@ func TestImageColorScaleAndColorM(t *testing.T) {
 	}
 }
 
+func TestImageColorScaleModeStraightAlpha(t *testing.T) {
+	const w, h = 16, 16
+	dst0 := ebiten.NewImage(w, h)
+	dst1 := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0x24, G: 0x3f, B: 0x6a, A: 0x88})
+
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.Scale(0.3, 0.4, 0.5, 0.6)
+	op.ColorScaleMode = ebiten.ColorScaleModeStraightAlpha
+	op.ColorScaleModeSet = true
+	dst0.DrawImage(src, op)
+
+	op = &ebiten.DrawImageOptions{}
+	op.ColorM.Scale(0.3, 0.4, 0.5, 0.6)
+	dst1.DrawImage(src, op)
+
+	got := dst0.At(0, 0)
+	want := dst1.At(0, 0)
+	if got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
 // Issue #2428
 func TestImageSetAndSubImage(t *testing.T) {
 	const w, h = 16, 16
@@ -4481,6 +4682,44 @@ func TestImageDrawTrianglesShaderWithGreaterIndexThanVerticesCount(t *testing.T)
 	dst.DrawTrianglesShader(vs, is, shader, nil)
 }
 
+func TestImageDrawTriangles32WithGeoM(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(w/2, h)
+	clr := color.RGBA{R: 0xff, A: 0xff}
+	src.Fill(clr)
+
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: w / 2, DstY: 0, SrcX: w / 2, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 0, DstY: h, SrcX: 0, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: w / 2, DstY: h, SrcX: w / 2, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+	}
+	is := []uint32{0, 1, 2, 1, 2, 3}
+
+	// The vertices are not modified, and can be reused with a different GeoM.
+	op := &ebiten.DrawTrianglesOptions{}
+	op.GeoM.Translate(w/2, 0)
+	dst.DrawTriangles32(vs, is, src, op)
+
+	if got, want := vs[0].DstX, float32(0); got != want {
+		t.Errorf("vs[0].DstX: got: %v, want: %v", got, want)
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j)
+			want := color.RGBA{}
+			if i >= w/2 {
+				want = clr
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
 // Issue #2733
 func TestImageGeoMAfterDraw(t *testing.T) {
 	src := ebiten.NewImage(1, 1)
@@ -4582,3 +4821,716 @@ func TestImageDrawImageAfterDeallocation(t *testing.T) {
 		}
 	}
 }
+
+func TestImageFillLinearGradient(t *testing.T) {
+	const w, h = 16, 4
+	img := ebiten.NewImage(w, h)
+	img.FillLinearGradient(0, 0, w, 0, color.Black, color.White)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j).(color.RGBA)
+			v := uint8(math.Round((float64(i) + 0.5) / w * 0xff))
+			want := color.RGBA{R: v, G: v, B: v, A: 0xff}
+			if !sameColors(got, want, 1) {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageFillRadialGradient(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.FillRadialGradient(w/2, h/2, w/2, color.White, color.Transparent)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j).(color.RGBA)
+			d := math.Hypot(float64(i)+0.5-w/2, float64(j)+0.5-h/2) / (w / 2)
+			v := uint8(math.Round((1 - math.Min(d, 1)) * 0xff))
+			want := color.RGBA{R: v, G: v, B: v, A: v}
+			if !sameColors(got, want, 1) {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageDrawPalettedImage(t *testing.T) {
+	const w, h = 16, 16
+	src := image.NewPaletted(image.Rect(0, 0, w, h), color.Palette{
+		color.RGBA{R: 0xff, A: 0xff},
+		color.RGBA{G: 0xff, A: 0xff},
+	})
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			src.SetColorIndex(i, j, uint8((i+j)%3))
+		}
+	}
+	p := ebiten.NewPalettedImageFromImage(src)
+
+	dst := ebiten.NewImage(w, h)
+	dst.DrawPalettedImage(p, nil)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j)
+			var want color.RGBA
+			switch (i + j) % 3 {
+			case 0:
+				want = color.RGBA{R: 0xff, A: 0xff}
+			case 1:
+				want = color.RGBA{G: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Swap the palette without updating the indices.
+	p.SetPalette(color.Palette{
+		color.RGBA{B: 0xff, A: 0xff},
+		color.RGBA{R: 0xff, G: 0xff, A: 0xff},
+		color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80},
+	})
+	dst.Clear()
+	dst.DrawPalettedImage(p, nil)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j)
+			var want color.RGBA
+			switch (i + j) % 3 {
+			case 0:
+				want = color.RGBA{B: 0xff, A: 0xff}
+			case 1:
+				want = color.RGBA{R: 0xff, G: 0xff, A: 0xff}
+			case 2:
+				want = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80}
+			}
+			if got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageWritePNG(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.Fill(color.RGBA{R: 0x40, G: 0x20, B: 0x10, A: 0x80})
+	img.SubImage(image.Rect(0, 0, w/2, h/2)).(*ebiten.Image).Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	var buf bytes.Buffer
+	if err := img.SubImage(image.Rect(w/2, h/2, w, h)).(*ebiten.Image).WritePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, _, err := image.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := decoded.Bounds(), image.Rect(0, 0, w/2, h/2); got != want {
+		t.Errorf("bounds: got: %v, want: %v", got, want)
+	}
+	got := color.NRGBAModel.Convert(decoded.At(0, 0)).(color.NRGBA)
+	want := color.NRGBA{R: 0x80, G: 0x40, B: 0x20, A: 0x80}
+	if !sameColors(color.RGBA(got), color.RGBA(want), 1) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageDrawTrianglesMaxAnisotropy(t *testing.T) {
+	const (
+		srcW = 64
+		dstW = 16
+		h    = 4
+	)
+
+	// Only every fourth column is white.
+	src := ebiten.NewImage(srcW, h)
+	pix := make([]byte, 4*srcW*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < srcW; i++ {
+			idx := 4 * (i + j*srcW)
+			if i%4 == 0 {
+				pix[idx] = 0xff
+				pix[idx+1] = 0xff
+				pix[idx+2] = 0xff
+			}
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: dstW, DstY: 0, SrcX: srcW, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 0, DstY: h, SrcX: 0, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: dstW, DstY: h, SrcX: srcW, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	for _, maxAnisotropy := range []int{0, 4, ebiten.MaxAnisotropyLimit} {
+		maxAnisotropy := maxAnisotropy
+		t.Run(fmt.Sprintf("max anisotropy %d", maxAnisotropy), func(t *testing.T) {
+			dst := ebiten.NewImage(dstW, h)
+			op := &ebiten.DrawTrianglesOptions{}
+			op.Filter = ebiten.FilterLinear
+			op.Address = ebiten.AddressRepeat
+			op.MaxAnisotropy = maxAnisotropy
+			dst.DrawTriangles(vs, is, src, op)
+
+			// Without anisotropic filtering, each pixel is the linear interpolation of the two black texels at the center.
+			// With anisotropic filtering, each pixel is the average of the four texels in its footprint.
+			want := color.RGBA{A: 0xff}
+			if maxAnisotropy >= 4 {
+				want = color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff}
+			}
+			for j := 0; j < h; j++ {
+				for i := 0; i < dstW; i++ {
+					got := dst.At(i, j).(color.RGBA)
+					if !sameColors(got, want, 2) {
+						t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestImageSnapshot(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.Fill(color.RGBA{R: 0xff, A: 0xff})
+	img.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image).Fill(color.RGBA{G: 0x80, A: 0x80})
+
+	s := img.Snapshot()
+	if got, want := s.Bounds(), image.Rect(0, 0, w, h); got != want {
+		t.Errorf("s.Bounds(): got: %v, want: %v", got, want)
+	}
+
+	img.Fill(color.RGBA{B: 0xff, A: 0xff})
+	img.Restore(s)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if 4 <= i && i < 8 && 4 <= j && j < 8 {
+				want = color.RGBA{G: 0x80, A: 0x80}
+			}
+			if got != want {
+				t.Errorf("img.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageSnapshotSubImage(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	sub := img.SubImage(image.Rect(4, 4, 8, 12)).(*ebiten.Image)
+	s := sub.Snapshot()
+	if got, want := s.Bounds(), image.Rect(0, 0, 4, 8); got != want {
+		t.Errorf("s.Bounds(): got: %v, want: %v", got, want)
+	}
+
+	// Restore the snapshot to another region.
+	img.Clear()
+	img.SubImage(image.Rect(8, 2, 12, 10)).(*ebiten.Image).Restore(s)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j).(color.RGBA)
+			want := color.RGBA{}
+			if 8 <= i && i < 12 && 2 <= j && j < 10 {
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("img.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	s.Deallocate()
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("Restore with a deallocated snapshot must panic")
+		}
+	}()
+	img.Restore(s)
+}
+
+func TestImageCopyFrom(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			pix[idx] = byte(i * 0x10)
+			pix[idx+1] = byte(j * 0x10)
+			pix[idx+2] = 0
+			pix[idx+3] = 0xf0
+		}
+	}
+	src.WritePixels(pix)
+
+	dst := ebiten.NewImage(w, h)
+	dst.Fill(color.RGBA{B: 0xff, A: 0xff})
+
+	// The source region is partially out of the source bounds.
+	dst.CopyFrom(src, image.Rect(-2, 4, 6, 8), image.Pt(8, 10))
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{B: 0xff, A: 0xff}
+			if 10 <= i && i < 16 && 10 <= j && j < 14 {
+				sx, sy := i-10, j-10+4
+				want = color.RGBA{R: byte(sx * 0x10), G: byte(sy * 0x10), A: 0xf0}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestFlushGraphics(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	// Set buffers the pixel. FlushGraphics must flush it before the following rendering.
+	dst.Set(1, 1, color.RGBA{G: 0xff, A: 0xff})
+	ebiten.FlushGraphics(nil)
+	dst.DrawImage(src.SubImage(image.Rect(0, 0, 1, 1)).(*ebiten.Image), nil)
+	ebiten.FlushGraphics(&ebiten.FlushGraphicsOptions{Wait: true})
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			switch {
+			case i == 0 && j == 0:
+				want = color.RGBA{R: 0xff, A: 0xff}
+			case i == 1 && j == 1:
+				want = color.RGBA{G: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageReadPixel(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	for i := 0; i < 3; i++ {
+		// Render the image every time to check the pixel is not cached.
+		clr := color.RGBA{R: byte(i * 0x40), G: 0x80, A: 0xff}
+		img.Fill(clr)
+		img.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image).Fill(color.RGBA{B: byte(i * 0x40), A: 0xff})
+
+		if got, want := img.ReadPixel(1, 1), clr; got != want {
+			t.Errorf("img.ReadPixel(1, 1): got: %v, want: %v", got, want)
+		}
+		if got, want := img.ReadPixel(5, 6), (color.RGBA{B: byte(i * 0x40), A: 0xff}); got != want {
+			t.Errorf("img.ReadPixel(5, 6): got: %v, want: %v", got, want)
+		}
+		if got, want := img.ReadPixel(-1, 0), (color.RGBA{}); got != want {
+			t.Errorf("img.ReadPixel(-1, 0): got: %v, want: %v", got, want)
+		}
+	}
+
+	// ReadPixel works on a sub-image.
+	sub := img.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image)
+	if got, want := sub.ReadPixel(4, 4), (color.RGBA{B: 0x80, A: 0xff}); got != want {
+		t.Errorf("sub.ReadPixel(4, 4): got: %v, want: %v", got, want)
+	}
+	if got, want := sub.ReadPixel(1, 1), (color.RGBA{}); got != want {
+		t.Errorf("sub.ReadPixel(1, 1): got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageWithOrigin(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImageWithOptions(image.Rect(2, 3, 2+w, 3+h), nil)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			pix[idx] = byte(i * 0x10)
+			pix[idx+1] = byte(j * 0x10)
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	img := src.WithOrigin(image.Pt(100, 200))
+	if got, want := img.Bounds(), image.Rect(100, 200, 100+w, 200+h); got != want {
+		t.Errorf("img.Bounds(): got: %v, want: %v", got, want)
+	}
+	if got, want := img.At(101, 202), (color.RGBA{R: 0x10, G: 0x20, A: 0xff}); got != want {
+		t.Errorf("img.At(101, 202): got: %v, want: %v", got, want)
+	}
+
+	// Set in the translated coordinate is reflected to the original image.
+	img.Set(103, 204, color.RGBA{B: 0xff, A: 0xff})
+	if got, want := src.At(5, 7), (color.RGBA{B: 0xff, A: 0xff}); got != want {
+		t.Errorf("src.At(5, 7): got: %v, want: %v", got, want)
+	}
+
+	// A sub-image of the translated image.
+	sub := img.SubImage(image.Rect(104, 208, 108, 212)).(*ebiten.Image)
+	if got, want := sub.At(104, 208), (color.RGBA{R: 0x40, G: 0x80, A: 0xff}); got != want {
+		t.Errorf("sub.At(104, 208): got: %v, want: %v", got, want)
+	}
+
+	// Drawing the translated sub-image renders its upper-left position at the origin.
+	dst := ebiten.NewImage(w, h)
+	dst.DrawImage(sub, nil)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			if i < 4 && j < 4 {
+				want = color.RGBA{R: byte((i + 4) * 0x10), G: byte((j + 8) * 0x10), A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Drawing onto the translated image works in the translated coordinate.
+	red := ebiten.NewImage(1, 1)
+	red.Fill(color.RGBA{R: 0xff, A: 0xff})
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(110, 210)
+	img.DrawImage(red, op)
+	if got, want := src.At(12, 13), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+		t.Errorf("src.At(12, 13): got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageNewImageFromCompressedData(t *testing.T) {
+	if !ebiten.IsCompressedTextureFormatSupported(ebiten.CompressedTextureFormatBC1) {
+		t.Skip("BC1 is not supported in this environment")
+	}
+
+	// A 4x4 BC1 block filled with the color0 (red in RGB565).
+	data := []byte{0x00, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	if _, err := ebiten.NewImageFromCompressedData(ebiten.CompressedTextureFormatBC1, data[:4], 4, 4); err == nil {
+		t.Errorf("NewImageFromCompressedData with too short data must return an error")
+	}
+
+	src, err := ebiten.NewImageFromCompressedData(ebiten.CompressedTextureFormatBC1, data, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Deallocate()
+	if got, want := src.Bounds(), image.Rect(0, 0, 4, 4); got != want {
+		t.Errorf("src.Bounds(): got: %v, want: %v", got, want)
+	}
+
+	dst := ebiten.NewImage(8, 8)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(2, 2)
+	dst.DrawImage(src, op)
+	for j := 0; j < 8; j++ {
+		for i := 0; i < 8; i++ {
+			got := dst.At(i, j)
+			want := color.RGBA{}
+			if 2 <= i && i < 6 && 2 <= j && j < 6 {
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("drawing on a compressed image must panic")
+			}
+		}()
+		src.Fill(color.White)
+	}()
+}
+
+func TestImageNewHighPrecisionImage(t *testing.T) {
+	if !ebiten.IsHighPrecisionImageSupported() {
+		t.Skip("high-precision images are not supported in this environment")
+	}
+
+	const w, h = 4, 4
+	img, err := ebiten.NewHighPrecisionImage(w, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer img.Deallocate()
+
+	// A value that cannot be represented with 8 bits, and a value out of [0, 1].
+	pix := make([]float32, 4*w*h)
+	for i := 0; i < w*h; i++ {
+		pix[4*i] = 0.1234
+		pix[4*i+1] = 2
+		pix[4*i+3] = 1
+	}
+	img.WritePixelsFloat32(pix)
+
+	got := make([]float32, 4*w*h)
+	img.ReadPixelsFloat32(got)
+	for i := 0; i < w*h; i++ {
+		if r := got[4*i]; math.Abs(float64(r-0.1234)) > 1.0/1024 {
+			t.Errorf("R at %d: got: %f, want: %f", i, r, 0.1234)
+		}
+		if g := got[4*i+1]; g != 2 {
+			t.Errorf("G at %d: got: %f, want: %f", i, g, 2.0)
+		}
+	}
+
+	// Accumulate small values, which would be lost with 8 bits per channel.
+	dst, err := ebiten.NewHighPrecisionImage(w, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Deallocate()
+
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+	for i := 0; i < 64; i++ {
+		op := &ebiten.DrawImageOptions{}
+		op.ColorScale.Scale(1.0/256, 1.0/256, 1.0/256, 1.0/256)
+		op.Blend = ebiten.BlendLighter
+		dst.DrawImage(src, op)
+	}
+	dst.ReadPixelsFloat32(got)
+	for i := 0; i < len(got); i++ {
+		if v := got[i]; math.Abs(float64(v-0.25)) > 1.0/1024 {
+			t.Errorf("got[%d]: got: %f, want: %f", i, v, 0.25)
+		}
+	}
+
+	// 8-bit functions still work with clamped values.
+	if got, want := img.At(0, 0), (color.RGBA{R: 0x1f, G: 0xff, A: 0xff}); got != want {
+		t.Errorf("img.At(0, 0): got: %v, want: %v", got, want)
+	}
+	img.Set(1, 1, color.RGBA{B: 0x80, A: 0xff})
+	sub := make([]float32, 4)
+	img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image).ReadPixelsFloat32(sub)
+	if got, want := sub[2], float32(0x80)/0xff; math.Abs(float64(got-want)) > 1.0/1024 {
+		t.Errorf("B at (1, 1): got: %f, want: %f", got, want)
+	}
+}
+
+func TestImageDebugGroupAndLabel(t *testing.T) {
+	src := ebiten.NewImageWithOptions(image.Rect(0, 0, 4, 4), &ebiten.NewImageOptions{
+		Unmanaged: true,
+	})
+	defer src.Deallocate()
+	src.SetDebugLabel("src")
+	src.Fill(color.White)
+
+	dst := ebiten.NewImage(4, 4)
+	dst.SetDebugLabel("dst")
+
+	ebiten.PushDebugGroup("outer")
+	ebiten.PushDebugGroup("inner")
+	dst.DrawImage(src, nil)
+	ebiten.PopDebugGroup()
+	ebiten.PopDebugGroup()
+	// An extra PopDebugGroup must do nothing.
+	ebiten.PopDebugGroup()
+
+	// A label of a sub-image is ignored.
+	dst.SubImage(image.Rect(1, 1, 3, 3)).(*ebiten.Image).SetDebugLabel("sub")
+
+	if got, want := dst.At(1, 1), (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageSupersamplingOption(t *testing.T) {
+	const w, h = 64, 48
+
+	dst0 := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		Supersampling: true,
+	})
+	dst1 := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(3, 3)
+	src.Fill(color.White)
+
+	vs := []ebiten.Vertex{
+		{DstX: 3, DstY: 5, SrcX: 1, SrcY: 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 61, DstY: 11, SrcX: 2, SrcY: 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 17, DstY: 43, SrcX: 1, SrcY: 2, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+	}
+	is := []uint16{0, 1, 2}
+
+	// An image with Supersampling is rendered in the same way as DrawTrianglesOptions.AntiAlias.
+	dst0.DrawTriangles(vs, is, src, nil)
+	op := &ebiten.DrawTrianglesOptions{}
+	op.AntiAlias = true
+	dst1.DrawTriangles(vs, is, src, op)
+
+	var partial bool
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst0.At(i, j).(color.RGBA)
+			want := dst1.At(i, j).(color.RGBA)
+			if got != want {
+				t.Errorf("dst0.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+			if got.A != 0 && got.A != 0xff {
+				partial = true
+			}
+		}
+	}
+	if !partial {
+		t.Errorf("the edges must be anti-aliased")
+	}
+}
+
+func TestImageDefaultBlendAndFilter(t *testing.T) {
+	const w, h = 16, 16
+
+	src := ebiten.NewImage(w/2, h/2)
+	src.Fill(color.RGBA{0xff, 0, 0, 0xff})
+	src.Set(0, 0, color.RGBA{0, 0xff, 0, 0xff})
+
+	// The default filter is used when the option's filter is the zero value.
+	src.SetDefaultFilter(ebiten.FilterLinear)
+	if got, want := src.DefaultFilter(), ebiten.FilterLinear; got != want {
+		t.Errorf("DefaultFilter: got: %v, want: %v", got, want)
+	}
+	dst0 := ebiten.NewImage(w, h)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(2, 2)
+	dst0.DrawImage(src, op)
+
+	dst1 := ebiten.NewImage(w, h)
+	src.SetDefaultFilter(ebiten.FilterNearest)
+	op.Filter = ebiten.FilterLinear
+	dst1.DrawImage(src, op)
+
+	dst2 := ebiten.NewImage(w, h)
+	op.Filter = ebiten.FilterNearest
+	dst2.DrawImage(src, op)
+
+	// FilterNearest with FilterSet overrides the default filter.
+	dst3 := ebiten.NewImage(w, h)
+	src.SetDefaultFilter(ebiten.FilterLinear)
+	op.Filter = ebiten.FilterNearest
+	op.FilterSet = true
+	dst3.DrawImage(src, op)
+
+	var diffNearest bool
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst0.At(i, j)
+			want := dst1.At(i, j)
+			if got != want {
+				t.Errorf("dst0.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+			if got != dst2.At(i, j) {
+				diffNearest = true
+			}
+			if got, want := dst3.At(i, j), dst2.At(i, j); got != want {
+				t.Errorf("dst3.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+	if !diffNearest {
+		t.Errorf("the default filter must be applied")
+	}
+
+	// A sub-image copies the default filter when it is created.
+	sub := src.SubImage(image.Rect(0, 0, 1, 1)).(*ebiten.Image)
+	src.SetDefaultFilter(ebiten.FilterNearest)
+	if got, want := sub.DefaultFilter(), ebiten.FilterLinear; got != want {
+		t.Errorf("sub.DefaultFilter: got: %v, want: %v", got, want)
+	}
+
+	// The default blend is used when the option's blend is the zero value.
+	dst := ebiten.NewImage(w, h)
+	dst.Fill(color.White)
+	transparent := ebiten.NewImage(w, h)
+	transparent.SetDefaultBlend(ebiten.BlendCopy)
+	if got, want := transparent.DefaultBlend(), ebiten.BlendCopy; got != want {
+		t.Errorf("DefaultBlend: got: %v, want: %v", got, want)
+	}
+	// A sub-image inherits the default blend.
+	dst.DrawImage(transparent.SubImage(image.Rect(0, 0, w/2, h)).(*ebiten.Image), nil)
+
+	// An explicit blend overrides the default blend, even if the blend is the zero value.
+	op = &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(w/2, 0)
+	op.BlendSet = true
+	dst.DrawImage(transparent.SubImage(image.Rect(w/2, 0, w, h)).(*ebiten.Image), op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j)
+			want := color.RGBA{0xff, 0xff, 0xff, 0xff}
+			if i < w/2 {
+				want = color.RGBA{}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageDrawRectShaderExtraImages(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0At(srcPos) + imageSrc4At(srcPos)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src0 := ebiten.NewImage(w, h)
+	src0.Fill(color.RGBA{R: 0xff, A: 0xff})
+	src4 := ebiten.NewImage(w, h)
+	src4.Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	dst := ebiten.NewImage(w, h)
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src0
+	op.ExtraImages = []*ebiten.Image{src4}
+	dst.DrawRectShader(w, h, s, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j)
+			want := color.RGBA{R: 0xff, G: 0xff, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("DrawRectShader with too many ExtraImages must panic")
+		}
+	}()
+	op.ExtraImages = make([]*ebiten.Image, ebiten.GraphicsLibraryInfo().MaxShaderImages)
+	dst.DrawRectShader(w, h, s, op)
+}
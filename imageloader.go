@@ -0,0 +1,168 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"image"
+	"io"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+)
+
+// imageLoaderBytesPerTick is the maximum number of bytes of pixels uploaded by the asynchronous image loader per tick.
+const imageLoaderBytesPerTick = 4 * 1024 * 1024
+
+// NewImageFromReaderAsync decodes an image from r on a different goroutine, and creates a new image from it.
+//
+// The decoded pixels are uploaded incrementally across ticks so that loading many large images doesn't stall a frame.
+// When the image is ready, callback is called with the image.
+// If decoding fails, callback is called with a nil image and the error.
+//
+// callback is called on the same goroutine as Game.Update, right before Update.
+// Then, callback is not called until RunGame starts.
+// callbacks are called in the order of NewImageFromReaderAsync calls.
+//
+// As with image.Decode, the image formats must be registered, e.g. by importing image/png.
+// r must not be used until callback is called.
+//
+// NewImageFromReaderAsync is concurrent-safe.
+func NewImageFromReaderAsync(r io.Reader, callback func(img *Image, err error)) {
+	job := &imageLoaderJob{
+		callback: callback,
+	}
+	theImageLoader.add(job)
+
+	go func() {
+		img, _, err := image.Decode(r)
+		if err != nil {
+			theImageLoader.setDecoded(job, nil, 0, 0, err)
+			return
+		}
+		size := img.Bounds().Size()
+		if size.X <= 0 || size.Y <= 0 {
+			theImageLoader.setDecoded(job, nil, 0, 0, errors.New("ebiten: the decoded image is empty"))
+			return
+		}
+		// Convert the image to bytes on this goroutine, as the conversion might be heavy.
+		theImageLoader.setDecoded(job, imageToBytes(img), size.X, size.Y, nil)
+	}()
+}
+
+type imageLoaderJob struct {
+	callback func(img *Image, err error)
+
+	// decoded, pixels, width, height, and err are protected by imageLoader.m.
+	decoded bool
+	pixels  []byte
+	width   int
+	height  int
+	err     error
+
+	// img and uploadedRows are used only on the goroutine of Game.Update.
+	img          *Image
+	uploadedRows int
+}
+
+type imageLoader struct {
+	jobs []*imageLoaderJob
+
+	once sync.Once
+	m    sync.Mutex
+}
+
+var theImageLoader imageLoader
+
+func (i *imageLoader) add(job *imageLoaderJob) {
+	i.once.Do(func() {
+		hook.AppendHookOnBeforeUpdate(func() error {
+			i.update()
+			return nil
+		})
+	})
+
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.jobs = append(i.jobs, job)
+}
+
+func (i *imageLoader) setDecoded(job *imageLoaderJob, pixels []byte, width, height int, err error) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	job.decoded = true
+	job.pixels = pixels
+	job.width = width
+	job.height = height
+	job.err = err
+}
+
+// update uploads the decoded pixels up to imageLoaderBytesPerTick, and calls the callbacks for the finished jobs.
+func (i *imageLoader) update() {
+	budget := imageLoaderBytesPerTick
+	for budget > 0 {
+		i.m.Lock()
+		if len(i.jobs) == 0 || !i.jobs[0].decoded {
+			i.m.Unlock()
+			return
+		}
+		job := i.jobs[0]
+		i.m.Unlock()
+
+		if job.err == nil {
+			budget -= job.upload(budget)
+			if job.uploadedRows < job.height {
+				return
+			}
+		}
+
+		i.m.Lock()
+		i.jobs = i.jobs[1:]
+		i.m.Unlock()
+
+		// Call the callback without the lock so that the callback can call NewImageFromReaderAsync.
+		if job.err != nil {
+			job.callback(nil, job.err)
+			continue
+		}
+		img := job.img
+		job.img = nil
+		job.pixels = nil
+		job.callback(img, nil)
+	}
+}
+
+// upload uploads the rows of the pixels up to budget bytes, and returns the number of the uploaded bytes.
+// At least one row is uploaded even if the row is larger than budget.
+func (j *imageLoaderJob) upload(budget int) int {
+	if j.img == nil {
+		j.img = NewImage(j.width, j.height)
+	}
+
+	stride := 4 * j.width
+	rows := budget / stride
+	if rows < 1 {
+		rows = 1
+	}
+	if rows > j.height-j.uploadedRows {
+		rows = j.height - j.uploadedRows
+	}
+
+	y0 := j.uploadedRows
+	y1 := y0 + rows
+	j.img.SubImage(image.Rect(0, y0, j.width, y1)).(*Image).WritePixels(j.pixels[stride*y0 : stride*y1])
+	j.uploadedRows = y1
+	return stride * rows
+}
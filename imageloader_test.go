@@ -0,0 +1,210 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func encodePNGForTesting(t *testing.T, width, height int, clr color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for j := 0; j < height; j++ {
+		for i := 0; i < width; i++ {
+			img.Set(i, j, clr)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func waitImageLoaderDecodedJobs(t *testing.T, n int) {
+	deadline := time.Now().Add(10 * time.Second)
+	for ebiten.ImageLoaderDecodedJobCountForTesting() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout: the number of the decoded jobs didn't reach %d", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// gatedReader blocks reading until gate is closed.
+type gatedReader struct {
+	r    io.Reader
+	gate chan struct{}
+}
+
+func (g *gatedReader) Read(buf []byte) (int, error) {
+	<-g.gate
+	return g.r.Read(buf)
+}
+
+func TestNewImageFromReaderAsyncOrder(t *testing.T) {
+	var got []string
+
+	clr0 := color.RGBA{0xff, 0, 0, 0xff}
+	clr1 := color.RGBA{0, 0xff, 0, 0xff}
+	clr2 := color.RGBA{0, 0, 0xff, 0xff}
+	ebiten.NewImageFromReaderAsync(bytes.NewReader(encodePNGForTesting(t, 4, 4, clr0)), func(img *ebiten.Image, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if c := img.At(0, 0); c != clr0 {
+			t.Errorf("img.At(0, 0): got: %v, want: %v", c, clr0)
+		}
+		got = append(got, "0")
+	})
+	ebiten.NewImageFromReaderAsync(bytes.NewReader(encodePNGForTesting(t, 8, 8, clr1)), func(img *ebiten.Image, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if c := img.At(7, 7); c != clr1 {
+			t.Errorf("img.At(7, 7): got: %v, want: %v", c, clr1)
+		}
+		got = append(got, "1")
+	})
+	ebiten.NewImageFromReaderAsync(bytes.NewReader(encodePNGForTesting(t, 2, 2, clr2)), func(img *ebiten.Image, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if c := img.At(1, 1); c != clr2 {
+			t.Errorf("img.At(1, 1): got: %v, want: %v", c, clr2)
+		}
+		got = append(got, "2")
+	})
+
+	if len(got) != 0 {
+		t.Errorf("callbacks must not be called before an update: got: %v", got)
+	}
+
+	waitImageLoaderDecodedJobs(t, 3)
+	ebiten.UpdateImageLoaderForTesting()
+
+	// Small images are finished in one tick.
+	if want := []string{"0", "1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestNewImageFromReaderAsyncError(t *testing.T) {
+	var called bool
+	ebiten.NewImageFromReaderAsync(bytes.NewReader([]byte("not an image")), func(img *ebiten.Image, err error) {
+		called = true
+		if img != nil {
+			t.Errorf("img must be nil")
+		}
+		if err == nil {
+			t.Errorf("err must not be nil")
+		}
+	})
+
+	waitImageLoaderDecodedJobs(t, 1)
+	ebiten.UpdateImageLoaderForTesting()
+
+	if !called {
+		t.Errorf("the callback must be called")
+	}
+}
+
+func TestNewImageFromReaderAsyncBudget(t *testing.T) {
+	const (
+		w = 1024
+		h = 2048
+	)
+	// The image requires two ticks to be uploaded.
+	if 4*w*h <= ebiten.ImageLoaderBytesPerTick || 4*w*h > 2*ebiten.ImageLoaderBytesPerTick {
+		t.Fatalf("the image size must be between one and two ticks' budget")
+	}
+
+	clr := color.RGBA{0x80, 0x40, 0x20, 0xff}
+	var result *ebiten.Image
+	ebiten.NewImageFromReaderAsync(bytes.NewReader(encodePNGForTesting(t, w, h, clr)), func(img *ebiten.Image, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result = img
+	})
+
+	waitImageLoaderDecodedJobs(t, 1)
+
+	ebiten.UpdateImageLoaderForTesting()
+	if result != nil {
+		t.Fatalf("the callback must not be called in the first tick")
+	}
+
+	ebiten.UpdateImageLoaderForTesting()
+	if result == nil {
+		t.Fatalf("the callback must be called in the second tick")
+	}
+	if got, want := result.Bounds().Size(), (image.Pt(w, h)); got != want {
+		t.Errorf("result.Bounds().Size(): got: %v, want: %v", got, want)
+	}
+	for _, p := range []image.Point{{0, 0}, {w - 1, h/2 - 1}, {0, h / 2}, {w - 1, h - 1}} {
+		if got := result.At(p.X, p.Y); got != clr {
+			t.Errorf("result.At(%d, %d): got: %v, want: %v", p.X, p.Y, got, clr)
+		}
+	}
+}
+
+func TestNewImageFromReaderAsyncSlowDecode(t *testing.T) {
+	var got []string
+
+	gate := make(chan struct{})
+	slow := &gatedReader{
+		r:    bytes.NewReader(encodePNGForTesting(t, 4, 4, color.White)),
+		gate: gate,
+	}
+	ebiten.NewImageFromReaderAsync(slow, func(img *ebiten.Image, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		got = append(got, "slow")
+	})
+	ebiten.NewImageFromReaderAsync(bytes.NewReader(encodePNGForTesting(t, 4, 4, color.White)), func(img *ebiten.Image, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		got = append(got, "fast")
+	})
+
+	// The later job is decoded first, but must wait for the earlier job.
+	waitImageLoaderDecodedJobs(t, 1)
+	ebiten.UpdateImageLoaderForTesting()
+	if len(got) != 0 {
+		t.Errorf("callbacks must not be called while the earlier job is being decoded: got: %v", got)
+	}
+
+	close(gate)
+	waitImageLoaderDecodedJobs(t, 2)
+	ebiten.UpdateImageLoaderForTesting()
+	if want := []string{"slow", "fast"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
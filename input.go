@@ -17,6 +17,7 @@ package ebiten
 import (
 	"io/fs"
 	"sync"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
@@ -51,6 +52,9 @@ func InputChars() []rune {
 // If you want to know whether the key started being pressed in the current tick,
 // use inpututil.IsKeyJustPressed
 //
+// If the input buffering is enabled by SetInputBufferingEnabled,
+// a key pressed and released between two ticks is treated as pressed for one tick.
+//
 // Note that a Key represents a physical key of US keyboard layout.
 // For example, KeyQ represents Q key on US keyboards and ' (quote) key on Dvorak keyboards.
 //
@@ -66,6 +70,49 @@ func IsKeyPressed(key Key) bool {
 	return theInputState.isKeyPressed(key)
 }
 
+// IsKeyRepeated reports whether key is auto-repeated by the platform in the current tick.
+//
+// Auto-repeat events are generated while a key is held, following the platform's key-repeat delay and rate settings.
+// This is useful to implement text fields where holding e.g. backspace should behave as the platform does.
+// IsKeyRepeated doesn't report true for the initial press. Use inpututil.IsKeyJustPressed for this.
+//
+// IsKeyRepeated is supported by desktops and browsers. IsKeyRepeated always returns false on the other platforms.
+//
+// IsKeyRepeated is concurrent-safe.
+func IsKeyRepeated(key Key) bool {
+	return theInputState.isKeyRepeated(key)
+}
+
+// KeyEventTime returns the time of the latest press or release event of the key.
+//
+// The returned time has a monotonic clock reading, so the difference between two times
+// and the difference from time.Now are reliable.
+// The platform's event time is used where available, e.g. on browsers.
+// Otherwise, the time when the input state is sampled is used, so the precision depends on the sampling rate.
+//
+// KeyEventTime returns the zero time if the key has never been pressed.
+//
+// KeyEventTime is concurrent-safe.
+func KeyEventTime(key Key) time.Time {
+	return theInputState.keyEventTime(key)
+}
+
+// KeyPressCount returns the number of times the key started being pressed since the previous tick.
+//
+// The input is sampled more often than the game ticks, e.g. when the display's refresh rate is higher than TPS.
+// KeyPressCount counts all the presses in the tick's window, so this is useful to detect quick inputs that
+// would be coalesced in one tick, e.g. a double tap for fighting games.
+// For example, if a key is pressed, released, and pressed again between two ticks, KeyPressCount returns 2.
+// inpututil.IsKeyJustPressed reports true in such cases, but doesn't tell the number of presses.
+//
+// On desktops, presses are counted with the platform's key events.
+// On the other platforms, the precision depends on the sampling rate.
+//
+// KeyPressCount is concurrent-safe.
+func KeyPressCount(key Key) int {
+	return theInputState.keyPressCount(key)
+}
+
 // KeyName returns a key name for the current keyboard layout.
 // For example, KeyName(KeyQ) returns 'q' for a QWERTY keyboard, and returns 'a' for an AZERTY keyboard.
 //
@@ -95,11 +142,27 @@ func CursorPosition() (x, y int) {
 // Wheel returns x and y offsets of the mouse wheel or touchpad scroll.
 // It returns 0 if the wheel isn't being rolled.
 //
+// The offsets are floating-point values. A touchpad or a tilt wheel can give fractional offsets in both axes.
+// Use IsWheelPrecise to know whether the offsets can be used for smooth scrolling.
+//
 // Wheel is concurrent-safe.
 func Wheel() (xoff, yoff float64) {
 	return theInputState.wheel()
 }
 
+// IsWheelPrecise reports whether the wheel offsets of the current tick come from a precise scrolling device like a trackpad.
+//
+// Precise offsets are fine-grained and suitable for smooth scrolling like panning a map,
+// while non-precise offsets are quantized steps of a regular mouse wheel.
+//
+// IsWheelPrecise works on macOS and Windows. On Windows, a wheel offset that is not an integer is treated as precise.
+// On the other platforms, IsWheelPrecise always returns false.
+//
+// IsWheelPrecise is concurrent-safe.
+func IsWheelPrecise() bool {
+	return theInputState.isWheelPrecise()
+}
+
 // IsMouseButtonPressed returns a boolean indicating whether mouseButton is pressed.
 //
 // If you want to know whether the mouseButton started being pressed in the current tick,
@@ -110,7 +173,33 @@ func IsMouseButtonPressed(mouseButton MouseButton) bool {
 	return theInputState.isMouseButtonPressed(mouseButton)
 }
 
+// MouseButtonPressCount returns the number of times the mouse button started being pressed since the previous tick.
+//
+// See KeyPressCount for the details.
+//
+// MouseButtonPressCount is concurrent-safe.
+func MouseButtonPressCount(mouseButton MouseButton) int {
+	return theInputState.mouseButtonPressCount(mouseButton)
+}
+
+// MouseButtonEventTime returns the time of the latest press or release event of the mouse button.
+//
+// See KeyEventTime for the details of the time.
+//
+// MouseButtonEventTime returns the zero time if the mouse button has never been pressed.
+//
+// MouseButtonEventTime is concurrent-safe.
+func MouseButtonEventTime(mouseButton MouseButton) time.Time {
+	return theInputState.mouseButtonEventTime(mouseButton)
+}
+
 // GamepadID represents a gamepad identifier.
+//
+// A gamepad ID is assigned when a gamepad is connected, and is kept while the gamepad is connected.
+// When a gamepad is disconnected, its ID is reserved, and the gamepad gets the ID back when it is reconnected.
+// Gamepads are identified by GamepadSDLID for this purpose,
+// so two gamepads of the same model might swap their IDs when both are reconnected.
+// A new gamepad gets the smallest ID that has never been used.
 type GamepadID = gamepad.ID
 
 // GamepadSDLID returns a string with the GUID generated in the same way as SDL.
@@ -127,6 +216,20 @@ func GamepadSDLID(id GamepadID) string {
 	return g.SDLID()
 }
 
+// GamepadTransport returns a string representing how the gamepad is connected: "USB", "Bluetooth", or "Unknown".
+// This is useful e.g. to warn players that a wireless connection might add latency.
+//
+// GamepadTransport is available only on macOS. GamepadTransport returns "Unknown" on the other platforms.
+//
+// GamepadTransport is concurrent-safe.
+func GamepadTransport(id GamepadID) string {
+	g := gamepad.Get(id)
+	if g == nil {
+		return gamepad.TransportUnknown
+	}
+	return g.Transport()
+}
+
 // GamepadName returns a string with the name.
 // This function may vary in how it returns descriptions for the same device across platforms.
 // for example the following drivers/platforms see an Xbox One controller as the following:
@@ -152,6 +255,13 @@ func AppendGamepadIDs(gamepadIDs []GamepadID) []GamepadID {
 	return gamepad.AppendGamepadIDs(gamepadIDs)
 }
 
+// GamepadCount returns the number of the connected gamepads.
+//
+// GamepadCount is concurrent-safe.
+func GamepadCount() int {
+	return gamepad.Count()
+}
+
 // GamepadIDs returns a slice indicating available gamepad IDs.
 //
 // Deprecated: as of v2.2. Use AppendGamepadIDs instead.
@@ -215,6 +325,66 @@ func GamepadButtonNum(id GamepadID) int {
 	return GamepadButtonCount(id)
 }
 
+// GamepadHatCount returns the number of the hats (directional pads) of the given gamepad (id).
+//
+// Each hat is also treated as 4 buttons after the actual buttons in GamepadButtonCount and IsGamepadButtonPressed.
+//
+// GamepadHatCount is concurrent-safe.
+func GamepadHatCount(id GamepadID) int {
+	g := gamepad.Get(id)
+	if g == nil {
+		return 0
+	}
+	return g.HatCount()
+}
+
+// GamepadCapabilities represents the capabilities of a gamepad.
+type GamepadCapabilities struct {
+	// AxisCount is the number of the axes, which is the same as GamepadAxisCount.
+	AxisCount int
+
+	// ButtonCount is the number of the buttons, which is the same as GamepadButtonCount.
+	// ButtonCount includes the buttons for the hats.
+	ButtonCount int
+
+	// HatCount is the number of the hats, which is the same as GamepadHatCount.
+	HatCount int
+
+	// StandardLayout reports whether the standard layout is available, which is the same as IsStandardGamepadLayoutAvailable.
+	StandardLayout bool
+
+	// Vibration reports whether the gamepad can vibrate, which is the same as IsGamepadVibrationSupported.
+	Vibration bool
+
+	// Light reports whether the gamepad's light can be controlled, which is the same as IsGamepadLightSupported.
+	Light bool
+
+	// Motion reports whether the gamepad provides motion data, which is the same as IsGamepadMotionSupported.
+	Motion bool
+}
+
+// ReadGamepadCapabilities reads the capabilities of the given gamepad (id) into capabilities.
+//
+// If the gamepad doesn't exist, ReadGamepadCapabilities resets capabilities to the zero value.
+//
+// ReadGamepadCapabilities is concurrent-safe.
+func ReadGamepadCapabilities(id GamepadID, capabilities *GamepadCapabilities) {
+	*capabilities = GamepadCapabilities{}
+
+	g := gamepad.Get(id)
+	if g == nil {
+		return
+	}
+	capabilities.AxisCount = g.AxisCount()
+	capabilities.HatCount = g.HatCount()
+	// For backward compatibility, hats are treated as buttons in GLFW.
+	capabilities.ButtonCount = g.ButtonCount() + capabilities.HatCount*4
+	capabilities.StandardLayout = g.IsStandardLayoutAvailable()
+	capabilities.Vibration = g.IsVibrationSupported()
+	capabilities.Light = g.IsLightSupported()
+	capabilities.Motion = g.IsMotionSupported()
+}
+
 // IsGamepadButtonPressed reports whether the given button of the gamepad (id) is pressed or not.
 //
 // If you want to know whether the given button of gamepad (id) started being pressed in the current tick,
@@ -294,6 +464,55 @@ func IsStandardGamepadLayoutAvailable(id GamepadID) bool {
 	return g.IsStandardLayoutAvailable()
 }
 
+// GamepadMappingName returns the name of the standard gamepad layout mapping in the SDL game controller database for the gamepad (id).
+// ok reports whether the gamepad matched an entry in the database.
+//
+// If ok is false, the gamepad might still have a standard layout without the database, e.g. on browsers or for Xbox controllers on Windows.
+// Use IsStandardGamepadLayoutAvailable to know whether the standard layout is available regardless of the database.
+// If a gamepad is not found, the GamepadSDLID might be generated from the gamepad name instead of the vendor and product IDs.
+// In this case, use UpdateStandardGamepadLayoutMappings to add a mapping for the GamepadSDLID.
+//
+// GamepadMappingName is concurrent-safe.
+func GamepadMappingName(id GamepadID) (name string, ok bool) {
+	g := gamepad.Get(id)
+	if g == nil {
+		return "", false
+	}
+	return g.MappingName()
+}
+
+// GamepadCalibration returns the calibration data of the axes of the gamepad (id).
+//
+// Some platforms calibrate the axis ranges while the gamepad is used.
+// Save the returned data e.g. with GamepadSDLID, and restore it by SetGamepadCalibration at the next session
+// so that the axis values are correct immediately.
+//
+// GamepadCalibration returns nil if the gamepad is not found, or if the platform doesn't calibrate the axes.
+// Currently, only macOS calibrates the axes.
+//
+// GamepadCalibration is concurrent-safe.
+func GamepadCalibration(id GamepadID) []byte {
+	g := gamepad.Get(id)
+	if g == nil {
+		return nil
+	}
+	return g.AxisCalibration()
+}
+
+// SetGamepadCalibration restores the calibration data of the axes of the gamepad (id) returned by GamepadCalibration.
+//
+// SetGamepadCalibration returns an error if the data is invalid or doesn't match the gamepad's axes.
+// SetGamepadCalibration does nothing if the gamepad is not found, or if the platform doesn't calibrate the axes.
+//
+// SetGamepadCalibration is concurrent-safe.
+func SetGamepadCalibration(id GamepadID, data []byte) error {
+	g := gamepad.Get(id)
+	if g == nil {
+		return nil
+	}
+	return g.SetAxisCalibration(data)
+}
+
 // IsStandardGamepadAxisAvailable reports whether the standard gamepad axis is available on the gamepad (id).
 //
 // IsStandardGamepadAxisAvailable is concurrent-safe.
@@ -387,6 +606,40 @@ func TouchPosition(id TouchID) (int, int) {
 	return theInputState.touchPosition(id)
 }
 
+// TouchForce returns the pressure of the touch of the specified ID.
+//
+// The pressure is usually in [0, 1], but might exceed 1 on some devices.
+// TouchForce returns -1 if the platform doesn't provide the pressure, or if the touch of the specified ID is not present.
+//
+// TouchForce works on browsers and mobiles where the device supports it.
+//
+// TouchForce is concurrent-safe.
+func TouchForce(id TouchID) float64 {
+	return theInputState.touchForce(id)
+}
+
+// TouchRadius returns the contact radius of the touch of the specified ID in logical pixels.
+//
+// TouchRadius returns -1 if the platform doesn't provide the radius, or if the touch of the specified ID is not present.
+//
+// TouchRadius works on browsers and mobiles where the device supports it.
+//
+// TouchRadius is concurrent-safe.
+func TouchRadius(id TouchID) float64 {
+	return theInputState.touchRadius(id)
+}
+
+// TouchEventTime returns the time of the latest event that started or moved the touch of the specified ID.
+//
+// See KeyEventTime for the details of the time.
+//
+// TouchEventTime returns the zero time if the touch of the specified ID is not present.
+//
+// TouchEventTime is concurrent-safe.
+func TouchEventTime(id TouchID) time.Time {
+	return theInputState.touchEventTime(id)
+}
+
 var theInputState inputState
 
 type inputState struct {
@@ -398,6 +651,7 @@ func (i *inputState) update(fn func(*ui.InputState)) {
 	i.m.Lock()
 	defer i.m.Unlock()
 	fn(&i.state)
+	theInputRecorder.process(&i.state)
 }
 
 func (i *inputState) appendInputChars(runes []rune) []rune {
@@ -428,6 +682,79 @@ func (i *inputState) isKeyPressed(key Key) bool {
 	}
 }
 
+func (i *inputState) isKeyRepeated(key Key) bool {
+	if !key.isValid() {
+		return false
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	switch key {
+	case KeyAlt:
+		return i.state.KeyRepeated[ui.KeyAltLeft] || i.state.KeyRepeated[ui.KeyAltRight]
+	case KeyControl:
+		return i.state.KeyRepeated[ui.KeyControlLeft] || i.state.KeyRepeated[ui.KeyControlRight]
+	case KeyShift:
+		return i.state.KeyRepeated[ui.KeyShiftLeft] || i.state.KeyRepeated[ui.KeyShiftRight]
+	case KeyMeta:
+		return i.state.KeyRepeated[ui.KeyMetaLeft] || i.state.KeyRepeated[ui.KeyMetaRight]
+	default:
+		return i.state.KeyRepeated[key]
+	}
+}
+
+func (i *inputState) keyPressCount(key Key) int {
+	if !key.isValid() {
+		return 0
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	switch key {
+	case KeyAlt:
+		return i.state.KeyPressCount[ui.KeyAltLeft] + i.state.KeyPressCount[ui.KeyAltRight]
+	case KeyControl:
+		return i.state.KeyPressCount[ui.KeyControlLeft] + i.state.KeyPressCount[ui.KeyControlRight]
+	case KeyShift:
+		return i.state.KeyPressCount[ui.KeyShiftLeft] + i.state.KeyPressCount[ui.KeyShiftRight]
+	case KeyMeta:
+		return i.state.KeyPressCount[ui.KeyMetaLeft] + i.state.KeyPressCount[ui.KeyMetaRight]
+	default:
+		return i.state.KeyPressCount[key]
+	}
+}
+
+func (i *inputState) keyEventTime(key Key) time.Time {
+	if !key.isValid() {
+		return time.Time{}
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	later := func(a, b time.Time) time.Time {
+		if a.After(b) {
+			return a
+		}
+		return b
+	}
+
+	switch key {
+	case KeyAlt:
+		return later(i.state.KeyEventTime[ui.KeyAltLeft], i.state.KeyEventTime[ui.KeyAltRight])
+	case KeyControl:
+		return later(i.state.KeyEventTime[ui.KeyControlLeft], i.state.KeyEventTime[ui.KeyControlRight])
+	case KeyShift:
+		return later(i.state.KeyEventTime[ui.KeyShiftLeft], i.state.KeyEventTime[ui.KeyShiftRight])
+	case KeyMeta:
+		return later(i.state.KeyEventTime[ui.KeyMetaLeft], i.state.KeyEventTime[ui.KeyMetaRight])
+	default:
+		return i.state.KeyEventTime[key]
+	}
+}
+
 func (i *inputState) cursorPosition() (float64, float64) {
 	i.m.Lock()
 	defer i.m.Unlock()
@@ -440,12 +767,30 @@ func (i *inputState) wheel() (float64, float64) {
 	return i.state.WheelX, i.state.WheelY
 }
 
+func (i *inputState) isWheelPrecise() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.WheelPrecise
+}
+
 func (i *inputState) isMouseButtonPressed(mouseButton MouseButton) bool {
 	i.m.Lock()
 	defer i.m.Unlock()
 	return i.state.MouseButtonPressed[mouseButton]
 }
 
+func (i *inputState) mouseButtonPressCount(mouseButton MouseButton) int {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.MouseButtonPressCount[mouseButton]
+}
+
+func (i *inputState) mouseButtonEventTime(mouseButton MouseButton) time.Time {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.MouseButtonEventTime[mouseButton]
+}
+
 func (i *inputState) appendTouchIDs(touches []TouchID) []TouchID {
 	i.m.Lock()
 	defer i.m.Unlock()
@@ -469,6 +814,45 @@ func (i *inputState) touchPosition(id TouchID) (int, int) {
 	return 0, 0
 }
 
+func (i *inputState) touchForce(id TouchID) float64 {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for _, t := range i.state.Touches {
+		if id != t.ID {
+			continue
+		}
+		return t.Force
+	}
+	return -1
+}
+
+func (i *inputState) touchRadius(id TouchID) float64 {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for _, t := range i.state.Touches {
+		if id != t.ID {
+			continue
+		}
+		return t.Radius
+	}
+	return -1
+}
+
+func (i *inputState) touchEventTime(id TouchID) time.Time {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for _, t := range i.state.Touches {
+		if id != t.ID {
+			continue
+		}
+		return t.Time
+	}
+	return time.Time{}
+}
+
 func (i *inputState) windowBeingClosed() bool {
 	i.m.Lock()
 	defer i.m.Unlock()
@@ -0,0 +1,345 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// StartInputRecording starts recording the input state of every tick to w.
+//
+// The recorded input state includes keyboard, mouse, touches, gamepads, and input characters.
+// The motion data of gamepads and dropped files are not recorded so far.
+// The input state is recorded once per tick, right before Update, so the recording can be played back by StartInputPlayback
+// deterministically in terms of ticks.
+//
+// The format of the recording is not stable, and might be changed in a future version.
+//
+// StartInputRecording returns an error if a recording is already running.
+//
+// StartInputRecording is concurrent-safe.
+func StartInputRecording(w io.Writer) error {
+	return theInputRecorder.startRecording(w)
+}
+
+// StopInputRecording stops the current input recording.
+//
+// StopInputRecording returns the error that happened at writing the recording, if any.
+// StopInputRecording does nothing and returns nil if no input recording is running.
+//
+// StopInputRecording is concurrent-safe.
+func StopInputRecording() error {
+	return theInputRecorder.stopRecording()
+}
+
+// StartInputPlayback starts playing back an input recording made by StartInputRecording from r.
+//
+// While a playback is running, the game sees the recorded input state instead of the live input, one record per tick.
+// When the recording ends, the playback stops automatically and the live input is used again from the next tick.
+//
+// The positions of the cursor and the touches are reproduced as they are.
+// During the playback, only the recorded gamepads are visible, and all the connected gamepads are hidden
+// in the same way as the other live input.
+// A played back gamepad cannot vibrate, change its light, or provide motion data.
+// Then, the game should run with the same screen size as the recording.
+// The event times like KeyEventTime are reproduced relatively to the time when the playback starts.
+//
+// StartInputPlayback returns an error if a playback is already running.
+//
+// StartInputPlayback is concurrent-safe.
+func StartInputPlayback(r io.Reader) error {
+	return theInputRecorder.startPlayback(r)
+}
+
+// StopInputPlayback stops the current input playback.
+//
+// StopInputPlayback returns the error that happened at reading the recording, if any.
+// The end of the recording is not treated as an error.
+//
+// StopInputPlayback is concurrent-safe.
+func StopInputPlayback() error {
+	return theInputRecorder.stopPlayback()
+}
+
+// IsInputPlaybackRunning reports whether an input playback started by StartInputPlayback is running.
+//
+// IsInputPlaybackRunning returns false after the recording ends.
+//
+// IsInputPlaybackRunning is concurrent-safe.
+func IsInputPlaybackRunning() bool {
+	theInputRecorder.m.Lock()
+	defer theInputRecorder.m.Unlock()
+	return theInputRecorder.decoder != nil
+}
+
+// noEventTime represents a zero time.Time in a recording.
+const noEventTime = math.MinInt64
+
+// inputRecord is the input state of one tick in a recording.
+type inputRecord struct {
+	Keys              []inputRecordButton
+	MouseButtons      []inputRecordButton
+	CursorX           float64
+	CursorY           float64
+	WheelX            float64
+	WheelY            float64
+	WheelPrecise      bool
+	Touches           []inputRecordTouch
+	Runes             []rune
+	WindowBeingClosed bool
+	Gamepads          []gamepad.State
+}
+
+type inputRecordButton struct {
+	Index      int
+	Pressed    bool
+	Repeated   bool
+	PressCount int
+
+	// EventTime is the time from the start of the recording in nanoseconds.
+	EventTime int64
+}
+
+type inputRecordTouch struct {
+	ID     int
+	X      int
+	Y      int
+	Force  float64
+	Radius float64
+
+	// Time is the time from the start of the recording in nanoseconds.
+	Time int64
+}
+
+type inputRecorder struct {
+	encoder        *gob.Encoder
+	recordingStart time.Time
+	recordingErr   error
+
+	decoder       *gob.Decoder
+	playbackStart time.Time
+	playbackErr   error
+
+	// record is reused to reduce allocations.
+	record inputRecord
+
+	m sync.Mutex
+}
+
+var theInputRecorder inputRecorder
+
+func (i *inputRecorder) startRecording(w io.Writer) error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if w == nil {
+		return errors.New("ebiten: the writer must not be nil")
+	}
+	if i.encoder != nil {
+		return errors.New("ebiten: an input recording is already running")
+	}
+	i.encoder = gob.NewEncoder(w)
+	i.recordingStart = time.Now()
+	i.recordingErr = nil
+	return nil
+}
+
+func (i *inputRecorder) stopRecording() error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	i.encoder = nil
+	err := i.recordingErr
+	i.recordingErr = nil
+	return err
+}
+
+func (i *inputRecorder) startPlayback(r io.Reader) error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if r == nil {
+		return errors.New("ebiten: the reader must not be nil")
+	}
+	if i.decoder != nil {
+		return errors.New("ebiten: an input playback is already running")
+	}
+	i.decoder = gob.NewDecoder(r)
+	i.playbackStart = time.Now()
+	i.playbackErr = nil
+	return nil
+}
+
+func (i *inputRecorder) stopPlayback() error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if i.decoder != nil {
+		gamepad.StopPlayback()
+	}
+	i.decoder = nil
+	err := i.playbackErr
+	i.playbackErr = nil
+	return err
+}
+
+// process replaces the state with the recorded one if a playback is running, and then records the state if a recording is running.
+// process is called once per tick.
+func (i *inputRecorder) process(state *ui.InputState) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if i.decoder != nil {
+		i.record = inputRecord{}
+		if err := i.decoder.Decode(&i.record); err != nil {
+			if !errors.Is(err, io.EOF) {
+				i.playbackErr = err
+			}
+			i.decoder = nil
+			// As the recording might end with pressed keys, release all of them.
+			i.record = inputRecord{}
+		}
+		i.record.toInputState(state, i.playbackStart)
+		if i.decoder != nil {
+			gamepad.StartPlayback(i.record.Gamepads)
+		} else {
+			gamepad.StopPlayback()
+		}
+	}
+
+	if i.encoder != nil {
+		i.record.fromInputState(state, i.recordingStart)
+		if err := i.encoder.Encode(&i.record); err != nil {
+			i.recordingErr = err
+			i.encoder = nil
+		}
+	}
+}
+
+func timeToRecord(t time.Time, start time.Time) int64 {
+	if t.IsZero() {
+		return noEventTime
+	}
+	return int64(t.Sub(start))
+}
+
+func timeFromRecord(t int64, start time.Time) time.Time {
+	if t == noEventTime {
+		return time.Time{}
+	}
+	return start.Add(time.Duration(t))
+}
+
+func (r *inputRecord) fromInputState(state *ui.InputState, start time.Time) {
+	r.Keys = r.Keys[:0]
+	for k := range state.KeyPressed {
+		if !state.KeyPressed[k] && !state.KeyRepeated[k] && state.KeyPressCount[k] == 0 && state.KeyEventTime[k].IsZero() {
+			continue
+		}
+		r.Keys = append(r.Keys, inputRecordButton{
+			Index:      k,
+			Pressed:    state.KeyPressed[k],
+			Repeated:   state.KeyRepeated[k],
+			PressCount: state.KeyPressCount[k],
+			EventTime:  timeToRecord(state.KeyEventTime[k], start),
+		})
+	}
+
+	r.MouseButtons = r.MouseButtons[:0]
+	for b := range state.MouseButtonPressed {
+		if !state.MouseButtonPressed[b] && state.MouseButtonPressCount[b] == 0 && state.MouseButtonEventTime[b].IsZero() {
+			continue
+		}
+		r.MouseButtons = append(r.MouseButtons, inputRecordButton{
+			Index:      b,
+			Pressed:    state.MouseButtonPressed[b],
+			PressCount: state.MouseButtonPressCount[b],
+			EventTime:  timeToRecord(state.MouseButtonEventTime[b], start),
+		})
+	}
+
+	r.CursorX = state.CursorX
+	r.CursorY = state.CursorY
+	r.WheelX = state.WheelX
+	r.WheelY = state.WheelY
+	r.WheelPrecise = state.WheelPrecise
+
+	r.Touches = r.Touches[:0]
+	for _, t := range state.Touches {
+		r.Touches = append(r.Touches, inputRecordTouch{
+			ID:     int(t.ID),
+			X:      t.X,
+			Y:      t.Y,
+			Force:  t.Force,
+			Radius: t.Radius,
+			Time:   timeToRecord(t.Time, start),
+		})
+	}
+
+	r.Runes = append(r.Runes[:0], state.Runes...)
+	r.WindowBeingClosed = state.WindowBeingClosed
+	r.Gamepads = gamepad.AppendStates(r.Gamepads[:0])
+}
+
+func (r *inputRecord) toInputState(state *ui.InputState, start time.Time) {
+	*state = ui.InputState{
+		CursorX:           r.CursorX,
+		CursorY:           r.CursorY,
+		WheelX:            r.WheelX,
+		WheelY:            r.WheelY,
+		WheelPrecise:      r.WheelPrecise,
+		Touches:           state.Touches[:0],
+		Runes:             append(state.Runes[:0], r.Runes...),
+		WindowBeingClosed: r.WindowBeingClosed,
+	}
+
+	for _, k := range r.Keys {
+		if k.Index < 0 || k.Index >= len(state.KeyPressed) {
+			continue
+		}
+		state.KeyPressed[k.Index] = k.Pressed
+		state.KeyRepeated[k.Index] = k.Repeated
+		state.KeyPressCount[k.Index] = k.PressCount
+		state.KeyEventTime[k.Index] = timeFromRecord(k.EventTime, start)
+	}
+
+	for _, b := range r.MouseButtons {
+		if b.Index < 0 || b.Index >= len(state.MouseButtonPressed) {
+			continue
+		}
+		state.MouseButtonPressed[b.Index] = b.Pressed
+		state.MouseButtonPressCount[b.Index] = b.PressCount
+		state.MouseButtonEventTime[b.Index] = timeFromRecord(b.EventTime, start)
+	}
+
+	for _, t := range r.Touches {
+		state.Touches = append(state.Touches, ui.Touch{
+			ID:     ui.TouchID(t.ID),
+			X:      t.X,
+			Y:      t.Y,
+			Force:  t.Force,
+			Radius: t.Radius,
+			Time:   timeFromRecord(t.Time, start),
+		})
+	}
+}
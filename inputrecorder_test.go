@@ -0,0 +1,67 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestInputRecordingStartStop(t *testing.T) {
+	if err := ebiten.StartInputRecording(nil); err == nil {
+		t.Errorf("StartInputRecording with a nil writer must return an error")
+	}
+
+	var buf bytes.Buffer
+	if err := ebiten.StartInputRecording(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := ebiten.StartInputRecording(&buf); err == nil {
+		t.Errorf("StartInputRecording during a recording must return an error")
+	}
+	if err := ebiten.StopInputRecording(); err != nil {
+		t.Error(err)
+	}
+	if err := ebiten.StopInputRecording(); err != nil {
+		t.Errorf("StopInputRecording without a recording must return nil: %v", err)
+	}
+}
+
+func TestInputPlaybackStartStop(t *testing.T) {
+	if err := ebiten.StartInputPlayback(nil); err == nil {
+		t.Errorf("StartInputPlayback with a nil reader must return an error")
+	}
+	if ebiten.IsInputPlaybackRunning() {
+		t.Errorf("IsInputPlaybackRunning must return false before StartInputPlayback")
+	}
+
+	if err := ebiten.StartInputPlayback(&bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !ebiten.IsInputPlaybackRunning() {
+		t.Errorf("IsInputPlaybackRunning must return true after StartInputPlayback")
+	}
+	if err := ebiten.StartInputPlayback(&bytes.Buffer{}); err == nil {
+		t.Errorf("StartInputPlayback during a playback must return an error")
+	}
+	if err := ebiten.StopInputPlayback(); err != nil {
+		t.Error(err)
+	}
+	if ebiten.IsInputPlaybackRunning() {
+		t.Errorf("IsInputPlaybackRunning must return false after StopInputPlayback")
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ActionMap maps physical inputs like keys, mouse buttons, and standard gamepad buttons to named logical actions
+// like "jump".
+//
+// An action can have multiple inputs, and an input can be bound to multiple actions.
+// Use AppendActionsForKey and the similar functions to find the actions an input is already bound to.
+// An action is pressed when any of its inputs is pressed.
+// A standard gamepad button is applied to all the gamepads that have a standard gamepad layout mapping.
+//
+// The zero value of ActionMap is an empty map ready to use.
+//
+// ActionMap is concurrent-safe.
+type ActionMap struct {
+	actions map[string]*action
+
+	m sync.Mutex
+}
+
+type actionInputKind int
+
+const (
+	actionInputKindKey actionInputKind = iota
+	actionInputKindMouseButton
+	actionInputKindStandardGamepadButton
+)
+
+type actionInput struct {
+	kind  actionInputKind
+	value int
+}
+
+type action struct {
+	// inputs is sorted by kind and value.
+	inputs []actionInput
+}
+
+// BindKey binds the key to the action.
+// BindKey does nothing if the key is already bound to the action.
+func (a *ActionMap) BindKey(name string, key ebiten.Key) {
+	a.bind(name, actionInput{kind: actionInputKindKey, value: int(key)})
+}
+
+// BindMouseButton binds the mouse button to the action.
+// BindMouseButton does nothing if the mouse button is already bound to the action.
+func (a *ActionMap) BindMouseButton(name string, mouseButton ebiten.MouseButton) {
+	a.bind(name, actionInput{kind: actionInputKindMouseButton, value: int(mouseButton)})
+}
+
+// BindStandardGamepadButton binds the standard gamepad button to the action.
+// BindStandardGamepadButton does nothing if the button is already bound to the action.
+func (a *ActionMap) BindStandardGamepadButton(name string, button ebiten.StandardGamepadButton) {
+	a.bind(name, actionInput{kind: actionInputKindStandardGamepadButton, value: int(button)})
+}
+
+// UnbindKey removes the binding of the key from the action.
+func (a *ActionMap) UnbindKey(name string, key ebiten.Key) {
+	a.unbind(name, actionInput{kind: actionInputKindKey, value: int(key)})
+}
+
+// UnbindMouseButton removes the binding of the mouse button from the action.
+func (a *ActionMap) UnbindMouseButton(name string, mouseButton ebiten.MouseButton) {
+	a.unbind(name, actionInput{kind: actionInputKindMouseButton, value: int(mouseButton)})
+}
+
+// UnbindStandardGamepadButton removes the binding of the standard gamepad button from the action.
+func (a *ActionMap) UnbindStandardGamepadButton(name string, button ebiten.StandardGamepadButton) {
+	a.unbind(name, actionInput{kind: actionInputKindStandardGamepadButton, value: int(button)})
+}
+
+// Clear removes all the bindings of the action.
+func (a *ActionMap) Clear(name string) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	delete(a.actions, name)
+}
+
+// AppendKeys appends the keys bound to the action to keys, and returns the extended buffer.
+// The keys are sorted in ascending order.
+// Giving a slice that already has enough capacity works efficiently.
+func (a *ActionMap) AppendKeys(keys []ebiten.Key, name string) []ebiten.Key {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	act, ok := a.actions[name]
+	if !ok {
+		return keys
+	}
+	for _, in := range act.inputs {
+		if in.kind == actionInputKindKey {
+			keys = append(keys, ebiten.Key(in.value))
+		}
+	}
+	return keys
+}
+
+// AppendMouseButtons appends the mouse buttons bound to the action to mouseButtons, and returns the extended buffer.
+// The mouse buttons are sorted in ascending order.
+// Giving a slice that already has enough capacity works efficiently.
+func (a *ActionMap) AppendMouseButtons(mouseButtons []ebiten.MouseButton, name string) []ebiten.MouseButton {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	act, ok := a.actions[name]
+	if !ok {
+		return mouseButtons
+	}
+	for _, in := range act.inputs {
+		if in.kind == actionInputKindMouseButton {
+			mouseButtons = append(mouseButtons, ebiten.MouseButton(in.value))
+		}
+	}
+	return mouseButtons
+}
+
+// AppendStandardGamepadButtons appends the standard gamepad buttons bound to the action to buttons, and returns the extended buffer.
+// The buttons are sorted in ascending order.
+// Giving a slice that already has enough capacity works efficiently.
+func (a *ActionMap) AppendStandardGamepadButtons(buttons []ebiten.StandardGamepadButton, name string) []ebiten.StandardGamepadButton {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	act, ok := a.actions[name]
+	if !ok {
+		return buttons
+	}
+	for _, in := range act.inputs {
+		if in.kind == actionInputKindStandardGamepadButton {
+			buttons = append(buttons, ebiten.StandardGamepadButton(in.value))
+		}
+	}
+	return buttons
+}
+
+// AppendActionsForKey appends the names of the actions the key is bound to to actions, and returns the extended buffer.
+// The names are sorted in ascending order.
+//
+// AppendActionsForKey is useful to detect conflicts, e.g. when a user rebinds a key that is already used by another action.
+func (a *ActionMap) AppendActionsForKey(actions []string, key ebiten.Key) []string {
+	return a.appendActionsForInput(actions, actionInput{kind: actionInputKindKey, value: int(key)})
+}
+
+// AppendActionsForMouseButton appends the names of the actions the mouse button is bound to to actions, and returns the extended buffer.
+// The names are sorted in ascending order.
+func (a *ActionMap) AppendActionsForMouseButton(actions []string, mouseButton ebiten.MouseButton) []string {
+	return a.appendActionsForInput(actions, actionInput{kind: actionInputKindMouseButton, value: int(mouseButton)})
+}
+
+// AppendActionsForStandardGamepadButton appends the names of the actions the standard gamepad button is bound to to actions, and returns the extended buffer.
+// The names are sorted in ascending order.
+func (a *ActionMap) AppendActionsForStandardGamepadButton(actions []string, button ebiten.StandardGamepadButton) []string {
+	return a.appendActionsForInput(actions, actionInput{kind: actionInputKindStandardGamepadButton, value: int(button)})
+}
+
+// IsPressed reports whether any of the inputs bound to the action is pressed.
+//
+// IsPressed must be called in a game's Update, not Draw.
+func (a *ActionMap) IsPressed(name string) bool {
+	_, current := a.states(name)
+	return current
+}
+
+// IsJustPressed reports whether the action starts being pressed just in the current tick,
+// i.e. none of the inputs was pressed in the previous tick and any of them is pressed in the current tick.
+//
+// IsJustPressed must be called in a game's Update, not Draw.
+func (a *ActionMap) IsJustPressed(name string) bool {
+	prev, current := a.states(name)
+	return !prev && current
+}
+
+// IsJustReleased reports whether the action is released just in the current tick,
+// i.e. any of the inputs was pressed in the previous tick and none of them is pressed in the current tick.
+//
+// IsJustReleased must be called in a game's Update, not Draw.
+func (a *ActionMap) IsJustReleased(name string) bool {
+	prev, current := a.states(name)
+	return prev && !current
+}
+
+func (a *ActionMap) appendActionsForInput(actions []string, input actionInput) []string {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	origLen := len(actions)
+	for name, act := range a.actions {
+		for _, in := range act.inputs {
+			if in == input {
+				actions = append(actions, name)
+				break
+			}
+		}
+	}
+	sort.Strings(actions[origLen:])
+	return actions
+}
+
+func (a *ActionMap) bind(name string, input actionInput) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if a.actions == nil {
+		a.actions = map[string]*action{}
+	}
+	act, ok := a.actions[name]
+	if !ok {
+		act = &action{}
+		a.actions[name] = act
+	}
+	for _, in := range act.inputs {
+		if in == input {
+			return
+		}
+	}
+	act.inputs = append(act.inputs, input)
+	sort.Slice(act.inputs, func(i, j int) bool {
+		if act.inputs[i].kind != act.inputs[j].kind {
+			return act.inputs[i].kind < act.inputs[j].kind
+		}
+		return act.inputs[i].value < act.inputs[j].value
+	})
+}
+
+func (a *ActionMap) unbind(name string, input actionInput) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	act, ok := a.actions[name]
+	if !ok {
+		return
+	}
+	for i, in := range act.inputs {
+		if in == input {
+			act.inputs = append(act.inputs[:i], act.inputs[i+1:]...)
+			break
+		}
+	}
+	if len(act.inputs) == 0 {
+		delete(a.actions, name)
+	}
+}
+
+// states reports whether any of the inputs bound to the action was pressed in the previous tick and is pressed in the current tick.
+func (a *ActionMap) states(name string) (prev, current bool) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	act, ok := a.actions[name]
+	if !ok {
+		return false, false
+	}
+
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	for _, in := range act.inputs {
+		p, c := theInputState.actionInputStates(in)
+		prev = prev || p
+		current = current || c
+		if prev && current {
+			break
+		}
+	}
+	return prev, current
+}
+
+// actionInputStates reports whether the input was pressed in the previous tick and is pressed in the current tick.
+// i.m must be locked.
+func (i *inputState) actionInputStates(input actionInput) (prev, current bool) {
+	switch input.kind {
+	case actionInputKindKey:
+		if input.value < 0 || input.value >= len(i.keyDurations) {
+			return false, false
+		}
+		return i.prevKeyDurations[input.value] > 0, i.keyDurations[input.value] > 0
+	case actionInputKindMouseButton:
+		b := ebiten.MouseButton(input.value)
+		return i.prevMouseButtonDurations[b] > 0, i.mouseButtonDurations[b] > 0
+	case actionInputKindStandardGamepadButton:
+		if input.value < 0 || input.value > int(ebiten.StandardGamepadButtonMax) {
+			return false, false
+		}
+		for _, ds := range i.prevStandardGamepadButtonDurations {
+			if ds[input.value] > 0 {
+				prev = true
+				break
+			}
+		}
+		for _, ds := range i.standardGamepadButtonDurations {
+			if ds[input.value] > 0 {
+				current = true
+				break
+			}
+		}
+		return prev, current
+	}
+	return false, false
+}
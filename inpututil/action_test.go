@@ -0,0 +1,118 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+func TestActionMapBindings(t *testing.T) {
+	var m inpututil.ActionMap
+
+	m.BindKey("jump", ebiten.KeySpace)
+	m.BindKey("jump", ebiten.KeyW)
+	m.BindKey("jump", ebiten.KeySpace)
+	m.BindMouseButton("jump", ebiten.MouseButtonRight)
+	m.BindStandardGamepadButton("jump", ebiten.StandardGamepadButtonRightBottom)
+
+	if got, want := m.AppendKeys(nil, "jump"), []ebiten.Key{ebiten.KeyW, ebiten.KeySpace}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := m.AppendMouseButtons(nil, "jump"), []ebiten.MouseButton{ebiten.MouseButtonRight}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := m.AppendStandardGamepadButtons(nil, "jump"), []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	m.UnbindKey("jump", ebiten.KeyW)
+	if got, want := m.AppendKeys(nil, "jump"), []ebiten.Key{ebiten.KeySpace}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	m.Clear("jump")
+	if got := m.AppendKeys(nil, "jump"); len(got) != 0 {
+		t.Errorf("got: %v, want: empty", got)
+	}
+	if got := m.AppendMouseButtons(nil, "jump"); len(got) != 0 {
+		t.Errorf("got: %v, want: empty", got)
+	}
+	if m.IsPressed("jump") {
+		t.Errorf("IsPressed for a cleared action must be false")
+	}
+}
+
+func TestActionMapMultipleActions(t *testing.T) {
+	var m inpututil.ActionMap
+
+	// A key can be bound to multiple actions.
+	m.BindKey("confirm", ebiten.KeyEnter)
+	m.BindKey("start", ebiten.KeyEnter)
+
+	for _, name := range []string{"confirm", "start"} {
+		if got, want := m.AppendKeys(nil, name), []ebiten.Key{ebiten.KeyEnter}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got: %v, want: %v", name, got, want)
+		}
+	}
+
+	m.UnbindKey("confirm", ebiten.KeyEnter)
+	if got := m.AppendKeys(nil, "confirm"); len(got) != 0 {
+		t.Errorf("got: %v, want: empty", got)
+	}
+	if got, want := m.AppendKeys(nil, "start"), []ebiten.Key{ebiten.KeyEnter}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestActionMapConflicts(t *testing.T) {
+	var m inpututil.ActionMap
+
+	m.BindKey("start", ebiten.KeyEnter)
+	m.BindKey("confirm", ebiten.KeyEnter)
+	m.BindKey("jump", ebiten.KeySpace)
+	m.BindMouseButton("fire", ebiten.MouseButtonLeft)
+	m.BindMouseButton("select", ebiten.MouseButtonLeft)
+	m.BindStandardGamepadButton("jump", ebiten.StandardGamepadButtonRightBottom)
+
+	if got, want := m.AppendActionsForKey(nil, ebiten.KeyEnter), []string{"confirm", "start"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendActionsForKey(KeyEnter): got: %v, want: %v", got, want)
+	}
+	if got, want := m.AppendActionsForKey(nil, ebiten.KeySpace), []string{"jump"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendActionsForKey(KeySpace): got: %v, want: %v", got, want)
+	}
+	if got := m.AppendActionsForKey(nil, ebiten.KeyA); len(got) != 0 {
+		t.Errorf("AppendActionsForKey(KeyA): got: %v, want: empty", got)
+	}
+	if got, want := m.AppendActionsForMouseButton(nil, ebiten.MouseButtonLeft), []string{"fire", "select"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendActionsForMouseButton(MouseButtonLeft): got: %v, want: %v", got, want)
+	}
+	if got, want := m.AppendActionsForStandardGamepadButton(nil, ebiten.StandardGamepadButtonRightBottom), []string{"jump"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendActionsForStandardGamepadButton(StandardGamepadButtonRightBottom): got: %v, want: %v", got, want)
+	}
+
+	// The given buffer is kept, and only the appended names are sorted.
+	if got, want := m.AppendActionsForKey([]string{"z"}, ebiten.KeyEnter), []string{"z", "confirm", "start"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendActionsForKey with a buffer: got: %v, want: %v", got, want)
+	}
+
+	m.UnbindKey("start", ebiten.KeyEnter)
+	if got, want := m.AppendActionsForKey(nil, ebiten.KeyEnter), []string{"confirm"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendActionsForKey(KeyEnter) after UnbindKey: got: %v, want: %v", got, want)
+	}
+}
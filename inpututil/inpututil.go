@@ -44,6 +44,9 @@ type inputState struct {
 	standardGamepadButtonDurations     map[ebiten.GamepadID][]int
 	prevStandardGamepadButtonDurations map[ebiten.GamepadID][]int
 
+	standardGamepadButtonValues     map[ebiten.GamepadID][]float64
+	prevStandardGamepadButtonValues map[ebiten.GamepadID][]float64
+
 	touchIDs           map[ebiten.TouchID]struct{}
 	touchDurations     map[ebiten.TouchID]int
 	touchPositions     map[ebiten.TouchID]pos
@@ -72,6 +75,9 @@ var theInputState = &inputState{
 	standardGamepadButtonDurations:     map[ebiten.GamepadID][]int{},
 	prevStandardGamepadButtonDurations: map[ebiten.GamepadID][]int{},
 
+	standardGamepadButtonValues:     map[ebiten.GamepadID][]float64{},
+	prevStandardGamepadButtonValues: map[ebiten.GamepadID][]float64{},
+
 	touchIDs:           map[ebiten.TouchID]struct{}{},
 	touchDurations:     map[ebiten.TouchID]int{},
 	touchPositions:     map[ebiten.TouchID]pos{},
@@ -135,6 +141,13 @@ func (i *inputState) update() {
 		i.prevStandardGamepadButtonDurations[id] = append([]int{}, ds...)
 	}
 
+	for id := range i.prevStandardGamepadButtonValues {
+		delete(i.prevStandardGamepadButtonValues, id)
+	}
+	for id, vs := range i.standardGamepadButtonValues {
+		i.prevStandardGamepadButtonValues[id] = append([]float64{}, vs...)
+	}
+
 	for id := range i.gamepadIDs {
 		delete(i.gamepadIDs, id)
 	}
@@ -163,6 +176,13 @@ func (i *inputState) update() {
 				i.standardGamepadButtonDurations[id][b] = 0
 			}
 		}
+
+		if _, ok := i.standardGamepadButtonValues[id]; !ok {
+			i.standardGamepadButtonValues[id] = make([]float64, ebiten.StandardGamepadButtonMax+1)
+		}
+		for b := ebiten.StandardGamepadButton(0); b <= ebiten.StandardGamepadButtonMax; b++ {
+			i.standardGamepadButtonValues[id][b] = ebiten.StandardGamepadButtonValue(id, b)
+		}
 	}
 	for id := range i.gamepadButtonDurations {
 		if _, ok := i.gamepadIDs[id]; !ok {
@@ -174,6 +194,11 @@ func (i *inputState) update() {
 			delete(i.standardGamepadButtonDurations, id)
 		}
 	}
+	for id := range i.standardGamepadButtonValues {
+		if _, ok := i.gamepadIDs[id]; !ok {
+			delete(i.standardGamepadButtonValues, id)
+		}
+	}
 
 	// Touches
 
@@ -301,6 +326,20 @@ func IsKeyJustReleased(key ebiten.Key) bool {
 	return r
 }
 
+// IsKeyJustPressedOrRepeated returns a boolean value indicating
+// whether the given key is pressed just in the current tick, or is auto-repeated by the platform in the current tick.
+//
+// IsKeyJustPressedOrRepeated follows the platform's key-repeat delay and rate.
+// This is useful for e.g. a backspace key in a text field.
+// See also ebiten.IsKeyRepeated.
+//
+// IsKeyJustPressedOrRepeated must be called in a game's Update, not Draw.
+//
+// IsKeyJustPressedOrRepeated is concurrent safe.
+func IsKeyJustPressedOrRepeated(key ebiten.Key) bool {
+	return IsKeyJustPressed(key) || ebiten.IsKeyRepeated(key)
+}
+
 // KeyPressDuration returns how long the key is pressed in ticks (Update).
 //
 // KeyPressDuration must be called in a game's Update, not Draw.
@@ -631,6 +670,47 @@ func IsStandardGamepadButtonJustReleased(id ebiten.GamepadID, button ebiten.Stan
 	return current == 0 && prev > 0
 }
 
+// IsStandardGamepadTriggerJustPressed returns a boolean value indicating
+// whether the value of the given standard gamepad button of the gamepad id reaches threshold just in the current tick,
+// i.e. the value was less than threshold in the previous tick and is greater than or equal to threshold in the current tick.
+//
+// IsStandardGamepadTriggerJustPressed is useful to treat an analog trigger like StandardGamepadButtonFrontBottomLeft
+// or StandardGamepadButtonFrontBottomRight as a digital button with a custom threshold.
+// The value is the same as ebiten.StandardGamepadButtonValue.
+//
+// IsStandardGamepadTriggerJustPressed must be called in a game's Update, not Draw.
+//
+// IsStandardGamepadTriggerJustPressed is concurrent safe.
+func IsStandardGamepadTriggerJustPressed(id ebiten.GamepadID, trigger ebiten.StandardGamepadButton, threshold float64) bool {
+	prev, current := standardGamepadButtonValues(id, trigger)
+	return prev < threshold && current >= threshold
+}
+
+// IsStandardGamepadTriggerJustReleased returns a boolean value indicating
+// whether the value of the given standard gamepad button of the gamepad id falls below threshold just in the current tick,
+// i.e. the value was greater than or equal to threshold in the previous tick and is less than threshold in the current tick.
+//
+// IsStandardGamepadTriggerJustReleased must be called in a game's Update, not Draw.
+//
+// IsStandardGamepadTriggerJustReleased is concurrent safe.
+func IsStandardGamepadTriggerJustReleased(id ebiten.GamepadID, trigger ebiten.StandardGamepadButton, threshold float64) bool {
+	prev, current := standardGamepadButtonValues(id, trigger)
+	return prev >= threshold && current < threshold
+}
+
+func standardGamepadButtonValues(id ebiten.GamepadID, button ebiten.StandardGamepadButton) (prev, current float64) {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	if _, ok := theInputState.prevStandardGamepadButtonValues[id]; ok {
+		prev = theInputState.prevStandardGamepadButtonValues[id][button]
+	}
+	if _, ok := theInputState.standardGamepadButtonValues[id]; ok {
+		current = theInputState.standardGamepadButtonValues[id][button]
+	}
+	return
+}
+
 // StandardGamepadButtonPressDuration returns how long the standard gamepad button of the gamepad id is pressed in ticks (Update).
 //
 // StandardGamepadButtonPressDuration must be called in a game's Update, not Draw.
@@ -67,3 +67,9 @@ func (i *Image) EnsureIsolatedFromSourceForTesting(backends []*backend) {
 var FlushDeferredForTesting = flushDeferred
 
 var FloorPowerOf2 = floorPowerOf2
+
+func BackendCountForTesting() int {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+	return len(theBackends)
+}
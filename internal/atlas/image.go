@@ -20,6 +20,7 @@ import (
 	"math"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -34,6 +35,11 @@ var (
 	maxSize            = 0
 )
 
+// maxShaderImages is the maximum number of source images of a shader the graphics driver supports.
+// maxShaderImages is 0 until the graphics driver is initialized.
+// maxShaderImages is accessed atomically as this can be read outside of a frame.
+var maxShaderImages int32
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -141,6 +147,23 @@ type Image struct {
 	height    int
 	imageType ImageType
 
+	// nativeTexture is a native texture handle that the image wraps.
+	// nativeTexture is 0 if the image doesn't wrap a native texture.
+	nativeTexture uintptr
+
+	// compressedFormat and compressedData are GPU-compressed data that the image is created with.
+	// compressedData is nil if the image doesn't have compressed data or is already allocated.
+	compressedFormat graphicsdriver.CompressedTextureFormat
+	compressedData   []byte
+
+	// highPrecision reports whether the image has 16-bit floating point numbers per channel.
+	// Unlike nativeTexture and compressedData, highPrecision is kept after deallocation.
+	highPrecision bool
+
+	// debugLabel is a label shown in GPU debugging tools.
+	// debugLabel is applied only when the image has its own backend, i.e., the image is not on an atlas.
+	debugLabel string
+
 	backend                   *backend
 	backendCreatedInThisFrame bool
 
@@ -450,7 +473,7 @@ func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
 }
 
 func (i *Image) writePixels(pix []byte, region image.Rectangle) {
-	if l := 4 * region.Dx() * region.Dy(); len(pix) != l {
+	if l := i.bytesPerPixel() * region.Dx() * region.Dy(); len(pix) != l {
 		panic(fmt.Sprintf("atlas: len(p) must be %d but %d", l, len(pix)))
 	}
 
@@ -510,6 +533,44 @@ func (i *Image) writePixels(pix []byte, region image.Rectangle) {
 	i.backend.restorable.WritePixels(pixb, r)
 }
 
+// ClearPixels clears the pixels at the specified region.
+//
+// ClearPixels is cheaper than drawing transparent pixels, and does nothing for an image that is not allocated yet.
+func (i *Image) ClearPixels(region image.Rectangle) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		appendDeferred(func() {
+			i.clearPixels(region)
+		})
+		return
+	}
+
+	i.clearPixels(region)
+}
+
+func (i *Image) clearPixels(region image.Rectangle) {
+	if region.Empty() {
+		return
+	}
+
+	i.resetUsedAsSourceCount()
+
+	// An image without a backend has no pixels.
+	if i.backend == nil {
+		return
+	}
+
+	r := i.regionWithPadding()
+	if region.Eq(image.Rect(0, 0, i.width, i.height)) {
+		// Clear the padding as well.
+		i.backend.restorable.ClearPixels(r)
+		return
+	}
+	i.backend.restorable.ClearPixels(region.Add(r.Min))
+}
+
 // ReadPixels reads pixels on the given region to the given slice pixels.
 //
 // ReadPixels blocks until BeginFrame is called if necessary in order to ensure this is called in a frame (between BeginFrame and EndFrame).
@@ -567,6 +628,10 @@ func (i *Image) deallocate() {
 	defer func() {
 		i.backend = nil
 		i.node = nil
+		// After deallocation, the image is a new cleared image and no longer wraps the native texture or has the
+		// compressed data.
+		i.nativeTexture = 0
+		i.compressedData = nil
 	}()
 
 	i.resetUsedAsSourceCount()
@@ -614,6 +679,53 @@ func NewImage(width, height int, imageType ImageType) *Image {
 	}
 }
 
+// NewImageFromNativeTexture creates an image that wraps the given native texture.
+//
+// The image is never on an atlas.
+func NewImageFromNativeTexture(handle uintptr, width, height int) *Image {
+	// Actual allocation is done lazily, and the lock is not needed.
+	return &Image{
+		width:         width,
+		height:        height,
+		imageType:     ImageTypeUnmanaged,
+		nativeTexture: handle,
+	}
+}
+
+// NewImageFromCompressedData creates an image with the given GPU-compressed data.
+//
+// The image is never on an atlas, as compressed blocks cannot be repacked.
+func NewImageFromCompressedData(format graphicsdriver.CompressedTextureFormat, data []byte, width, height int) *Image {
+	// Actual allocation is done lazily, and the lock is not needed.
+	return &Image{
+		width:            width,
+		height:           height,
+		imageType:        ImageTypeUnmanaged,
+		compressedFormat: format,
+		compressedData:   data,
+	}
+}
+
+// NewHighPrecisionImage creates an image with 16-bit floating point numbers per channel.
+//
+// The image is never on an atlas, as an atlas consists of 8-bit images.
+func NewHighPrecisionImage(width, height int) *Image {
+	// Actual allocation is done lazily, and the lock is not needed.
+	return &Image{
+		width:         width,
+		height:        height,
+		imageType:     ImageTypeUnmanaged,
+		highPrecision: true,
+	}
+}
+
+func (i *Image) bytesPerPixel() int {
+	if i.highPrecision {
+		return 8
+	}
+	return 4
+}
+
 func (i *Image) canBePutOnAtlas() bool {
 	if minSourceSize == 0 || minDestinationSize == 0 || maxSize == 0 {
 		panic("atlas: min*Size or maxSize must be initialized")
@@ -629,12 +741,14 @@ func (i *Image) allocate(forbiddenBackends []*backend, asSource bool) {
 		panic("atlas: the image is already allocated")
 	}
 
+	defer i.applyDebugLabel()
+
 	runtime.SetFinalizer(i, func(image *Image) {
 		// A function from finalizer must not be blocked, but disposing operation can be blocked.
 		// Defer this operation until it becomes safe. (#913)
 		appendDeferred(func() {
 			image.deallocate()
-			runtime.SetFinalizer(i, nil)
+			runtime.SetFinalizer(image, nil)
 		})
 	})
 
@@ -650,6 +764,35 @@ func (i *Image) allocate(forbiddenBackends []*backend, asSource bool) {
 		return
 	}
 
+	if i.nativeTexture != 0 {
+		// An image wrapping a native texture is never on an atlas.
+		i.backend = &backend{
+			restorable: restorable.NewImageFromNativeTexture(i.nativeTexture, i.width, i.height),
+		}
+		theBackends = append(theBackends, i.backend)
+		return
+	}
+
+	if i.compressedData != nil {
+		// An image with compressed data is never on an atlas.
+		i.backend = &backend{
+			restorable: restorable.NewImageFromCompressedData(i.compressedFormat, i.compressedData, i.width, i.height),
+		}
+		theBackends = append(theBackends, i.backend)
+		// The data is no longer needed after the image is created.
+		i.compressedData = nil
+		return
+	}
+
+	if i.highPrecision {
+		// A high-precision image is never on an atlas.
+		i.backend = &backend{
+			restorable: restorable.NewHighPrecisionImage(i.width, i.height),
+		}
+		theBackends = append(theBackends, i.backend)
+		return
+	}
+
 	wp := i.width + i.paddingSize()
 	hp := i.height + i.paddingSize()
 
@@ -767,6 +910,81 @@ func SwapBuffers(graphicsDriver graphicsdriver.Graphics) error {
 	return nil
 }
 
+// Flush flushes the pending graphics commands without presenting the screen.
+// Flush does nothing outside of a frame.
+func Flush(graphicsDriver graphicsdriver.Graphics, wait bool) error {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		return nil
+	}
+	return restorable.Flush(graphicsDriver, wait)
+}
+
+// SetDebugLabel sets a label to the image, which is shown in GPU debugging tools.
+//
+// The label is not applied while the image is on an atlas, as the texture is shared with other images.
+func (i *Image) SetDebugLabel(label string) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		appendDeferred(func() {
+			i.setDebugLabel(label)
+		})
+		return
+	}
+
+	i.setDebugLabel(label)
+}
+
+func (i *Image) setDebugLabel(label string) {
+	i.debugLabel = label
+	i.applyDebugLabel()
+}
+
+func (i *Image) applyDebugLabel() {
+	if i.debugLabel == "" {
+		return
+	}
+	if i.backend == nil || i.isOnAtlas() {
+		return
+	}
+	i.backend.restorable.SetDebugLabel(i.debugLabel)
+}
+
+// PushDebugGroup pushes a debug group, which is shown in GPU debugging tools.
+// The rendering commands until the corresponding PopDebugGroup call belong to the group.
+func PushDebugGroup(name string) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		appendDeferred(func() {
+			restorable.PushDebugGroup(name)
+		})
+		return
+	}
+
+	restorable.PushDebugGroup(name)
+}
+
+// PopDebugGroup pops the current debug group.
+func PopDebugGroup() {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		appendDeferred(func() {
+			restorable.PopDebugGroup()
+		})
+		return
+	}
+
+	restorable.PopDebugGroup()
+}
+
 func floorPowerOf2(x int) int {
 	if x <= 0 {
 		return 0
@@ -811,6 +1029,7 @@ func BeginFrame(graphicsDriver graphicsdriver.Graphics) error {
 		if maxSize == 0 {
 			maxSize = floorPowerOf2(restorable.MaxImageSize(graphicsDriver))
 		}
+		atomic.StoreInt32(&maxShaderImages, int32(restorable.MaxShaderImages(graphicsDriver)))
 	})
 	if err != nil {
 		return err
@@ -822,6 +1041,68 @@ func BeginFrame(graphicsDriver graphicsdriver.Graphics) error {
 	return nil
 }
 
+// ReleaseUnusedResources runs a garbage collection and disposes the images and the shaders that are no longer referenced.
+//
+// ReleaseUnusedResources waits for the finalizers queued by the garbage collection, so the unreferenced images are
+// released by the time this returns if this is called in a frame. Otherwise, they are released at the next BeginFrame.
+// Atlases that no longer have images are disposed.
+func ReleaseUnusedResources() {
+	runtime.GC()
+	waitForFinalizers()
+
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	// If inFrame is false, the deferred functions are executed at the next BeginFrame.
+	if !inFrame {
+		return
+	}
+	flushDeferred()
+}
+
+// waitForFinalizers waits until the finalizers queued before this call are executed.
+//
+// Finalizers are executed one by one in a single goroutine, but the order in one batch is not specified.
+// A sentinel's finalizer is waited for twice so that the second sentinel is queued after the batch including
+// the finalizers of the last garbage collection.
+func waitForFinalizers() {
+	for i := 0; i < 2; i++ {
+		ch := make(chan struct{})
+		func() {
+			// A sentinel must not be allocated by the tiny allocator, or its finalizer might not run.
+			s := new([16]byte)
+			runtime.SetFinalizer(s, func(*[16]byte) {
+				close(ch)
+			})
+		}()
+		runtime.GC()
+		<-ch
+	}
+}
+
+// MaxImageSize returns the maximum size of an image the graphics driver supports.
+func MaxImageSize(graphicsDriver graphicsdriver.Graphics) int {
+	return restorable.MaxImageSize(graphicsDriver)
+}
+
+// MaxShaderImages returns the maximum number of source images of a shader the graphics driver supports.
+// MaxShaderImages returns graphics.ShaderImageCount if the graphics driver is not initialized yet.
+func MaxShaderImages() int {
+	n := atomic.LoadInt32(&maxShaderImages)
+	if n == 0 {
+		return graphics.ShaderImageCount
+	}
+	return int(n)
+}
+
+func IsCompressedTextureFormatSupported(graphicsDriver graphicsdriver.Graphics, format graphicsdriver.CompressedTextureFormat) bool {
+	return restorable.IsCompressedTextureFormatSupported(graphicsDriver, format)
+}
+
+func IsHighPrecisionImageSupported(graphicsDriver graphicsdriver.Graphics) bool {
+	return restorable.IsHighPrecisionImageSupported(graphicsDriver)
+}
+
 func DumpImages(graphicsDriver graphicsdriver.Graphics, dir string) (string, error) {
 	backendsM.Lock()
 	defer backendsM.Unlock()
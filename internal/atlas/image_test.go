@@ -15,6 +15,7 @@
 package atlas_test
 
 import (
+	"bytes"
 	"image"
 	"image/color"
 	"runtime"
@@ -810,4 +811,46 @@ func TestIteratingImagesToPutOnSourceBackend(t *testing.T) {
 	}
 }
 
+func TestReleaseUnusedResources(t *testing.T) {
+	const w, h = 16, 16
+
+	img0 := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer img0.Deallocate()
+	pix := make([]byte, 4*w*h)
+	for i := range pix {
+		pix[i] = 0xff
+	}
+	img0.WritePixels(pix, image.Rect(0, 0, w, h))
+
+	// Release the resources left by the other tests first.
+	atlas.ReleaseUnusedResources()
+	count := atlas.BackendCountForTesting()
+
+	// img1 is too big to share an atlas with img0, and is no longer referenced after this.
+	func() {
+		size := maxImageSizeForTesting - img0.PaddingSizeForTesting()
+		img1 := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+		img1.WritePixels(make([]byte, 4*size*size), image.Rect(0, 0, size, size))
+	}()
+	if got, want := atlas.BackendCountForTesting(), count+1; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+
+	atlas.ReleaseUnusedResources()
+
+	// img1's atlas must be released.
+	if got, want := atlas.BackendCountForTesting(), count; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// img0 must not be affected.
+	got := make([]byte, 4*w*h)
+	if err := img0.ReadPixels(ui.Get().GraphicsDriverForTesting(), got, image.Rect(0, 0, w, h)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, pix) {
+		t.Errorf("img0's pixels were changed")
+	}
+}
+
 // TODO: Add tests to extend image on an atlas out of the main loop
@@ -30,6 +30,9 @@ type Image struct {
 
 	// pixels is valid only when restorable.AlwaysReadPixelsFromGPU() returns true.
 	pixels []byte
+
+	// highPrecision reports whether the image has 16-bit floating point numbers per channel.
+	highPrecision bool
 }
 
 func NewImage(width, height int, imageType atlas.ImageType) *Image {
@@ -40,6 +43,43 @@ func NewImage(width, height int, imageType atlas.ImageType) *Image {
 	}
 }
 
+// NewImageFromNativeTexture creates an image that wraps the given native texture.
+func NewImageFromNativeTexture(handle uintptr, width, height int) *Image {
+	return &Image{
+		width:  width,
+		height: height,
+		img:    atlas.NewImageFromNativeTexture(handle, width, height),
+	}
+}
+
+// NewImageFromCompressedData creates an image with the given GPU-compressed data.
+func NewImageFromCompressedData(format graphicsdriver.CompressedTextureFormat, data []byte, width, height int) *Image {
+	return &Image{
+		width:  width,
+		height: height,
+		img:    atlas.NewImageFromCompressedData(format, data, width, height),
+	}
+}
+
+// NewHighPrecisionImage creates an image with 16-bit floating point numbers per channel.
+//
+// The pixels of the image are 8 bytes per pixel.
+func NewHighPrecisionImage(width, height int) *Image {
+	return &Image{
+		width:         width,
+		height:        height,
+		img:           atlas.NewHighPrecisionImage(width, height),
+		highPrecision: true,
+	}
+}
+
+func (i *Image) bytesPerPixel() int {
+	if i.highPrecision {
+		return 8
+	}
+	return 4
+}
+
 func (i *Image) invalidatePixels() {
 	i.pixels = nil
 }
@@ -48,22 +88,41 @@ func (i *Image) Deallocate() {
 	i.img.Deallocate()
 }
 
+func (i *Image) SetDebugLabel(label string) {
+	i.img.SetDebugLabel(label)
+}
+
 func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) error {
 	if i.pixels == nil {
-		pix := make([]byte, 4*i.width*i.height)
+		pix := make([]byte, i.bytesPerPixel()*i.width*i.height)
 		if err := i.img.ReadPixels(graphicsDriver, pix, image.Rect(0, 0, i.width, i.height)); err != nil {
 			return err
 		}
 		i.pixels = pix
 	}
 
-	lineWidth := 4 * region.Dx()
+	i.readPixelsFromCache(pixels, region)
+	return nil
+}
+
+// ReadPixelsWithoutCache reads the pixels at the specified region without caching the pixels of the whole image.
+// This is more efficient than ReadPixels to read a small region of an image that is modified often.
+func (i *Image) ReadPixelsWithoutCache(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) error {
+	if i.pixels != nil {
+		i.readPixelsFromCache(pixels, region)
+		return nil
+	}
+	return i.img.ReadPixels(graphicsDriver, pixels, region)
+}
+
+func (i *Image) readPixelsFromCache(pixels []byte, region image.Rectangle) {
+	bpp := i.bytesPerPixel()
+	lineWidth := bpp * region.Dx()
 	for j := 0; j < region.Dy(); j++ {
-		dstX := 4 * j * region.Dx()
-		srcX := 4 * ((region.Min.Y+j)*i.width + region.Min.X)
+		dstX := bpp * j * region.Dx()
+		srcX := bpp * ((region.Min.Y+j)*i.width + region.Min.X)
 		copy(pixels[dstX:dstX+lineWidth], i.pixels[srcX:srcX+lineWidth])
 	}
-	return nil
 }
 
 func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name string, blackbg bool) (string, error) {
@@ -72,7 +131,7 @@ func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name stri
 
 // WritePixels replaces the pixels at the specified region.
 func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
-	if l := 4 * region.Dx() * region.Dy(); len(pix) != l {
+	if l := i.bytesPerPixel() * region.Dx() * region.Dy(); len(pix) != l {
 		panic(fmt.Sprintf("buffered: len(pix) was %d but must be %d", len(pix), l))
 	}
 	i.invalidatePixels()
@@ -80,6 +139,22 @@ func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
 	i.img.WritePixels(pix, region)
 }
 
+// ClearPixels clears the pixels at the specified region.
+func (i *Image) ClearPixels(region image.Rectangle) {
+	if i.pixels != nil {
+		bpp := i.bytesPerPixel()
+		lineWidth := bpp * region.Dx()
+		for j := 0; j < region.Dy(); j++ {
+			x := bpp * ((region.Min.Y+j)*i.width + region.Min.X)
+			for k := x; k < x+lineWidth; k++ {
+				i.pixels[k] = 0
+			}
+		}
+	}
+
+	i.img.ClearPixels(region)
+}
+
 // DrawTriangles draws the src image with the given vertices.
 //
 // Copying vertices and indices is the caller's responsibility.
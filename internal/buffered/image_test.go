@@ -15,6 +15,7 @@
 package buffered_test
 
 import (
+	"image"
 	"image/color"
 	"os"
 	"runtime"
@@ -24,6 +25,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/buffered"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
 var gameUpdateCh = make(chan func())
@@ -331,3 +333,29 @@ func TestGC(t *testing.T) {
 		}
 	})
 }
+
+func TestGCImageWithBuffer(t *testing.T) {
+	gced := make(chan struct{})
+	runOnGameUpdate(func() {
+		img := ui.Get().NewImage(16, 16, atlas.ImageTypeRegular)
+		// Writing one pixel is buffered, and the image is registered as an image with buffers.
+		img.WritePixels([]byte{0xff, 0xff, 0xff, 0xff}, image.Rect(0, 0, 1, 1))
+		runtime.SetFinalizer(img, func(*ui.Image) {
+			close(gced)
+		})
+	})
+
+	// The buffers are flushed at the end of the frame, and then the image is no longer held.
+	runOnGameUpdate(func() {
+		runtime.GC()
+
+		// A finalizer should be called eventually, but this might not be immediate.
+		// Set a time out.
+		select {
+		case <-gced:
+			return
+		case <-time.After(time.Second):
+			t.Error("an image with buffers must be GCed after it is dropped but not")
+		}
+	})
+}
@@ -26,9 +26,16 @@ type Filter int
 const (
 	FilterNearest Filter = iota
 	FilterLinear
+
+	// FilterAnisotropic is a linear filter taking multiple samples along the major axis of the pixel footprint.
+	// FilterAnisotropic is not exposed as a public filter, but is used when anisotropic filtering is enabled.
+	FilterAnisotropic
 )
 
-const FilterCount = 2
+const FilterCount = 3
+
+// MaxAnisotropy is the maximum number of samples for FilterAnisotropic.
+const MaxAnisotropy = 16
 
 type Address int
 
@@ -36,13 +43,16 @@ const (
 	AddressUnsafe Address = iota
 	AddressClampToZero
 	AddressRepeat
+	AddressClampToEdge
+	AddressMirror
 )
 
-const AddressCount = 3
+const AddressCount = 5
 
 const (
 	UniformColorMBody        = "ColorMBody"
 	UniformColorMTranslation = "ColorMTranslation"
+	UniformMaxAnisotropy     = "MaxAnisotropy"
 )
 
 var (
@@ -59,30 +69,49 @@ var ColorMBody mat4
 var ColorMTranslation vec4
 {{end}}
 
+{{if eq .Filter .FilterAnisotropic}}
+var MaxAnisotropy float
+{{end}}
+
 {{if eq .Address .AddressRepeat}}
 func adjustTexelForAddressRepeat(p vec2) vec2 {
 	origin := imageSrc0Origin()
 	size := imageSrc0Size()
 	return mod(p - origin, size) + origin
 }
+{{else if eq .Address .AddressClampToEdge}}
+func adjustTexelForAddressClampToEdge(p vec2) vec2 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	return clamp(p, origin, origin + size - 1/2.0)
+}
+{{else if eq .Address .AddressMirror}}
+func adjustTexelForAddressMirror(p vec2) vec2 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	q := mod(p - origin, 2 * size)
+	// Fold the second half of the period, and avoid the exact end of the image.
+	q = min(size - abs(q - size), size - 1/2.0)
+	return q + origin
+}
 {{end}}
 
-func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
-{{if eq .Filter .FilterNearest}}
-{{if eq .Address .AddressUnsafe}}
-	clr := imageSrc0UnsafeAt(srcPos)
-{{else if eq .Address .AddressClampToZero}}
-	clr := imageSrc0At(srcPos)
-{{else if eq .Address .AddressRepeat}}
-	clr := imageSrc0At(adjustTexelForAddressRepeat(srcPos))
-{{end}}
-{{else if eq .Filter .FilterLinear}}
+{{if ne .Filter .FilterNearest}}
+func sampleLinear(srcPos vec2) vec4 {
 	p0 := srcPos - 1/2.0
 	p1 := srcPos + 1/2.0
+	// Calculate the rate before adjusting the texels, as clamping and mirroring change the fractional parts.
+	rate := fract(p1)
 
 {{if eq .Address .AddressRepeat}}
 	p0 = adjustTexelForAddressRepeat(p0)
 	p1 = adjustTexelForAddressRepeat(p1)
+{{else if eq .Address .AddressClampToEdge}}
+	p0 = adjustTexelForAddressClampToEdge(p0)
+	p1 = adjustTexelForAddressClampToEdge(p1)
+{{else if eq .Address .AddressMirror}}
+	p0 = adjustTexelForAddressMirror(p0)
+	p1 = adjustTexelForAddressMirror(p1)
 {{end}}
 
 {{if eq .Address .AddressUnsafe}}
@@ -97,8 +126,45 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	c3 := imageSrc0At(p1)
 {{end}}
 
-	rate := fract(p1)
-	clr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+	return mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+}
+{{end}}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+{{if eq .Filter .FilterNearest}}
+{{if eq .Address .AddressUnsafe}}
+	clr := imageSrc0UnsafeAt(srcPos)
+{{else if eq .Address .AddressClampToZero}}
+	clr := imageSrc0At(srcPos)
+{{else if eq .Address .AddressRepeat}}
+	clr := imageSrc0At(adjustTexelForAddressRepeat(srcPos))
+{{else if eq .Address .AddressClampToEdge}}
+	clr := imageSrc0At(adjustTexelForAddressClampToEdge(srcPos))
+{{else if eq .Address .AddressMirror}}
+	clr := imageSrc0At(adjustTexelForAddressMirror(srcPos))
+{{end}}
+{{else if eq .Filter .FilterLinear}}
+	clr := sampleLinear(srcPos)
+{{else if eq .Filter .FilterAnisotropic}}
+	// Take multiple linear samples along the major axis of the pixel footprint in the source image.
+	dx := dfdx(srcPos)
+	dy := dfdy(srcPos)
+	major := dx
+	majorLen := length(dx)
+	minorLen := length(dy)
+	if minorLen > majorLen {
+		major = dy
+		majorLen, minorLen = minorLen, majorLen
+	}
+	n := clamp(ceil(majorLen/max(minorLen, 1.0/65536)), 1, MaxAnisotropy)
+	clr := vec4(0)
+	for i := 0; i < {{.MaxAnisotropy}}; i++ {
+		if float(i) >= n {
+			break
+		}
+		clr += sampleLinear(srcPos + major*((float(i)+0.5)/n-0.5))
+	}
+	clr /= n
 {{end}}
 
 {{if .UseColorM}}
@@ -143,19 +209,27 @@ func Shader(filter Filter, address Address, useColorM bool) []byte {
 		Filter             Filter
 		FilterNearest      Filter
 		FilterLinear       Filter
+		FilterAnisotropic  Filter
+		MaxAnisotropy      int
 		Address            Address
 		AddressUnsafe      Address
 		AddressClampToZero Address
 		AddressRepeat      Address
+		AddressClampToEdge Address
+		AddressMirror      Address
 		UseColorM          bool
 	}{
 		Filter:             filter,
 		FilterNearest:      FilterNearest,
 		FilterLinear:       FilterLinear,
+		FilterAnisotropic:  FilterAnisotropic,
+		MaxAnisotropy:      MaxAnisotropy,
 		Address:            address,
 		AddressUnsafe:      AddressUnsafe,
 		AddressClampToZero: AddressClampToZero,
 		AddressRepeat:      AddressRepeat,
+		AddressClampToEdge: AddressClampToEdge,
+		AddressMirror:      AddressMirror,
 		UseColorM:          useColorM,
 	}); err != nil {
 		panic(fmt.Sprintf("builtinshader: tmpl.Execute failed: %v", err))
@@ -0,0 +1,38 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+)
+
+func TestCompile(t *testing.T) {
+	for filter := builtinshader.Filter(0); filter < builtinshader.FilterCount; filter++ {
+		for address := builtinshader.Address(0); address < builtinshader.AddressCount; address++ {
+			for _, useColorM := range []bool{false, true} {
+				filter, address, useColorM := filter, address, useColorM
+				t.Run(fmt.Sprintf("filter=%d,address=%d,colorm=%t", filter, address, useColorM), func(t *testing.T) {
+					if _, err := graphics.CompileShader(builtinshader.Shader(filter, address, useColorM)); err != nil {
+						t.Error(err)
+					}
+				})
+			}
+		}
+	}
+}
@@ -16,6 +16,7 @@
 package clock
 
 import (
+	"math"
 	"sync"
 	"time"
 )
@@ -42,6 +43,19 @@ var (
 	fpsCount    = 0
 	tpsCount    = 0
 
+	// skippedCount is the number of ticks skipped by syncing the game time with the system clock.
+	skippedCount = 0
+
+	// catchUpCount is the number of extra ticks executed in frames updating more than once.
+	catchUpCount = 0
+
+	lastStats Stats
+
+	lastFrameStats FrameStats
+
+	// interpolationAlpha is the fractional progress from the last tick to the next tick at the last UpdateFrame.
+	interpolationAlpha float64
+
 	m sync.Mutex
 )
 
@@ -64,6 +78,52 @@ func ActualTPS() float64 {
 	return actualTPS
 }
 
+// Stats represents statistics of ticks in the last one-second window.
+type Stats struct {
+	Ticks        int
+	SkippedTicks int
+	CatchUpTicks int
+
+	// Backlog is the game time which is behind the system clock at the last UpdateFrame.
+	Backlog time.Duration
+}
+
+// FrameStats represents statistics of frames in the last one-second window.
+type FrameStats struct {
+	Frames int
+
+	// AverageFrameTime is the average duration between frames.
+	AverageFrameTime time.Duration
+
+	// Window is a serial number of the one-second window, which is incremented every time the statistics are updated.
+	Window int
+}
+
+// CurrentFrameStats returns the statistics of frames in the last one-second window.
+func CurrentFrameStats() FrameStats {
+	m.Lock()
+	defer m.Unlock()
+	return lastFrameStats
+}
+
+// InterpolationAlpha returns the fractional progress in [0, 1) from the last tick to the next tick at the last UpdateFrame.
+func InterpolationAlpha() float64 {
+	m.Lock()
+	defer m.Unlock()
+	return interpolationAlpha
+}
+
+func CurrentStats() Stats {
+	m.Lock()
+	defer m.Unlock()
+
+	s := lastStats
+	if tps > 0 {
+		s.Backlog = time.Duration(max(lastNow-lastSystemTime, 0))
+	}
+	return s
+}
+
 func max(a, b int64) int64 {
 	if a < b {
 		return b
@@ -93,6 +153,9 @@ func calcCountFromTPS(tps int64, now int64) int {
 		// The previous time is too old.
 		// Let's force to sync the game time with the system clock.
 		syncWithSystemClock = true
+		if prevTPS == tps {
+			skippedCount += int(diff * tps / int64(time.Second))
+		}
 	} else {
 		count = int(diff * tps / int64(time.Second))
 	}
@@ -117,9 +180,25 @@ func calcCountFromTPS(tps int64, now int64) int {
 	return count
 }
 
+func calcInterpolationAlpha(tps int64, now int64) float64 {
+	// lastSystemTime is the logical time of the last tick, which can be bigger than now due to the stabilization of the count.
+	a := float64(now-lastSystemTime) * float64(tps) / float64(time.Second)
+	if a < 0 {
+		return 0
+	}
+	if a >= 1 {
+		// Use the largest value less than 1.
+		return math.Nextafter(1, 0)
+	}
+	return a
+}
+
 func updateFPSAndTPS(now int64, count int) {
 	fpsCount++
 	tpsCount += count
+	if count > 1 {
+		catchUpCount += count - 1
+	}
 	if now < lastUpdated {
 		panic("clock: lastUpdated must be older than now")
 	}
@@ -128,9 +207,21 @@ func updateFPSAndTPS(now int64, count int) {
 	}
 	actualFPS = float64(fpsCount) * float64(time.Second) / float64(now-lastUpdated)
 	actualTPS = float64(tpsCount) * float64(time.Second) / float64(now-lastUpdated)
+	lastStats = Stats{
+		Ticks:        tpsCount,
+		SkippedTicks: skippedCount,
+		CatchUpTicks: catchUpCount,
+	}
+	lastFrameStats = FrameStats{
+		Frames:           fpsCount,
+		AverageFrameTime: time.Duration((now - lastUpdated) / int64(fpsCount)),
+		Window:           lastFrameStats.Window + 1,
+	}
 	lastUpdated = now
 	fpsCount = 0
 	tpsCount = 0
+	skippedCount = 0
+	catchUpCount = 0
 }
 
 // UpdateFrame updates the inner clock state and returns an integer value
@@ -152,10 +243,12 @@ func UpdateFrame() int {
 	lastNow = n
 
 	c := 0
+	interpolationAlpha = 0
 	if tps == SyncWithFPS {
 		c = 1
 	} else if tps > 0 {
 		c = calcCountFromTPS(int64(tps), n)
+		interpolationAlpha = calcInterpolationAlpha(int64(tps), n)
 	}
 	updateFPSAndTPS(n, c)
 
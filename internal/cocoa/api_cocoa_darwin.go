@@ -30,6 +30,7 @@ var (
 	class_NSWindow          = objc.GetClass("NSWindow")
 	class_NSView            = objc.GetClass("NSView")
 	class_NSScreen          = objc.GetClass("NSScreen")
+	class_NSApplication     = objc.GetClass("NSApplication")
 )
 
 var (
@@ -49,6 +50,7 @@ var (
 	sel_UTF8String                         = objc.RegisterName("UTF8String")
 	sel_length                             = objc.RegisterName("length")
 	sel_processInfo                        = objc.RegisterName("processInfo")
+	sel_beginActivityWithOptionsReason     = objc.RegisterName("beginActivityWithOptions:reason:")
 	sel_frame                              = objc.RegisterName("frame")
 	sel_contentView                        = objc.RegisterName("contentView")
 	sel_setBackgroundColor                 = objc.RegisterName("setBackgroundColor:")
@@ -63,6 +65,9 @@ var (
 	sel_deviceDescription                  = objc.RegisterName("deviceDescription")
 	sel_objectForKey                       = objc.RegisterName("objectForKey:")
 	sel_unsignedIntValue                   = objc.RegisterName("unsignedIntValue")
+	sel_sharedApplication                  = objc.RegisterName("sharedApplication")
+	sel_currentEvent                       = objc.RegisterName("currentEvent")
+	sel_hasPreciseScrollingDeltas          = objc.RegisterName("hasPreciseScrollingDeltas")
 )
 
 const (
@@ -122,6 +127,10 @@ func NSProcessInfo_processInfo() NSProcessInfo {
 	return NSProcessInfo{objc.ID(class_NSProcessInfo).Send(sel_processInfo)}
 }
 
+func (p NSProcessInfo) BeginActivityWithOptionsReason(options uint64, reason NSString) objc.ID {
+	return p.Send(sel_beginActivityWithOptionsReason, options, reason.ID)
+}
+
 type NSWindow struct {
 	objc.ID
 }
@@ -304,3 +313,23 @@ type NSNumber struct {
 func (n NSNumber) UnsignedIntValue() uint {
 	return uint(n.Send(sel_unsignedIntValue))
 }
+
+type NSApplication struct {
+	objc.ID
+}
+
+func NSApplication_sharedApplication() NSApplication {
+	return NSApplication{objc.ID(class_NSApplication).Send(sel_sharedApplication)}
+}
+
+func (a NSApplication) CurrentEvent() NSEvent {
+	return NSEvent{a.Send(sel_currentEvent)}
+}
+
+type NSEvent struct {
+	objc.ID
+}
+
+func (e NSEvent) HasPreciseScrollingDeltas() bool {
+	return e.Send(sel_hasPreciseScrollingDeltas) != 0
+}
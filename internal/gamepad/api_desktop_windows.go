@@ -58,8 +58,9 @@ const (
 	_DIPH_DEVICE = 0
 	_DIPH_BYID   = 2
 
-	_DIPROP_AXISMODE = 2
-	_DIPROP_RANGE    = 4
+	_DIPROP_AXISMODE    = 2
+	_DIPROP_RANGE       = 4
+	_DIPROP_GUIDANDPATH = 12
 
 	_DIPROPAXISMODE_ABS = 0
 
@@ -67,6 +68,8 @@ const (
 
 	_GWL_WNDPROC = -4
 
+	_HIDP_STATUS_SUCCESS = 0x00110000
+
 	_MAX_PATH = 260
 
 	_RIDI_DEVICEINFO = 0x2000000b
@@ -133,9 +136,14 @@ var (
 )
 
 var (
+	hid      = windows.NewLazySystemDLL("hid.dll")
 	kernel32 = windows.NewLazySystemDLL("kernel32.dll")
 	user32   = windows.NewLazySystemDLL("user32.dll")
 
+	procHidD_FreePreparsedData = hid.NewProc("HidD_FreePreparsedData")
+	procHidD_GetPreparsedData  = hid.NewProc("HidD_GetPreparsedData")
+	procHidP_GetCaps           = hid.NewProc("HidP_GetCaps")
+
 	procGetModuleHandleW = kernel32.NewProc("GetModuleHandleW")
 
 	procCallWindowProcW        = user32.NewProc("CallWindowProcW")
@@ -146,6 +154,31 @@ var (
 	procSetWindowLongPtrW = user32.NewProc("SetWindowLongPtrW") // 64-Bit Windows version.
 )
 
+func _HidD_FreePreparsedData(preparsedData uintptr) {
+	_, _, _ = procHidD_FreePreparsedData.Call(preparsedData)
+}
+
+func _HidD_GetPreparsedData(hidDeviceObject windows.Handle) (uintptr, error) {
+	var preparsedData uintptr
+	r, _, e := procHidD_GetPreparsedData.Call(uintptr(hidDeviceObject), uintptr(unsafe.Pointer(&preparsedData)))
+	if byte(r) == 0 {
+		if e != nil && e != windows.ERROR_SUCCESS {
+			return 0, fmt.Errorf("gamepad: HidD_GetPreparsedData failed: %w", e)
+		}
+		return 0, fmt.Errorf("gamepad: HidD_GetPreparsedData returned false")
+	}
+	return preparsedData, nil
+}
+
+func _HidP_GetCaps(preparsedData uintptr) (_HIDP_CAPS, error) {
+	var caps _HIDP_CAPS
+	r, _, _ := procHidP_GetCaps.Call(preparsedData, uintptr(unsafe.Pointer(&caps)))
+	if uint32(r) != _HIDP_STATUS_SUCCESS {
+		return _HIDP_CAPS{}, fmt.Errorf("gamepad: HidP_GetCaps failed: %d", uint32(r))
+	}
+	return caps, nil
+}
+
 func _GetModuleHandleW() (uintptr, error) {
 	m, _, e := procGetModuleHandleW.Call(0)
 	if m == 0 {
@@ -287,6 +320,12 @@ type _DIPROPHEADER struct {
 	dwHow        uint32
 }
 
+type _DIPROPGUIDANDPATH struct {
+	diph      _DIPROPHEADER
+	guidClass windows.GUID
+	wszPath   [_MAX_PATH]uint16
+}
+
 type _DIPROPRANGE struct {
 	diph _DIPROPHEADER
 	lMin int32
@@ -401,6 +440,14 @@ func (d *_IDirectInputDevice8W) GetCapabilities(lpDIDevCaps *_DIDEVCAPS) error {
 	return nil
 }
 
+func (d *_IDirectInputDevice8W) GetProperty(rguidProp uintptr, pdiph *_DIPROPHEADER) error {
+	r, _, _ := syscall.Syscall(d.vtbl.GetProperty, 3, uintptr(unsafe.Pointer(d)), rguidProp, uintptr(unsafe.Pointer(pdiph)))
+	if uint32(r) != _DI_OK {
+		return fmt.Errorf("gamepad: IDirectInputDevice8::GetProperty failed: %w", handleError(windows.Handle(uint32(r))))
+	}
+	return nil
+}
+
 func (d *_IDirectInputDevice8W) GetDeviceState(cbData uint32, lpvData unsafe.Pointer) error {
 	r, _, _ := syscall.Syscall(d.vtbl.GetDeviceState, 3, uintptr(unsafe.Pointer(d)), uintptr(cbData), uintptr(lpvData))
 	if uint32(r) != _DI_OK {
@@ -438,6 +485,25 @@ func (d *_IDirectInputDevice8W) SetProperty(rguidProp uintptr, pdiph *_DIPROPHEA
 	return nil
 }
 
+type _HIDP_CAPS struct {
+	Usage                     uint16
+	UsagePage                 uint16
+	InputReportByteLength     uint16
+	OutputReportByteLength    uint16
+	FeatureReportByteLength   uint16
+	Reserved                  [17]uint16
+	NumberLinkCollectionNodes uint16
+	NumberInputButtonCaps     uint16
+	NumberInputValueCaps      uint16
+	NumberInputDataIndices    uint16
+	NumberOutputButtonCaps    uint16
+	NumberOutputValueCaps     uint16
+	NumberOutputDataIndices   uint16
+	NumberFeatureButtonCaps   uint16
+	NumberFeatureValueCaps    uint16
+	NumberFeatureDataIndices  uint16
+}
+
 type _RID_DEVICE_INFO struct {
 	cbSize uint32
 	dwType uint32
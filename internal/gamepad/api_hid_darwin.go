@@ -24,6 +24,8 @@ const kIOReturnSuccess = 0
 
 const kIOHIDOptionsTypeNone _IOOptionBits = 0
 
+const kIOHIDReportTypeOutput _IOHIDReportType = 1
+
 const (
 	kIOHIDElementTypeInput_Misc   = 1
 	kIOHIDElementTypeInput_Button = 2
@@ -70,6 +72,7 @@ var (
 	kIOHIDProductIDKey       = []byte("ProductID\x00")
 	kIOHIDVersionNumberKey   = []byte("VersionNumber\x00")
 	kIOHIDProductKey         = []byte("Product\x00")
+	kIOHIDTransportKey       = []byte("Transport\x00")
 	kIOHIDDeviceUsagePageKey = []byte("DeviceUsagePage\x00")
 	kIOHIDDeviceUsageKey     = []byte("DeviceUsage\x00")
 )
@@ -82,10 +85,13 @@ type (
 	_IOHIDValueRef    uintptr
 	_IOReturn         int32
 	_IOHIDElementType uint32
+	_IOHIDReportType  uint32
 )
 
 type _IOHIDDeviceCallback func(context unsafe.Pointer, result _IOReturn, sender unsafe.Pointer, device _IOHIDDeviceRef)
 
+type _IOHIDReportCallback func(context unsafe.Pointer, result _IOReturn, sender unsafe.Pointer, typ _IOHIDReportType, reportID uint32, report *byte, reportLength _CFIndex)
+
 func initializeIOKit() error {
 	iokit, err := purego.Dlopen("/System/Library/Frameworks/IOKit.framework/IOKit", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
 	if err != nil {
@@ -99,6 +105,7 @@ func initializeIOKit() error {
 	purego.RegisterLibFunc(&_IOHIDManagerSetDeviceMatchingMultiple, iokit, "IOHIDManagerSetDeviceMatchingMultiple")
 	purego.RegisterLibFunc(&_IOHIDManagerRegisterDeviceMatchingCallback, iokit, "IOHIDManagerRegisterDeviceMatchingCallback")
 	purego.RegisterLibFunc(&_IOHIDManagerRegisterDeviceRemovalCallback, iokit, "IOHIDManagerRegisterDeviceRemovalCallback")
+	purego.RegisterLibFunc(&_IOHIDDeviceRegisterInputReportCallback, iokit, "IOHIDDeviceRegisterInputReportCallback")
 	purego.RegisterLibFunc(&_IOHIDManagerScheduleWithRunLoop, iokit, "IOHIDManagerScheduleWithRunLoop")
 	purego.RegisterLibFunc(&_IOHIDElementGetType, iokit, "IOHIDElementGetType")
 	purego.RegisterLibFunc(&_IOHIDElementGetUsage, iokit, "IOHIDElementGetUsage")
@@ -108,6 +115,7 @@ func initializeIOKit() error {
 	purego.RegisterLibFunc(&_IOHIDDeviceGetValue, iokit, "IOHIDDeviceGetValue")
 	purego.RegisterLibFunc(&_IOHIDValueGetIntegerValue, iokit, "IOHIDValueGetIntegerValue")
 	purego.RegisterLibFunc(&_IOHIDDeviceCopyMatchingElements, iokit, "IOHIDDeviceCopyMatchingElements")
+	purego.RegisterLibFunc(&_IOHIDDeviceSetReport, iokit, "IOHIDDeviceSetReport")
 
 	return nil
 }
@@ -120,6 +128,7 @@ var (
 	_IOHIDManagerSetDeviceMatchingMultiple      func(manager _IOHIDManagerRef, multiple _CFArrayRef)
 	_IOHIDManagerRegisterDeviceMatchingCallback func(manager _IOHIDManagerRef, callback _IOHIDDeviceCallback, context unsafe.Pointer)
 	_IOHIDManagerRegisterDeviceRemovalCallback  func(manager _IOHIDManagerRef, callback _IOHIDDeviceCallback, context unsafe.Pointer)
+	_IOHIDDeviceRegisterInputReportCallback     func(device _IOHIDDeviceRef, report *byte, reportLength _CFIndex, callback _IOHIDReportCallback, context unsafe.Pointer)
 	_IOHIDManagerScheduleWithRunLoop            func(manager _IOHIDManagerRef, runLoop _CFRunLoopRef, runLoopMode _CFStringRef)
 	_IOHIDElementGetType                        func(element _IOHIDElementRef) _IOHIDElementType
 	_IOHIDElementGetUsage                       func(element _IOHIDElementRef) uint32
@@ -129,4 +138,5 @@ var (
 	_IOHIDDeviceGetValue                        func(device _IOHIDDeviceRef, element _IOHIDElementRef, pValue *_IOHIDValueRef) _IOReturn
 	_IOHIDValueGetIntegerValue                  func(value _IOHIDValueRef) _CFIndex
 	_IOHIDDeviceCopyMatchingElements            func(device _IOHIDDeviceRef, matching _CFDictionaryRef, options _IOOptionBits) _CFArrayRef
+	_IOHIDDeviceSetReport                       func(device _IOHIDDeviceRef, reportType _IOHIDReportType, reportID _CFIndex, report *byte, reportLength _CFIndex) _IOReturn
 )
@@ -0,0 +1,106 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const axisCalibrationVersion = 1
+
+// axisRange is a range of raw axis values observed or reported by a device.
+type axisRange struct {
+	minimum int
+	maximum int
+}
+
+// axisCalibrator is implemented by a native gamepad that calibrates its axis ranges at runtime.
+type axisCalibrator interface {
+	axisRanges() []axisRange
+	setAxisRanges(ranges []axisRange)
+}
+
+// encodeAxisRanges encodes the ranges as a version byte, an axis count, and pairs of the minimum and the maximum.
+func encodeAxisRanges(ranges []axisRange) []byte {
+	data := make([]byte, 3+8*len(ranges))
+	data[0] = axisCalibrationVersion
+	binary.LittleEndian.PutUint16(data[1:3], uint16(len(ranges)))
+	for i, r := range ranges {
+		binary.LittleEndian.PutUint32(data[3+8*i:], uint32(int32(r.minimum)))
+		binary.LittleEndian.PutUint32(data[3+8*i+4:], uint32(int32(r.maximum)))
+	}
+	return data
+}
+
+func decodeAxisRanges(data []byte) ([]axisRange, error) {
+	if len(data) < 3 {
+		return nil, errors.New("gamepad: calibration data is too short")
+	}
+	if data[0] != axisCalibrationVersion {
+		return nil, fmt.Errorf("gamepad: unsupported calibration version: %d", data[0])
+	}
+	n := int(binary.LittleEndian.Uint16(data[1:3]))
+	if len(data) != 3+8*n {
+		return nil, fmt.Errorf("gamepad: calibration data length must be %d but %d", 3+8*n, len(data))
+	}
+	ranges := make([]axisRange, n)
+	for i := range ranges {
+		ranges[i].minimum = int(int32(binary.LittleEndian.Uint32(data[3+8*i:])))
+		ranges[i].maximum = int(int32(binary.LittleEndian.Uint32(data[3+8*i+4:])))
+		if ranges[i].minimum > ranges[i].maximum {
+			return nil, fmt.Errorf("gamepad: the minimum %d must not be greater than the maximum %d", ranges[i].minimum, ranges[i].maximum)
+		}
+	}
+	return ranges, nil
+}
+
+// AxisCalibration returns the calibration of the axes, or nil if the gamepad doesn't calibrate its axes.
+//
+// AxisCalibration is concurrent-safe.
+func (g *Gamepad) AxisCalibration() []byte {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	c, ok := g.native.(axisCalibrator)
+	if !ok {
+		return nil
+	}
+	return encodeAxisRanges(c.axisRanges())
+}
+
+// SetAxisCalibration restores the calibration of the axes returned by AxisCalibration.
+// SetAxisCalibration does nothing if the gamepad doesn't calibrate its axes.
+//
+// SetAxisCalibration is concurrent-safe.
+func (g *Gamepad) SetAxisCalibration(data []byte) error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	c, ok := g.native.(axisCalibrator)
+	if !ok {
+		return nil
+	}
+	ranges, err := decodeAxisRanges(data)
+	if err != nil {
+		return err
+	}
+	if got, want := len(ranges), len(c.axisRanges()); got != want {
+		return fmt.Errorf("gamepad: the number of axes in the calibration must be %d but %d", want, got)
+	}
+	c.setAxisRanges(ranges)
+	return nil
+}
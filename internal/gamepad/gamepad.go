@@ -38,7 +38,17 @@ const (
 type gamepads struct {
 	inited   bool
 	gamepads []*Gamepad
-	m        sync.Mutex
+
+	// lastSDLIDs is the SDL IDs of the gamepads that used the IDs last time.
+	// The indices are the same as gamepads.
+	lastSDLIDs []string
+
+	// playback is the gamepads played back from a recording, which are visible instead of gamepads if playing is true.
+	// The indices are the IDs.
+	playback []*Gamepad
+	playing  bool
+
+	m sync.Mutex
 
 	native nativeGamepads
 }
@@ -57,11 +67,25 @@ func AppendGamepadIDs(ids []ID) []ID {
 	return theGamepads.appendGamepadIDs(ids)
 }
 
+// Count is concurrent-safe.
+func Count() int {
+	return theGamepads.count()
+}
+
 // Update is concurrent-safe.
 func Update() error {
 	return theGamepads.update()
 }
 
+// ReadPresses resets the button presses recorded by Update since the last call.
+// If buffered is true, the presses are visible for one tick, i.e. a button pressed and released between two ticks is
+// treated as pressed during the tick.
+//
+// ReadPresses is concurrent-safe.
+func ReadPresses(buffered bool) {
+	theGamepads.readPresses(buffered)
+}
+
 // Get is concurrent-safe.
 func Get(id ID) *Gamepad {
 	return theGamepads.get(id)
@@ -75,7 +99,7 @@ func (g *gamepads) appendGamepadIDs(ids []ID) []ID {
 	g.m.Lock()
 	defer g.m.Unlock()
 
-	for i, gp := range g.gamepads {
+	for i, gp := range g.visibleGamepads() {
 		if gp != nil {
 			ids = append(ids, ID(i))
 		}
@@ -83,6 +107,19 @@ func (g *gamepads) appendGamepadIDs(ids []ID) []ID {
 	return ids
 }
 
+func (g *gamepads) count() int {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	var n int
+	for _, gp := range g.visibleGamepads() {
+		if gp != nil {
+			n++
+		}
+	}
+	return n
+}
+
 func (g *gamepads) update() error {
 	g.m.Lock()
 	defer g.m.Unlock()
@@ -112,18 +149,32 @@ func (g *gamepads) update() error {
 		if err := gp.update(g); err != nil {
 			return err
 		}
+		gp.recordPresses()
 	}
 	return nil
 }
 
+func (g *gamepads) readPresses(buffered bool) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	for _, gp := range g.gamepads {
+		if gp == nil {
+			continue
+		}
+		gp.readPresses(buffered)
+	}
+}
+
 func (g *gamepads) get(id ID) *Gamepad {
 	g.m.Lock()
 	defer g.m.Unlock()
 
-	if id < 0 || int(id) >= len(g.gamepads) {
+	gamepads := g.visibleGamepads()
+	if id < 0 || int(id) >= len(gamepads) {
 		return nil
 	}
-	return g.gamepads[id]
+	return gamepads[id]
 }
 
 func (g *gamepads) find(cond func(*Gamepad) bool) *Gamepad {
@@ -138,23 +189,45 @@ func (g *gamepads) find(cond func(*Gamepad) bool) *Gamepad {
 	return nil
 }
 
+// add adds a new gamepad and returns it.
+//
+// The ID for the new gamepad is chosen in this order:
+//
+//  1. An unused ID that was used by a gamepad with the same SDL ID.
+//     This keeps the ID stable when a gamepad is disconnected and then reconnected.
+//  2. The smallest unused ID that has never been used.
+//  3. A new ID.
+//
+// An unused ID that was used by a gamepad with a different SDL ID is kept reserved for that gamepad.
 func (g *gamepads) add(name, sdlID string) *Gamepad {
+	gp := &Gamepad{
+		name:  name,
+		sdlID: sdlID,
+	}
+
+	idx := -1
 	for i, gp := range g.gamepads {
-		if gp == nil {
-			gp := &Gamepad{
-				name:  name,
-				sdlID: sdlID,
+		if gp == nil && g.lastSDLIDs[i] == sdlID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		for i, gp := range g.gamepads {
+			if gp == nil && g.lastSDLIDs[i] == "" {
+				idx = i
+				break
 			}
-			g.gamepads[i] = gp
-			return gp
 		}
 	}
-
-	gp := &Gamepad{
-		name:  name,
-		sdlID: sdlID,
+	if idx < 0 {
+		g.gamepads = append(g.gamepads, nil)
+		g.lastSDLIDs = append(g.lastSDLIDs, "")
+		idx = len(g.gamepads) - 1
 	}
-	g.gamepads = append(g.gamepads, gp)
+
+	g.gamepads[idx] = gp
+	g.lastSDLIDs[idx] = sdlID
 	return gp
 }
 
@@ -164,6 +237,7 @@ func (g *gamepads) remove(cond func(*Gamepad) bool) {
 			continue
 		}
 		if cond(gp) {
+			// Keep lastSDLIDs so that the ID can be reused when the same gamepad is reconnected.
 			g.gamepads[i] = nil
 		}
 	}
@@ -179,12 +253,46 @@ func (g *gamepads) setNativeWindow(nativeWindow uintptr) {
 	}
 }
 
+const (
+	TransportUnknown   = "Unknown"
+	TransportUSB       = "USB"
+	TransportBluetooth = "Bluetooth"
+)
+
 type Gamepad struct {
-	name  string
-	sdlID string
-	m     sync.Mutex
+	name      string
+	sdlID     string
+	transport string
+	m         sync.Mutex
 
 	native nativeGamepad
+
+	// buttonsPressedSinceRead and standardButtonsPressedSinceRead are the buttons pressed at any update since the last read.
+	// buttonsRead and standardButtonsRead are the buttons treated as pressed until the next read.
+	buttonsPressedSinceRead         [ButtonCount]bool
+	buttonsRead                     [ButtonCount]bool
+	standardButtonsPressedSinceRead [gamepaddb.StandardButtonMax + 1]bool
+	standardButtonsRead             [gamepaddb.StandardButtonMax + 1]bool
+}
+
+// rawGamepadState is a gamepaddb.GamepadState that ignores the recorded presses.
+type rawGamepadState struct {
+	g *Gamepad
+}
+
+func (r rawGamepadState) Axis(axis int) float64 {
+	return r.g.Axis(axis)
+}
+
+func (r rawGamepadState) Button(button int) bool {
+	r.g.m.Lock()
+	defer r.g.m.Unlock()
+
+	return r.g.native.isButtonPressed(button)
+}
+
+func (r rawGamepadState) Hat(hat int) int {
+	return r.g.Hat(hat)
 }
 
 type mappingInput interface {
@@ -247,7 +355,13 @@ type nativeGamepad interface {
 	buttonValue(button int) float64
 	isButtonPressed(button int) bool
 	hatState(hat int) int
+	isVibrationSupported() bool
 	vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64)
+	isLightSupported() bool
+	setLightColor(red, green, blue uint8)
+	isMotionSupported() bool
+	gyroscope() (x, y, z float64)
+	accelerometer() (x, y, z float64)
 }
 
 func (g *Gamepad) update(gamepads *gamepads) error {
@@ -257,6 +371,38 @@ func (g *Gamepad) update(gamepads *gamepads) error {
 	return g.native.update(gamepads)
 }
 
+func (g *Gamepad) recordPresses() {
+	n := g.ButtonCount()
+	for b := 0; b < n && b < ButtonCount; b++ {
+		if (rawGamepadState{g}).Button(b) {
+			g.m.Lock()
+			g.buttonsPressedSinceRead[b] = true
+			g.m.Unlock()
+		}
+	}
+	for b := gamepaddb.StandardButton(0); b <= gamepaddb.StandardButtonMax; b++ {
+		if g.isStandardButtonPressed(b, rawGamepadState{g}) {
+			g.m.Lock()
+			g.standardButtonsPressedSinceRead[b] = true
+			g.m.Unlock()
+		}
+	}
+}
+
+func (g *Gamepad) readPresses(buffered bool) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	g.buttonsRead = [ButtonCount]bool{}
+	g.standardButtonsRead = [gamepaddb.StandardButtonMax + 1]bool{}
+	if buffered {
+		g.buttonsRead = g.buttonsPressedSinceRead
+		g.standardButtonsRead = g.standardButtonsPressedSinceRead
+	}
+	g.buttonsPressedSinceRead = [ButtonCount]bool{}
+	g.standardButtonsPressedSinceRead = [gamepaddb.StandardButtonMax + 1]bool{}
+}
+
 // Name is concurrent-safe.
 func (g *Gamepad) Name() string {
 	// This is immutable and doesn't have to be protected by a mutex.
@@ -272,6 +418,24 @@ func (g *Gamepad) SDLID() string {
 	return g.sdlID
 }
 
+// MappingName is concurrent-safe.
+func (g *Gamepad) MappingName() (string, bool) {
+	// The mappings are protected by gamepaddb.
+	if !gamepaddb.HasStandardLayoutMapping(g.sdlID) {
+		return "", false
+	}
+	return gamepaddb.Name(g.sdlID), true
+}
+
+// Transport is concurrent-safe.
+func (g *Gamepad) Transport() string {
+	// This is immutable and doesn't have to be protected by a mutex.
+	if g.transport == "" {
+		return TransportUnknown
+	}
+	return g.transport
+}
+
 // AxisCount is concurrent-safe.
 func (g *Gamepad) AxisCount() int {
 	g.m.Lock()
@@ -309,6 +473,9 @@ func (g *Gamepad) Button(button int) bool {
 	g.m.Lock()
 	defer g.m.Unlock()
 
+	if button >= 0 && button < ButtonCount && g.buttonsRead[button] {
+		return true
+	}
 	return g.native.isButtonPressed(button)
 }
 
@@ -377,8 +544,20 @@ func (g *Gamepad) StandardButtonValue(button gamepaddb.StandardButton) float64 {
 
 // IsStandardButtonPressed is concurrent-safe.
 func (g *Gamepad) IsStandardButtonPressed(button gamepaddb.StandardButton) bool {
+	if button >= 0 && button <= gamepaddb.StandardButtonMax {
+		g.m.Lock()
+		read := g.standardButtonsRead[button]
+		g.m.Unlock()
+		if read {
+			return true
+		}
+	}
+	return g.isStandardButtonPressed(button, g)
+}
+
+func (g *Gamepad) isStandardButtonPressed(button gamepaddb.StandardButton, state gamepaddb.GamepadState) bool {
 	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
-		return gamepaddb.IsButtonPressed(g.sdlID, button, g)
+		return gamepaddb.IsButtonPressed(g.sdlID, button, state)
 	}
 	if m := g.native.standardButtonInOwnMapping(button); m != nil {
 		return m.Pressed()
@@ -386,6 +565,14 @@ func (g *Gamepad) IsStandardButtonPressed(button gamepaddb.StandardButton) bool
 	return false
 }
 
+// IsVibrationSupported is concurrent-safe.
+func (g *Gamepad) IsVibrationSupported() bool {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.native.isVibrationSupported()
+}
+
 // Vibrate is concurrent-safe.
 func (g *Gamepad) Vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	g.m.Lock()
@@ -393,3 +580,43 @@ func (g *Gamepad) Vibrate(duration time.Duration, strongMagnitude float64, weakM
 
 	g.native.vibrate(duration, strongMagnitude, weakMagnitude)
 }
+
+// IsLightSupported is concurrent-safe.
+func (g *Gamepad) IsLightSupported() bool {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.native.isLightSupported()
+}
+
+// SetLightColor is concurrent-safe.
+func (g *Gamepad) SetLightColor(red, green, blue uint8) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	g.native.setLightColor(red, green, blue)
+}
+
+// IsMotionSupported is concurrent-safe.
+func (g *Gamepad) IsMotionSupported() bool {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.native.isMotionSupported()
+}
+
+// Gyroscope is concurrent-safe.
+func (g *Gamepad) Gyroscope() (x, y, z float64) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.native.gyroscope()
+}
+
+// Accelerometer is concurrent-safe.
+func (g *Gamepad) Accelerometer() (x, y, z float64) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.native.accelerometer()
+}
@@ -99,6 +99,29 @@ func (g *nativeGamepadImpl) hatState(hat int) int {
 	return g.hats[hat]
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	return false
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	// TODO: Implement this (#1452)
 }
+
+func (g *nativeGamepadImpl) isLightSupported() bool {
+	return false
+}
+
+func (g *nativeGamepadImpl) setLightColor(red, green, blue uint8) {
+}
+
+func (g *nativeGamepadImpl) isMotionSupported() bool {
+	return false
+}
+
+func (g *nativeGamepadImpl) gyroscope() (x, y, z float64) {
+	return 0, 0, 0
+}
+
+func (g *nativeGamepadImpl) accelerometer() (x, y, z float64) {
+	return 0, 0, 0
+}
@@ -127,6 +127,47 @@ func ebitenGamepadRemovalCallback(ctx unsafe.Pointer, res _IOReturn, sender unsa
 	n.devicesToRemove = append(n.devicesToRemove, device)
 }
 
+func ebitenGamepadInputReportCallback(ctx unsafe.Pointer, res _IOReturn, sender unsafe.Pointer, typ _IOHIDReportType, reportID uint32, report *byte, reportLength _CFIndex) {
+	if res != kIOReturnSuccess {
+		return
+	}
+	n := theInputReportGamepads.get(_IOHIDDeviceRef(sender))
+	if n == nil {
+		return
+	}
+	n.motion.updateWithReport(n.playStation, unsafe.Slice(report, reportLength))
+}
+
+// inputReportGamepads maps devices to the gamepads receiving input reports.
+// The input report callback is called without knowing the gamepads, so the gamepads are looked up by devices.
+type inputReportGamepads struct {
+	gamepads map[_IOHIDDeviceRef]*nativeGamepadImpl
+	m        sync.Mutex
+}
+
+var theInputReportGamepads inputReportGamepads
+
+func (i *inputReportGamepads) add(device _IOHIDDeviceRef, gamepad *nativeGamepadImpl) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	if i.gamepads == nil {
+		i.gamepads = map[_IOHIDDeviceRef]*nativeGamepadImpl{}
+	}
+	i.gamepads[device] = gamepad
+}
+
+func (i *inputReportGamepads) remove(device _IOHIDDeviceRef) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	delete(i.gamepads, device)
+}
+
+func (i *inputReportGamepads) get(device _IOHIDDeviceRef) *nativeGamepadImpl {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.gamepads[device]
+}
+
 func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 	n := theGamepads.native.(*nativeGamepadsImpl)
 	n.devicesM.Lock()
@@ -139,6 +180,7 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 		gamepads.remove(func(g *Gamepad) bool {
 			return g.native.(*nativeGamepadImpl).device == device
 		})
+		theInputReportGamepads.remove(device)
 	}
 	g.devicesToAdd = g.devicesToAdd[:0]
 	g.devicesToRemove = g.devicesToRemove[:0]
@@ -174,6 +216,19 @@ func (g *nativeGamepadsImpl) addDevice(device _IOHIDDeviceRef, gamepads *gamepad
 		_CFNumberGetValue(_CFNumberRef(prop), kCFNumberSInt32Type, unsafe.Pointer(&version))
 	}
 
+	transport := TransportUnknown
+	if prop := _IOHIDDeviceGetProperty(device, _CFStringCreateWithCString(kCFAllocatorDefault, kIOHIDTransportKey, kCFStringEncodingUTF8)); prop != 0 {
+		var cstr [256]byte
+		_CFStringGetCString(_CFStringRef(prop), cstr[:], kCFStringEncodingUTF8)
+		switch t := strings.TrimRight(string(cstr[:]), "\x00"); {
+		case t == "USB":
+			transport = TransportUSB
+		case strings.HasPrefix(t, "Bluetooth"):
+			// This includes "Bluetooth Low Energy".
+			transport = TransportBluetooth
+		}
+	}
+
 	var sdlID string
 	if vendor != 0 && product != 0 {
 		sdlID = fmt.Sprintf("03000000%02x%02x0000%02x%02x0000%02x%02x0000",
@@ -193,10 +248,19 @@ func (g *nativeGamepadsImpl) addDevice(device _IOHIDDeviceRef, gamepads *gamepad
 	defer _CFRelease(_CFTypeRef(elements))
 
 	n := &nativeGamepadImpl{
-		device: device,
+		device:      device,
+		playStation: playStationControllerFromIDs(vendor, product),
+		bluetooth:   transport == TransportBluetooth,
+	}
+	if n.playStation != playStationControllerNone {
+		// Motion data is not exposed as HID elements, so read raw input reports.
+		n.inputReport = make([]byte, 128)
+		theInputReportGamepads.add(device, n)
+		_IOHIDDeviceRegisterInputReportCallback(device, &n.inputReport[0], _CFIndex(len(n.inputReport)), ebitenGamepadInputReportCallback, nil)
 	}
 	gp := gamepads.add(name, sdlID)
 	gp.native = n
+	gp.transport = transport
 
 	for i := _CFIndex(0); i < _CFArrayGetCount(elements); i++ {
 		native := (_IOHIDElementRef)(_CFArrayGetValueAtIndex(elements, i))
@@ -306,6 +370,14 @@ type nativeGamepadImpl struct {
 	buttons elements
 	hats    elements
 
+	// playStation is the kind of the PlayStation controller, whose light bar can be controlled by HID output reports.
+	playStation playStationController
+	bluetooth   bool
+
+	// inputReport is the buffer for input reports, which is used by IOKit.
+	inputReport []byte
+	motion      motionState
+
 	axisValues   []float64
 	buttonValues []bool
 	hatValues    []int
@@ -335,8 +407,10 @@ func (g *nativeGamepadImpl) update(gamepads *gamepads) error {
 	}
 	g.hatValues = g.hatValues[:len(g.hats)]
 
-	for i, a := range g.axes {
-		raw := g.elementValue(&a)
+	for i := range g.axes {
+		// Update the element in the slice so that the expanded range is kept (calibration).
+		a := &g.axes[i]
+		raw := g.elementValue(a)
 		if raw < a.minimum {
 			a.minimum = raw
 		}
@@ -375,6 +449,24 @@ func (g *nativeGamepadImpl) update(gamepads *gamepads) error {
 	return nil
 }
 
+func (g *nativeGamepadImpl) axisRanges() []axisRange {
+	ranges := make([]axisRange, len(g.axes))
+	for i, a := range g.axes {
+		ranges[i] = axisRange{
+			minimum: a.minimum,
+			maximum: a.maximum,
+		}
+	}
+	return ranges
+}
+
+func (g *nativeGamepadImpl) setAxisRanges(ranges []axisRange) {
+	for i, r := range ranges {
+		g.axes[i].minimum = r.minimum
+		g.axes[i].maximum = r.maximum
+	}
+}
+
 func (g *nativeGamepadImpl) hasOwnStandardLayoutMapping() bool {
 	return false
 }
@@ -427,6 +519,35 @@ func (g *nativeGamepadImpl) hatState(hat int) int {
 	return g.hatValues[hat]
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	return false
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	// TODO: Implement this (#1452)
 }
+
+func (g *nativeGamepadImpl) isLightSupported() bool {
+	return g.playStation != playStationControllerNone
+}
+
+func (g *nativeGamepadImpl) isMotionSupported() bool {
+	return g.playStation != playStationControllerNone && g.motion.isReceived()
+}
+
+func (g *nativeGamepadImpl) gyroscope() (x, y, z float64) {
+	return g.motion.gyroscope()
+}
+
+func (g *nativeGamepadImpl) accelerometer() (x, y, z float64) {
+	return g.motion.accelerometer()
+}
+
+func (g *nativeGamepadImpl) setLightColor(red, green, blue uint8) {
+	report := g.playStation.lightReport(g.bluetooth, red, green, blue)
+	if report == nil {
+		return
+	}
+	// The result is ignored as the device might be disconnected.
+	_ = _IOHIDDeviceSetReport(g.device, kIOHIDReportTypeOutput, _CFIndex(report[0]), &report[0], _CFIndex(len(report)))
+}
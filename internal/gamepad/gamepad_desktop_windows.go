@@ -106,6 +106,7 @@ type nativeGamepadsDesktop struct {
 	procDirectInput8Create    uintptr
 	procXInputGetCapabilities uintptr
 	procXInputGetState        uintptr
+	procXInputSetState        uintptr
 
 	origWndProc         uintptr
 	wndProcCallback     uintptr
@@ -169,6 +170,13 @@ func (g *nativeGamepadsDesktop) init(gamepads *gamepads) error {
 				}
 				g.procXInputGetState = p
 			}
+			{
+				p, err := windows.GetProcAddress(h, "XInputSetState")
+				if err != nil {
+					return err
+				}
+				g.procXInputSetState = p
+			}
 			break
 		}
 	}
@@ -224,6 +232,16 @@ func (g *nativeGamepadsDesktop) xinputGetState(dwUserIndex uint32, pState *_XINP
 	return nil
 }
 
+func (g *nativeGamepadsDesktop) xinputSetState(dwUserIndex uint32, pVibration *_XINPUT_VIBRATION) error {
+	// XInputSetState doesn't call SetLastError and returns an error code directly.
+	r, _, _ := syscall.Syscall(g.procXInputSetState, 2,
+		uintptr(dwUserIndex), uintptr(unsafe.Pointer(pVibration)), 0)
+	if e := syscall.Errno(uint32(r)); e != windows.ERROR_SUCCESS {
+		return fmt.Errorf("gamepad: XInputSetState failed: %w", e)
+	}
+	return nil
+}
+
 func (g *nativeGamepadsDesktop) detectConnection(gamepads *gamepads) error {
 	if g.dinput8 != 0 {
 		if g.enumDevicesCallback == 0 {
@@ -280,7 +298,9 @@ func (g *nativeGamepadsDesktop) detectConnection(gamepads *gamepads) error {
 
 			gp := gamepads.add(name, sdlID)
 			gp.native = &nativeGamepadDesktop{
-				xinputIndex: i,
+				xinputIndex:     i,
+				xinputVibration: xic.vibration.wLeftMotorSpeed != 0 || xic.vibration.wRightMotorSpeed != 0,
+				native:          g,
 			}
 		}
 	}
@@ -373,7 +393,9 @@ func (g *nativeGamepadsDesktop) dinput8EnumDevicesCallback(lpddi *_DIDEVICEINSTA
 
 	name := windows.UTF16ToString(lpddi.tszInstanceName[:])
 	var sdlID string
+	playStation := playStationControllerNone
 	if string(lpddi.guidProduct.Data4[2:8]) == "PIDVID" {
+		playStation = playStationControllerFromIDs(lpddi.guidProduct.Data1&0xffff, lpddi.guidProduct.Data1>>16)
 		// This seems different from the current SDL implementation.
 		// Probably guidProduct includes the vendor and the product information, but this works.
 		// From the game controller database, the 'version' part seems always 0.
@@ -391,6 +413,25 @@ func (g *nativeGamepadsDesktop) dinput8EnumDevicesCallback(lpddi *_DIDEVICEINSTA
 			bs[0], bs[1], bs[2], bs[3], bs[4], bs[5], bs[6], bs[7], bs[8], bs[9], bs[10], bs[11])
 	}
 
+	// The HID device path is used to write HID output reports, e.g. to change the light bar color.
+	var hidPath string
+	if playStation != playStationControllerNone {
+		dipgp := _DIPROPGUIDANDPATH{
+			diph: _DIPROPHEADER{
+				dwSize:       uint32(unsafe.Sizeof(_DIPROPGUIDANDPATH{})),
+				dwHeaderSize: uint32(unsafe.Sizeof(_DIPROPHEADER{})),
+				dwHow:        _DIPH_DEVICE,
+			},
+		}
+		// Ignore the error. The light bar is just not available in this case.
+		if err := device.GetProperty(_DIPROP_GUIDANDPATH, &dipgp.diph); err == nil {
+			hidPath = windows.UTF16ToString(dipgp.wszPath[:])
+		}
+		if hidPath == "" {
+			playStation = playStationControllerNone
+		}
+	}
+
 	gp := gamepads.add(name, sdlID)
 	gp.native = &nativeGamepadDesktop{
 		dinputDevice:  device,
@@ -399,6 +440,8 @@ func (g *nativeGamepadsDesktop) dinput8EnumDevicesCallback(lpddi *_DIDEVICEINSTA
 		dinputAxes:    make([]float64, ctx.axisCount+ctx.sliderCount),
 		dinputButtons: make([]bool, ctx.buttonCount),
 		dinputHats:    make([]int, ctx.povCount),
+		playStation:   playStation,
+		hidPath:       hidPath,
 	}
 
 	return _DIENUM_CONTINUE
@@ -567,8 +610,30 @@ type nativeGamepadDesktop struct {
 	dinputButtons []bool
 	dinputHats    []int
 
-	xinputIndex int
-	xinputState _XINPUT_STATE
+	xinputIndex     int
+	xinputState     _XINPUT_STATE
+	xinputVibration bool
+
+	native *nativeGamepadsDesktop
+
+	vib    bool
+	vibEnd time.Time
+
+	// playStation is the kind of the PlayStation controller, whose light bar can be controlled by HID output reports.
+	playStation playStationController
+	hidPath     string
+
+	// hidFile is the file handle of hidPath. hidFile is opened lazily.
+	hidFile               windows.Handle
+	hidOutputReportLength int
+	hidBluetooth          bool
+
+	// motionFile is another file handle of hidPath to read input reports on a different goroutine.
+	// motionFile is opened lazily.
+	motionFile        windows.Handle
+	motionUnavailable bool
+	motionStopped     int32
+	motion            motionState
 }
 
 func (*nativeGamepadDesktop) hasOwnStandardLayoutMapping() bool {
@@ -599,6 +664,16 @@ func (g *nativeGamepadDesktop) update(gamepads *gamepads) (err error) {
 		if g.dinputDevice != nil {
 			g.dinputDevice.Release()
 		}
+		if g.hidFile != 0 {
+			_ = windows.CloseHandle(g.hidFile)
+			g.hidFile = 0
+		}
+		if g.motionFile != 0 {
+			// The handle is closed by the reading goroutine.
+			atomic.StoreInt32(&g.motionStopped, 1)
+			_ = windows.CancelIoEx(g.motionFile, nil)
+			g.motionFile = 0
+		}
 	}()
 
 	if g.usesDInput() {
@@ -683,6 +758,11 @@ func (g *nativeGamepadDesktop) update(gamepads *gamepads) (err error) {
 				hi++
 			}
 		}
+
+		if g.vib && time.Now().Sub(g.vibEnd) >= 0 {
+			g.writeHIDOutputReport(g.playStation.rumbleReport(g.hidBluetooth, 0, 0))
+			g.vib = false
+		}
 		return nil
 	}
 
@@ -695,6 +775,12 @@ func (g *nativeGamepadDesktop) update(gamepads *gamepads) (err error) {
 		return nil
 	}
 	g.xinputState = state
+
+	if g.vib && time.Now().Sub(g.vibEnd) >= 0 {
+		// Ignore the error as the gamepad might be disconnected just now.
+		_ = g.native.xinputSetState(uint32(g.xinputIndex), &_XINPUT_VIBRATION{})
+		g.vib = false
+	}
 	return nil
 }
 
@@ -793,6 +879,201 @@ func (g *nativeGamepadDesktop) hatState(hat int) int {
 	return v
 }
 
+func (g *nativeGamepadDesktop) isVibrationSupported() bool {
+	if g.usesDInput() {
+		// DualShock and DualSense are vibrated via HID output reports.
+		return g.playStation != playStationControllerNone
+	}
+	return g.xinputVibration
+}
+
 func (g *nativeGamepadDesktop) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
-	// TODO: Implement this (#1452)
+	if !g.isVibrationSupported() {
+		return
+	}
+
+	if g.usesDInput() {
+		g.vibrateWithHID(duration, strongMagnitude, weakMagnitude)
+		return
+	}
+
+	if strongMagnitude <= 0 && weakMagnitude <= 0 {
+		g.vib = false
+		_ = g.native.xinputSetState(uint32(g.xinputIndex), &_XINPUT_VIBRATION{})
+		return
+	}
+
+	// The left motor is the low-frequency rumble motor, and the right motor is the high-frequency rumble motor.
+	g.vib = true
+	g.vibEnd = time.Now().Add(duration)
+	_ = g.native.xinputSetState(uint32(g.xinputIndex), &_XINPUT_VIBRATION{
+		wLeftMotorSpeed:  magnitudeToXInputMotorSpeed(strongMagnitude),
+		wRightMotorSpeed: magnitudeToXInputMotorSpeed(weakMagnitude),
+	})
+}
+
+func (g *nativeGamepadDesktop) vibrateWithHID(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
+	if !g.ensureHIDFile() {
+		return
+	}
+
+	if strongMagnitude <= 0 && weakMagnitude <= 0 {
+		g.vib = false
+		g.writeHIDOutputReport(g.playStation.rumbleReport(g.hidBluetooth, 0, 0))
+		return
+	}
+
+	g.vib = true
+	g.vibEnd = time.Now().Add(duration)
+	g.writeHIDOutputReport(g.playStation.rumbleReport(g.hidBluetooth, magnitudeToPlayStationMotorSpeed(strongMagnitude), magnitudeToPlayStationMotorSpeed(weakMagnitude)))
+}
+
+func (g *nativeGamepadDesktop) isLightSupported() bool {
+	return g.playStation != playStationControllerNone
+}
+
+func (g *nativeGamepadDesktop) setLightColor(red, green, blue uint8) {
+	if !g.isLightSupported() {
+		return
+	}
+	if !g.ensureHIDFile() {
+		return
+	}
+	g.writeHIDOutputReport(g.playStation.lightReport(g.hidBluetooth, red, green, blue))
+}
+
+// ensureHIDFile opens the HID file if needed, and reports whether the HID output reports are available.
+func (g *nativeGamepadDesktop) ensureHIDFile() bool {
+	if g.hidFile != 0 {
+		return true
+	}
+	if err := g.openHIDFile(); err != nil {
+		// The output reports are not available, e.g. when the device is exclusively opened by another application.
+		g.playStation = playStationControllerNone
+		return false
+	}
+	return true
+}
+
+// writeHIDOutputReport writes an HID output report. g.hidFile must be opened.
+func (g *nativeGamepadDesktop) writeHIDOutputReport(report []byte) {
+	if len(report) == 0 || len(report) > g.hidOutputReportLength {
+		return
+	}
+	// WriteFile requires the exact length of the output report.
+	report = append(report, make([]byte, g.hidOutputReportLength-len(report))...)
+	// The result is ignored as the device might be disconnected.
+	_ = windows.WriteFile(g.hidFile, report, nil, nil)
+}
+
+func (g *nativeGamepadDesktop) openHIDFile() error {
+	path, err := windows.UTF16PtrFromString(g.hidPath)
+	if err != nil {
+		return err
+	}
+	f, err := windows.CreateFile(path, windows.GENERIC_READ|windows.GENERIC_WRITE, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	preparsedData, err := _HidD_GetPreparsedData(f)
+	if err != nil {
+		_ = windows.CloseHandle(f)
+		return err
+	}
+	defer _HidD_FreePreparsedData(preparsedData)
+
+	caps, err := _HidP_GetCaps(preparsedData)
+	if err != nil {
+		_ = windows.CloseHandle(f)
+		return err
+	}
+
+	g.hidFile = f
+	g.hidOutputReportLength = int(caps.OutputReportByteLength)
+	// The output report via Bluetooth is longer than the one via USB.
+	g.hidBluetooth = g.hidOutputReportLength != g.playStation.usbOutputReportSize()
+	return nil
+}
+
+func (g *nativeGamepadDesktop) isMotionSupported() bool {
+	if g.playStation == playStationControllerNone || g.motionUnavailable {
+		return false
+	}
+	if g.motionFile == 0 {
+		if err := g.startReadingMotion(); err != nil {
+			// The input reports are not available, e.g. when the device is exclusively opened by another application.
+			g.motionUnavailable = true
+			return false
+		}
+	}
+	return g.motion.isReceived()
+}
+
+func (g *nativeGamepadDesktop) gyroscope() (x, y, z float64) {
+	if !g.isMotionSupported() {
+		return 0, 0, 0
+	}
+	return g.motion.gyroscope()
+}
+
+func (g *nativeGamepadDesktop) accelerometer() (x, y, z float64) {
+	if !g.isMotionSupported() {
+		return 0, 0, 0
+	}
+	return g.motion.accelerometer()
+}
+
+// startReadingMotion starts a goroutine to read input reports including motion data.
+// The input reports are read with another handle so that reading doesn't block writing output reports.
+func (g *nativeGamepadDesktop) startReadingMotion() error {
+	path, err := windows.UTF16PtrFromString(g.hidPath)
+	if err != nil {
+		return err
+	}
+	f, err := windows.CreateFile(path, windows.GENERIC_READ, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	preparsedData, err := _HidD_GetPreparsedData(f)
+	if err != nil {
+		_ = windows.CloseHandle(f)
+		return err
+	}
+	caps, err := _HidP_GetCaps(preparsedData)
+	_HidD_FreePreparsedData(preparsedData)
+	if err != nil {
+		_ = windows.CloseHandle(f)
+		return err
+	}
+
+	g.motionFile = f
+	controller := g.playStation
+	stopped := &g.motionStopped
+	motion := &g.motion
+	go func() {
+		defer func() {
+			_ = windows.CloseHandle(f)
+		}()
+		buf := make([]byte, caps.InputReportByteLength)
+		for atomic.LoadInt32(stopped) == 0 {
+			var n uint32
+			if err := windows.ReadFile(f, buf, &n, nil); err != nil {
+				return
+			}
+			motion.updateWithReport(controller, buf[:n])
+		}
+	}()
+	return nil
+}
+
+func magnitudeToXInputMotorSpeed(magnitude float64) uint16 {
+	if magnitude <= 0 {
+		return 0
+	}
+	if magnitude >= 1 {
+		return 0xffff
+	}
+	return uint16(magnitude * 0xffff)
 }
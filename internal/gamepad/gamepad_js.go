@@ -180,6 +180,12 @@ func (g *nativeGamepadImpl) hatState(hat int) int {
 	return hatCentered
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	// vibrationActuator is available on Chrome.
+	va := g.value.Get("vibrationActuator")
+	return va.Truthy() && va.Get("playEffect").Truthy()
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	// vibrationActuator is available on Chrome.
 	if va := g.value.Get("vibrationActuator"); va.Truthy() {
@@ -208,3 +214,22 @@ func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude floa
 		return
 	}
 }
+
+func (g *nativeGamepadImpl) isLightSupported() bool {
+	return false
+}
+
+func (g *nativeGamepadImpl) setLightColor(red, green, blue uint8) {
+}
+
+func (g *nativeGamepadImpl) isMotionSupported() bool {
+	return false
+}
+
+func (g *nativeGamepadImpl) gyroscope() (x, y, z float64) {
+	return 0, 0, 0
+}
+
+func (g *nativeGamepadImpl) accelerometer() (x, y, z float64) {
+	return 0, 0, 0
+}
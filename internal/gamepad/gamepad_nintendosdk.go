@@ -171,6 +171,29 @@ func (*nativeGamepadImpl) hatState(hat int) int {
 	return hatCentered
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	return true
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	C.ebitengine_VibrateGamepad(C.int(g.id), C.double(float64(duration)/float64(time.Second)), C.double(strongMagnitude), C.double(weakMagnitude))
 }
+
+func (g *nativeGamepadImpl) isLightSupported() bool {
+	return false
+}
+
+func (g *nativeGamepadImpl) setLightColor(red, green, blue uint8) {
+}
+
+func (g *nativeGamepadImpl) isMotionSupported() bool {
+	return false
+}
+
+func (g *nativeGamepadImpl) gyroscope() (x, y, z float64) {
+	return 0, 0, 0
+}
+
+func (g *nativeGamepadImpl) accelerometer() (x, y, z float64) {
+	return 0, 0, 0
+}
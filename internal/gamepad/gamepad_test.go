@@ -0,0 +1,136 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"testing"
+)
+
+func (g *gamepads) idOf(gamepad *Gamepad) ID {
+	for i, gp := range g.gamepads {
+		if gp == gamepad {
+			return ID(i)
+		}
+	}
+	return -1
+}
+
+func TestGamepadIDReuse(t *testing.T) {
+	const (
+		sdlIDA = "030000005e0400008e02000000000000"
+		sdlIDB = "030000004c050000cc09000000000000"
+		sdlIDC = "03000000de2800000112000000000000"
+	)
+
+	var g gamepads
+	a := g.add("A", sdlIDA)
+	b := g.add("B", sdlIDB)
+	if got, want := g.idOf(a), ID(0); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+	if got, want := g.idOf(b), ID(1); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// Disconnect both, and reconnect them in the reversed order.
+	g.remove(func(gp *Gamepad) bool {
+		return true
+	})
+	b = g.add("B", sdlIDB)
+	a = g.add("A", sdlIDA)
+	if got, want := g.idOf(a), ID(0); got != want {
+		t.Errorf("A after reconnecting: got: %d, want: %d", got, want)
+	}
+	if got, want := g.idOf(b), ID(1); got != want {
+		t.Errorf("B after reconnecting: got: %d, want: %d", got, want)
+	}
+
+	// A new gamepad doesn't take the ID reserved for a disconnected gamepad.
+	g.remove(func(gp *Gamepad) bool {
+		return gp == a
+	})
+	c := g.add("C", sdlIDC)
+	if got, want := g.idOf(c), ID(2); got != want {
+		t.Errorf("C: got: %d, want: %d", got, want)
+	}
+
+	// A reconnected gamepad gets its reserved ID back.
+	a = g.add("A", sdlIDA)
+	if got, want := g.idOf(a), ID(0); got != want {
+		t.Errorf("A after C was connected: got: %d, want: %d", got, want)
+	}
+
+	if got, want := g.count(), 3; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestGamepadPlayback(t *testing.T) {
+	var g gamepads
+	// A connected gamepad is hidden during the playback even though no recorded gamepad has its ID.
+	live := g.add("L", "030000005e0400008e02000000000000")
+	recorded := []State{
+		{
+			ID:             1,
+			Name:           "A",
+			Axes:           []float64{0.5, -1},
+			ButtonValues:   []float64{1, 0, 0.25},
+			ButtonsPressed: []bool{true, false, false},
+			Hats:           []int{hatLeftUp},
+		},
+	}
+	g.startPlayback(recorded)
+
+	if got, want := g.count(), 1; got != want {
+		t.Fatalf("count: got: %d, want: %d", got, want)
+	}
+	if g.get(0) != nil {
+		t.Errorf("get(0) must return nil during the playback")
+	}
+	gp := g.get(1)
+	if gp == nil {
+		t.Fatal("get(1) must not return nil")
+	}
+	if got, want := gp.Axis(0), 0.5; got != want {
+		t.Errorf("Axis(0): got: %f, want: %f", got, want)
+	}
+	if !gp.Button(0) || gp.Button(1) {
+		t.Errorf("Button: got: %t, %t, want: true, false", gp.Button(0), gp.Button(1))
+	}
+	if got, want := gp.Hat(0), hatLeftUp; got != want {
+		t.Errorf("Hat(0): got: %d, want: %d", got, want)
+	}
+
+	// The snapshots of the played back gamepads must be the same as the given ones.
+	states := g.appendStates(nil)
+	if len(states) != 1 {
+		t.Fatalf("len(states): got: %d, want: 1", len(states))
+	}
+	if got, want := states[0].ID, recorded[0].ID; got != want {
+		t.Errorf("ID: got: %d, want: %d", got, want)
+	}
+	if got, want := states[0].ButtonValues[2], recorded[0].ButtonValues[2]; got != want {
+		t.Errorf("ButtonValues[2]: got: %f, want: %f", got, want)
+	}
+
+	// The actual gamepads are visible after the playback stops.
+	g.stopPlayback()
+	if got, want := g.count(), 1; got != want {
+		t.Errorf("count after stopPlayback: got: %d, want: %d", got, want)
+	}
+	if g.get(0) != live {
+		t.Errorf("get(0) after stopPlayback must return the connected gamepad")
+	}
+}
@@ -243,6 +243,10 @@ func (n *nativeGamepadXbox) hatState(hat int) int {
 	return 0
 }
 
+func (n *nativeGamepadXbox) isVibrationSupported() bool {
+	return true
+}
+
 func (n *nativeGamepadXbox) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	if strongMagnitude <= 0 && weakMagnitude <= 0 {
 		n.vib = false
@@ -259,3 +263,22 @@ func (n *nativeGamepadXbox) vibrate(duration time.Duration, strongMagnitude floa
 		highFrequency: float32(weakMagnitude),
 	}, 0)
 }
+
+func (n *nativeGamepadXbox) isLightSupported() bool {
+	return false
+}
+
+func (n *nativeGamepadXbox) setLightColor(red, green, blue uint8) {
+}
+
+func (n *nativeGamepadXbox) isMotionSupported() bool {
+	return false
+}
+
+func (n *nativeGamepadXbox) gyroscope() (x, y, z float64) {
+	return 0, 0, 0
+}
+
+func (n *nativeGamepadXbox) accelerometer() (x, y, z float64) {
+	return 0, 0, 0
+}
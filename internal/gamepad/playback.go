@@ -0,0 +1,299 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
+)
+
+// State is a snapshot of a gamepad, which is used to record and play back the input.
+type State struct {
+	ID    ID
+	Name  string
+	SDLID string
+
+	Axes           []float64
+	ButtonValues   []float64
+	ButtonsPressed []bool
+	Hats           []int
+
+	// OwnStandardLayout reports whether the gamepad has its own standard layout mapping, e.g. on browsers.
+	// StandardAxes and StandardButtons are recorded only in this case.
+	// Otherwise, the standard layout is calculated from the raw values with the gamepad database.
+	OwnStandardLayout bool
+	StandardAxes      []StandardInputState
+	StandardButtons   []StandardInputState
+}
+
+// StandardInputState is a snapshot of a standard axis or a standard button in the gamepad's own mapping.
+type StandardInputState struct {
+	Available bool
+	Pressed   bool
+
+	// Value is normalized to the range [0, 1].
+	Value float64
+}
+
+// AppendStates appends the snapshots of the current gamepads to states, and returns the extended buffer.
+//
+// AppendStates is concurrent-safe.
+func AppendStates(states []State) []State {
+	return theGamepads.appendStates(states)
+}
+
+// StartPlayback replaces the gamepads with the given snapshots until StopPlayback is called.
+// StartPlayback can be called every tick to update the snapshots.
+//
+// StartPlayback is concurrent-safe.
+func StartPlayback(states []State) {
+	theGamepads.startPlayback(states)
+}
+
+// StopPlayback stops the playback and makes the actual gamepads available again.
+//
+// StopPlayback is concurrent-safe.
+func StopPlayback() {
+	theGamepads.stopPlayback()
+}
+
+// visibleGamepads returns the actual gamepads, or the played back gamepads if a playback is running.
+// g.m must be locked.
+func (g *gamepads) visibleGamepads() []*Gamepad {
+	if g.playing {
+		return g.playback
+	}
+	return g.gamepads
+}
+
+func (g *gamepads) appendStates(states []State) []State {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	for i, gp := range g.visibleGamepads() {
+		if gp == nil {
+			continue
+		}
+		states = append(states, gp.state(ID(i)))
+	}
+	return states
+}
+
+func (g *gamepads) startPlayback(states []State) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	g.playing = true
+	for i := range g.playback {
+		g.playback[i] = nil
+	}
+	g.playback = g.playback[:0]
+	for _, s := range states {
+		if s.ID < 0 {
+			continue
+		}
+		for int(s.ID) >= len(g.playback) {
+			g.playback = append(g.playback, nil)
+		}
+		g.playback[s.ID] = &Gamepad{
+			name:   s.Name,
+			sdlID:  s.SDLID,
+			native: &playbackGamepad{state: s},
+		}
+	}
+}
+
+func (g *gamepads) stopPlayback() {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	g.playing = false
+	g.playback = nil
+}
+
+func (g *Gamepad) state(id ID) State {
+	s := State{
+		ID:    id,
+		Name:  g.name,
+		SDLID: g.sdlID,
+	}
+
+	n := g.AxisCount()
+	s.Axes = make([]float64, n)
+	for i := 0; i < n; i++ {
+		s.Axes[i] = g.Axis(i)
+	}
+
+	n = g.ButtonCount()
+	s.ButtonValues = make([]float64, n)
+	s.ButtonsPressed = make([]bool, n)
+	for i := 0; i < n; i++ {
+		s.ButtonValues[i] = g.buttonValue(i)
+		s.ButtonsPressed[i] = g.Button(i)
+	}
+
+	n = g.HatCount()
+	s.Hats = make([]int, n)
+	for i := 0; i < n; i++ {
+		s.Hats[i] = g.Hat(i)
+	}
+
+	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
+		return s
+	}
+
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if !g.native.hasOwnStandardLayoutMapping() {
+		return s
+	}
+	s.OwnStandardLayout = true
+	s.StandardAxes = make([]StandardInputState, gamepaddb.StandardAxisMax+1)
+	for a := range s.StandardAxes {
+		if m := g.native.standardAxisInOwnMapping(gamepaddb.StandardAxis(a)); m != nil {
+			s.StandardAxes[a] = StandardInputState{
+				Available: true,
+				Pressed:   m.Pressed(),
+				Value:     m.Value(),
+			}
+		}
+	}
+	s.StandardButtons = make([]StandardInputState, gamepaddb.StandardButtonMax+1)
+	for b := range s.StandardButtons {
+		if m := g.native.standardButtonInOwnMapping(gamepaddb.StandardButton(b)); m != nil {
+			s.StandardButtons[b] = StandardInputState{
+				Available: true,
+				Pressed:   m.Pressed() || g.standardButtonsRead[b],
+				Value:     m.Value(),
+			}
+		}
+	}
+	return s
+}
+
+func (g *Gamepad) buttonValue(button int) float64 {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.native.buttonValue(button)
+}
+
+// playbackGamepad is a nativeGamepad with a recorded state.
+// A played back gamepad cannot vibrate, change its light, or provide motion data.
+type playbackGamepad struct {
+	state State
+}
+
+type playbackMappingInput struct {
+	state StandardInputState
+}
+
+func (p playbackMappingInput) Pressed() bool {
+	return p.state.Pressed
+}
+
+func (p playbackMappingInput) Value() float64 {
+	return p.state.Value
+}
+
+func (*playbackGamepad) update(gamepads *gamepads) error {
+	return nil
+}
+
+func (p *playbackGamepad) hasOwnStandardLayoutMapping() bool {
+	return p.state.OwnStandardLayout
+}
+
+func (p *playbackGamepad) standardAxisInOwnMapping(axis gamepaddb.StandardAxis) mappingInput {
+	if axis < 0 || int(axis) >= len(p.state.StandardAxes) || !p.state.StandardAxes[axis].Available {
+		return nil
+	}
+	return playbackMappingInput{state: p.state.StandardAxes[axis]}
+}
+
+func (p *playbackGamepad) standardButtonInOwnMapping(button gamepaddb.StandardButton) mappingInput {
+	if button < 0 || int(button) >= len(p.state.StandardButtons) || !p.state.StandardButtons[button].Available {
+		return nil
+	}
+	return playbackMappingInput{state: p.state.StandardButtons[button]}
+}
+
+func (p *playbackGamepad) axisCount() int {
+	return len(p.state.Axes)
+}
+
+func (p *playbackGamepad) buttonCount() int {
+	return len(p.state.ButtonsPressed)
+}
+
+func (p *playbackGamepad) hatCount() int {
+	return len(p.state.Hats)
+}
+
+func (p *playbackGamepad) axisValue(axis int) float64 {
+	if axis < 0 || axis >= len(p.state.Axes) {
+		return 0
+	}
+	return p.state.Axes[axis]
+}
+
+func (p *playbackGamepad) buttonValue(button int) float64 {
+	if button < 0 || button >= len(p.state.ButtonValues) {
+		return 0
+	}
+	return p.state.ButtonValues[button]
+}
+
+func (p *playbackGamepad) isButtonPressed(button int) bool {
+	if button < 0 || button >= len(p.state.ButtonsPressed) {
+		return false
+	}
+	return p.state.ButtonsPressed[button]
+}
+
+func (p *playbackGamepad) hatState(hat int) int {
+	if hat < 0 || hat >= len(p.state.Hats) {
+		return hatCentered
+	}
+	return p.state.Hats[hat]
+}
+
+func (*playbackGamepad) isVibrationSupported() bool {
+	return false
+}
+
+func (*playbackGamepad) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
+}
+
+func (*playbackGamepad) isLightSupported() bool {
+	return false
+}
+
+func (*playbackGamepad) setLightColor(red, green, blue uint8) {
+}
+
+func (*playbackGamepad) isMotionSupported() bool {
+	return false
+}
+
+func (*playbackGamepad) gyroscope() (x, y, z float64) {
+	return 0, 0, 0
+}
+
+func (*playbackGamepad) accelerometer() (x, y, z float64) {
+	return 0, 0, 0
+}
@@ -0,0 +1,276 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (darwin && !ios) || windows
+
+package gamepad
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"sync"
+)
+
+const (
+	vendorIDSony = 0x054c
+
+	productIDDualShock4    = 0x05c4
+	productIDDualShock4V2  = 0x09cc
+	productIDDualSense     = 0x0ce6
+	productIDDualSenseEdge = 0x0df2
+)
+
+// playStationController represents a kind of PlayStation controllers that have a light bar.
+type playStationController int
+
+const (
+	playStationControllerNone playStationController = iota
+	playStationControllerDualShock4
+	playStationControllerDualSense
+)
+
+func playStationControllerFromIDs(vendor, product uint32) playStationController {
+	if vendor != vendorIDSony {
+		return playStationControllerNone
+	}
+	switch product {
+	case productIDDualShock4, productIDDualShock4V2:
+		return playStationControllerDualShock4
+	case productIDDualSense, productIDDualSenseEdge:
+		return playStationControllerDualSense
+	}
+	return playStationControllerNone
+}
+
+// usbOutputReportSize returns the size of the HID output report including the report ID via USB.
+func (p playStationController) usbOutputReportSize() int {
+	switch p {
+	case playStationControllerDualShock4:
+		return 32
+	case playStationControllerDualSense:
+		return 48
+	}
+	return 0
+}
+
+// lightReport returns an HID output report to change the color of the light bar.
+// The first byte of the returned report is the report ID.
+func (p playStationController) lightReport(bluetooth bool, red, green, blue uint8) []byte {
+	return p.outputReport(bluetooth, &[3]uint8{red, green, blue}, nil)
+}
+
+// rumbleReport returns an HID output report to change the speeds of the rumble motors.
+// strong is the speed of the low-frequency motor, and weak is the speed of the high-frequency motor.
+// The first byte of the returned report is the report ID.
+func (p playStationController) rumbleReport(bluetooth bool, strong, weak uint8) []byte {
+	return p.outputReport(bluetooth, nil, &[2]uint8{strong, weak})
+}
+
+// outputReport returns an HID output report to change the light bar color and the rumble motors' speeds.
+// light is the red, green, and blue values, and rumble is the strong and weak motors' speeds.
+// Nil values are not changed by the report.
+//
+// The report layouts are based on SDL's HIDAPI drivers for PS4 and PS5 controllers.
+func (p playStationController) outputReport(bluetooth bool, light *[3]uint8, rumble *[2]uint8) []byte {
+	var report []byte
+	switch p {
+	case playStationControllerDualShock4:
+		var flags byte
+		if rumble != nil {
+			flags |= 0x01
+		}
+		if light != nil {
+			flags |= 0x02
+		}
+
+		var offset int
+		if bluetooth {
+			report = make([]byte, 78)
+			report[0] = 0x11
+			// Enable HID and CRC.
+			report[1] = 0xc0
+			report[3] = flags
+			offset = 6
+		} else {
+			report = make([]byte, p.usbOutputReportSize())
+			report[0] = 0x05
+			report[1] = flags
+			offset = 4
+		}
+		if rumble != nil {
+			report[offset] = rumble[1]
+			report[offset+1] = rumble[0]
+		}
+		if light != nil {
+			report[offset+2] = light[0]
+			report[offset+3] = light[1]
+			report[offset+4] = light[2]
+		}
+	case playStationControllerDualSense:
+		var offset int
+		if bluetooth {
+			report = make([]byte, 78)
+			report[0] = 0x31
+			report[1] = 0x02
+			offset = 2
+		} else {
+			report = make([]byte, p.usbOutputReportSize())
+			report[0] = 0x02
+			offset = 1
+		}
+		if rumble != nil {
+			// Enable the rumble emulation and disable the audio haptics.
+			report[offset] = 0x01 | 0x02
+			report[offset+2] = rumble[1]
+			report[offset+3] = rumble[0]
+		}
+		if light != nil {
+			// Enable the light bar.
+			report[offset+1] = 0x04
+			report[offset+44] = light[0]
+			report[offset+45] = light[1]
+			report[offset+46] = light[2]
+		}
+	default:
+		return nil
+	}
+
+	if bluetooth {
+		// A report via Bluetooth needs a CRC at the end. The HID header (0xa2) is also a part of the CRC calculation.
+		crc := crc32.Update(crc32.ChecksumIEEE([]byte{0xa2}), crc32.IEEETable, report[:len(report)-4])
+		binary.LittleEndian.PutUint32(report[len(report)-4:], crc)
+	}
+	return report
+}
+
+// magnitudeToPlayStationMotorSpeed converts a vibration magnitude in [0, 1] to a motor speed of an HID output report.
+func magnitudeToPlayStationMotorSpeed(magnitude float64) uint8 {
+	if magnitude <= 0 {
+		return 0
+	}
+	if magnitude >= 1 {
+		return 0xff
+	}
+	return uint8(magnitude * 0xff)
+}
+
+const (
+	// playStationGyroResolutionPerDegree is the raw gyroscope value per degree per second.
+	playStationGyroResolutionPerDegree = 1024
+
+	// playStationAccelResolutionPerG is the raw accelerometer value per standard gravity.
+	playStationAccelResolutionPerG = 8192
+
+	standardGravity = 9.80665
+)
+
+// parseMotionReport parses an HID input report and returns the gyroscope values in radians per second and
+// the accelerometer values in meters per second squared.
+// The first byte of report is the report ID.
+//
+// parseMotionReport returns false if the report doesn't include motion data.
+// The values are not calibrated per device.
+//
+// The report layouts are based on SDL's HIDAPI drivers for PS4 and PS5 controllers.
+func (p playStationController) parseMotionReport(report []byte) (gyro, accel [3]float64, ok bool) {
+	if len(report) == 0 {
+		return
+	}
+
+	// offset is the offset of the gyroscope values in the report.
+	var offset int
+	switch p {
+	case playStationControllerDualShock4:
+		switch report[0] {
+		case 0x01:
+			offset = 1 + 12
+		case 0x11:
+			// A Bluetooth report with full data.
+			offset = 3 + 12
+		default:
+			return
+		}
+	case playStationControllerDualSense:
+		switch report[0] {
+		case 0x01:
+			offset = 1 + 15
+			// A simple Bluetooth report also has the ID 0x01, and doesn't include motion data.
+			if len(report) < 64 {
+				return
+			}
+		case 0x31:
+			// A Bluetooth report with full data.
+			offset = 2 + 15
+		default:
+			return
+		}
+	default:
+		return
+	}
+
+	if len(report) < offset+12 {
+		return
+	}
+	for i := 0; i < 3; i++ {
+		v := int16(binary.LittleEndian.Uint16(report[offset+2*i:]))
+		gyro[i] = float64(v) / playStationGyroResolutionPerDegree * math.Pi / 180
+	}
+	for i := 0; i < 3; i++ {
+		v := int16(binary.LittleEndian.Uint16(report[offset+6+2*i:]))
+		accel[i] = float64(v) / playStationAccelResolutionPerG * standardGravity
+	}
+	return gyro, accel, true
+}
+
+// motionState is the latest motion data of a gamepad.
+// motionState is updated on a different goroutine or thread from the gamepad's update.
+type motionState struct {
+	gyro     [3]float64
+	accel    [3]float64
+	received bool
+
+	m sync.Mutex
+}
+
+func (m *motionState) updateWithReport(controller playStationController, report []byte) {
+	gyro, accel, ok := controller.parseMotionReport(report)
+	if !ok {
+		return
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.gyro = gyro
+	m.accel = accel
+	m.received = true
+}
+
+func (m *motionState) isReceived() bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.received
+}
+
+func (m *motionState) gyroscope() (x, y, z float64) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.gyro[0], m.gyro[1], m.gyro[2]
+}
+
+func (m *motionState) accelerometer() (x, y, z float64) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.accel[0], m.accel[1], m.accel[2]
+}
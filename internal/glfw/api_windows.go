@@ -39,6 +39,8 @@ const (
 const (
 	_BI_BITFIELDS                                              = 3
 	_CCHDEVICENAME                                             = 32
+	_CF_DIB                                                    = 8
+	_CF_UNICODETEXT                                            = 13
 	_CCHFORMNAME                                               = 32
 	_CDS_TEST                                                  = 0x00000002
 	_CDS_FULLSCREEN                                            = 0x00000004
@@ -73,8 +75,18 @@ const (
 	_GET_MODULE_HANDLE_EX_FLAG_FROM_ADDRESS                    = 0x00000004
 	_GET_MODULE_HANDLE_EX_FLAG_UNCHANGED_REFCOUNT              = 0x00000002
 	_GWL_EXSTYLE                                               = -20
+	_GMEM_MOVEABLE                                             = 0x0002
 	_GWL_STYLE                                                 = -16
+	_HTBOTTOM                                                  = 15
+	_HTBOTTOMLEFT                                              = 16
+	_HTBOTTOMRIGHT                                             = 17
+	_HTCAPTION                                                 = 2
 	_HTCLIENT                                                  = 1
+	_HTLEFT                                                    = 10
+	_HTRIGHT                                                   = 11
+	_HTTOP                                                     = 12
+	_HTTOPLEFT                                                 = 13
+	_HTTOPRIGHT                                                = 14
 	_HORZSIZE                                                  = 4
 	_HWND_NOTOPMOST                               windows.HWND = (1 << intSize) - 2
 	_HWND_TOP                                     windows.HWND = 0
@@ -299,6 +311,7 @@ const (
 	_WM_MOUSEWHEEL                                             = 0x020A
 	_WM_MOVE                                                   = 0x0003
 	_WM_NCCREATE                                               = 0x0081
+	_WM_NCHITTEST                                              = 0x0084
 	_WM_PAINT                                                  = 0x000f
 	_WM_QUIT                                                   = 0x0012
 	_WM_RBUTTONDOWN                                            = 0x0204
@@ -717,6 +730,12 @@ var (
 	procSwapBuffers         = gdi32.NewProc("SwapBuffers")
 
 	procGetModuleHandleExW      = kernel32.NewProc("GetModuleHandleExW")
+	procGlobalAlloc             = kernel32.NewProc("GlobalAlloc")
+	procGlobalFree              = kernel32.NewProc("GlobalFree")
+	procGlobalLock              = kernel32.NewProc("GlobalLock")
+	procGlobalSize              = kernel32.NewProc("GlobalSize")
+	procGlobalUnlock            = kernel32.NewProc("GlobalUnlock")
+	procRtlMoveMemory           = kernel32.NewProc("RtlMoveMemory")
 	procSetThreadExecutionState = kernel32.NewProc("SetThreadExecutionState")
 	procTlsAlloc                = kernel32.NewProc("TlsAlloc")
 	procTlsFree                 = kernel32.NewProc("TlsFree")
@@ -753,12 +772,14 @@ var (
 	procChangeWindowMessageFilterEx   = user32.NewProc("ChangeWindowMessageFilterEx")
 	procClientToScreen                = user32.NewProc("ClientToScreen")
 	procClipCursor                    = user32.NewProc("ClipCursor")
+	procCloseClipboard                = user32.NewProc("CloseClipboard")
 	procCreateIconIndirect            = user32.NewProc("CreateIconIndirect")
 	procCreateWindowExW               = user32.NewProc("CreateWindowExW")
 	procDefWindowProcW                = user32.NewProc("DefWindowProcW")
 	procDestroyIcon                   = user32.NewProc("DestroyIcon")
 	procDestroyWindow                 = user32.NewProc("DestroyWindow")
 	procDispatchMessageW              = user32.NewProc("DispatchMessageW")
+	procEmptyClipboard                = user32.NewProc("EmptyClipboard")
 	procEnableNonClientDpiScaling     = user32.NewProc("EnableNonClientDpiScaling")
 	procEnumDisplayDevicesW           = user32.NewProc("EnumDisplayDevicesW")
 	procEnumDisplayMonitors           = user32.NewProc("EnumDisplayMonitors")
@@ -768,6 +789,7 @@ var (
 	procGetActiveWindow               = user32.NewProc("GetActiveWindow")
 	procGetClassLongPtrW              = user32.NewProc("GetClassLongPtrW")
 	procGetClientRect                 = user32.NewProc("GetClientRect")
+	procGetClipboardData              = user32.NewProc("GetClipboardData")
 	procGetCursorPos                  = user32.NewProc("GetCursorPos")
 	procGetDC                         = user32.NewProc("GetDC")
 	procGetDpiForWindow               = user32.NewProc("GetDpiForWindow")
@@ -791,6 +813,7 @@ var (
 	procMoveWindow                    = user32.NewProc("MoveWindow")
 	procMsgWaitForMultipleObjects     = user32.NewProc("MsgWaitForMultipleObjects")
 	procOffsetRect                    = user32.NewProc("OffsetRect")
+	procOpenClipboard                 = user32.NewProc("OpenClipboard")
 	procPeekMessageW                  = user32.NewProc("PeekMessageW")
 	procPostMessageW                  = user32.NewProc("PostMessageW")
 	procPtInRect                      = user32.NewProc("PtInRect")
@@ -802,6 +825,7 @@ var (
 	procScreenToClient                = user32.NewProc("ScreenToClient")
 	procSendMessageW                  = user32.NewProc("SendMessageW")
 	procSetCapture                    = user32.NewProc("SetCapture")
+	procSetClipboardData              = user32.NewProc("SetClipboardData")
 	procSetCursor                     = user32.NewProc("SetCursor")
 	procSetCursorPos                  = user32.NewProc("SetCursorPos")
 	procSetFocus                      = user32.NewProc("SetFocus")
@@ -913,6 +937,14 @@ func _ClipCursor(lpRect *_RECT) error {
 	return nil
 }
 
+func _CloseClipboard() error {
+	r, _, e := procCloseClipboard.Call()
+	if int32(r) == 0 && !errors.Is(e, windows.ERROR_SUCCESS) {
+		return fmt.Errorf("glfw: CloseClipboard failed: %w", e)
+	}
+	return nil
+}
+
 func _CreateBitmap(nWidth int32, nHeight int32, nPlanes uint32, nBitCount uint32, lpBits unsafe.Pointer) (_HBITMAP, error) {
 	r, _, e := procCreateBitmap.Call(uintptr(nWidth), uintptr(nHeight), uintptr(nPlanes), uintptr(nBitCount), uintptr(lpBits))
 	if _HBITMAP(r) == 0 {
@@ -1086,6 +1118,14 @@ func _DwmIsCompositionEnabled() (bool, error) {
 	return enabled != 0, nil
 }
 
+func _EmptyClipboard() error {
+	r, _, e := procEmptyClipboard.Call()
+	if int32(r) == 0 && !errors.Is(e, windows.ERROR_SUCCESS) {
+		return fmt.Errorf("glfw: EmptyClipboard failed: %w", e)
+	}
+	return nil
+}
+
 func _EnableNonClientDpiScaling(hwnd windows.HWND) error {
 	r, _, e := procEnableNonClientDpiScaling.Call(uintptr(hwnd))
 	if int32(r) == 0 && !errors.Is(e, windows.ERROR_SUCCESS) {
@@ -1198,6 +1238,14 @@ func _GetClientRect(hWnd windows.HWND) (_RECT, error) {
 	return rect, nil
 }
 
+func _GetClipboardData(uFormat uint32) (windows.Handle, error) {
+	r, _, e := procGetClipboardData.Call(uintptr(uFormat))
+	if r == 0 {
+		return 0, fmt.Errorf("glfw: GetClipboardData failed: %w", e)
+	}
+	return windows.Handle(r), nil
+}
+
 func _GetCursorPos() (_POINT, error) {
 	var point _POINT
 	r, _, e := procGetCursorPos.Call(uintptr(unsafe.Pointer(&point)))
@@ -1350,6 +1398,58 @@ func _GetWindowRect(hWnd windows.HWND) (_RECT, error) {
 	return rect, nil
 }
 
+func _GlobalAlloc(uFlags uint32, dwBytes uintptr) (windows.Handle, error) {
+	r, _, e := procGlobalAlloc.Call(uintptr(uFlags), dwBytes)
+	if r == 0 {
+		return 0, fmt.Errorf("glfw: GlobalAlloc failed: %w", e)
+	}
+	return windows.Handle(r), nil
+}
+
+func _GlobalFree(hMem windows.Handle) error {
+	r, _, e := procGlobalFree.Call(uintptr(hMem))
+	if r != 0 {
+		return fmt.Errorf("glfw: GlobalFree failed: %w", e)
+	}
+	return nil
+}
+
+// _GlobalLock returns the address of the locked memory as uintptr, as the memory is not managed by Go.
+// Use copyFromGlobalMemory and copyToGlobalMemory to access the memory.
+func _GlobalLock(hMem windows.Handle) (uintptr, error) {
+	r, _, e := procGlobalLock.Call(uintptr(hMem))
+	if r == 0 {
+		return 0, fmt.Errorf("glfw: GlobalLock failed: %w", e)
+	}
+	return r, nil
+}
+
+func _GlobalSize(hMem windows.Handle) (uintptr, error) {
+	r, _, e := procGlobalSize.Call(uintptr(hMem))
+	if r == 0 {
+		return 0, fmt.Errorf("glfw: GlobalSize failed: %w", e)
+	}
+	return r, nil
+}
+
+func _GlobalUnlock(hMem windows.Handle) error {
+	r, _, e := procGlobalUnlock.Call(uintptr(hMem))
+	if int32(r) == 0 && !errors.Is(e, windows.ERROR_SUCCESS) {
+		return fmt.Errorf("glfw: GlobalUnlock failed: %w", e)
+	}
+	return nil
+}
+
+// copyFromGlobalMemory copies size bytes from the global memory at src locked by _GlobalLock to dst.
+func copyFromGlobalMemory(dst unsafe.Pointer, src uintptr, size uintptr) {
+	_, _, _ = procRtlMoveMemory.Call(uintptr(dst), src, size)
+}
+
+// copyToGlobalMemory copies size bytes from src to the global memory at dst locked by _GlobalLock.
+func copyToGlobalMemory(dst uintptr, src unsafe.Pointer, size uintptr) {
+	_, _, _ = procRtlMoveMemory.Call(dst, uintptr(src), size)
+}
+
 func _IsIconic(hWnd windows.HWND) bool {
 	r, _, _ := procIsIconic.Call(uintptr(hWnd))
 	return int32(r) != 0
@@ -1420,6 +1520,14 @@ func _OffsetRect(lprect *_RECT, dx int32, dy int32) bool {
 	return int32(r) != 0
 }
 
+func _OpenClipboard(hWndNewOwner windows.HWND) error {
+	r, _, e := procOpenClipboard.Call(uintptr(hWndNewOwner))
+	if int32(r) == 0 {
+		return fmt.Errorf("glfw: OpenClipboard failed: %w", e)
+	}
+	return nil
+}
+
 func _PeekMessageW(lpMsg *_MSG, hWnd windows.HWND, wMsgFilterMin uint32, wMsgFilterMax uint32, wRemoveMsg uint32) bool {
 	r, _, _ := procPeekMessageW.Call(uintptr(unsafe.Pointer(lpMsg)), uintptr(hWnd), uintptr(wMsgFilterMin), uintptr(wMsgFilterMax), uintptr(wRemoveMsg))
 	return int32(r) != 0
@@ -1510,6 +1618,14 @@ func _SetCapture(hWnd windows.HWND) windows.HWND {
 	return windows.HWND(r)
 }
 
+func _SetClipboardData(uFormat uint32, hMem windows.Handle) error {
+	r, _, e := procSetClipboardData.Call(uintptr(uFormat), uintptr(hMem))
+	if r == 0 {
+		return fmt.Errorf("glfw: SetClipboardData failed: %w", e)
+	}
+	return nil
+}
+
 func _SetCursor(hCursor _HCURSOR) _HCURSOR {
 	r, _, _ := procSetCursor.Call(uintptr(hCursor))
 	return _HCURSOR(r)
@@ -1770,6 +1770,12 @@ int _glfwPlatformCreateStandardCursor(_GLFWcursor* cursor, int shape)
             case GLFW_NOT_ALLOWED_CURSOR:
                 cursor->ns.object = [NSCursor operationNotAllowedCursor];
                 break;
+            case GLFW_GRAB_CURSOR:
+                cursor->ns.object = [NSCursor openHandCursor];
+                break;
+            case GLFW_GRABBING_CURSOR:
+                cursor->ns.object = [NSCursor closedHandCursor];
+                break;
         }
     }
 
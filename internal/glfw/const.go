@@ -30,6 +30,9 @@ type (
 	MouseButton     int
 	PeripheralEvent int
 	StandardCursor  int
+
+	// HitTestResult is added by Ebitengine.
+	HitTestResult int
 )
 
 const (
@@ -199,4 +202,22 @@ const (
 	ResizeNESWCursor = StandardCursor(0x00036008)
 	ResizeAllCursor  = StandardCursor(0x00036009)
 	NotAllowedCursor = StandardCursor(0x0003600A)
+
+	// Added by Ebitengine.
+	GrabCursor     = StandardCursor(0x0003600B)
+	GrabbingCursor = StandardCursor(0x0003600C)
+)
+
+// Added by Ebitengine.
+const (
+	HitTestClient = HitTestResult(iota)
+	HitTestCaption
+	HitTestResizeLeft
+	HitTestResizeRight
+	HitTestResizeTop
+	HitTestResizeBottom
+	HitTestResizeTopLeft
+	HitTestResizeTopRight
+	HitTestResizeBottomLeft
+	HitTestResizeBottomRight
 )
@@ -977,6 +977,10 @@ extern "C" {
 #define GLFW_RESIZE_ALL_CURSOR  0x00036009
 #define GLFW_NOT_ALLOWED_CURSOR 0x0003600A
 
+// Added by Ebitengine.
+#define GLFW_GRAB_CURSOR        0x0003600B
+#define GLFW_GRABBING_CURSOR    0x0003600C
+
 #define GLFW_CONNECTED              0x00040001
 #define GLFW_DISCONNECTED           0x00040002
 
@@ -6,7 +6,10 @@
 package glfw
 
 import (
+	"errors"
 	"fmt"
+	"image"
+	"image/draw"
 	"math"
 )
 
@@ -339,6 +342,31 @@ func (w *Window) SetCursorPos(xpos, ypos float64) error {
 	}
 }
 
+func CreateCursor(img image.Image, xhot, yhot int) (*Cursor, error) {
+	if !_glfw.initialized {
+		return nil, NotInitialized
+	}
+
+	b := img.Bounds()
+	m := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(m, m.Bounds(), img, b.Min, draw.Src)
+	gimg := &Image{
+		Width:  b.Dx(),
+		Height: b.Dy(),
+		Pixels: m.Pix,
+	}
+
+	cursor := &Cursor{}
+	_glfw.cursors = append(_glfw.cursors, cursor)
+
+	if err := cursor.platformCreateCursor(gimg, xhot, yhot); err != nil {
+		_ = cursor.Destroy()
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
 func CreateStandardCursor(shape StandardCursor) (*Cursor, error) {
 	if !_glfw.initialized {
 		return nil, NotInitialized
@@ -353,7 +381,9 @@ func CreateStandardCursor(shape StandardCursor) (*Cursor, error) {
 		shape != ResizeNWSECursor &&
 		shape != ResizeNESWCursor &&
 		shape != ResizeAllCursor &&
-		shape != NotAllowedCursor {
+		shape != NotAllowedCursor &&
+		shape != GrabCursor &&
+		shape != GrabbingCursor {
 		return nil, fmt.Errorf("glfw: invalid standard cursor 0x%08X: %w", shape, InvalidEnum)
 	}
 
@@ -494,9 +524,47 @@ func (w *Window) SetClipboardString(str string) error {
 	return platformSetClipboardString(str)
 }
 
+func SetClipboardString(str string) error {
+	if !_glfw.initialized {
+		return NotInitialized
+	}
+	return platformSetClipboardString(str)
+}
+
 func GetClipboardString() (string, error) {
 	if !_glfw.initialized {
 		return "", NotInitialized
 	}
-	return platformGetClipboardString()
+	s, err := platformGetClipboardString()
+	if err != nil {
+		if errors.Is(err, FormatUnavailable) {
+			return "", nil
+		}
+		return "", err
+	}
+	return s, nil
+}
+
+// SetClipboardDIB puts a packed device-independent bitmap, i.e. a BITMAPINFO followed by pixels, to the clipboard.
+func SetClipboardDIB(data []byte) error {
+	if !_glfw.initialized {
+		return NotInitialized
+	}
+	return platformSetClipboardDIB(data)
+}
+
+// GetClipboardDIB returns the clipboard content as a packed device-independent bitmap.
+// GetClipboardDIB returns nil if the clipboard doesn't have a bitmap.
+func GetClipboardDIB() ([]byte, error) {
+	if !_glfw.initialized {
+		return nil, NotInitialized
+	}
+	data, err := platformGetClipboardDIB()
+	if err != nil {
+		if errors.Is(err, FormatUnavailable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
 }
@@ -114,6 +114,9 @@ type (
 	CharModsCallback        func(w *Window, char rune, mods ModifierKey)
 	DropCallback            func(w *Window, names []string)
 	MonitorCallback         func(monitor *Monitor, event PeripheralEvent)
+
+	// HitTestCallback is added by Ebitengine.
+	HitTestCallback func(w *Window, xpos int, ypos int) HitTestResult
 )
 
 type Window struct {
@@ -168,6 +171,7 @@ type Window struct {
 		character   CharCallback
 		charmods    CharModsCallback
 		drop        DropCallback
+		hitTest     HitTestCallback
 	}
 
 	platform platformWindowState
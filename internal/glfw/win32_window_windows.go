@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"runtime"
+	"time"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -1101,6 +1102,44 @@ func windowProc(hWnd windows.HWND, uMsg uint32, wParam _WPARAM, lParam _LPARAM)
 	case _WM_ERASEBKGND:
 		return 1
 
+	case _WM_NCHITTEST:
+		// Added by Ebitengine.
+		if window.callbacks.hitTest == nil {
+			break
+		}
+		pt := _POINT{
+			x: int32(_GET_X_LPARAM(lParam)),
+			y: int32(_GET_Y_LPARAM(lParam)),
+		}
+		if err := _ScreenToClient(hWnd, &pt); err != nil {
+			_glfw.errors = append(_glfw.errors, err)
+			break
+		}
+		var ht uintptr
+		switch window.callbacks.hitTest(window, int(pt.x), int(pt.y)) {
+		case HitTestCaption:
+			ht = _HTCAPTION
+		case HitTestResizeLeft:
+			ht = _HTLEFT
+		case HitTestResizeRight:
+			ht = _HTRIGHT
+		case HitTestResizeTop:
+			ht = _HTTOP
+		case HitTestResizeBottom:
+			ht = _HTBOTTOM
+		case HitTestResizeTopLeft:
+			ht = _HTTOPLEFT
+		case HitTestResizeTopRight:
+			ht = _HTTOPRIGHT
+		case HitTestResizeBottomLeft:
+			ht = _HTBOTTOMLEFT
+		case HitTestResizeBottomRight:
+			ht = _HTBOTTOMRIGHT
+		}
+		if ht != 0 {
+			return ht
+		}
+
 	case _WM_NCACTIVATE, _WM_NCPAINT:
 		// Prevent title bar from being drawn after restoring a minimized
 		// undecorated window
@@ -2275,6 +2314,20 @@ func platformGetKeyScancode(key Key) int {
 	return _glfw.platformWindow.scancodes[key]
 }
 
+func (c *Cursor) platformCreateCursor(image *Image, xhot, yhot int) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	h, err := createIcon(image, xhot, yhot, false)
+	if err != nil {
+		return err
+	}
+	c.platform.handle = _HCURSOR(h)
+
+	return nil
+}
+
 func (c *Cursor) platformCreateStandardCursor(shape StandardCursor) error {
 	if microsoftgdk.IsXbox() {
 		return nil
@@ -2302,6 +2355,9 @@ func (c *Cursor) platformCreateStandardCursor(shape StandardCursor) error {
 		id = _OCR_SIZEALL
 	case NotAllowedCursor: // v3.4
 		id = _OCR_NO
+	case GrabCursor, GrabbingCursor:
+		// Windows doesn't have system cursors for grabbing. Use the move cursor instead.
+		id = _OCR_SIZEALL
 	default:
 		return fmt.Errorf("glfw: invalid shape: %d", shape)
 	}
@@ -2337,12 +2393,118 @@ func (w *Window) platformSetCursor(cursor *Cursor) error {
 	return nil
 }
 
+// openClipboard opens the clipboard with the helper window.
+// Retry opening the clipboard a few times as some other application might have it open,
+// and also the Windows Clipboard History reads it after each update.
+func openClipboard() error {
+	var err error
+	for i := 0; i < 3; i++ {
+		if err = _OpenClipboard(_glfw.platformWindow.helperWindowHandle); err == nil {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return fmt.Errorf("glfw: failed to open the clipboard: %v: %w", err, PlatformError)
+}
+
 func platformSetClipboardString(str string) error {
-	panic("glfw: platformSetClipboardString is not implemented")
+	s, err := windows.UTF16FromString(str)
+	if err != nil {
+		return err
+	}
+	return setClipboardData(_CF_UNICODETEXT, unsafe.Pointer(&s[0]), uintptr(len(s))*unsafe.Sizeof(s[0]))
 }
 
 func platformGetClipboardString() (string, error) {
-	panic("glfw: platformGetClipboardString is not implemented")
+	b, err := getClipboardData(_CF_UNICODETEXT)
+	if err != nil {
+		return "", err
+	}
+	s := make([]uint16, len(b)/2)
+	for i := range s {
+		s[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+	}
+	return windows.UTF16ToString(s), nil
+}
+
+func platformSetClipboardDIB(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return setClipboardData(_CF_DIB, unsafe.Pointer(&data[0]), uintptr(len(data)))
+}
+
+func platformGetClipboardDIB() ([]byte, error) {
+	return getClipboardData(_CF_DIB)
+}
+
+// setClipboardData copies size bytes from data to a new global memory, and puts it to the clipboard in the given format.
+func setClipboardData(format uint32, data unsafe.Pointer, size uintptr) error {
+	object, err := _GlobalAlloc(_GMEM_MOVEABLE, size)
+	if err != nil {
+		return err
+	}
+	buffer, err := _GlobalLock(object)
+	if err != nil {
+		_ = _GlobalFree(object)
+		return err
+	}
+	copyToGlobalMemory(buffer, data, size)
+	if err := _GlobalUnlock(object); err != nil {
+		_ = _GlobalFree(object)
+		return err
+	}
+
+	if err := openClipboard(); err != nil {
+		_ = _GlobalFree(object)
+		return err
+	}
+	defer func() {
+		_ = _CloseClipboard()
+	}()
+
+	if err := _EmptyClipboard(); err != nil {
+		_ = _GlobalFree(object)
+		return err
+	}
+	// The system owns object after SetClipboardData succeeds.
+	if err := _SetClipboardData(format, object); err != nil {
+		_ = _GlobalFree(object)
+		return err
+	}
+	return nil
+}
+
+// getClipboardData returns a copy of the clipboard data in the given format.
+// getClipboardData returns an error wrapping FormatUnavailable if the clipboard doesn't have the format.
+func getClipboardData(format uint32) ([]byte, error) {
+	if err := openClipboard(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = _CloseClipboard()
+	}()
+
+	object, err := _GetClipboardData(format)
+	if err != nil {
+		return nil, fmt.Errorf("glfw: failed to get the clipboard data: %v: %w", err, FormatUnavailable)
+	}
+	size, err := _GlobalSize(object)
+	if err != nil {
+		return nil, err
+	}
+	buffer, err := _GlobalLock(object)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = _GlobalUnlock(object)
+	}()
+
+	// Copy the data so that the result doesn't refer to the memory owned by the system.
+	data := make([]byte, size)
+	copyFromGlobalMemory(unsafe.Pointer(&data[0]), buffer, size)
+	return data, nil
 }
 
 func (w *Window) GetWin32Window() (windows.HWND, error) {
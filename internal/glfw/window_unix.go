@@ -516,8 +516,12 @@ func (w *Window) GetContentScale() (float32, float32) {
 // The initial opacity value for newly created windows is one.
 //
 // This function may only be called from the main thread.
-func (w *Window) GetOpacity() float32 {
-	return float32(C.glfwGetWindowOpacity(w.data))
+func (w *Window) GetOpacity() (float32, error) {
+	ret := float32(C.glfwGetWindowOpacity(w.data))
+	if err := fetchErrorIgnoringPlatformError(); err != nil {
+		return 0, err
+	}
+	return ret, nil
 }
 
 // SetOpacity function sets the opacity of the window, including any
@@ -530,8 +534,12 @@ func (w *Window) GetOpacity() float32 {
 // transparency. The results of doing this are undefined.
 //
 // This function may only be called from the main thread.
-func (w *Window) SetOpacity(opacity float32) {
+func (w *Window) SetOpacity(opacity float32) error {
 	C.glfwSetWindowOpacity(w.data, C.float(opacity))
+	if err := fetchErrorIgnoringPlatformError(); err != nil {
+		return err
+	}
+	return nil
 }
 
 // RequestWindowAttention funciton requests user attention to the specified
@@ -897,6 +905,19 @@ func (w *Window) SetIconifyCallback(cbfun IconifyCallback) (previous IconifyCall
 	return previous, nil
 }
 
+// HitTestCallback is the window hit-test callback.
+//
+// HitTestCallback is added by Ebitengine.
+type HitTestCallback func(w *Window, xpos int, ypos int) HitTestResult
+
+// SetHitTestCallback sets the hit-test callback of the window.
+//
+// SetHitTestCallback is added by Ebitengine, and is not implemented on this platform so far.
+// The callback is never called.
+func (w *Window) SetHitTestCallback(cbfun HitTestCallback) (previous HitTestCallback, err error) {
+	return nil, nil
+}
+
 // SetClipboardString sets the system clipboard to the specified UTF-8 encoded
 // string.
 //
@@ -809,6 +809,19 @@ func (w *Window) SetIconifyCallback(cbfun IconifyCallback) (IconifyCallback, err
 	return old, nil
 }
 
+// SetHitTestCallback is added by Ebitengine.
+//
+// The callback is called with a cursor position in the client area in pixels,
+// and the result determines whether the position is treated as a caption or a resizing border of the window.
+func (w *Window) SetHitTestCallback(cbfun HitTestCallback) (HitTestCallback, error) {
+	if !_glfw.initialized {
+		return nil, NotInitialized
+	}
+	old := w.callbacks.hitTest
+	w.callbacks.hitTest = cbfun
+	return old, nil
+}
+
 func (w *Window) SetMaximizeCallback(cbfun MaximizeCallback) (MaximizeCallback, error) {
 	if !_glfw.initialized {
 		return nil, NotInitialized
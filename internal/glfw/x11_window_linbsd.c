@@ -2993,6 +2993,12 @@ int _glfwPlatformCreateStandardCursor(_GLFWcursor* cursor, int shape)
                 case GLFW_NOT_ALLOWED_CURSOR:
                     name = "not-allowed";
                     break;
+                case GLFW_GRAB_CURSOR:
+                    name = "grab";
+                    break;
+                case GLFW_GRABBING_CURSOR:
+                    name = "grabbing";
+                    break;
             }
 
             XcursorImage* image = XcursorLibraryLoadImage(name, theme, size);
@@ -3029,6 +3035,9 @@ int _glfwPlatformCreateStandardCursor(_GLFWcursor* cursor, int shape)
                 native = XC_sb_v_double_arrow;
                 break;
             case GLFW_RESIZE_ALL_CURSOR:
+            case GLFW_GRAB_CURSOR:
+            case GLFW_GRABBING_CURSOR:
+                // The X11 cursor font doesn't have hand cursors for grabbing. Use the move cursor instead.
                 native = XC_fleur;
                 break;
             default:
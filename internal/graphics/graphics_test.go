@@ -15,6 +15,9 @@
 package graphics_test
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
@@ -79,3 +82,57 @@ func BenchmarkAdjustPixel(b *testing.B) {
 		graphics.AdjustDestinationPixelForTesting(float32(i) / 17)
 	}
 }
+
+func TestFloat16(t *testing.T) {
+	testCases := []struct {
+		In   float32
+		Bits uint16
+		Out  float32
+	}{
+		{In: 0, Bits: 0x0000, Out: 0},
+		{In: 1, Bits: 0x3c00, Out: 1},
+		{In: -2, Bits: 0xc000, Out: -2},
+		{In: 0.5, Bits: 0x3800, Out: 0.5},
+		{In: 65504, Bits: 0x7bff, Out: 65504},
+		{In: 1.0 / (1 << 24), Bits: 0x0001, Out: 1.0 / (1 << 24)},
+		{In: 1.0 / (1 << 14), Bits: 0x0400, Out: 1.0 / (1 << 14)},
+		// 1 + 2^-11 is the halfway between 1 and the next value, and is rounded to the even.
+		{In: 1 + 1.0/(1<<11), Bits: 0x3c00, Out: 1},
+		{In: 1 + 3.0/(1<<11), Bits: 0x3c02, Out: 1 + 2.0/(1<<10)},
+		{In: 1e10, Bits: 0x7c00, Out: float32(math.Inf(1))},
+		{In: 1e-10, Bits: 0x0000, Out: 0},
+	}
+	for _, tc := range testCases {
+		if got, want := graphics.Float32ToFloat16(tc.In), tc.Bits; got != want {
+			t.Errorf("Float32ToFloat16(%v): got: %#04x, want: %#04x", tc.In, got, want)
+		}
+		if got, want := graphics.Float16ToFloat32(tc.Bits), tc.Out; got != want {
+			t.Errorf("Float16ToFloat32(%#04x): got: %v, want: %v", tc.Bits, got, want)
+		}
+	}
+
+	if f := graphics.Float16ToFloat32(graphics.Float32ToFloat16(float32(math.NaN()))); !math.IsNaN(float64(f)) {
+		t.Errorf("NaN must be kept but was %v", f)
+	}
+}
+
+func TestCompileShaderImageCount(t *testing.T) {
+	for i := 0; i <= graphics.ShaderImageCount; i++ {
+		src := fmt.Sprintf(`package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc%dAt(srcPos)
+}
+`, i)
+		_, err := graphics.CompileShader([]byte(src))
+		if i < graphics.ShaderImageCount {
+			if err != nil {
+				t.Errorf("imageSrc%dAt: error must be nil but %v", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), "not available") {
+			t.Errorf("imageSrc%dAt: error must report the unavailable image but %v", i, err)
+		}
+	}
+}
@@ -15,14 +15,15 @@
 package graphics
 
 import (
-	"bytes"
 	"fmt"
+	"go/ast"
+	"go/token"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/shader"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
 )
 
-func shaderSuffix(unit shaderir.Unit) (string, error) {
+func shaderSuffix(unit shaderir.Unit, customVertex bool) (string, error) {
 	shaderSuffix := fmt.Sprintf(`
 var __imageDstTextureSize vec2
 
@@ -153,33 +154,110 @@ func imageSrc%[1]dAt(pos vec2) vec4 {
 
 	shaderSuffix += `
 var __projectionMatrix mat4
-
+`
+	if customVertex {
+		// The user-defined vertex function takes and returns the positions in pixels, and the projection is applied after that.
+		shaderSuffix += `
+func __vertex(dstPos vec2, srcPos vec2, color vec4) (vec4, vec2, vec4) {
+	p, s, c := Vertex(dstPos, srcPos, color)
+	return __projectionMatrix * vec4(p, 0, 1), s, c
+}
+`
+	} else {
+		shaderSuffix += `
 func __vertex(dstPos vec2, srcPos vec2, color vec4) (vec4, vec2, vec4) {
 	return __projectionMatrix * vec4(dstPos, 0, 1), srcPos, color
 }
 `
+	}
 	return shaderSuffix, nil
 }
 
+// vertexEntry is the name of the optional user-defined vertex entry point.
+const vertexEntry = "Vertex"
+
+// checkVertexFunc checks that the file has a vertex entry point with the expected signature.
+func checkVertexFunc(f *ast.File) error {
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if fd.Recv != nil || fd.Name.Name != vertexEntry {
+			continue
+		}
+		if !isFieldTypes(fd.Type.Params, "vec2", "vec2", "vec4") || !isFieldTypes(fd.Type.Results, "vec2", "vec2", "vec4") {
+			return fmt.Errorf("graphics: vertex entry point '%s' must be func(vec2, vec2, vec4) (vec2, vec2, vec4)", vertexEntry)
+		}
+		return nil
+	}
+	return fmt.Errorf("graphics: //kage:vertex is specified but vertex entry point '%s' is missing", vertexEntry)
+}
+
+// isFieldTypes reports whether the fields have the given type names in order.
+func isFieldTypes(fields *ast.FieldList, typeNames ...string) bool {
+	var names []string
+	if fields != nil {
+		for _, f := range fields.List {
+			t, ok := f.Type.(*ast.Ident)
+			if !ok {
+				return false
+			}
+			n := len(f.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				names = append(names, t.Name)
+			}
+		}
+	}
+	if len(names) != len(typeNames) {
+		return false
+	}
+	for i := range names {
+		if names[i] != typeNames[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func CompileShader(src []byte) (*shaderir.Program, error) {
-	unit, err := shader.ParseCompilerDirectives(src)
+	fs := token.NewFileSet()
+	f, err := shader.ParseFile(fs, src)
 	if err != nil {
 		return nil, err
 	}
-	suffix, err := shaderSuffix(unit)
+	directives, err := shader.ParseCompilerDirectives(fs, f)
 	if err != nil {
 		return nil, err
 	}
 
-	var buf bytes.Buffer
-	buf.Write(src)
-	buf.WriteString(suffix)
+	// Without //kage:vertex, a function named Vertex is treated as a regular function for compatibility.
+	customVertex := directives.Vertex
+	if customVertex {
+		if err := checkVertexFunc(f); err != nil {
+			return nil, err
+		}
+	}
+
+	suffix, err := shaderSuffix(directives.Unit, customVertex)
+	if err != nil {
+		return nil, err
+	}
+
+	// The suffix and the library functions are parsed as another file, and are compiled with the user's file.
+	suffixFile, err := shader.ParseFile(fs, []byte("package main\n"+suffix+shaderLibSource(f)))
+	if err != nil {
+		return nil, err
+	}
 
 	const (
 		vert = "__vertex"
 		frag = "Fragment"
 	)
-	ir, err := shader.Compile(buf.Bytes(), vert, frag, ShaderImageCount)
+	ir, err := shader.CompileFiles(fs, []*ast.File{f, suffixFile}, vert, frag, ShaderImageCount)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +268,9 @@ func CompileShader(src []byte) (*shaderir.Program, error) {
 	if ir.FragmentFunc.Block == nil {
 		return nil, fmt.Errorf("graphics: fragment shader entry point '%s' is missing", frag)
 	}
+	if customVertex && ir.ReadsTexturesFromBlock(ir.VertexFunc.Block) {
+		return nil, fmt.Errorf("graphics: vertex entry point '%s' must not read source images", vertexEntry)
+	}
 
 	return ir, nil
 }
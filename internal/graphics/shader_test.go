@@ -0,0 +1,205 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/glsl"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/hlsl"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/msl"
+)
+
+func TestCompileShaderWithVertex(t *testing.T) {
+	const src = `//kage:unit pixels
+//kage:vertex
+
+package main
+
+var Time float
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4) (vec2, vec2, vec4) {
+	dstPos.y += 4 * sin(Time+dstPos.x/16)
+	return dstPos, srcPos, color
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0At(srcPos) * color
+}
+`
+	ir, err := graphics.CompileShader([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(ir.Attributes), 3; got != want {
+		t.Errorf("len(ir.Attributes): got: %d, want: %d", got, want)
+	}
+
+	// Check that the vertex function can be compiled for all the backends.
+	glsl.Compile(ir, glsl.GLSLVersionDefault)
+	hlsl.Compile(ir)
+	msl.Compile(ir)
+}
+
+func TestCompileShaderWithInvalidVertex(t *testing.T) {
+	const src = `//kage:unit pixels
+//kage:vertex
+
+package main
+
+func Vertex(dstPos vec2) vec2 {
+	return dstPos
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`
+	if _, err := graphics.CompileShader([]byte(src)); err == nil {
+		t.Errorf("CompileShader must return an error for an invalid Vertex signature")
+	}
+}
+
+func TestCompileShaderWithVertexHelper(t *testing.T) {
+	// Without //kage:vertex, Vertex is a regular function.
+	const src = `//kage:unit pixels
+
+package main
+
+func Vertex(v vec4) vec4 {
+	return v * 0.5
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return Vertex(color)
+}
+`
+	if _, err := graphics.CompileShader([]byte(src)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCompileShaderWithDuplicatedVertexDirective(t *testing.T) {
+	const src = `//kage:unit pixels
+//kage:vertex
+//kage:vertex
+
+package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4) (vec2, vec2, vec4) {
+	return dstPos, srcPos, color
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`
+	if _, err := graphics.CompileShader([]byte(src)); err == nil {
+		t.Errorf("CompileShader must return an error for duplicated //kage:vertex")
+	}
+}
+
+func TestCompileShaderWithVertexDirectiveInBlockComment(t *testing.T) {
+	// //kage:vertex in a block comment is not a directive, so Vertex is a regular function.
+	const src = `//kage:unit pixels
+
+/*
+//kage:vertex
+*/
+
+package main
+
+func Vertex(v vec4) vec4 {
+	return v * 0.5
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return Vertex(color)
+}
+`
+	if _, err := graphics.CompileShader([]byte(src)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCompileShaderWithVertexReadingImage(t *testing.T) {
+	const src = `//kage:unit pixels
+//kage:vertex
+
+package main
+
+func offset(pos vec2) vec2 {
+	return imageSrc0At(pos).xy
+}
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4) (vec2, vec2, vec4) {
+	return dstPos + offset(srcPos), srcPos, color
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0At(srcPos) * color
+}
+`
+	if _, err := graphics.CompileShader([]byte(src)); err == nil {
+		t.Errorf("CompileShader must return an error when Vertex reads a source image")
+	}
+}
+
+func TestCompileShaderWithLibrary(t *testing.T) {
+	const src = `//kage:unit pixels
+
+package main
+
+var Time float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	p := rotate2D(Time) * (dstPos.xy - 64)
+	d := sdRoundedRect(p, vec2(32, 16), 4)
+	n := valueNoise(p/8) + perlinNoise(p/16)
+	hsv := rgbToHSV(imageSrc0At(srcPos).rgb)
+	hsv.x = fract(hsv.x + n)
+	return vec4(hsvToRGB(hsv), 1) * step(d, 0)
+}
+`
+	ir, err := graphics.CompileShader([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check that the library functions can be compiled for all the backends.
+	glsl.Compile(ir, glsl.GLSLVersionDefault)
+	hlsl.Compile(ir)
+	msl.Compile(ir)
+}
+
+func TestCompileShaderWithLibraryOverridden(t *testing.T) {
+	// A user-defined function has priority over the library function of the same name.
+	const src = `//kage:unit pixels
+
+package main
+
+func valueNoise(p vec2, scale float) float {
+	return fract(p.x * scale)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return vec4(valueNoise(dstPos.xy, 2))
+}
+`
+	if _, err := graphics.CompileShader([]byte(src)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// shaderLibFunc is a function of the Kage standard library.
+type shaderLibFunc struct {
+	src string
+
+	// deps are the names of the other library functions that the function calls.
+	deps []string
+}
+
+// shaderLibFuncs are the functions of the Kage standard library.
+//
+// A library function is added to a shader only when the shader calls it and doesn't define a function of the same name.
+var shaderLibFuncs = map[string]shaderLibFunc{
+	"hsvToRGB": {
+		src: `
+func hsvToRGB(c vec3) vec3 {
+	k := vec4(1, 2.0/3.0, 1.0/3.0, 3)
+	p := abs(fract(c.xxx+k.xyz)*6 - k.www)
+	return c.z * mix(k.xxx, clamp(p-k.xxx, 0, 1), c.y)
+}
+`,
+	},
+	"rgbToHSV": {
+		src: `
+func rgbToHSV(c vec3) vec3 {
+	k := vec4(0, -1.0/3.0, 2.0/3.0, -1)
+	p := mix(vec4(c.zy, k.wz), vec4(c.yz, k.xy), step(c.z, c.y))
+	q := mix(vec4(p.xyw, c.x), vec4(c.x, p.yzx), step(p.x, c.x))
+	d := q.x - min(q.w, q.y)
+	e := 1.0e-10
+	return vec3(abs(q.z+(q.w-q.y)/(6*d+e)), d/(q.x+e), q.x)
+}
+`,
+	},
+	"valueNoise": {
+		src: `
+func valueNoise(p vec2) float {
+	i := floor(p)
+	f := fract(p)
+	u := f * f * (3 - 2*f)
+	a := __hash2D(i)
+	b := __hash2D(i + vec2(1, 0))
+	c := __hash2D(i + vec2(0, 1))
+	d := __hash2D(i + vec2(1, 1))
+	return mix(mix(a, b, u.x), mix(c, d, u.x), u.y)
+}
+`,
+		deps: []string{"__hash2D"},
+	},
+	"perlinNoise": {
+		src: `
+func perlinNoise(p vec2) float {
+	i := floor(p)
+	f := fract(p)
+	u := f * f * f * (f*(f*6-15) + 10)
+	a := dot(__gradient2D(i), f)
+	b := dot(__gradient2D(i+vec2(1, 0)), f-vec2(1, 0))
+	c := dot(__gradient2D(i+vec2(0, 1)), f-vec2(0, 1))
+	d := dot(__gradient2D(i+vec2(1, 1)), f-vec2(1, 1))
+	// Scale the result so that the range is roughly [-1, 1].
+	return mix(mix(a, b, u.x), mix(c, d, u.x), u.y) * 1.4142135
+}
+`,
+		deps: []string{"__gradient2D"},
+	},
+	"rotate2D": {
+		src: `
+func rotate2D(angle float) mat2 {
+	s := sin(angle)
+	c := cos(angle)
+	return mat2(c, s, -s, c)
+}
+`,
+	},
+	"sdRoundedRect": {
+		src: `
+func sdRoundedRect(p vec2, halfSize vec2, radius float) float {
+	q := abs(p) - halfSize + radius
+	return length(max(q, vec2(0))) + min(max(q.x, q.y), 0) - radius
+}
+`,
+	},
+
+	"__hash2D": {
+		src: `
+func __hash2D(p vec2) float {
+	q := fract(p * vec2(123.34, 456.21))
+	q += dot(q, q+45.32)
+	return fract(q.x * q.y)
+}
+`,
+	},
+	"__gradient2D": {
+		src: `
+func __gradient2D(p vec2) vec2 {
+	a := __hash2D(p) * 6.2831853
+	return vec2(cos(a), sin(a))
+}
+`,
+		deps: []string{"__hash2D"},
+	},
+}
+
+// shaderLibSource returns the source of the library functions that the given shader file uses.
+func shaderLibSource(f *ast.File) string {
+	defined := map[string]struct{}{}
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				defined[d.Name.Name] = struct{}{}
+			}
+		case *ast.GenDecl:
+			for _, s := range d.Specs {
+				if s, ok := s.(*ast.ValueSpec); ok {
+					for _, n := range s.Names {
+						defined[n.Name] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	used := map[string]struct{}{}
+	var use func(name string)
+	use = func(name string) {
+		if _, ok := used[name]; ok {
+			return
+		}
+		if _, ok := defined[name]; ok {
+			return
+		}
+		fn, ok := shaderLibFuncs[name]
+		if !ok {
+			return
+		}
+		used[name] = struct{}{}
+		for _, d := range fn.deps {
+			use(d)
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		c, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := c.Fun.(*ast.Ident); ok {
+			use(id.Name)
+		}
+		return true
+	})
+
+	// Sort the names to make the source deterministic.
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf strings.Builder
+	for _, name := range names {
+		buf.WriteString(shaderLibFuncs[name].src)
+	}
+	return buf.String()
+}
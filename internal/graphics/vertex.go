@@ -15,7 +15,7 @@
 package graphics
 
 const (
-	ShaderImageCount = 4
+	ShaderImageCount = 8
 
 	// PreservedUniformVariablesCount represents the number of preserved uniform variables.
 	// Any shaders in Ebitengine must have these uniform variables.
@@ -311,22 +311,119 @@ func (c *disposeShaderCommand) NeedsSync() bool {
 	return false
 }
 
+// pushDebugGroupCommand represents a command to push a debug group.
+type pushDebugGroupCommand struct {
+	name string
+}
+
+func (c *pushDebugGroupCommand) String() string {
+	return fmt.Sprintf("push-debug-group: name: %q", c.name)
+}
+
+// Exec executes the pushDebugGroupCommand.
+func (c *pushDebugGroupCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	if g, ok := graphicsDriver.(graphicsdriver.DebugGrouper); ok {
+		g.PushDebugGroup(c.name)
+	}
+	return nil
+}
+
+func (c *pushDebugGroupCommand) NeedsSync() bool {
+	return false
+}
+
+// popDebugGroupCommand represents a command to pop a debug group.
+type popDebugGroupCommand struct {
+}
+
+func (c *popDebugGroupCommand) String() string {
+	return "pop-debug-group"
+}
+
+// Exec executes the popDebugGroupCommand.
+func (c *popDebugGroupCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	if g, ok := graphicsDriver.(graphicsdriver.DebugGrouper); ok {
+		g.PopDebugGroup()
+	}
+	return nil
+}
+
+func (c *popDebugGroupCommand) NeedsSync() bool {
+	return false
+}
+
+// setDebugLabelCommand represents a command to set a debug label to an image.
+type setDebugLabelCommand struct {
+	target *Image
+	label  string
+}
+
+func (c *setDebugLabelCommand) String() string {
+	return fmt.Sprintf("set-debug-label: target: %d, label: %q", c.target.id, c.label)
+}
+
+// Exec executes the setDebugLabelCommand.
+func (c *setDebugLabelCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	if l, ok := c.target.image.(graphicsdriver.DebugLabeler); ok {
+		l.SetDebugLabel(c.label)
+	}
+	return nil
+}
+
+func (c *setDebugLabelCommand) NeedsSync() bool {
+	return false
+}
+
 // newImageCommand represents a command to create an empty image with given width and height.
 type newImageCommand struct {
-	result *Image
-	width  int
-	height int
-	screen bool
+	result        *Image
+	width         int
+	height        int
+	screen        bool
+	nativeTexture uintptr
+
+	compressedFormat graphicsdriver.CompressedTextureFormat
+	compressedData   []byte
+
+	highPrecision bool
 }
 
 func (c *newImageCommand) String() string {
+	if c.nativeTexture != 0 {
+		return fmt.Sprintf("new-image: result: %d, width: %d, height: %d, native texture: %#x", c.result.id, c.width, c.height, c.nativeTexture)
+	}
+	if c.compressedData != nil {
+		return fmt.Sprintf("new-image: result: %d, width: %d, height: %d, compressed format: %d", c.result.id, c.width, c.height, c.compressedFormat)
+	}
+	if c.highPrecision {
+		return fmt.Sprintf("new-image: result: %d, width: %d, height: %d, high precision", c.result.id, c.width, c.height)
+	}
 	return fmt.Sprintf("new-image: result: %d, width: %d, height: %d, screen: %t", c.result.id, c.width, c.height, c.screen)
 }
 
 // Exec executes a newImageCommand.
 func (c *newImageCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
 	var err error
-	if c.screen {
+	if c.nativeTexture != 0 {
+		im, ok := graphicsDriver.(graphicsdriver.NativeTextureImporter)
+		if !ok {
+			return fmt.Errorf("graphicscommand: the graphics driver cannot import a native texture")
+		}
+		c.result.image, err = im.NewImageFromNativeTexture(c.nativeTexture, c.width, c.height)
+	} else if c.compressedData != nil {
+		u, ok := graphicsDriver.(graphicsdriver.CompressedTextureUploader)
+		if !ok {
+			return fmt.Errorf("graphicscommand: the graphics driver cannot upload compressed data")
+		}
+		c.result.image, err = u.NewImageFromCompressedData(c.compressedFormat, c.compressedData, c.width, c.height)
+		c.compressedData = nil
+	} else if c.highPrecision {
+		h, ok := graphicsDriver.(graphicsdriver.HighPrecisionImageCreator)
+		if !ok {
+			return fmt.Errorf("graphicscommand: the graphics driver cannot create a high-precision image")
+		}
+		c.result.image, err = h.NewHighPrecisionImage(c.width, c.height)
+	} else if c.screen {
 		c.result.image, err = graphicsDriver.NewScreenFramebufferImage(c.width, c.height)
 	} else {
 		c.result.image, err = graphicsDriver.NewImage(c.width, c.height)
@@ -389,3 +486,45 @@ func MaxImageSize(graphicsDriver graphicsdriver.Graphics) int {
 	}, true)
 	return size
 }
+
+// MaxShaderImages returns the maximum number of source images of a shader the graphics driver supports.
+func MaxShaderImages(graphicsDriver graphicsdriver.Graphics) int {
+	c, ok := graphicsDriver.(graphicsdriver.TextureUnitCounter)
+	if !ok {
+		return graphics.ShaderImageCount
+	}
+	var n int
+	runOnRenderThread(func() {
+		n = c.MaxTextureUnits()
+	}, true)
+	if n <= 0 || n > graphics.ShaderImageCount {
+		return graphics.ShaderImageCount
+	}
+	return n
+}
+
+// IsCompressedTextureFormatSupported reports whether the graphics driver can upload data in the given compressed texture format.
+func IsCompressedTextureFormatSupported(graphicsDriver graphicsdriver.Graphics, format graphicsdriver.CompressedTextureFormat) bool {
+	u, ok := graphicsDriver.(graphicsdriver.CompressedTextureUploader)
+	if !ok {
+		return false
+	}
+	var supported bool
+	runOnRenderThread(func() {
+		supported = u.IsCompressedTextureFormatSupported(format)
+	}, true)
+	return supported
+}
+
+// IsHighPrecisionImageSupported reports whether the graphics driver can create a high-precision image.
+func IsHighPrecisionImageSupported(graphicsDriver graphicsdriver.Graphics) bool {
+	h, ok := graphicsDriver.(graphicsdriver.HighPrecisionImageCreator)
+	if !ok {
+		return false
+	}
+	var supported bool
+	runOnRenderThread(func() {
+		supported = h.IsHighPrecisionImageSupported()
+	}, true)
+	return supported
+}
@@ -66,6 +66,22 @@ func FlushCommands(graphicsDriver graphicsdriver.Graphics, endFrame bool) error
 	return nil
 }
 
+// Flush flushes the command queue and all the buffered pixels without presenting the screen.
+// If wait is true, Flush waits until the flushed commands are executed on the render thread.
+func Flush(graphicsDriver graphicsdriver.Graphics, wait bool) error {
+	for img := range imagesWithBufferedWritePixels {
+		img.flushBufferedWritePixels()
+	}
+	if err := theCommandQueueManager.flush(graphicsDriver, false); err != nil {
+		return err
+	}
+	if wait {
+		// The queued functions on the render thread are executed in order.
+		runOnRenderThread(func() {}, true)
+	}
+	return nil
+}
+
 // commandQueue is a command queue for drawing commands.
 type commandQueue struct {
 	// commands is a queue of drawing commands.
@@ -335,38 +351,17 @@ func (q *commandQueue) prependPreservedUniforms(uniforms []uint32, shader *Shade
 	uniforms[1] = math.Float32bits(float32(dh))
 
 	// Set the source texture sizes.
-	if srcs[0] != nil {
-		w, h := srcs[0].InternalSize()
-		uniforms[2] = math.Float32bits(float32(w))
-		uniforms[3] = math.Float32bits(float32(h))
-	} else {
-		uniforms[2] = 0
-		uniforms[3] = 0
-	}
-	if srcs[1] != nil {
-		w, h := srcs[1].InternalSize()
-		uniforms[4] = math.Float32bits(float32(w))
-		uniforms[5] = math.Float32bits(float32(h))
-	} else {
-		uniforms[4] = 0
-		uniforms[5] = 0
-	}
-	if srcs[2] != nil {
-		w, h := srcs[2].InternalSize()
-		uniforms[6] = math.Float32bits(float32(w))
-		uniforms[7] = math.Float32bits(float32(h))
-	} else {
-		uniforms[6] = 0
-		uniforms[7] = 0
-	}
-	if srcs[3] != nil {
-		w, h := srcs[3].InternalSize()
-		uniforms[8] = math.Float32bits(float32(w))
-		uniforms[9] = math.Float32bits(float32(h))
-	} else {
-		uniforms[8] = 0
-		uniforms[9] = 0
+	for i, src := range srcs {
+		if src == nil {
+			uniforms[2+2*i] = 0
+			uniforms[2+2*i+1] = 0
+			continue
+		}
+		w, h := src.InternalSize()
+		uniforms[2+2*i] = math.Float32bits(float32(w))
+		uniforms[2+2*i+1] = math.Float32bits(float32(h))
 	}
+	idx := 2 + 2*graphics.ShaderImageCount
 
 	dr := imageRectangleToRectangleF32(dstRegion)
 	if shader.unit() == shaderir.Texels {
@@ -377,12 +372,14 @@ func (q *commandQueue) prependPreservedUniforms(uniforms []uint32, shader *Shade
 	}
 
 	// Set the destination region origin.
-	uniforms[10] = math.Float32bits(dr.x)
-	uniforms[11] = math.Float32bits(dr.y)
+	uniforms[idx] = math.Float32bits(dr.x)
+	uniforms[idx+1] = math.Float32bits(dr.y)
+	idx += 2
 
 	// Set the destination region size.
-	uniforms[12] = math.Float32bits(dr.width)
-	uniforms[13] = math.Float32bits(dr.height)
+	uniforms[idx] = math.Float32bits(dr.width)
+	uniforms[idx+1] = math.Float32bits(dr.height)
+	idx += 2
 
 	var srs [graphics.ShaderImageCount]rectangleF32
 	for i, r := range srcRegions {
@@ -402,42 +399,36 @@ func (q *commandQueue) prependPreservedUniforms(uniforms []uint32, shader *Shade
 	}
 
 	// Set the source region origins.
-	uniforms[14] = math.Float32bits(srs[0].x)
-	uniforms[15] = math.Float32bits(srs[0].y)
-	uniforms[16] = math.Float32bits(srs[1].x)
-	uniforms[17] = math.Float32bits(srs[1].y)
-	uniforms[18] = math.Float32bits(srs[2].x)
-	uniforms[19] = math.Float32bits(srs[2].y)
-	uniforms[20] = math.Float32bits(srs[3].x)
-	uniforms[21] = math.Float32bits(srs[3].y)
+	for i := range srs {
+		uniforms[idx+2*i] = math.Float32bits(srs[i].x)
+		uniforms[idx+2*i+1] = math.Float32bits(srs[i].y)
+	}
+	idx += 2 * graphics.ShaderImageCount
 
 	// Set the source region sizes.
-	uniforms[22] = math.Float32bits(srs[0].width)
-	uniforms[23] = math.Float32bits(srs[0].height)
-	uniforms[24] = math.Float32bits(srs[1].width)
-	uniforms[25] = math.Float32bits(srs[1].height)
-	uniforms[26] = math.Float32bits(srs[2].width)
-	uniforms[27] = math.Float32bits(srs[2].height)
-	uniforms[28] = math.Float32bits(srs[3].width)
-	uniforms[29] = math.Float32bits(srs[3].height)
+	for i := range srs {
+		uniforms[idx+2*i] = math.Float32bits(srs[i].width)
+		uniforms[idx+2*i+1] = math.Float32bits(srs[i].height)
+	}
+	idx += 2 * graphics.ShaderImageCount
 
 	// Set the projection matrix.
-	uniforms[30] = math.Float32bits(2 / float32(dw))
-	uniforms[31] = 0
-	uniforms[32] = 0
-	uniforms[33] = 0
-	uniforms[34] = 0
-	uniforms[35] = math.Float32bits(2 / float32(dh))
-	uniforms[36] = 0
-	uniforms[37] = 0
-	uniforms[38] = 0
-	uniforms[39] = 0
-	uniforms[40] = math.Float32bits(1)
-	uniforms[41] = 0
-	uniforms[42] = math.Float32bits(-1)
-	uniforms[43] = math.Float32bits(-1)
-	uniforms[44] = 0
-	uniforms[45] = math.Float32bits(1)
+	uniforms[idx] = math.Float32bits(2 / float32(dw))
+	uniforms[idx+1] = 0
+	uniforms[idx+2] = 0
+	uniforms[idx+3] = 0
+	uniforms[idx+4] = 0
+	uniforms[idx+5] = math.Float32bits(2 / float32(dh))
+	uniforms[idx+6] = 0
+	uniforms[idx+7] = 0
+	uniforms[idx+8] = 0
+	uniforms[idx+9] = 0
+	uniforms[idx+10] = math.Float32bits(1)
+	uniforms[idx+11] = 0
+	uniforms[idx+12] = math.Float32bits(-1)
+	uniforms[idx+13] = math.Float32bits(-1)
+	uniforms[idx+14] = 0
+	uniforms[idx+15] = math.Float32bits(1)
 
 	return uniforms
 }
@@ -37,6 +37,16 @@ type Image struct {
 	internalHeight int
 	screen         bool
 
+	// native reports whether the image wraps a native texture that is not created by Ebitengine.
+	native bool
+
+	// compressed reports whether the image has GPU-compressed data.
+	// A compressed image can be used only as a rendering source.
+	compressed bool
+
+	// highPrecision reports whether the image has 16-bit floating point numbers per channel.
+	highPrecision bool
+
 	// id is an identifier for the image. This is used only when dumping the information.
 	//
 	// This is duplicated with graphicsdriver.Image's ID, but this id is still necessary because this image might not
@@ -74,6 +84,70 @@ func NewImage(width, height int, screenFramebuffer bool) *Image {
 	return i
 }
 
+// NewImageFromNativeTexture returns a new image that wraps the given native texture.
+//
+// The image's internal size is exactly the given size.
+func NewImageFromNativeTexture(handle uintptr, width, height int) *Image {
+	i := &Image{
+		width:  width,
+		height: height,
+		native: true,
+		id:     genNextImageID(),
+	}
+	c := &newImageCommand{
+		result:        i,
+		width:         width,
+		height:        height,
+		nativeTexture: handle,
+	}
+	theCommandQueueManager.enqueueCommand(c)
+	return i
+}
+
+// NewImageFromCompressedData returns a new image with the given GPU-compressed data.
+//
+// The image's internal size is exactly the given size.
+func NewImageFromCompressedData(format graphicsdriver.CompressedTextureFormat, data []byte, width, height int) *Image {
+	i := &Image{
+		width:      width,
+		height:     height,
+		compressed: true,
+		id:         genNextImageID(),
+	}
+	c := &newImageCommand{
+		result:           i,
+		width:            width,
+		height:           height,
+		compressedFormat: format,
+		compressedData:   data,
+	}
+	theCommandQueueManager.enqueueCommand(c)
+	return i
+}
+
+// NewHighPrecisionImage returns a new image with 16-bit floating point numbers per channel.
+//
+// Note that the image is not initialized yet.
+func NewHighPrecisionImage(width, height int) *Image {
+	i := &Image{
+		width:         width,
+		height:        height,
+		highPrecision: true,
+		id:            genNextImageID(),
+	}
+	c := &newImageCommand{
+		result:        i,
+		width:         width,
+		height:        height,
+		highPrecision: true,
+	}
+	theCommandQueueManager.enqueueCommand(c)
+	return i
+}
+
+// imagesWithBufferedWritePixels is a set of images that have buffered WritePixels calls.
+var imagesWithBufferedWritePixels = map[*Image]struct{}{}
+
 func (i *Image) flushBufferedWritePixels() {
 	if len(i.bufferedWritePixelsArgs) == 0 {
 		return
@@ -86,10 +160,12 @@ func (i *Image) flushBufferedWritePixels() {
 	theCommandQueueManager.enqueueCommand(c)
 
 	i.bufferedWritePixelsArgs = nil
+	delete(imagesWithBufferedWritePixels, i)
 }
 
 func (i *Image) Dispose() {
 	i.bufferedWritePixelsArgs = nil
+	delete(imagesWithBufferedWritePixels, i)
 	c := &disposeImageCommand{
 		target: i,
 	}
@@ -97,7 +173,7 @@ func (i *Image) Dispose() {
 }
 
 func (i *Image) InternalSize() (int, int) {
-	if i.screen {
+	if i.screen || i.native || i.compressed {
 		return i.width, i.height
 	}
 	if i.internalWidth == 0 {
@@ -161,12 +237,35 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, args []graphi
 }
 
 func (i *Image) WritePixels(pixels *graphics.ManagedBytes, region image.Rectangle) {
+	imagesWithBufferedWritePixels[i] = struct{}{}
 	i.bufferedWritePixelsArgs = append(i.bufferedWritePixelsArgs, writePixelsCommandArgs{
 		pixels: pixels,
 		region: region,
 	})
 }
 
+// SetDebugLabel sets a label to the image, which is shown in GPU debugging tools.
+func (i *Image) SetDebugLabel(label string) {
+	c := &setDebugLabelCommand{
+		target: i,
+		label:  label,
+	}
+	theCommandQueueManager.enqueueCommand(c)
+}
+
+// PushDebugGroup pushes a debug group, which is shown in GPU debugging tools.
+// The commands enqueued until the corresponding PopDebugGroup call belong to the group.
+func PushDebugGroup(name string) {
+	theCommandQueueManager.enqueueCommand(&pushDebugGroupCommand{
+		name: name,
+	})
+}
+
+// PopDebugGroup pops the current debug group.
+func PopDebugGroup() {
+	theCommandQueueManager.enqueueCommand(&popDebugGroupCommand{})
+}
+
 func (i *Image) dumpName(path string) string {
 	return strings.ReplaceAll(path, "*", strconv.Itoa(i.id))
 }
@@ -180,6 +279,9 @@ func (i *Image) dumpTo(w io.Writer, graphicsDriver graphicsdriver.Graphics, blac
 	if i.screen {
 		return fmt.Errorf("graphicscommand: a screen image cannot be dumped")
 	}
+	if i.highPrecision {
+		return fmt.Errorf("graphicscommand: a high-precision image cannot be dumped")
+	}
 
 	pix := make([]byte, 4*i.width*i.height)
 	if err := i.ReadPixels(graphicsDriver, []graphicsdriver.PixelsArgs{
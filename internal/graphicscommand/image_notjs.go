@@ -69,8 +69,8 @@ func DumpImages(images []*Image, graphicsDriver graphicsdriver.Graphics, dir str
 	}
 
 	for _, img := range images {
-		// Screen image cannot be dumped.
-		if img.screen {
+		// Screen image, compressed images, and high-precision images cannot be dumped.
+		if img.screen || img.compressed || img.highPrecision {
 			continue
 		}
 
@@ -44,13 +44,15 @@ const (
 type _DXGI_FORMAT int32
 
 const (
-	_DXGI_FORMAT_UNKNOWN            _DXGI_FORMAT = 0
-	_DXGI_FORMAT_R32G32B32A32_FLOAT _DXGI_FORMAT = 2
-	_DXGI_FORMAT_R32G32_FLOAT       _DXGI_FORMAT = 16
-	_DXGI_FORMAT_R8G8B8A8_UNORM     _DXGI_FORMAT = 28
-	_DXGI_FORMAT_R32_UINT           _DXGI_FORMAT = 42
-	_DXGI_FORMAT_D24_UNORM_S8_UINT  _DXGI_FORMAT = 45
-	_DXGI_FORMAT_B8G8R8A8_UNORM     _DXGI_FORMAT = 87
+	_DXGI_FORMAT_UNKNOWN             _DXGI_FORMAT = 0
+	_DXGI_FORMAT_R32G32B32A32_FLOAT  _DXGI_FORMAT = 2
+	_DXGI_FORMAT_R32G32_FLOAT        _DXGI_FORMAT = 16
+	_DXGI_FORMAT_R8G8B8A8_UNORM      _DXGI_FORMAT = 28
+	_DXGI_FORMAT_R8G8B8A8_UNORM_SRGB _DXGI_FORMAT = 29
+	_DXGI_FORMAT_R32_UINT            _DXGI_FORMAT = 42
+	_DXGI_FORMAT_D24_UNORM_S8_UINT   _DXGI_FORMAT = 45
+	_DXGI_FORMAT_B8G8R8A8_UNORM      _DXGI_FORMAT = 87
+	_DXGI_FORMAT_B8G8R8A8_UNORM_SRGB _DXGI_FORMAT = 91
 )
 
 type _DXGI_MODE_SCANLINE_ORDER int32
@@ -149,10 +149,28 @@ type graphics11 struct {
 	vsyncEnabled bool
 	window       windows.HWND
 
+	// srgb reports whether the textures and the screen are treated as sRGB.
+	srgb bool
+
 	newScreenWidth  int
 	newScreenHeight int
 }
 
+// EnableSRGB makes the textures and the screen treated as sRGB.
+// EnableSRGB must be called before any images are created.
+func (g *graphics11) EnableSRGB() bool {
+	g.srgb = true
+	return true
+}
+
+// textureFormat returns the pixel format of textures for offscreen images.
+func (g *graphics11) textureFormat() _DXGI_FORMAT {
+	if g.srgb {
+		return _DXGI_FORMAT_R8G8B8A8_UNORM_SRGB
+	}
+	return _DXGI_FORMAT_R8G8B8A8_UNORM
+}
+
 func newGraphics11(useWARP bool, useDebugLayer bool) (gr11 *graphics11, ferr error) {
 	g := &graphics11{
 		vsyncEnabled: true,
@@ -384,7 +402,7 @@ func (g *graphics11) NewImage(width, height int) (graphicsdriver.Image, error) {
 		Height:    uint32(graphics.InternalImageSize(height)),
 		MipLevels: 1, // 0 doesn't work when shrinking the image.
 		ArraySize: 1,
-		Format:    _DXGI_FORMAT_R8G8B8A8_UNORM,
+		Format:    g.textureFormat(),
 		SampleDesc: _DXGI_SAMPLE_DESC{
 			Count:   1,
 			Quality: 0,
@@ -409,6 +427,20 @@ func (g *graphics11) NewImage(width, height int) (graphicsdriver.Image, error) {
 	return i, nil
 }
 
+// NewImageFromNativeTexture creates an image wrapping the given ID3D11Texture2D.
+func (g *graphics11) NewImageFromNativeTexture(handle uintptr, width, height int) (graphicsdriver.Image, error) {
+	i := &image11{
+		graphics: g,
+		id:       g.genNextImageID(),
+		width:    width,
+		height:   height,
+		external: true,
+		texture:  (*_ID3D11Texture2D)(unsafe.Pointer(handle)),
+	}
+	g.addImage(i)
+	return i, nil
+}
+
 func (g *graphics11) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
 	imageWidth := width
 	imageHeight := height
@@ -30,6 +30,10 @@ type image11 struct {
 	height   int
 	screen   bool
 
+	// external reports whether the texture is created outside of Ebitengine.
+	// An external texture is not released at Dispose.
+	external bool
+
 	texture            *_ID3D11Texture2D
 	stencil            *_ID3D11Texture2D
 	renderTargetView   *_ID3D11RenderTargetView
@@ -38,7 +42,7 @@ type image11 struct {
 }
 
 func (i *image11) internalSize() (int, int) {
-	if i.screen {
+	if i.screen || i.external {
 		return i.width, i.height
 	}
 	return graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
@@ -55,7 +59,9 @@ func (i *image11) Dispose() {
 
 func (i *image11) disposeBuffers() {
 	if i.texture != nil {
-		i.texture.Release()
+		if !i.external {
+			i.texture.Release()
+		}
 		i.texture = nil
 	}
 	if i.stencil != nil {
@@ -87,7 +93,7 @@ func (i *image11) ReadPixels(args []graphicsdriver.PixelsArgs) error {
 		Height:    uint32(unionRegion.Dy()),
 		MipLevels: 0,
 		ArraySize: 1,
-		Format:    _DXGI_FORMAT_R8G8B8A8_UNORM,
+		Format:    i.graphics.textureFormat(),
 		SampleDesc: _DXGI_SAMPLE_DESC{
 			Count:   1,
 			Quality: 0,
@@ -151,7 +157,16 @@ func (i *image11) WritePixels(args []graphicsdriver.PixelsArgs) error {
 
 func (i *image11) setAsRenderTarget(useStencil bool) error {
 	if i.renderTargetView == nil {
-		rtv, err := i.graphics.device.CreateRenderTargetView(unsafe.Pointer(i.texture), nil)
+		var desc *_D3D11_RENDER_TARGET_VIEW_DESC
+		if i.screen && i.graphics.srgb {
+			// The swap chain's buffer cannot be in an sRGB format with the flip model.
+			// Use an sRGB view instead.
+			desc = &_D3D11_RENDER_TARGET_VIEW_DESC{
+				Format:        _DXGI_FORMAT_B8G8R8A8_UNORM_SRGB,
+				ViewDimension: _D3D11_RTV_DIMENSION_TEXTURE2D,
+			}
+		}
+		rtv, err := i.graphics.device.CreateRenderTargetView(unsafe.Pointer(i.texture), desc)
 		if err != nil {
 			return err
 		}
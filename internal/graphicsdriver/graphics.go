@@ -75,6 +75,99 @@ type Resetter interface {
 	Reset() error
 }
 
+// SRGBEnabler is implemented by a graphics driver that can treat the textures and the screen as sRGB.
+// With sRGB, blending and sampling are done in the linear color space.
+//
+// EnableSRGB must be called before any images are created.
+// EnableSRGB returns false if sRGB is not available.
+type SRGBEnabler interface {
+	EnableSRGB() bool
+}
+
+// NativeTextureImporter is implemented by a graphics driver that can wrap an existing native texture as an image.
+//
+// The returned image's size is exactly the given size, and the image doesn't release the native texture at Dispose.
+type NativeTextureImporter interface {
+	NewImageFromNativeTexture(handle uintptr, width, height int) (Image, error)
+}
+
+// CompressedTextureFormat represents a format of GPU-compressed texture data.
+type CompressedTextureFormat int
+
+const (
+	CompressedTextureFormatBC1 CompressedTextureFormat = iota
+	CompressedTextureFormatBC3
+	CompressedTextureFormatBC7
+	CompressedTextureFormatETC2RGB8
+	CompressedTextureFormatETC2RGBA8
+	CompressedTextureFormatASTC4x4
+)
+
+func (f CompressedTextureFormat) IsValid() bool {
+	return f >= CompressedTextureFormatBC1 && f <= CompressedTextureFormatASTC4x4
+}
+
+// DataSize returns the size of the compressed data in bytes for the given image size.
+//
+// All the formats are 4x4 block formats.
+func (f CompressedTextureFormat) DataSize(width, height int) int {
+	var bytesPerBlock int
+	switch f {
+	case CompressedTextureFormatBC1, CompressedTextureFormatETC2RGB8:
+		bytesPerBlock = 8
+	case CompressedTextureFormatBC3, CompressedTextureFormatBC7, CompressedTextureFormatETC2RGBA8, CompressedTextureFormatASTC4x4:
+		bytesPerBlock = 16
+	default:
+		panic(fmt.Sprintf("graphicsdriver: unexpected compressed texture format: %d", f))
+	}
+	return ((width + 3) / 4) * ((height + 3) / 4) * bytesPerBlock
+}
+
+// CompressedTextureUploader is implemented by a graphics driver that can create an image from GPU-compressed data.
+//
+// The returned image's size is exactly the given size.
+// The image can be used only as a rendering source. The image cannot be a rendering destination,
+// and ReadPixels and WritePixels return an error.
+//
+// IsCompressedTextureFormatSupported must be called after the graphics driver is initialized.
+type CompressedTextureUploader interface {
+	IsCompressedTextureFormatSupported(format CompressedTextureFormat) bool
+	NewImageFromCompressedData(format CompressedTextureFormat, data []byte, width, height int) (Image, error)
+}
+
+// HighPrecisionImageCreator is implemented by a graphics driver that can create an image with 16-bit floating point numbers per channel.
+//
+// The pixels for ReadPixels and WritePixels of a high-precision image are premultiplied-alpha RGBA, and each channel is
+// an IEEE 754 half-precision floating point number in little endian. Then, a pixel is 8 bytes.
+//
+// A high-precision image can be used as both a rendering source and a rendering destination.
+//
+// IsHighPrecisionImageSupported must be called after the graphics driver is initialized.
+type HighPrecisionImageCreator interface {
+	IsHighPrecisionImageSupported() bool
+	NewHighPrecisionImage(width, height int) (Image, error)
+}
+
+// TextureUnitCounter is implemented by a graphics driver that can report the number of textures a fragment shader can sample.
+//
+// MaxTextureUnits must be called after the graphics driver is initialized.
+type TextureUnitCounter interface {
+	MaxTextureUnits() int
+}
+
+// DebugGrouper is implemented by a graphics driver that can emit debug groups, which are shown in GPU debugging tools.
+//
+// PopDebugGroup does nothing if there is no debug group pushed.
+type DebugGrouper interface {
+	PushDebugGroup(name string)
+	PopDebugGroup()
+}
+
+// DebugLabeler is implemented by an image that can have a debug label, which is shown in GPU debugging tools.
+type DebugLabeler interface {
+	SetDebugLabel(label string)
+}
+
 type Image interface {
 	ID() ImageID
 	Dispose()
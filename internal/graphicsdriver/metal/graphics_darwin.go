@@ -64,6 +64,10 @@ type Graphics struct {
 
 	lastFlush time.Time
 
+	// debugGroups is a stack of the pushed debug group names.
+	// As a debug group cannot be across command buffers, the groups are pushed again for every new command buffer.
+	debugGroups []string
+
 	pool cocoa.NSAutoreleasePool
 }
 
@@ -185,10 +189,18 @@ func (g *Graphics) gcBuffers() {
 	}
 }
 
-func (g *Graphics) availableBuffer(length uintptr) mtl.Buffer {
-	if g.cb == (mtl.CommandBuffer{}) {
-		g.cb = g.cq.MakeCommandBuffer()
+func (g *Graphics) ensureCommandBuffer() {
+	if g.cb != (mtl.CommandBuffer{}) {
+		return
 	}
+	g.cb = g.cq.MakeCommandBuffer()
+	for _, name := range g.debugGroups {
+		g.cb.PushDebugGroup(name)
+	}
+}
+
+func (g *Graphics) availableBuffer(length uintptr) mtl.Buffer {
+	g.ensureCommandBuffer()
 
 	var newBuf mtl.Buffer
 	for b := range g.unusedBuffers {
@@ -256,6 +268,11 @@ func (g *Graphics) flushIfNeeded(present bool) {
 		}
 	}
 
+	if g.cb != (mtl.CommandBuffer{}) {
+		for range g.debugGroups {
+			g.cb.PopDebugGroup()
+		}
+	}
 	g.cb.Commit()
 
 	for _, t := range g.tmpTextures {
@@ -296,7 +313,7 @@ func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
 	g.checkSize(width, height)
 	td := mtl.TextureDescriptor{
 		TextureType: mtl.TextureType2D,
-		PixelFormat: mtl.PixelFormatRGBA8UNorm,
+		PixelFormat: g.view.textureFormat(),
 		Width:       graphics.InternalImageSize(width),
 		Height:      graphics.InternalImageSize(height),
 		StorageMode: storageMode,
@@ -314,6 +331,32 @@ func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
 	return i, nil
 }
 
+// NewImageFromNativeTexture creates an image wrapping the given id<MTLTexture>.
+func (g *Graphics) NewImageFromNativeTexture(handle uintptr, width, height int) (graphicsdriver.Image, error) {
+	g.checkSize(width, height)
+	t := mtl.NewTexture(objc.ID(handle))
+	if t.Width() != width || t.Height() != height {
+		return nil, fmt.Errorf("metal: the texture size (%d, %d) doesn't match with the given size (%d, %d)", t.Width(), t.Height(), width, height)
+	}
+	i := &Image{
+		id:       g.genNextImageID(),
+		graphics: g,
+		width:    width,
+		height:   height,
+		texture:  t,
+		external: true,
+	}
+	g.addImage(i)
+	return i, nil
+}
+
+// EnableSRGB makes the textures and the screen treated as sRGB.
+// EnableSRGB must be called before any images are created.
+func (g *Graphics) EnableSRGB() bool {
+	g.view.setSRGB(true)
+	return true
+}
+
 func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
 	g.view.setDrawableSize(width, height)
 	i := &Image{
@@ -474,6 +517,26 @@ func (g *Graphics) Initialize() error {
 	return nil
 }
 
+func (g *Graphics) PushDebugGroup(name string) {
+	// A debug group cannot be pushed to a command buffer while an encoder is active.
+	g.flushRenderCommandEncoderIfNeeded()
+	g.debugGroups = append(g.debugGroups, name)
+	if g.cb != (mtl.CommandBuffer{}) {
+		g.cb.PushDebugGroup(name)
+	}
+}
+
+func (g *Graphics) PopDebugGroup() {
+	if len(g.debugGroups) == 0 {
+		return
+	}
+	g.flushRenderCommandEncoderIfNeeded()
+	g.debugGroups = g.debugGroups[:len(g.debugGroups)-1]
+	if g.cb != (mtl.CommandBuffer{}) {
+		g.cb.PopDebugGroup()
+	}
+}
+
 func (g *Graphics) flushRenderCommandEncoderIfNeeded() {
 	if g.rce == (mtl.RenderCommandEncoder{}) {
 		return
@@ -520,9 +583,7 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 			rpd.StencilAttachment.Texture = dst.stencil
 		}
 
-		if g.cb == (mtl.CommandBuffer{}) {
-			g.cb = g.cq.MakeCommandBuffer()
-		}
+		g.ensureCommandBuffer()
 		g.rce = g.cb.MakeRenderCommandEncoder(rpd)
 	}
 
@@ -792,6 +853,10 @@ type Image struct {
 	screen   bool
 	texture  mtl.Texture
 	stencil  mtl.Texture
+
+	// external reports whether the texture is created outside of Ebitengine.
+	// An external texture is not released at Dispose.
+	external bool
 }
 
 func (i *Image) ID() graphicsdriver.ImageID {
@@ -799,19 +864,28 @@ func (i *Image) ID() graphicsdriver.ImageID {
 }
 
 func (i *Image) internalSize() (int, int) {
-	if i.screen {
+	if i.screen || i.external {
 		return i.width, i.height
 	}
 	return graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
 }
 
+func (i *Image) SetDebugLabel(label string) {
+	if i.texture == (mtl.Texture{}) {
+		return
+	}
+	i.texture.SetLabel(label)
+}
+
 func (i *Image) Dispose() {
 	if i.stencil != (mtl.Texture{}) {
 		i.stencil.Release()
 		i.stencil = mtl.Texture{}
 	}
 	if i.texture != (mtl.Texture{}) {
-		i.texture.Release()
+		if !i.external {
+			i.texture.Release()
+		}
 		i.texture = mtl.Texture{}
 	}
 	i.graphics.removeImage(i)
@@ -869,7 +943,7 @@ func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
 	// The texture cannot be reused until sending the pixels finishes, then create new ones for each call.
 	td := mtl.TextureDescriptor{
 		TextureType: mtl.TextureType2D,
-		PixelFormat: mtl.PixelFormatRGBA8UNorm,
+		PixelFormat: g.view.textureFormat(),
 		Width:       region.Dx(),
 		Height:      region.Dy(),
 		StorageMode: storageMode,
@@ -885,9 +959,7 @@ func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
 		}, 0, unsafe.Pointer(&a.Pixels[0]), 4*a.Region.Dx())
 	}
 
-	if g.cb == (mtl.CommandBuffer{}) {
-		g.cb = i.graphics.cq.MakeCommandBuffer()
-	}
+	g.ensureCommandBuffer()
 	bce := g.cb.MakeBlitCommandEncoder()
 	for _, a := range args {
 		so := mtl.Origin{X: a.Region.Min.X - region.Min.X, Y: a.Region.Min.Y - region.Min.Y, Z: 0}
@@ -922,11 +994,16 @@ func (i *Image) ensureStencil() {
 		return
 	}
 
+	w, h := graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
+	if i.external {
+		// The stencil buffer must be the same size as the external texture.
+		w, h = i.width, i.height
+	}
 	td := mtl.TextureDescriptor{
 		TextureType: mtl.TextureType2D,
 		PixelFormat: mtl.PixelFormatStencil8,
-		Width:       graphics.InternalImageSize(i.width),
-		Height:      graphics.InternalImageSize(i.height),
+		Width:       w,
+		Height:      h,
 		StorageMode: mtl.StorageModePrivate,
 		Usage:       mtl.TextureUsageRenderTarget,
 	}
@@ -529,6 +529,9 @@ var (
 	sel_presentDrawable                                                                                                               = objc.RegisterName("presentDrawable:")
 	sel_commit                                                                                                                        = objc.RegisterName("commit")
 	sel_waitUntilCompleted                                                                                                            = objc.RegisterName("waitUntilCompleted")
+	sel_pushDebugGroup                                                                                                                = objc.RegisterName("pushDebugGroup:")
+	sel_popDebugGroup                                                                                                                 = objc.RegisterName("popDebugGroup")
+	sel_setLabel                                                                                                                      = objc.RegisterName("setLabel:")
 	sel_waitUntilScheduled                                                                                                            = objc.RegisterName("waitUntilScheduled")
 	sel_renderCommandEncoderWithDescriptor                                                                                            = objc.RegisterName("renderCommandEncoderWithDescriptor:")
 	sel_stencilAttachment                                                                                                             = objc.RegisterName("stencilAttachment")
@@ -825,6 +828,22 @@ func (cb CommandBuffer) WaitUntilScheduled() {
 	cb.commandBuffer.Send(sel_waitUntilScheduled)
 }
 
+// PushDebugGroup pushes a new named string onto a stack of string labels.
+//
+// Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/2869550-pushdebuggroup
+func (cb CommandBuffer) PushDebugGroup(name string) {
+	s := cocoa.NSString_alloc().InitWithUTF8String(name)
+	defer s.Send(sel_release)
+	cb.commandBuffer.Send(sel_pushDebugGroup, s.ID)
+}
+
+// PopDebugGroup pops the latest string off of a stack of string labels.
+//
+// Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/2869549-popdebuggroup
+func (cb CommandBuffer) PopDebugGroup() {
+	cb.commandBuffer.Send(sel_popDebugGroup)
+}
+
 // MakeRenderCommandEncoder creates an encoder object that can
 // encode graphics rendering commands into this command buffer.
 //
@@ -1048,6 +1067,15 @@ func (t Texture) Release() {
 	t.texture.Send(sel_release)
 }
 
+// SetLabel sets a string that identifies the texture in GPU debugging tools.
+//
+// Reference: https://developer.apple.com/documentation/metal/mtlresource/1515814-label
+func (t Texture) SetLabel(label string) {
+	s := cocoa.NSString_alloc().InitWithUTF8String(label)
+	defer s.Send(sel_release)
+	t.texture.Send(sel_setLabel, s.ID)
+}
+
 // GetBytes copies a block of pixels from the storage allocation of texture
 // slice zero into system memory at a specified address.
 //
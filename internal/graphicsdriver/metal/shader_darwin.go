@@ -100,7 +100,7 @@ func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, ste
 	}
 
 	// TODO: For the precise pixel format, whether the render target is the screen or not must be considered.
-	pix := mtl.PixelFormatRGBA8UNorm
+	pix := view.textureFormat()
 	if screen {
 		pix = view.colorPixelFormat()
 	}
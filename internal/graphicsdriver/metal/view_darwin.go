@@ -28,6 +28,9 @@ type view struct {
 	windowChanged bool
 	vsyncDisabled bool
 
+	// srgb reports whether the textures and the layer are treated as sRGB.
+	srgb bool
+
 	device mtl.Device
 	ml     ca.MetalLayer
 
@@ -58,6 +61,29 @@ func (v *view) colorPixelFormat() mtl.PixelFormat {
 	return v.ml.PixelFormat()
 }
 
+// textureFormat returns the pixel format of textures for offscreen images.
+func (v *view) textureFormat() mtl.PixelFormat {
+	if v.srgb {
+		return mtl.PixelFormatRGBA8UNormSRGB
+	}
+	return mtl.PixelFormatRGBA8UNorm
+}
+
+func (v *view) layerPixelFormat() mtl.PixelFormat {
+	if v.srgb {
+		return mtl.PixelFormatBGRA8UNormSRGB
+	}
+	return mtl.PixelFormatBGRA8UNorm
+}
+
+func (v *view) setSRGB(srgb bool) {
+	v.srgb = srgb
+	// On macOS, the layer is already initialized.
+	if v.ml != (ca.MetalLayer{}) {
+		v.ml.SetPixelFormat(v.layerPixelFormat())
+	}
+}
+
 func (v *view) initialize(device mtl.Device) error {
 	v.device = device
 
@@ -72,7 +98,7 @@ func (v *view) initialize(device mtl.Device) error {
 	// The pixel format for a Metal layer must be MTLPixelFormatBGRA8Unorm,
 	// MTLPixelFormatBGRA8Unorm_sRGB, MTLPixelFormatRGBA16Float, MTLPixelFormatBGRA10_XR, or
 	// MTLPixelFormatBGRA10_XR_sRGB.
-	v.ml.SetPixelFormat(mtl.PixelFormatBGRA8UNorm)
+	v.ml.SetPixelFormat(v.layerPixelFormat())
 
 	// The vsync state might be reset. Set the state again (#1364).
 	v.forceSetDisplaySyncEnabled(!v.vsyncDisabled)
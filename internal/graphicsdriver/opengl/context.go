@@ -17,11 +17,14 @@
 package opengl
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"image"
+	"math"
 	"sync"
 
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/opengl/gl"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
@@ -100,19 +103,32 @@ const (
 type context struct {
 	ctx gl.Context
 
-	locationCache      *locationCache
-	screenFramebuffer  framebufferNative // This might not be the default frame buffer '0' (e.g. iOS).
-	lastFramebuffer    framebufferNative
-	lastTexture        textureNative
-	lastRenderbuffer   renderbufferNative
-	lastViewportWidth  int
-	lastViewportHeight int
-	lastBlend          graphicsdriver.Blend
-	maxTextureSize     int
-	maxTextureSizeOnce sync.Once
-	highp              bool
-	highpOnce          sync.Once
-	initOnce           sync.Once
+	locationCache       *locationCache
+	screenFramebuffer   framebufferNative // This might not be the default frame buffer '0' (e.g. iOS).
+	lastFramebuffer     framebufferNative
+	lastTexture         textureNative
+	lastRenderbuffer    renderbufferNative
+	lastViewportWidth   int
+	lastViewportHeight  int
+	lastBlend           graphicsdriver.Blend
+	maxTextureSize      int
+	maxTextureSizeOnce  sync.Once
+	maxTextureUnits     int
+	maxTextureUnitsOnce sync.Once
+	highp               bool
+	highpOnce           sync.Once
+	initOnce            sync.Once
+
+	// compressedTextureFormats is the set of the compressed texture formats the context supports.
+	compressedTextureFormats     map[uint32]struct{}
+	compressedTextureFormatsOnce sync.Once
+
+	// highPrecisionImageSupported reports whether a high-precision texture can be a framebuffer.
+	highPrecisionImageSupported     bool
+	highPrecisionImageSupportedOnce sync.Once
+
+	// srgb reports whether the textures and the screen are treated as sRGB.
+	srgb bool
 }
 
 func (c *context) bindTexture(t textureNative) {
@@ -177,6 +193,13 @@ func (c *context) getMaxTextureSize() int {
 	return c.maxTextureSize
 }
 
+func (c *context) getMaxTextureUnits() int {
+	c.maxTextureUnitsOnce.Do(func() {
+		c.maxTextureUnits = c.ctx.GetInteger(gl.MAX_TEXTURE_IMAGE_UNITS)
+	})
+	return c.maxTextureUnits
+}
+
 func (c *context) reset() error {
 	var err1 error
 	c.initOnce.Do(func() {
@@ -199,6 +222,9 @@ func (c *context) reset() error {
 
 	c.ctx.Enable(gl.BLEND)
 	c.ctx.Enable(gl.SCISSOR_TEST)
+	if c.srgb {
+		c.ctx.Enable(gl.FRAMEBUFFER_SRGB)
+	}
 	c.blend(graphicsdriver.BlendSourceOver)
 	c.screenFramebuffer = framebufferNative(c.ctx.GetInteger(gl.FRAMEBUFFER_BINDING))
 	// TODO: Need to update screenFramebufferWidth/Height?
@@ -244,11 +270,24 @@ func (c *context) newTexture(width, height int) (textureNative, error) {
 	// avoided.
 	//
 	// See also https://stackoverflow.com/questions/57734645.
-	c.ctx.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, int32(width), int32(height), gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	internalFormat := int32(gl.RGBA)
+	if c.srgb {
+		internalFormat = gl.SRGB8_ALPHA8
+	}
+	c.ctx.TexImage2D(gl.TEXTURE_2D, 0, internalFormat, int32(width), int32(height), gl.RGBA, gl.UNSIGNED_BYTE, nil)
 
 	return textureNative(t), nil
 }
 
+// setNativeTextureParameters sets the texture parameters Ebitengine requires to an external texture.
+func (c *context) setNativeTextureParameters(t textureNative) {
+	c.bindTexture(t)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+}
+
 func (c *context) framebufferPixels(buf []byte, f *framebuffer, region image.Rectangle) error {
 	if got, want := len(buf), 4*region.Dx()*region.Dy(); got != want {
 		return fmt.Errorf("opengl: len(buf) must be %d but was %d at framebufferPixels", got, want)
@@ -264,6 +303,29 @@ func (c *context) framebufferPixels(buf []byte, f *framebuffer, region image.Rec
 	return nil
 }
 
+func (c *context) framebufferPixelsHighPrecision(buf []byte, f *framebuffer, region image.Rectangle) error {
+	if got, want := len(buf), 8*region.Dx()*region.Dy(); got != want {
+		return fmt.Errorf("opengl: len(buf) must be %d but was %d at framebufferPixelsHighPrecision", got, want)
+	}
+
+	c.ctx.Flush()
+	c.bindFramebuffer(f.native)
+	x := int32(region.Min.X)
+	y := int32(region.Min.Y)
+	width := int32(region.Dx())
+	height := int32(region.Dy())
+
+	// Read the pixels as 32-bit floats, as RGBA and FLOAT is the combination that OpenGL ES always accepts
+	// for floating point color buffers.
+	tmp := make([]byte, 16*region.Dx()*region.Dy())
+	c.ctx.ReadPixels(tmp, x, y, width, height, gl.RGBA, gl.FLOAT)
+	for i := 0; i < len(tmp)/4; i++ {
+		v := math.Float32frombits(binary.LittleEndian.Uint32(tmp[4*i:]))
+		binary.LittleEndian.PutUint16(buf[2*i:], graphics.Float32ToFloat16(v))
+	}
+	return nil
+}
+
 func (c *context) framebufferPixelsToBuffer(f *framebuffer, buffer buffer, width, height int) {
 	c.ctx.Flush()
 
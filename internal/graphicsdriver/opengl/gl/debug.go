@@ -158,6 +158,14 @@ func (d *DebugContext) CompileShader(arg0 uint32) {
 	}
 }
 
+func (d *DebugContext) CompressedTexImage2D(arg0 uint32, arg1 int32, arg2 uint32, arg3 int32, arg4 int32, arg5 []uint8) {
+	d.Context.CompressedTexImage2D(arg0, arg1, arg2, arg3, arg4, arg5)
+	fmt.Fprintln(os.Stderr, "CompressedTexImage2D")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at CompressedTexImage2D", e))
+	}
+}
+
 func (d *DebugContext) CreateBuffer() uint32 {
 	out0 := d.Context.CreateBuffer()
 	fmt.Fprintln(os.Stderr, "CreateBuffer")
@@ -356,6 +364,14 @@ func (d *DebugContext) GetInteger(arg0 uint32) int {
 	return out0
 }
 
+func (d *DebugContext) GetIntegerv(arg0 []int32, arg1 uint32) {
+	d.Context.GetIntegerv(arg0, arg1)
+	fmt.Fprintln(os.Stderr, "GetIntegerv")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at GetIntegerv", e))
+	}
+}
+
 func (d *DebugContext) GetProgramInfoLog(arg0 uint32) string {
 	out0 := d.Context.GetProgramInfoLog(arg0)
 	fmt.Fprintln(os.Stderr, "GetProgramInfoLog")
@@ -446,6 +462,14 @@ func (d *DebugContext) LoadFunctions() error {
 	return out0
 }
 
+func (d *DebugContext) ObjectLabel(arg0 uint32, arg1 uint32, arg2 string) {
+	d.Context.ObjectLabel(arg0, arg1, arg2)
+	fmt.Fprintln(os.Stderr, "ObjectLabel")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at ObjectLabel", e))
+	}
+}
+
 func (d *DebugContext) PixelStorei(arg0 uint32, arg1 int32) {
 	d.Context.PixelStorei(arg0, arg1)
 	fmt.Fprintln(os.Stderr, "PixelStorei")
@@ -454,6 +478,22 @@ func (d *DebugContext) PixelStorei(arg0 uint32, arg1 int32) {
 	}
 }
 
+func (d *DebugContext) PopDebugGroup() {
+	d.Context.PopDebugGroup()
+	fmt.Fprintln(os.Stderr, "PopDebugGroup")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at PopDebugGroup", e))
+	}
+}
+
+func (d *DebugContext) PushDebugGroup(arg0 string) {
+	d.Context.PushDebugGroup(arg0)
+	fmt.Fprintln(os.Stderr, "PushDebugGroup")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at PushDebugGroup", e))
+	}
+}
+
 func (d *DebugContext) ReadPixels(arg0 []uint8, arg1 int32, arg2 int32, arg3 int32, arg4 int32, arg5 uint32, arg6 uint32) {
 	d.Context.ReadPixels(arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	fmt.Fprintln(os.Stderr, "ReadPixels")
@@ -84,6 +84,10 @@ package gl
 //   typedef void (*fn)(GLuint shader);
 //   ((fn)(fnptr))(shader);
 // }
+// static void glowCompressedTexImage2D(uintptr_t fnptr, GLenum target, GLint level, GLenum internalformat, GLsizei width, GLsizei height, GLint border, GLsizei imageSize, const void* data) {
+//   typedef void (*fn)(GLenum target, GLint level, GLenum internalformat, GLsizei width, GLsizei height, GLint border, GLsizei imageSize, const void* data);
+//   ((fn)(fnptr))(target, level, internalformat, width, height, border, imageSize, data);
+// }
 // static GLuint glowCreateProgram(uintptr_t fnptr) {
 //   typedef GLuint (*fn)();
 //   return ((fn)(fnptr))();
@@ -216,10 +220,22 @@ package gl
 //   typedef void (*fn)(GLuint program);
 //   ((fn)(fnptr))(program);
 // }
+// static void glowObjectLabel(uintptr_t fnptr, GLenum identifier, GLuint name, GLsizei length, const GLchar* label) {
+//   typedef void (*fn)(GLenum identifier, GLuint name, GLsizei length, const GLchar* label);
+//   ((fn)(fnptr))(identifier, name, length, label);
+// }
 // static void glowPixelStorei(uintptr_t fnptr, GLenum pname, GLint param) {
 //   typedef void (*fn)(GLenum pname, GLint param);
 //   ((fn)(fnptr))(pname, param);
 // }
+// static void glowPopDebugGroup(uintptr_t fnptr) {
+//   typedef void (*fn)();
+//   ((fn)(fnptr))();
+// }
+// static void glowPushDebugGroup(uintptr_t fnptr, GLenum source, GLuint id, GLsizei length, const GLchar* message) {
+//   typedef void (*fn)(GLenum source, GLuint id, GLsizei length, const GLchar* message);
+//   ((fn)(fnptr))(source, id, length, message);
+// }
 // static void glowReadPixels(uintptr_t fnptr, GLint x, GLint y, GLsizei width, GLsizei height, GLenum format, GLenum type, void* pixels) {
 //   typedef void (*fn)(GLint x, GLint y, GLsizei width, GLsizei height, GLenum format, GLenum type, void* pixels);
 //   ((fn)(fnptr))(x, y, width, height, format, type, pixels);
@@ -340,6 +356,7 @@ type defaultContext struct {
 	gpClear                    C.uintptr_t
 	gpColorMask                C.uintptr_t
 	gpCompileShader            C.uintptr_t
+	gpCompressedTexImage2D     C.uintptr_t
 	gpCreateProgram            C.uintptr_t
 	gpCreateShader             C.uintptr_t
 	gpDeleteBuffers            C.uintptr_t
@@ -373,7 +390,10 @@ type defaultContext struct {
 	gpIsProgram                C.uintptr_t
 	gpIsRenderbuffer           C.uintptr_t
 	gpLinkProgram              C.uintptr_t
+	gpObjectLabel              C.uintptr_t
 	gpPixelStorei              C.uintptr_t
+	gpPopDebugGroup            C.uintptr_t
+	gpPushDebugGroup           C.uintptr_t
 	gpReadPixels               C.uintptr_t
 	gpRenderbufferStorage      C.uintptr_t
 	gpScissor                  C.uintptr_t
@@ -489,6 +509,11 @@ func (c *defaultContext) CompileShader(shader uint32) {
 	C.glowCompileShader(c.gpCompileShader, C.GLuint(shader))
 }
 
+func (c *defaultContext) CompressedTexImage2D(target uint32, level int32, internalformat uint32, width int32, height int32, data []byte) {
+	C.glowCompressedTexImage2D(c.gpCompressedTexImage2D, C.GLenum(target), C.GLint(level), C.GLenum(internalformat), C.GLsizei(width), C.GLsizei(height), 0, C.GLsizei(len(data)), unsafe.Pointer(&data[0]))
+	runtime.KeepAlive(data)
+}
+
 func (c *defaultContext) CreateBuffer() uint32 {
 	var buffer uint32
 	C.glowGenBuffers(c.gpGenBuffers, 1, (*C.GLuint)(unsafe.Pointer(&buffer)))
@@ -600,6 +625,11 @@ func (c *defaultContext) GetInteger(pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetIntegerv(dst []int32, pname uint32) {
+	C.glowGetIntegerv(c.gpGetIntegerv, C.GLenum(pname), (*C.GLint)(unsafe.Pointer(&dst[0])))
+	runtime.KeepAlive(dst)
+}
+
 func (c *defaultContext) GetProgramInfoLog(program uint32) string {
 	bufSize := c.GetProgrami(program, INFO_LOG_LENGTH)
 	infoLog := make([]byte, bufSize)
@@ -652,10 +682,35 @@ func (c *defaultContext) LinkProgram(program uint32) {
 	C.glowLinkProgram(c.gpLinkProgram, C.GLuint(program))
 }
 
+func (c *defaultContext) ObjectLabel(identifier uint32, name uint32, label string) {
+	if c.gpObjectLabel == 0 {
+		return
+	}
+	clabel := C.CString(label)
+	defer C.free(unsafe.Pointer(clabel))
+	C.glowObjectLabel(c.gpObjectLabel, C.GLenum(identifier), C.GLuint(name), -1, (*C.GLchar)(unsafe.Pointer(clabel)))
+}
+
 func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	C.glowPixelStorei(c.gpPixelStorei, C.GLenum(pname), C.GLint(param))
 }
 
+func (c *defaultContext) PopDebugGroup() {
+	if c.gpPopDebugGroup == 0 {
+		return
+	}
+	C.glowPopDebugGroup(c.gpPopDebugGroup)
+}
+
+func (c *defaultContext) PushDebugGroup(message string) {
+	if c.gpPushDebugGroup == 0 {
+		return
+	}
+	cmessage := C.CString(message)
+	defer C.free(unsafe.Pointer(cmessage))
+	C.glowPushDebugGroup(c.gpPushDebugGroup, DEBUG_SOURCE_APPLICATION, 0, -1, (*C.GLchar)(unsafe.Pointer(cmessage)))
+}
+
 func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32) {
 	C.glowReadPixels(c.gpReadPixels, C.GLint(x), C.GLint(y), C.GLsizei(width), C.GLsizei(height), C.GLenum(format), C.GLenum(xtype), unsafe.Pointer(&dst[0]))
 }
@@ -790,6 +845,7 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpClear = C.uintptr_t(g.get("glClear"))
 	c.gpColorMask = C.uintptr_t(g.get("glColorMask"))
 	c.gpCompileShader = C.uintptr_t(g.get("glCompileShader"))
+	c.gpCompressedTexImage2D = C.uintptr_t(g.get("glCompressedTexImage2D"))
 	c.gpCreateProgram = C.uintptr_t(g.get("glCreateProgram"))
 	c.gpCreateShader = C.uintptr_t(g.get("glCreateShader"))
 	c.gpDeleteBuffers = C.uintptr_t(g.get("glDeleteBuffers"))
@@ -849,5 +905,10 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpVertexAttribPointer = C.uintptr_t(g.get("glVertexAttribPointer"))
 	c.gpViewport = C.uintptr_t(g.get("glViewport"))
 
+	// The debug functions are optional. They are available with OpenGL 4.3, OpenGL ES 3.2, or KHR_debug.
+	c.gpObjectLabel = C.uintptr_t(g.getOptional("glObjectLabel", "glObjectLabelKHR"))
+	c.gpPopDebugGroup = C.uintptr_t(g.getOptional("glPopDebugGroup", "glPopDebugGroupKHR"))
+	c.gpPushDebugGroup = C.uintptr_t(g.getOptional("glPushDebugGroup", "glPushDebugGroupKHR"))
+
 	return g.error()
 }
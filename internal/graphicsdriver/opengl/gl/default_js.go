@@ -312,6 +312,10 @@ func (c *defaultContext) CompileShader(shader uint32) {
 	c.fnCompileShader.Invoke(c.shaders.get(shader))
 }
 
+func (c *defaultContext) CompressedTexImage2D(target uint32, level int32, internalformat uint32, width int32, height int32, data []byte) {
+	panic("gl: CompressedTexImage2D is not implemented")
+}
+
 func (c *defaultContext) CreateBuffer() uint32 {
 	return c.buffers.create(c.fnCreateBuffer.Invoke())
 }
@@ -428,6 +432,10 @@ func (c *defaultContext) GetInteger(pname uint32) int {
 	}
 }
 
+func (c *defaultContext) GetIntegerv(dst []int32, pname uint32) {
+	panic("gl: GetIntegerv is not implemented")
+}
+
 func (c *defaultContext) GetProgramInfoLog(program uint32) string {
 	return c.fnGetProgramInfoLog.Invoke(c.programs.get(program)).String()
 }
@@ -497,10 +505,22 @@ func (c *defaultContext) LinkProgram(program uint32) {
 	c.fnLinkProgram.Invoke(c.programs.get(program))
 }
 
+func (c *defaultContext) ObjectLabel(identifier uint32, name uint32, label string) {
+	// WebGL doesn't have debug labels.
+}
+
 func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	c.fnPixelStorei.Invoke(pname, param)
 }
 
+func (c *defaultContext) PopDebugGroup() {
+	// WebGL doesn't have debug groups.
+}
+
+func (c *defaultContext) PushDebugGroup(message string) {
+	// WebGL doesn't have debug groups.
+}
+
 func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32) {
 	if dst == nil {
 		c.fnReadPixels.Invoke(x, y, width, height, format, xtype, 0)
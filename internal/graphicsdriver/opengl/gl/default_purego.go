@@ -40,6 +40,7 @@ type defaultContext struct {
 	gpClear                    uintptr
 	gpColorMask                uintptr
 	gpCompileShader            uintptr
+	gpCompressedTexImage2D     uintptr
 	gpCreateProgram            uintptr
 	gpCreateShader             uintptr
 	gpDeleteBuffers            uintptr
@@ -73,7 +74,10 @@ type defaultContext struct {
 	gpIsProgram                uintptr
 	gpIsRenderbuffer           uintptr
 	gpLinkProgram              uintptr
+	gpObjectLabel              uintptr
 	gpPixelStorei              uintptr
+	gpPopDebugGroup            uintptr
+	gpPushDebugGroup           uintptr
 	gpReadPixels               uintptr
 	gpRenderbufferStorage      uintptr
 	gpScissor                  uintptr
@@ -189,6 +193,11 @@ func (c *defaultContext) CompileShader(shader uint32) {
 	purego.SyscallN(c.gpCompileShader, uintptr(shader))
 }
 
+func (c *defaultContext) CompressedTexImage2D(target uint32, level int32, internalformat uint32, width int32, height int32, data []byte) {
+	purego.SyscallN(c.gpCompressedTexImage2D, uintptr(target), uintptr(level), uintptr(internalformat), uintptr(width), uintptr(height), 0, uintptr(len(data)), uintptr(unsafe.Pointer(&data[0])))
+	runtime.KeepAlive(data)
+}
+
 func (c *defaultContext) CreateBuffer() uint32 {
 	var buffer uint32
 	purego.SyscallN(c.gpGenBuffers, 1, uintptr(unsafe.Pointer(&buffer)))
@@ -300,6 +309,11 @@ func (c *defaultContext) GetInteger(pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetIntegerv(dst []int32, pname uint32) {
+	purego.SyscallN(c.gpGetIntegerv, uintptr(pname), uintptr(unsafe.Pointer(&dst[0])))
+	runtime.KeepAlive(dst)
+}
+
 func (c *defaultContext) GetProgramInfoLog(program uint32) string {
 	bufSize := c.GetProgrami(program, INFO_LOG_LENGTH)
 	infoLog := make([]byte, bufSize)
@@ -352,10 +366,35 @@ func (c *defaultContext) LinkProgram(program uint32) {
 	purego.SyscallN(c.gpLinkProgram, uintptr(program))
 }
 
+func (c *defaultContext) ObjectLabel(identifier uint32, name uint32, label string) {
+	if c.gpObjectLabel == 0 {
+		return
+	}
+	clabel, free := cStr(label)
+	defer free()
+	purego.SyscallN(c.gpObjectLabel, uintptr(identifier), uintptr(name), ^uintptr(0), uintptr(unsafe.Pointer(clabel)))
+}
+
 func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	purego.SyscallN(c.gpPixelStorei, uintptr(pname), uintptr(param))
 }
 
+func (c *defaultContext) PopDebugGroup() {
+	if c.gpPopDebugGroup == 0 {
+		return
+	}
+	purego.SyscallN(c.gpPopDebugGroup)
+}
+
+func (c *defaultContext) PushDebugGroup(message string) {
+	if c.gpPushDebugGroup == 0 {
+		return
+	}
+	cmessage, free := cStr(message)
+	defer free()
+	purego.SyscallN(c.gpPushDebugGroup, DEBUG_SOURCE_APPLICATION, 0, ^uintptr(0), uintptr(unsafe.Pointer(cmessage)))
+}
+
 func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32) {
 	purego.SyscallN(c.gpReadPixels, uintptr(x), uintptr(y), uintptr(width), uintptr(height), uintptr(format), uintptr(xtype), uintptr(unsafe.Pointer(&dst[0])))
 }
@@ -490,6 +529,7 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpClear = g.get("glClear")
 	c.gpColorMask = g.get("glColorMask")
 	c.gpCompileShader = g.get("glCompileShader")
+	c.gpCompressedTexImage2D = g.get("glCompressedTexImage2D")
 	c.gpCreateProgram = g.get("glCreateProgram")
 	c.gpCreateShader = g.get("glCreateShader")
 	c.gpDeleteBuffers = g.get("glDeleteBuffers")
@@ -549,6 +589,11 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpVertexAttribPointer = g.get("glVertexAttribPointer")
 	c.gpViewport = g.get("glViewport")
 
+	// The debug functions are optional. They are available with OpenGL 4.3, OpenGL ES 3.2, or KHR_debug.
+	c.gpObjectLabel = g.getOptional("glObjectLabel", "glObjectLabelKHR")
+	c.gpPopDebugGroup = g.getOptional("glPopDebugGroup", "glPopDebugGroupKHR")
+	c.gpPushDebugGroup = g.getOptional("glPushDebugGroup", "glPushDebugGroupKHR")
+
 	return g.error()
 }
 
@@ -43,6 +43,7 @@ type Context interface {
 	Clear(mask uint32)
 	ColorMask(red, green, blue, alpha bool)
 	CompileShader(shader uint32)
+	CompressedTexImage2D(target uint32, level int32, internalformat uint32, width int32, height int32, data []byte)
 	CreateBuffer() uint32
 	CreateFramebuffer() uint32
 	CreateProgram() uint32
@@ -67,6 +68,7 @@ type Context interface {
 	FramebufferTexture2D(target uint32, attachment uint32, textarget uint32, texture uint32, level int32)
 	GetError() uint32
 	GetInteger(pname uint32) int
+	GetIntegerv(dst []int32, pname uint32)
 	GetProgramInfoLog(program uint32) string
 	GetProgrami(program uint32, pname uint32) int
 	GetShaderInfoLog(shader uint32) string
@@ -76,7 +78,10 @@ type Context interface {
 	IsProgram(program uint32) bool
 	IsRenderbuffer(renderbuffer uint32) bool
 	LinkProgram(program uint32)
+	ObjectLabel(identifier uint32, name uint32, label string)
 	PixelStorei(pname uint32, param int32)
+	PopDebugGroup()
+	PushDebugGroup(message string)
 	ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32)
 	RenderbufferStorage(target uint32, internalFormat uint32, width int32, height int32)
 	Scissor(x, y, width, height int32)
@@ -38,6 +38,21 @@ func (p *procAddressGetter) get(name string) uintptr {
 	return proc
 }
 
+// getOptional returns the first available function among the given names.
+// getOptional returns 0 without an error if none of them is available.
+func (p *procAddressGetter) getOptional(names ...string) uintptr {
+	for _, name := range names {
+		proc, err := p.ctx.getProcAddress(name)
+		if err != nil {
+			continue
+		}
+		if proc != 0 {
+			return proc
+		}
+	}
+	return 0
+}
+
 func (p *procAddressGetter) error() error {
 	return p.err
 }
@@ -54,6 +54,9 @@ type Graphics struct {
 	// textureNative cannot be a map key unfortunately.
 	activatedTextures []activatedTexture
 
+	// debugGroupDepth is the number of the pushed debug groups.
+	debugGroupDepth int
+
 	graphicsPlatform
 }
 
@@ -317,6 +320,24 @@ func (g *Graphics) MaxImageSize() int {
 	return g.context.getMaxTextureSize()
 }
 
+// MaxTextureUnits returns the number of textures a fragment shader can sample.
+func (g *Graphics) MaxTextureUnits() int {
+	return g.context.getMaxTextureUnits()
+}
+
+func (g *Graphics) PushDebugGroup(name string) {
+	g.context.ctx.PushDebugGroup(name)
+	g.debugGroupDepth++
+}
+
+func (g *Graphics) PopDebugGroup() {
+	if g.debugGroupDepth == 0 {
+		return
+	}
+	g.context.ctx.PopDebugGroup()
+	g.debugGroupDepth--
+}
+
 func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
 	s, err := newShader(g.genNextShaderID(), g, program)
 	if err != nil {
@@ -87,6 +87,21 @@ func setGLFWClientAPI(isES bool) error {
 	return nil
 }
 
+// EnableSRGB makes the textures and the screen treated as sRGB.
+// EnableSRGB must be called before the window is created.
+//
+// EnableSRGB returns false with OpenGL ES, where the default framebuffer cannot be treated as sRGB.
+func (g *Graphics) EnableSRGB() bool {
+	if g.context.ctx.IsES() {
+		return false
+	}
+	if err := glfw.WindowHint(glfw.SRGBCapable, glfw.True); err != nil {
+		return false
+	}
+	g.context.srgb = true
+	return true
+}
+
 func (g *Graphics) SetGLFWWindow(window *glfw.Window) {
 	g.window = window
 }
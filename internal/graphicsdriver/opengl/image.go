@@ -33,6 +33,17 @@ type Image struct {
 	width       int
 	height      int
 	screen      bool
+
+	// external reports whether the texture is created outside of Ebitengine.
+	// An external texture is not deleted at Dispose.
+	external bool
+
+	// compressed reports whether the texture has GPU-compressed data.
+	// A compressed texture cannot be attached to a framebuffer.
+	compressed bool
+
+	// highPrecision reports whether the texture has 16-bit floating point numbers per channel.
+	highPrecision bool
 }
 
 // framebuffer is a wrapper of OpenGL's framebuffer.
@@ -51,7 +62,7 @@ func (i *Image) Dispose() {
 	if i.framebuffer != nil {
 		i.graphics.context.deleteFramebuffer(i.framebuffer.native)
 	}
-	if i.texture != 0 {
+	if i.texture != 0 && !i.external {
 		i.graphics.context.deleteTexture(i.texture)
 	}
 	if i.stencil != 0 {
@@ -61,6 +72,13 @@ func (i *Image) Dispose() {
 	i.graphics.removeImage(i)
 }
 
+func (i *Image) SetDebugLabel(label string) {
+	if i.texture == 0 {
+		return
+	}
+	i.graphics.context.ctx.ObjectLabel(gl.TEXTURE, uint32(i.texture), label)
+}
+
 func (i *Image) setViewport() error {
 	if err := i.ensureFramebuffer(); err != nil {
 		return err
@@ -74,6 +92,12 @@ func (i *Image) ReadPixels(args []graphicsdriver.PixelsArgs) error {
 		return err
 	}
 	for _, arg := range args {
+		if i.highPrecision {
+			if err := i.graphics.context.framebufferPixelsHighPrecision(arg.Pixels, i.framebuffer, arg.Region); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := i.graphics.context.framebufferPixels(arg.Pixels, i.framebuffer, arg.Region); err != nil {
 			return err
 		}
@@ -88,6 +112,9 @@ func (i *Image) framebufferSize() (int, int) {
 		// Edge can't treat a bigger viewport than the drawing area (#71).
 		return i.width, i.height
 	}
+	if i.external || i.compressed {
+		return i.width, i.height
+	}
 	return graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
 }
 
@@ -95,6 +122,9 @@ func (i *Image) ensureFramebuffer() error {
 	if i.framebuffer != nil {
 		return nil
 	}
+	if i.compressed {
+		return errors.New("opengl: a compressed image cannot be a framebuffer")
+	}
 
 	w, h := i.framebufferSize()
 	if i.screen {
@@ -134,6 +164,9 @@ func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
 	if i.screen {
 		return errors.New("opengl: WritePixels cannot be called on the screen")
 	}
+	if i.compressed {
+		return errors.New("opengl: WritePixels cannot be called on a compressed image")
+	}
 	if len(args) == 0 {
 		return nil
 	}
@@ -145,13 +178,18 @@ func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
 	}
 	i.graphics.drawCalled = false
 
+	xtype := uint32(gl.UNSIGNED_BYTE)
+	if i.highPrecision {
+		xtype = gl.HALF_FLOAT
+	}
+
 	i.graphics.context.bindTexture(i.texture)
 	for _, a := range args {
 		x := int32(a.Region.Min.X)
 		y := int32(a.Region.Min.Y)
 		width := int32(a.Region.Dx())
 		height := int32(a.Region.Dy())
-		i.graphics.context.ctx.TexSubImage2D(gl.TEXTURE_2D, 0, x, y, width, height, gl.RGBA, gl.UNSIGNED_BYTE, a.Pixels)
+		i.graphics.context.ctx.TexSubImage2D(gl.TEXTURE_2D, 0, x, y, width, height, gl.RGBA, xtype, a.Pixels)
 	}
 
 	return nil
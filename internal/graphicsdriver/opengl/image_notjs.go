@@ -0,0 +1,216 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !js && !playstation5
+
+package opengl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/opengl/gl"
+)
+
+// NewImageFromNativeTexture creates an image wrapping the given texture name.
+//
+// This is not available on browsers as WebGL doesn't have integer texture names.
+func (g *Graphics) NewImageFromNativeTexture(handle uintptr, width, height int) (graphicsdriver.Image, error) {
+	g.checkSize(width, height)
+	i := &Image{
+		id:       g.genNextImageID(),
+		graphics: g,
+		texture:  textureNative(handle),
+		width:    width,
+		height:   height,
+		external: true,
+	}
+	g.context.setNativeTextureParameters(i.texture)
+	g.addImage(i)
+	return i, nil
+}
+
+// IsCompressedTextureFormatSupported reports whether the given compressed texture format is available.
+//
+// This is not available on browsers.
+func (g *Graphics) IsCompressedTextureFormatSupported(format graphicsdriver.CompressedTextureFormat) bool {
+	return g.context.isCompressedTextureFormatSupported(compressedTextureInternalFormat(format, g.context.srgb))
+}
+
+// NewImageFromCompressedData creates an image with the given GPU-compressed data.
+//
+// This is not available on browsers.
+func (g *Graphics) NewImageFromCompressedData(format graphicsdriver.CompressedTextureFormat, data []byte, width, height int) (graphicsdriver.Image, error) {
+	g.checkSize(width, height)
+	t, err := g.context.newCompressedTexture(compressedTextureInternalFormat(format, g.context.srgb), data, width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := &Image{
+		id:         g.genNextImageID(),
+		graphics:   g,
+		texture:    t,
+		width:      width,
+		height:     height,
+		compressed: true,
+	}
+	g.addImage(i)
+	return i, nil
+}
+
+func compressedTextureInternalFormat(format graphicsdriver.CompressedTextureFormat, srgb bool) uint32 {
+	switch format {
+	case graphicsdriver.CompressedTextureFormatBC1:
+		if srgb {
+			return gl.COMPRESSED_SRGB_ALPHA_S3TC_DXT1_EXT
+		}
+		return gl.COMPRESSED_RGBA_S3TC_DXT1_EXT
+	case graphicsdriver.CompressedTextureFormatBC3:
+		if srgb {
+			return gl.COMPRESSED_SRGB_ALPHA_S3TC_DXT5_EXT
+		}
+		return gl.COMPRESSED_RGBA_S3TC_DXT5_EXT
+	case graphicsdriver.CompressedTextureFormatBC7:
+		if srgb {
+			return gl.COMPRESSED_SRGB_ALPHA_BPTC_UNORM
+		}
+		return gl.COMPRESSED_RGBA_BPTC_UNORM
+	case graphicsdriver.CompressedTextureFormatETC2RGB8:
+		if srgb {
+			return gl.COMPRESSED_SRGB8_ETC2
+		}
+		return gl.COMPRESSED_RGB8_ETC2
+	case graphicsdriver.CompressedTextureFormatETC2RGBA8:
+		if srgb {
+			return gl.COMPRESSED_SRGB8_ALPHA8_ETC2_EAC
+		}
+		return gl.COMPRESSED_RGBA8_ETC2_EAC
+	case graphicsdriver.CompressedTextureFormatASTC4x4:
+		if srgb {
+			return gl.COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR
+		}
+		return gl.COMPRESSED_RGBA_ASTC_4x4_KHR
+	default:
+		panic(fmt.Sprintf("opengl: unexpected compressed texture format: %d", format))
+	}
+}
+
+func (c *context) isCompressedTextureFormatSupported(internalFormat uint32) bool {
+	c.compressedTextureFormatsOnce.Do(func() {
+		c.compressedTextureFormats = map[uint32]struct{}{}
+		n := c.ctx.GetInteger(gl.NUM_COMPRESSED_TEXTURE_FORMATS)
+		if n <= 0 {
+			return
+		}
+		formats := make([]int32, n)
+		c.ctx.GetIntegerv(formats, gl.COMPRESSED_TEXTURE_FORMATS)
+		for _, f := range formats {
+			c.compressedTextureFormats[uint32(f)] = struct{}{}
+		}
+	})
+	_, ok := c.compressedTextureFormats[internalFormat]
+	return ok
+}
+
+func (c *context) newCompressedTexture(internalFormat uint32, data []byte, width, height int) (textureNative, error) {
+	t := c.ctx.CreateTexture()
+	if t <= 0 {
+		return 0, errors.New("opengl: creating texture failed")
+	}
+	c.bindTexture(textureNative(t))
+
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	c.ctx.CompressedTexImage2D(gl.TEXTURE_2D, 0, internalFormat, int32(width), int32(height), data)
+
+	return textureNative(t), nil
+}
+
+// IsHighPrecisionImageSupported reports whether a high-precision image is available.
+//
+// This is not available on browsers.
+func (g *Graphics) IsHighPrecisionImageSupported() bool {
+	return g.context.isHighPrecisionImageSupported()
+}
+
+// NewHighPrecisionImage creates an image with 16-bit floating point numbers per channel.
+//
+// This is not available on browsers.
+func (g *Graphics) NewHighPrecisionImage(width, height int) (graphicsdriver.Image, error) {
+	i := &Image{
+		id:            g.genNextImageID(),
+		graphics:      g,
+		width:         width,
+		height:        height,
+		highPrecision: true,
+	}
+	w := graphics.InternalImageSize(width)
+	h := graphics.InternalImageSize(height)
+	g.checkSize(w, h)
+	t, err := g.context.newHighPrecisionTexture(w, h)
+	if err != nil {
+		return nil, err
+	}
+	i.texture = t
+	g.addImage(i)
+	return i, nil
+}
+
+func (c *context) isHighPrecisionImageSupported() bool {
+	c.highPrecisionImageSupportedOnce.Do(func() {
+		// A floating point texture is not always renderable, e.g. on OpenGL ES 3.0 without EXT_color_buffer_float.
+		// Try to attach a small texture to a framebuffer to confirm that.
+		t, err := c.newHighPrecisionTexture(1, 1)
+		if err != nil {
+			return
+		}
+		defer c.deleteTexture(t)
+
+		if e := c.ctx.GetError(); e != gl.NO_ERROR {
+			return
+		}
+
+		f := c.ctx.CreateFramebuffer()
+		if f <= 0 {
+			return
+		}
+		c.bindFramebuffer(framebufferNative(f))
+		defer c.deleteFramebuffer(framebufferNative(f))
+
+		c.ctx.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, uint32(t), 0)
+		c.highPrecisionImageSupported = c.ctx.CheckFramebufferStatus(gl.FRAMEBUFFER) == gl.FRAMEBUFFER_COMPLETE
+	})
+	return c.highPrecisionImageSupported
+}
+
+func (c *context) newHighPrecisionTexture(width, height int) (textureNative, error) {
+	t := c.ctx.CreateTexture()
+	if t <= 0 {
+		return 0, errors.New("opengl: creating texture failed")
+	}
+	c.bindTexture(textureNative(t))
+
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	c.ctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	c.ctx.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
+	c.ctx.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA16F, int32(width), int32(height), gl.RGBA, gl.HALF_FLOAT, nil)
+
+	return textureNative(t), nil
+}
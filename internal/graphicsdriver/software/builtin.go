@@ -0,0 +1,311 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+func map1(x value, f func(a float64) float64) value {
+	v := value{typ: resultType(x)}
+	for i := 0; i < v.count(); i++ {
+		v.v[i] = f(x.at(i))
+	}
+	return v
+}
+
+func map2(x, y value, f func(a, b float64) float64) value {
+	v := value{typ: resultType(x, y)}
+	for i := 0; i < v.count(); i++ {
+		v.v[i] = f(x.at(i), y.at(i))
+	}
+	return v
+}
+
+func map3(x, y, z value, f func(a, b, c float64) float64) value {
+	v := value{typ: resultType(x, y, z)}
+	for i := 0; i < v.count(); i++ {
+		v.v[i] = f(x.at(i), y.at(i), z.at(i))
+	}
+	return v
+}
+
+func dot(x, y value) float64 {
+	var d float64
+	for i := 0; i < x.count(); i++ {
+		d += x.v[i] * y.v[i]
+	}
+	return d
+}
+
+func scale(x value, s float64) value {
+	for i := 0; i < x.count(); i++ {
+		x.v[i] *= s
+	}
+	return x
+}
+
+func sub(x, y value) value {
+	return arithmetic(shaderir.Sub, x, y)
+}
+
+func clamp(x, lo, hi float64) float64 {
+	return math.Min(math.Max(x, lo), hi)
+}
+
+func construct(t shaderir.BasicType, args []value) value {
+	v := value{typ: t}
+	n := v.count()
+	if len(args) == 1 && args[0].count() == 1 {
+		for i := 0; i < n; i++ {
+			v.v[i] = args[0].v[0]
+		}
+		return v.convert(t)
+	}
+	var k int
+	for _, a := range args {
+		for i := 0; i < a.count() && k < n; i++ {
+			v.v[k] = a.v[i]
+			k++
+		}
+	}
+	if elementType(t) == shaderir.Int {
+		for i := 0; i < n; i++ {
+			v.v[i] = truncateToInt32(v.v[i])
+		}
+	}
+	return v
+}
+
+func constructMatrix(t shaderir.BasicType, args []value) value {
+	v := value{typ: t}
+	n := matrixSize(t)
+	if len(args) == 1 {
+		a := args[0]
+		switch {
+		case a.count() == 1:
+			for i := 0; i < n; i++ {
+				v.v[i*n+i] = a.v[0]
+			}
+			return v
+		case isMatrix(a.typ):
+			// Take the upper-left part, and fill the rest with the identity matrix.
+			m := matrixSize(a.typ)
+			for c := 0; c < n; c++ {
+				for r := 0; r < n; r++ {
+					switch {
+					case c < m && r < m:
+						v.v[c*n+r] = a.v[c*m+r]
+					case c == r:
+						v.v[c*n+r] = 1
+					}
+				}
+			}
+			return v
+		}
+	}
+	return construct(t, args)
+}
+
+func (in *interpreter) builtin(f shaderir.BuiltinFunc, args []value) value {
+	switch f {
+	case shaderir.Len, shaderir.Cap:
+		if args[0].typ == shaderir.Array {
+			return scalarValue(shaderir.Int, float64(len(args[0].elems)))
+		}
+		return scalarValue(shaderir.Int, float64(args[0].count()))
+	case shaderir.BoolF:
+		return boolValue(args[0].v[0] != 0)
+	case shaderir.IntF:
+		return scalarValue(shaderir.Int, truncateToInt32(args[0].v[0]))
+	case shaderir.FloatF:
+		return scalarValue(shaderir.Float, args[0].v[0])
+	case shaderir.Vec2F:
+		return construct(shaderir.Vec2, args)
+	case shaderir.Vec3F:
+		return construct(shaderir.Vec3, args)
+	case shaderir.Vec4F:
+		return construct(shaderir.Vec4, args)
+	case shaderir.IVec2F:
+		return construct(shaderir.IVec2, args)
+	case shaderir.IVec3F:
+		return construct(shaderir.IVec3, args)
+	case shaderir.IVec4F:
+		return construct(shaderir.IVec4, args)
+	case shaderir.Mat2F:
+		return constructMatrix(shaderir.Mat2, args)
+	case shaderir.Mat3F:
+		return constructMatrix(shaderir.Mat3, args)
+	case shaderir.Mat4F:
+		return constructMatrix(shaderir.Mat4, args)
+	case shaderir.Radians:
+		return map1(args[0], func(a float64) float64 { return a * math.Pi / 180 })
+	case shaderir.Degrees:
+		return map1(args[0], func(a float64) float64 { return a * 180 / math.Pi })
+	case shaderir.Sin:
+		return map1(args[0], math.Sin)
+	case shaderir.Cos:
+		return map1(args[0], math.Cos)
+	case shaderir.Tan:
+		return map1(args[0], math.Tan)
+	case shaderir.Asin:
+		return map1(args[0], math.Asin)
+	case shaderir.Acos:
+		return map1(args[0], math.Acos)
+	case shaderir.Atan:
+		return map1(args[0], math.Atan)
+	case shaderir.Atan2:
+		return map2(args[0], args[1], math.Atan2)
+	case shaderir.Pow:
+		return map2(args[0], args[1], math.Pow)
+	case shaderir.Exp:
+		return map1(args[0], math.Exp)
+	case shaderir.Log:
+		return map1(args[0], math.Log)
+	case shaderir.Exp2:
+		return map1(args[0], math.Exp2)
+	case shaderir.Log2:
+		return map1(args[0], math.Log2)
+	case shaderir.Sqrt:
+		return map1(args[0], math.Sqrt)
+	case shaderir.Inversesqrt:
+		return map1(args[0], func(a float64) float64 { return 1 / math.Sqrt(a) })
+	case shaderir.Abs:
+		return map1(args[0], math.Abs)
+	case shaderir.Sign:
+		return map1(args[0], func(a float64) float64 {
+			switch {
+			case a > 0:
+				return 1
+			case a < 0:
+				return -1
+			}
+			return 0
+		})
+	case shaderir.Floor:
+		return map1(args[0], math.Floor)
+	case shaderir.Ceil:
+		return map1(args[0], math.Ceil)
+	case shaderir.Fract:
+		return map1(args[0], func(a float64) float64 { return a - math.Floor(a) })
+	case shaderir.Mod:
+		return map2(args[0], args[1], func(a, b float64) float64 { return a - b*math.Floor(a/b) })
+	case shaderir.Min:
+		return map2(args[0], args[1], math.Min)
+	case shaderir.Max:
+		return map2(args[0], args[1], math.Max)
+	case shaderir.Clamp:
+		return map3(args[0], args[1], args[2], clamp)
+	case shaderir.Mix:
+		return map3(args[0], args[1], args[2], func(a, b, c float64) float64 { return a*(1-c) + b*c })
+	case shaderir.Step:
+		return map2(args[0], args[1], func(edge, a float64) float64 {
+			if a < edge {
+				return 0
+			}
+			return 1
+		})
+	case shaderir.Smoothstep:
+		return map3(args[0], args[1], args[2], func(e0, e1, a float64) float64 {
+			t := clamp((a-e0)/(e1-e0), 0, 1)
+			return t * t * (3 - 2*t)
+		})
+	case shaderir.Length:
+		return scalarValue(shaderir.Float, math.Sqrt(dot(args[0], args[0])))
+	case shaderir.Distance:
+		d := sub(args[0], args[1])
+		return scalarValue(shaderir.Float, math.Sqrt(dot(d, d)))
+	case shaderir.Dot:
+		return scalarValue(shaderir.Float, dot(args[0], args[1]))
+	case shaderir.Cross:
+		x, y := args[0], args[1]
+		v := value{typ: shaderir.Vec3}
+		v.v[0] = x.v[1]*y.v[2] - x.v[2]*y.v[1]
+		v.v[1] = x.v[2]*y.v[0] - x.v[0]*y.v[2]
+		v.v[2] = x.v[0]*y.v[1] - x.v[1]*y.v[0]
+		return v
+	case shaderir.Normalize:
+		return scale(args[0], 1/math.Sqrt(dot(args[0], args[0])))
+	case shaderir.Faceforward:
+		if dot(args[2], args[1]) < 0 {
+			return args[0]
+		}
+		return scale(args[0], -1)
+	case shaderir.Reflect:
+		i, n := args[0], args[1]
+		return sub(i, scale(n, 2*dot(n, i)))
+	case shaderir.Refract:
+		i, n, eta := args[0], args[1], args[2].v[0]
+		d := dot(n, i)
+		k := 1 - eta*eta*(1-d*d)
+		if k < 0 {
+			return i.zero()
+		}
+		return sub(scale(i, eta), scale(n, eta*d+math.Sqrt(k)))
+	case shaderir.Transpose:
+		x := args[0]
+		n := matrixSize(x.typ)
+		v := value{typ: x.typ}
+		for c := 0; c < n; c++ {
+			for r := 0; r < n; r++ {
+				v.v[c*n+r] = x.v[r*n+c]
+			}
+		}
+		return v
+	case shaderir.Dfdx, shaderir.Dfdy, shaderir.Fwidth:
+		// Derivatives require evaluating neighboring fragments together, which this interpreter doesn't do.
+		// Treat the values as constants.
+		return args[0].zero()
+	case shaderir.TexelAt:
+		return in.texelAt(args[0].tex, args[1].v[0], args[1].v[1])
+	default:
+		panic(fmt.Sprintf("software: unexpected builtin function: %s", f))
+	}
+}
+
+// texelAt returns the texel of the given texture at (x, y) as a premultiplied-alpha color.
+//
+// For the pixel unit, (x, y) is in pixels and this works like texelFetch.
+// For the texel unit, (x, y) is in texels and this works like a texture sampling with the nearest filter.
+func (in *interpreter) texelAt(tex int, x, y float64) value {
+	v := value{typ: shaderir.Vec4}
+	img := in.textures[tex]
+	if img == nil {
+		return v
+	}
+
+	w, h := img.framebufferSize()
+	var ix, iy int
+	switch in.program.Unit {
+	case shaderir.Pixels:
+		// Truncate the values toward zero like ivec2 in GLSL.
+		ix, iy = int(x), int(y)
+		if ix < 0 || iy < 0 || ix >= w || iy >= h {
+			return v
+		}
+	case shaderir.Texels:
+		ix = int(clamp(math.Floor(x*float64(w)), 0, float64(w-1)))
+		iy = int(clamp(math.Floor(y*float64(h)), 0, float64(h-1)))
+	}
+
+	p := img.pixels[4*(iy*w+ix):]
+	for i := 0; i < 4; i++ {
+		v.v[i] = float64(p[i]) / 0xff
+	}
+	return v
+}
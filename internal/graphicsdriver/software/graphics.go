@@ -0,0 +1,196 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package software offers a graphics driver that renders images with CPUs.
+//
+// The driver interprets shader programs and rasterizes triangles in pure Go.
+// This is slow, but the results are deterministic and don't depend on GPUs,
+// which is useful for testing the rendering without GPUs.
+//
+// The driver doesn't present the screen to a window.
+package software
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+// maxImageSize is the maximum size of an image.
+// 4096 is enough for the texture atlas, and this limits the memory usage.
+const maxImageSize = 4096
+
+type Graphics struct {
+	nextImageID graphicsdriver.ImageID
+	images      map[graphicsdriver.ImageID]*Image
+
+	nextShaderID graphicsdriver.ShaderID
+	shaders      map[graphicsdriver.ShaderID]*Shader
+
+	vertices []float32
+	indices  []uint32
+
+	rasterizer rasterizer
+}
+
+func NewGraphics() *Graphics {
+	return &Graphics{}
+}
+
+func (g *Graphics) Initialize() error {
+	return nil
+}
+
+func (g *Graphics) Begin() error {
+	return nil
+}
+
+func (g *Graphics) End(present bool) error {
+	return nil
+}
+
+func (g *Graphics) SetTransparent(transparent bool) {
+	// Do nothing.
+}
+
+func (g *Graphics) SetVertices(vertices []float32, indices []uint32) error {
+	// Copy the slices as the caller might reuse them.
+	g.vertices = append(g.vertices[:0], vertices...)
+	g.indices = append(g.indices[:0], indices...)
+	return nil
+}
+
+func (g *Graphics) checkSize(width, height int) {
+	if width < 1 {
+		panic(fmt.Sprintf("software: width (%d) must be equal or more than %d", width, 1))
+	}
+	if height < 1 {
+		panic(fmt.Sprintf("software: height (%d) must be equal or more than %d", height, 1))
+	}
+	if width > maxImageSize {
+		panic(fmt.Sprintf("software: width (%d) must be less than or equal to %d", width, maxImageSize))
+	}
+	if height > maxImageSize {
+		panic(fmt.Sprintf("software: height (%d) must be less than or equal to %d", height, maxImageSize))
+	}
+}
+
+func (g *Graphics) genNextImageID() graphicsdriver.ImageID {
+	g.nextImageID++
+	return g.nextImageID
+}
+
+func (g *Graphics) genNextShaderID() graphicsdriver.ShaderID {
+	g.nextShaderID++
+	return g.nextShaderID
+}
+
+func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
+	g.checkSize(graphics.InternalImageSize(width), graphics.InternalImageSize(height))
+	i := newImage(g.genNextImageID(), g, width, height, false)
+	g.addImage(i)
+	return i, nil
+}
+
+func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
+	g.checkSize(width, height)
+	i := newImage(g.genNextImageID(), g, width, height, true)
+	g.addImage(i)
+	return i, nil
+}
+
+func (g *Graphics) addImage(img *Image) {
+	if g.images == nil {
+		g.images = map[graphicsdriver.ImageID]*Image{}
+	}
+	if _, ok := g.images[img.id]; ok {
+		panic(fmt.Sprintf("software: image ID %d was already registered", img.id))
+	}
+	g.images[img.id] = img
+}
+
+func (g *Graphics) removeImage(img *Image) {
+	delete(g.images, img.id)
+}
+
+func (g *Graphics) SetVsyncEnabled(enabled bool) {
+	// Do nothing.
+}
+
+func (g *Graphics) NeedsClearingScreen() bool {
+	return true
+}
+
+func (g *Graphics) MaxImageSize() int {
+	return maxImageSize
+}
+
+func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
+	s, err := newShader(g.genNextShaderID(), g, program)
+	if err != nil {
+		return nil, err
+	}
+	g.addShader(s)
+	return s, nil
+}
+
+func (g *Graphics) addShader(shader *Shader) {
+	if g.shaders == nil {
+		g.shaders = map[graphicsdriver.ShaderID]*Shader{}
+	}
+	if _, ok := g.shaders[shader.id]; ok {
+		panic(fmt.Sprintf("software: shader ID %d was already registered", shader.id))
+	}
+	g.shaders[shader.id] = shader
+}
+
+func (g *Graphics) removeShader(shader *Shader) {
+	delete(g.shaders, shader.id)
+}
+
+func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.ShaderImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+	if shaderID == graphicsdriver.InvalidShaderID {
+		return fmt.Errorf("software: shader ID is invalid")
+	}
+
+	destination := g.images[dstID]
+	shader := g.shaders[shaderID]
+	shader.setUniforms(uniforms)
+
+	in := shader.interpreter
+	for i, srcID := range srcIDs {
+		in.textures[i] = nil
+		if srcID == graphicsdriver.InvalidImageID {
+			continue
+		}
+		in.textures[i] = g.images[srcID]
+	}
+	defer func() {
+		// Don't keep the images after drawing.
+		for i := range in.textures {
+			in.textures[i] = nil
+		}
+	}()
+
+	g.rasterizer.begin(destination, shader, g.vertices, blend, fillRule)
+	for _, dstRegion := range dstRegions {
+		g.rasterizer.drawRegion(dstRegion.Region, g.indices[indexOffset:indexOffset+dstRegion.IndexCount])
+		indexOffset += dstRegion.IndexCount
+	}
+	g.rasterizer.end()
+
+	return nil
+}
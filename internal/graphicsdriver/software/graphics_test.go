@@ -0,0 +1,319 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/software"
+)
+
+// preservedUniforms returns the preserved uniform values in the same way as internal/graphicscommand does for the pixel unit.
+func preservedUniforms(dstSize, srcSize image.Point, dstRegion, srcRegion image.Rectangle) []uint32 {
+	u := make([]uint32, graphics.PreservedUniformUint32Count)
+	f := func(idx int, x float32) {
+		u[idx] = math.Float32bits(x)
+	}
+
+	f(0, float32(dstSize.X))
+	f(1, float32(dstSize.Y))
+	f(2, float32(srcSize.X))
+	f(3, float32(srcSize.Y))
+	idx := 2 + 2*graphics.ShaderImageCount
+
+	f(idx, float32(dstRegion.Min.X))
+	f(idx+1, float32(dstRegion.Min.Y))
+	f(idx+2, float32(dstRegion.Dx()))
+	f(idx+3, float32(dstRegion.Dy()))
+	idx += 4
+
+	f(idx, float32(srcRegion.Min.X))
+	f(idx+1, float32(srcRegion.Min.Y))
+	idx += 2 * graphics.ShaderImageCount
+	f(idx, float32(srcRegion.Dx()))
+	f(idx+1, float32(srcRegion.Dy()))
+	idx += 2 * graphics.ShaderImageCount
+
+	f(idx, 2/float32(dstSize.X))
+	f(idx+5, 2/float32(dstSize.Y))
+	f(idx+10, 1)
+	f(idx+12, -1)
+	f(idx+13, -1)
+	f(idx+15, 1)
+	return u
+}
+
+func newShader(t *testing.T, g *software.Graphics, useColorM bool) graphicsdriver.Shader {
+	ir, err := graphics.CompileShader(builtinshader.Shader(builtinshader.FilterNearest, builtinshader.AddressUnsafe, useColorM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := g.NewShader(ir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newImage(t *testing.T, g *software.Graphics, width, height int, pix []byte) graphicsdriver.Image {
+	img, err := g.NewImage(width, height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pix != nil {
+		if err := img.WritePixels([]graphicsdriver.PixelsArgs{
+			{
+				Pixels: pix,
+				Region: image.Rect(0, 0, width, height),
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return img
+}
+
+func readPixels(t *testing.T, img graphicsdriver.Image, width, height int) []byte {
+	pix := make([]byte, 4*width*height)
+	if err := img.ReadPixels([]graphicsdriver.PixelsArgs{
+		{
+			Pixels: pix,
+			Region: image.Rect(0, 0, width, height),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return pix
+}
+
+// drawQuad draws the whole src onto dst with the given scale and the translation.
+func drawQuad(t *testing.T, g *software.Graphics, dst, src graphicsdriver.Image, dstSize, srcSize image.Point, shader graphicsdriver.Shader, scale, tx, ty float32, blend graphicsdriver.Blend, extraUniforms []uint32) {
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	graphics.QuadVertices(vs, 0, 0, float32(srcSize.X), float32(srcSize.Y), scale, 0, 0, scale, tx, ty, 1, 1, 1, 1)
+	if err := g.SetVertices(vs, graphics.QuadIndices()); err != nil {
+		t.Fatal(err)
+	}
+
+	dstRegion := image.Rect(0, 0, dstSize.X, dstSize.Y)
+	us := preservedUniforms(image.Pt(graphics.InternalImageSize(dstSize.X), graphics.InternalImageSize(dstSize.Y)), image.Pt(graphics.InternalImageSize(srcSize.X), graphics.InternalImageSize(srcSize.Y)), dstRegion, image.Rect(0, 0, srcSize.X, srcSize.Y))
+	us = append(us, extraUniforms...)
+
+	var srcs [graphics.ShaderImageCount]graphicsdriver.ImageID
+	srcs[0] = src.ID()
+	if err := g.DrawTriangles(dst.ID(), srcs, shader.ID(), []graphicsdriver.DstRegion{
+		{
+			Region:     dstRegion,
+			IndexCount: len(graphics.QuadIndices()),
+		},
+	}, 0, blend, us, graphicsdriver.FillAll); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDrawTriangles(t *testing.T) {
+	g := software.NewGraphics()
+	shader := newShader(t, g, false)
+
+	src := newImage(t, g, 2, 2, []byte{
+		0xff, 0, 0, 0xff, 0, 0xff, 0, 0xff,
+		0, 0, 0xff, 0xff, 0x80, 0x80, 0x80, 0x80,
+	})
+	dst := newImage(t, g, 5, 5, nil)
+	drawQuad(t, g, dst, src, image.Pt(5, 5), image.Pt(2, 2), shader, 2, 1, 1, graphicsdriver.BlendSourceOver, nil)
+
+	got := readPixels(t, dst, 5, 5)
+	for j := 0; j < 5; j++ {
+		for i := 0; i < 5; i++ {
+			var want [4]byte
+			if i >= 1 && j >= 1 {
+				sx, sy := (i-1)/2, (j-1)/2
+				switch {
+				case sx == 0 && sy == 0:
+					want = [4]byte{0xff, 0, 0, 0xff}
+				case sx == 1 && sy == 0:
+					want = [4]byte{0, 0xff, 0, 0xff}
+				case sx == 0 && sy == 1:
+					want = [4]byte{0, 0, 0xff, 0xff}
+				default:
+					want = [4]byte{0x80, 0x80, 0x80, 0x80}
+				}
+			}
+			idx := 4 * (j*5 + i)
+			if got := [4]byte{got[idx], got[idx+1], got[idx+2], got[idx+3]}; got != want {
+				t.Errorf("(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawTrianglesSharedEdge(t *testing.T) {
+	g := software.NewGraphics()
+	shader := newShader(t, g, false)
+
+	const size = 4
+	pix := make([]byte, 4*size*size)
+	for i := 0; i < len(pix); i += 4 {
+		pix[i] = 0x40
+		pix[i+3] = 0x40
+	}
+	src := newImage(t, g, size, size, pix)
+	dst := newImage(t, g, size, size, nil)
+	drawQuad(t, g, dst, src, image.Pt(size, size), image.Pt(size, size), shader, 1, 0, 0, graphicsdriver.BlendSourceOver, nil)
+
+	// The pixels on the diagonal are on the edge shared by the two triangles, and must be drawn only once.
+	got := readPixels(t, dst, size, size)
+	for i := 0; i < len(got); i += 4 {
+		if got[i] != 0x40 || got[i+3] != 0x40 {
+			t.Errorf("pixel %d: got: %v, want: [64 0 0 64]", i/4, got[i:i+4])
+		}
+	}
+}
+
+func TestDrawTrianglesColorM(t *testing.T) {
+	g := software.NewGraphics()
+	shader := newShader(t, g, true)
+
+	src := newImage(t, g, 1, 1, []byte{0x80, 0x40, 0x20, 0xff})
+	dst := newImage(t, g, 1, 1, nil)
+
+	// Swap the red and the blue channels, and add 0.5 to the green.
+	body := []float32{
+		0, 0, 1, 0,
+		0, 1, 0, 0,
+		1, 0, 0, 0,
+		0, 0, 0, 1,
+	}
+	translation := []float32{0, 0.5, 0, 0}
+	var us []uint32
+	for _, x := range append(body, translation...) {
+		us = append(us, math.Float32bits(x))
+	}
+	drawQuad(t, g, dst, src, image.Pt(1, 1), image.Pt(1, 1), shader, 1, 0, 0, graphicsdriver.BlendCopy, us)
+
+	got := readPixels(t, dst, 1, 1)
+	want := []byte{0x20, 0xc0, 0x80, 0xff}
+	for i := range want {
+		// Allow an error of 1 for rounding.
+		if d := int(got[i]) - int(want[i]); d < -1 || d > 1 {
+			t.Errorf("got: %v, want: %v", got, want)
+			break
+		}
+	}
+}
+
+func TestDrawTrianglesEvenOdd(t *testing.T) {
+	g := software.NewGraphics()
+	shader := newShader(t, g, false)
+
+	src := newImage(t, g, 1, 1, []byte{0xff, 0xff, 0xff, 0xff})
+	dst := newImage(t, g, 4, 1, nil)
+
+	// Two overlapping quads: [0, 3) and [1, 4).
+	vs := make([]float32, 8*graphics.VertexFloatCount)
+	graphics.QuadVertices(vs, 0, 0, 1, 1, 3, 0, 0, 1, 0, 0, 1, 1, 1, 1)
+	graphics.QuadVertices(vs[4*graphics.VertexFloatCount:], 0, 0, 1, 1, 3, 0, 0, 1, 1, 0, 1, 1, 1, 1)
+	is := []uint32{0, 1, 2, 1, 2, 3, 4, 5, 6, 5, 6, 7}
+	if err := g.SetVertices(vs, is); err != nil {
+		t.Fatal(err)
+	}
+
+	dstRegion := image.Rect(0, 0, 4, 1)
+	us := preservedUniforms(image.Pt(graphics.InternalImageSize(4), graphics.InternalImageSize(1)), image.Pt(graphics.InternalImageSize(1), graphics.InternalImageSize(1)), dstRegion, image.Rect(0, 0, 1, 1))
+	var srcs [graphics.ShaderImageCount]graphicsdriver.ImageID
+	srcs[0] = src.ID()
+	if err := g.DrawTriangles(dst.ID(), srcs, shader.ID(), []graphicsdriver.DstRegion{
+		{
+			Region:     dstRegion,
+			IndexCount: len(is),
+		},
+	}, 0, graphicsdriver.BlendCopy, us, graphicsdriver.EvenOdd); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readPixels(t, dst, 4, 1)
+	for i := 0; i < 4; i++ {
+		want := byte(0)
+		if i == 0 || i == 3 {
+			want = 0xff
+		}
+		if got[4*i+3] != want {
+			t.Errorf("pixel %d: alpha: got: %d, want: %d", i, got[4*i+3], want)
+		}
+	}
+}
+
+func TestDrawTrianglesCustomShader(t *testing.T) {
+	g := software.NewGraphics()
+
+	ir, err := graphics.CompileShader([]byte(`//kage:unit pixels
+
+package main
+
+var Values [4]float
+
+func sumAndMax(xs [4]float) (float, float) {
+	s := 0.0
+	m := 0.0
+	for i := 0; i < 4; i++ {
+		s += xs[i]
+		if xs[i] > m {
+			m = xs[i]
+		}
+	}
+	return s, m
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	s, m := sumAndMax(Values)
+	if dstPos.x > 1 {
+		discard()
+	}
+	var c vec4
+	c.rg = vec2(s, m) / 4
+	c.a = 1
+	return c
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shader, err := g.NewShader(ir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := newImage(t, g, 2, 1, nil)
+	dst := newImage(t, g, 2, 1, nil)
+	var us []uint32
+	for _, x := range []float32{0.25, 1, 0.5, 0.25} {
+		us = append(us, math.Float32bits(x))
+	}
+	drawQuad(t, g, dst, src, image.Pt(2, 1), image.Pt(2, 1), shader, 1, 0, 0, graphicsdriver.BlendCopy, us)
+
+	got := readPixels(t, dst, 2, 1)
+	// The sum is 2 and the max is 1. The second pixel is discarded.
+	want := []byte{0x80, 0x40, 0, 0xff, 0, 0, 0, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got: %v, want: %v", got, want)
+			break
+		}
+	}
+}
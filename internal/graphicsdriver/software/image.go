@@ -0,0 +1,90 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"errors"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+)
+
+// Image is an image whose pixels are premultiplied-alpha RGBA values on memory.
+type Image struct {
+	id       graphicsdriver.ImageID
+	graphics *Graphics
+	width    int
+	height   int
+	screen   bool
+
+	// pixels's size is the framebuffer size, not the image size.
+	pixels []byte
+}
+
+func newImage(id graphicsdriver.ImageID, graphics *Graphics, width, height int, screen bool) *Image {
+	i := &Image{
+		id:       id,
+		graphics: graphics,
+		width:    width,
+		height:   height,
+		screen:   screen,
+	}
+	w, h := i.framebufferSize()
+	i.pixels = make([]byte, 4*w*h)
+	return i
+}
+
+func (i *Image) ID() graphicsdriver.ImageID {
+	return i.id
+}
+
+func (i *Image) Dispose() {
+	i.pixels = nil
+	i.graphics.removeImage(i)
+}
+
+func (i *Image) framebufferSize() (int, int) {
+	if i.screen {
+		return i.width, i.height
+	}
+	return graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
+}
+
+func (i *Image) ReadPixels(args []graphicsdriver.PixelsArgs) error {
+	w, _ := i.framebufferSize()
+	for _, a := range args {
+		n := 4 * a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			src := i.pixels[4*((a.Region.Min.Y+j)*w+a.Region.Min.X):]
+			copy(a.Pixels[j*n:(j+1)*n], src[:n])
+		}
+	}
+	return nil
+}
+
+func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
+	if i.screen {
+		return errors.New("software: WritePixels cannot be called on the screen")
+	}
+	w, _ := i.framebufferSize()
+	for _, a := range args {
+		n := 4 * a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			dst := i.pixels[4*((a.Region.Min.Y+j)*w+a.Region.Min.X):]
+			copy(dst[:n], a.Pixels[j*n:(j+1)*n])
+		}
+	}
+	return nil
+}
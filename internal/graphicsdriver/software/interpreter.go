@@ -0,0 +1,623 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"go/constant"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+// flow represents how the control goes after a statement is executed.
+type flow int
+
+const (
+	flowNext flow = iota
+	flowBreak
+	flowContinue
+	flowReturn
+	flowDiscard
+)
+
+type frame struct {
+	locals []value
+	ret    value
+}
+
+func (f *frame) local(idx int) *value {
+	for len(f.locals) <= idx {
+		f.locals = append(f.locals, value{})
+	}
+	return &f.locals[idx]
+}
+
+// interpreter executes a shader program in the intermediate representation.
+type interpreter struct {
+	program  *shaderir.Program
+	funcs    map[int]*shaderir.Func
+	uniforms []value
+	textures [graphics.ShaderImageCount]*Image
+
+	frames []*frame
+	depth  int
+}
+
+func newInterpreter(program *shaderir.Program) *interpreter {
+	in := &interpreter{
+		program: program,
+		funcs:   map[int]*shaderir.Func{},
+	}
+	for i := range program.Funcs {
+		f := &program.Funcs[i]
+		in.funcs[f.Index] = f
+	}
+	return in
+}
+
+func (in *interpreter) pushFrame() *frame {
+	if in.depth == len(in.frames) {
+		in.frames = append(in.frames, &frame{})
+	}
+	f := in.frames[in.depth]
+	f.ret = value{}
+	in.depth++
+	return f
+}
+
+func (in *interpreter) popFrame() {
+	in.depth--
+}
+
+// runVertex runs the vertex entry point with a vertex's attributes.
+// runVertex returns the position in the clip space, and writes the varying values into varyings.
+func (in *interpreter) runVertex(vertex []float32, varyings []value) value {
+	p := in.program
+	f := in.pushFrame()
+	defer in.popFrame()
+
+	var k int
+	for i, t := range p.Attributes {
+		v := value{typ: t.Main}
+		for j := 0; j < v.count(); j++ {
+			v.v[j] = float64(vertex[k])
+			k++
+		}
+		*f.local(i) = v
+	}
+	na := len(p.Attributes)
+	*f.local(na) = value{typ: shaderir.Vec4}
+	for i := range p.Varyings {
+		*f.local(na + 1 + i) = zeroValue(&p.Varyings[i])
+	}
+
+	in.execBlock(f, p.VertexFunc.Block)
+
+	for i := range varyings {
+		varyings[i] = f.locals[na+1+i]
+	}
+	return f.locals[na]
+}
+
+// runFragment runs the fragment entry point.
+// runFragment returns the color and true, or returns false when the fragment is discarded.
+func (in *interpreter) runFragment(fragCoord value, varyings []value) (value, bool) {
+	f := in.pushFrame()
+	defer in.popFrame()
+
+	*f.local(0) = fragCoord
+	for i, v := range varyings {
+		*f.local(1 + i) = v
+	}
+
+	if in.execBlock(f, in.program.FragmentFunc.Block) == flowDiscard {
+		return value{}, false
+	}
+	return f.ret, true
+}
+
+func (in *interpreter) execBlock(f *frame, block *shaderir.Block) flow {
+	for i := range block.LocalVars {
+		*f.local(block.LocalVarIndexOffset + i) = zeroValue(&block.LocalVars[i])
+	}
+	for i := range block.Stmts {
+		if fl := in.execStmt(f, &block.Stmts[i]); fl != flowNext {
+			return fl
+		}
+	}
+	return flowNext
+}
+
+func (in *interpreter) execStmt(f *frame, s *shaderir.Stmt) flow {
+	switch s.Type {
+	case shaderir.ExprStmt:
+		in.eval(f, &s.Exprs[0])
+	case shaderir.BlockStmt:
+		return in.execBlock(f, s.Blocks[0])
+	case shaderir.Assign:
+		in.store(f, &s.Exprs[0], in.eval(f, &s.Exprs[1]))
+	case shaderir.Init:
+		v := f.local(s.InitIndex)
+		*v = v.zero()
+	case shaderir.If:
+		if c := in.eval(f, &s.Exprs[0]); c.bool() {
+			return in.execBlock(f, s.Blocks[0])
+		}
+		if len(s.Blocks) > 1 {
+			return in.execBlock(f, s.Blocks[1])
+		}
+	case shaderir.For:
+		t := s.ForVarType.Main
+		end := constantValue(s.ForEnd, t)
+		delta := constantValue(s.ForDelta, t)
+		*f.local(s.ForVarIndex) = constantValue(s.ForInit, t)
+		for compare(s.ForOp, f.locals[s.ForVarIndex].v[0], end.v[0]) {
+			fl := in.execBlock(f, s.Blocks[0])
+			if fl == flowBreak {
+				break
+			}
+			if fl == flowReturn || fl == flowDiscard {
+				return fl
+			}
+			f.locals[s.ForVarIndex].v[0] += delta.v[0]
+		}
+	case shaderir.Continue:
+		return flowContinue
+	case shaderir.Break:
+		return flowBreak
+	case shaderir.Return:
+		if len(s.Exprs) > 0 {
+			f.ret = in.eval(f, &s.Exprs[0]).clone()
+		}
+		return flowReturn
+	case shaderir.Discard:
+		return flowDiscard
+	default:
+		panic(fmt.Sprintf("software: unexpected statement: %d", s.Type))
+	}
+	return flowNext
+}
+
+func (in *interpreter) eval(f *frame, e *shaderir.Expr) value {
+	switch e.Type {
+	case shaderir.NumberExpr:
+		return constantValue(e.Const, shaderir.None)
+	case shaderir.UniformVariable:
+		return in.uniforms[e.Index]
+	case shaderir.TextureVariable:
+		return value{typ: shaderir.Texture, tex: e.Index}
+	case shaderir.LocalVariable:
+		return *f.local(e.Index)
+	case shaderir.Unary:
+		x := in.eval(f, &e.Exprs[0])
+		switch e.Op {
+		case shaderir.Add:
+			return x
+		case shaderir.Sub:
+			for i := 0; i < x.count(); i++ {
+				x.v[i] = -x.v[i]
+			}
+			return x
+		case shaderir.NotOp:
+			return boolValue(!x.bool())
+		default:
+			panic(fmt.Sprintf("software: unexpected unary operator: %d", e.Op))
+		}
+	case shaderir.Binary:
+		return in.binary(f, e)
+	case shaderir.Selection:
+		if c := in.eval(f, &e.Exprs[0]); c.bool() {
+			return in.eval(f, &e.Exprs[1])
+		}
+		return in.eval(f, &e.Exprs[2])
+	case shaderir.Call:
+		return in.call(f, e)
+	case shaderir.FieldSelector:
+		return swizzle(in.eval(f, &e.Exprs[0]), e.Exprs[1].Swizzling)
+	case shaderir.Index:
+		x := in.eval(f, &e.Exprs[0])
+		i := in.eval(f, &e.Exprs[1])
+		return index(x, int(i.v[0]))
+	default:
+		panic(fmt.Sprintf("software: unexpected expression: %d", e.Type))
+	}
+}
+
+// store assigns v to the place that e represents.
+func (in *interpreter) store(f *frame, e *shaderir.Expr, v value) {
+	switch e.Type {
+	case shaderir.LocalVariable:
+		dst := f.local(e.Index)
+		if dst.typ == shaderir.Array {
+			// Copy the elements so that the array doesn't share the elements with other variables.
+			for i := range dst.elems {
+				dst.elems[i] = v.elems[i].convert(dst.elems[i].typ)
+			}
+			return
+		}
+		*dst = v.clone().convert(dst.typ)
+	case shaderir.FieldSelector:
+		base := in.eval(f, &e.Exprs[0])
+		for i, c := range e.Exprs[1].Swizzling {
+			base.v[swizzleIndex(c)] = v.at(i)
+		}
+		in.store(f, &e.Exprs[0], base)
+	case shaderir.Index:
+		base := in.eval(f, &e.Exprs[0])
+		idx := in.eval(f, &e.Exprs[1])
+		i := int(idx.v[0])
+		switch {
+		case base.typ == shaderir.Array:
+			if i < 0 || i >= len(base.elems) {
+				return
+			}
+			base.elems[i] = v.convert(base.elems[i].typ)
+		case isMatrix(base.typ):
+			n := matrixSize(base.typ)
+			if i < 0 || i >= n {
+				return
+			}
+			for r := 0; r < n; r++ {
+				base.v[i*n+r] = v.at(r)
+			}
+		default:
+			if i < 0 || i >= base.count() {
+				return
+			}
+			base.v[i] = v.v[0]
+		}
+		in.store(f, &e.Exprs[0], base)
+	default:
+		panic(fmt.Sprintf("software: unexpected expression to assign: %d", e.Type))
+	}
+}
+
+func (in *interpreter) call(f *frame, e *shaderir.Expr) value {
+	callee := &e.Exprs[0]
+	args := e.Exprs[1:]
+
+	switch callee.Type {
+	case shaderir.BuiltinFuncExpr:
+		var buf [4]value
+		vs := buf[:0]
+		for i := range args {
+			vs = append(vs, in.eval(f, &args[i]))
+		}
+		return in.builtin(callee.BuiltinFunc, vs)
+	case shaderir.FunctionExpr:
+		fn, ok := in.funcs[callee.Index]
+		if !ok {
+			panic(fmt.Sprintf("software: function %d is not found", callee.Index))
+		}
+
+		// Evaluate the arguments with the caller's frame before pushing a new frame,
+		// as pushing a frame might reuse a frame.
+		var buf [4]value
+		vs := buf[:0]
+		for i := range fn.InParams {
+			vs = append(vs, in.eval(f, &args[i]).clone().convert(fn.InParams[i].Main))
+		}
+
+		cf := in.pushFrame()
+		for i, v := range vs {
+			*cf.local(i) = v
+		}
+		nin := len(fn.InParams)
+		for i := range fn.OutParams {
+			*cf.local(nin + i) = zeroValue(&fn.OutParams[i])
+		}
+
+		in.execBlock(cf, fn.Block)
+
+		// cf might be reused when storing the out-params, so copy the values first.
+		var outBuf [4]value
+		outs := outBuf[:0]
+		for i := range fn.OutParams {
+			outs = append(outs, cf.locals[nin+i].clone())
+		}
+		ret := cf.ret.convert(fn.Return.Main)
+		in.popFrame()
+
+		for i, v := range outs {
+			in.store(f, &args[nin+i], v)
+		}
+		return ret
+	default:
+		panic(fmt.Sprintf("software: unexpected callee: %d", callee.Type))
+	}
+}
+
+func (in *interpreter) binary(f *frame, e *shaderir.Expr) value {
+	switch e.Op {
+	case shaderir.AndAnd:
+		if l := in.eval(f, &e.Exprs[0]); !l.bool() {
+			return boolValue(false)
+		}
+		r := in.eval(f, &e.Exprs[1])
+		return boolValue(r.bool())
+	case shaderir.OrOr:
+		if l := in.eval(f, &e.Exprs[0]); l.bool() {
+			return boolValue(true)
+		}
+		r := in.eval(f, &e.Exprs[1])
+		return boolValue(r.bool())
+	}
+
+	l := in.eval(f, &e.Exprs[0])
+	r := in.eval(f, &e.Exprs[1])
+	switch e.Op {
+	case shaderir.Add, shaderir.Sub, shaderir.ComponentWiseMul, shaderir.Div, shaderir.ModOp:
+		return arithmetic(e.Op, l, r)
+	case shaderir.MatrixMul:
+		return matrixMul(l, r)
+	case shaderir.LeftShift, shaderir.RightShift, shaderir.And, shaderir.Xor, shaderir.Or:
+		return bitwise(e.Op, l, r)
+	case shaderir.LessThanOp, shaderir.LessThanEqualOp, shaderir.GreaterThanOp, shaderir.GreaterThanEqualOp:
+		return boolValue(compare(e.Op, l.v[0], r.v[0]))
+	case shaderir.EqualOp, shaderir.VectorEqualOp:
+		return boolValue(equal(&l, &r))
+	case shaderir.NotEqualOp, shaderir.VectorNotEqualOp:
+		return boolValue(!equal(&l, &r))
+	default:
+		panic(fmt.Sprintf("software: unexpected binary operator: %d", e.Op))
+	}
+}
+
+func constantValue(c constant.Value, t shaderir.BasicType) value {
+	if t == shaderir.None {
+		switch c.Kind() {
+		case constant.Bool:
+			t = shaderir.Bool
+		case constant.Int:
+			t = shaderir.Int
+		default:
+			t = shaderir.Float
+		}
+	}
+	switch t {
+	case shaderir.Bool:
+		return boolValue(constant.BoolVal(c))
+	case shaderir.Int:
+		x, _ := constant.Int64Val(constant.ToInt(c))
+		return scalarValue(shaderir.Int, float64(x))
+	default:
+		x, _ := constant.Float64Val(constant.ToFloat(c))
+		return scalarValue(shaderir.Float, x)
+	}
+}
+
+// resultType returns the type of a component-wise operation's result.
+func resultType(vs ...value) shaderir.BasicType {
+	n := 1
+	elem := shaderir.Bool
+	for _, v := range vs {
+		if isMatrix(v.typ) {
+			return v.typ
+		}
+		if c := v.count(); c > n {
+			n = c
+		}
+		switch elementType(v.typ) {
+		case shaderir.Float:
+			elem = shaderir.Float
+		case shaderir.Int:
+			if elem == shaderir.Bool {
+				elem = shaderir.Int
+			}
+		}
+	}
+	return vectorType(elem, n)
+}
+
+func arithmetic(op shaderir.Op, l, r value) value {
+	v := value{typ: resultType(l, r)}
+	isInt := elementType(v.typ) == shaderir.Int
+	for i := 0; i < v.count(); i++ {
+		a, b := l.at(i), r.at(i)
+		var x float64
+		switch op {
+		case shaderir.Add:
+			x = a + b
+		case shaderir.Sub:
+			x = a - b
+		case shaderir.ComponentWiseMul:
+			x = a * b
+		case shaderir.Div:
+			if isInt {
+				if b != 0 {
+					x = float64(int64(a) / int64(b))
+				}
+			} else {
+				x = a / b
+			}
+		case shaderir.ModOp:
+			if isInt {
+				if b != 0 {
+					x = float64(int64(a) % int64(b))
+				}
+			} else {
+				x = a - b*math.Floor(a/b)
+			}
+		}
+		if isInt {
+			x = truncateToInt32(x)
+		}
+		v.v[i] = x
+	}
+	return v
+}
+
+func matrixMul(l, r value) value {
+	if !isMatrix(l.typ) || !isMatrix(r.typ) {
+		if l.count() == 1 || r.count() == 1 {
+			return arithmetic(shaderir.ComponentWiseMul, l, r)
+		}
+	}
+
+	switch {
+	case isMatrix(l.typ) && isMatrix(r.typ):
+		n := matrixSize(l.typ)
+		v := value{typ: l.typ}
+		for c := 0; c < n; c++ {
+			for row := 0; row < n; row++ {
+				var x float64
+				for k := 0; k < n; k++ {
+					x += l.v[k*n+row] * r.v[c*n+k]
+				}
+				v.v[c*n+row] = x
+			}
+		}
+		return v
+	case isMatrix(l.typ):
+		n := matrixSize(l.typ)
+		v := value{typ: vectorType(shaderir.Float, n)}
+		for row := 0; row < n; row++ {
+			var x float64
+			for k := 0; k < n; k++ {
+				x += l.v[k*n+row] * r.v[k]
+			}
+			v.v[row] = x
+		}
+		return v
+	case isMatrix(r.typ):
+		n := matrixSize(r.typ)
+		v := value{typ: vectorType(shaderir.Float, n)}
+		for c := 0; c < n; c++ {
+			var x float64
+			for k := 0; k < n; k++ {
+				x += l.v[k] * r.v[c*n+k]
+			}
+			v.v[c] = x
+		}
+		return v
+	default:
+		panic(fmt.Sprintf("software: unexpected operands for matrix multiplication: %d and %d", l.typ, r.typ))
+	}
+}
+
+func bitwise(op shaderir.Op, l, r value) value {
+	v := value{typ: resultType(l, r)}
+	for i := 0; i < v.count(); i++ {
+		a, b := int32(l.at(i)), int32(r.at(i))
+		var x int32
+		switch op {
+		case shaderir.LeftShift:
+			x = a << (uint32(b) & 31)
+		case shaderir.RightShift:
+			x = a >> (uint32(b) & 31)
+		case shaderir.And:
+			x = a & b
+		case shaderir.Xor:
+			x = a ^ b
+		case shaderir.Or:
+			x = a | b
+		}
+		v.v[i] = float64(x)
+	}
+	return v
+}
+
+func compare(op shaderir.Op, a, b float64) bool {
+	switch op {
+	case shaderir.LessThanOp:
+		return a < b
+	case shaderir.LessThanEqualOp:
+		return a <= b
+	case shaderir.GreaterThanOp:
+		return a > b
+	case shaderir.GreaterThanEqualOp:
+		return a >= b
+	case shaderir.EqualOp:
+		return a == b
+	case shaderir.NotEqualOp:
+		return a != b
+	default:
+		panic(fmt.Sprintf("software: unexpected comparison operator: %d", op))
+	}
+}
+
+func equal(l, r *value) bool {
+	if l.typ == shaderir.Array {
+		if len(l.elems) != len(r.elems) {
+			return false
+		}
+		for i := range l.elems {
+			if !equal(&l.elems[i], &r.elems[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	n := l.count()
+	if c := r.count(); c > n {
+		n = c
+	}
+	for i := 0; i < n; i++ {
+		if l.at(i) != r.at(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func swizzleIndex(c rune) int {
+	switch c {
+	case 'x', 'r', 's':
+		return 0
+	case 'y', 'g', 't':
+		return 1
+	case 'z', 'b', 'p':
+		return 2
+	case 'w', 'a', 'q':
+		return 3
+	default:
+		panic(fmt.Sprintf("software: unexpected swizzling: %c", c))
+	}
+}
+
+func swizzle(x value, s string) value {
+	v := value{typ: vectorType(elementType(x.typ), len(s))}
+	for i, c := range s {
+		v.v[i] = x.v[swizzleIndex(c)]
+	}
+	return v
+}
+
+func index(x value, i int) value {
+	switch {
+	case x.typ == shaderir.Array:
+		if i < 0 || i >= len(x.elems) {
+			return x.elems[0].zero()
+		}
+		return x.elems[i]
+	case isMatrix(x.typ):
+		n := matrixSize(x.typ)
+		v := value{typ: vectorType(shaderir.Float, n)}
+		if i < 0 || i >= n {
+			return v
+		}
+		copy(v.v[:n], x.v[i*n:(i+1)*n])
+		return v
+	default:
+		v := value{typ: elementType(x.typ)}
+		if i < 0 || i >= x.count() {
+			return v
+		}
+		v.v[0] = x.v[i]
+		return v
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+// vertexOutput is a result of the vertex shader in the window coordinates.
+type vertexOutput struct {
+	x, y, z  float64
+	invW     float64
+	varyings []value
+}
+
+// rasterizer draws triangles on an image.
+//
+// A pixel is covered when its center is in a triangle.
+// A pixel whose center is exactly on an edge is covered only when the edge is a top or left edge,
+// so that a pixel is never drawn twice by triangles sharing an edge.
+type rasterizer struct {
+	dst      *Image
+	shader   *Shader
+	vertices []float32
+	blend    graphicsdriver.Blend
+	fillRule graphicsdriver.FillRule
+
+	vertexOutputs map[uint32]*vertexOutput
+	stencil       []byte
+	varyings      []value
+}
+
+func (r *rasterizer) begin(dst *Image, shader *Shader, vertices []float32, blend graphicsdriver.Blend, fillRule graphicsdriver.FillRule) {
+	r.dst = dst
+	r.shader = shader
+	r.vertices = vertices
+	r.blend = blend
+	r.fillRule = fillRule
+	if r.vertexOutputs == nil {
+		r.vertexOutputs = map[uint32]*vertexOutput{}
+	}
+	if n := len(shader.ir.Varyings); cap(r.varyings) < n {
+		r.varyings = make([]value, n)
+	} else {
+		r.varyings = r.varyings[:n]
+	}
+}
+
+func (r *rasterizer) end() {
+	r.dst = nil
+	r.shader = nil
+	r.vertices = nil
+	for k := range r.vertexOutputs {
+		delete(r.vertexOutputs, k)
+	}
+}
+
+func (r *rasterizer) vertexOutput(index uint32) *vertexOutput {
+	if v, ok := r.vertexOutputs[index]; ok {
+		return v
+	}
+
+	v := &vertexOutput{
+		varyings: make([]value, len(r.shader.ir.Varyings)),
+	}
+	vertex := r.vertices[int(index)*graphics.VertexFloatCount : int(index+1)*graphics.VertexFloatCount]
+	pos := r.shader.interpreter.runVertex(vertex, v.varyings)
+
+	// Convert the clip-space position to the window coordinates. The viewport is the whole framebuffer.
+	// Unlike OpenGL, the Y direction is not flipped even for the screen, as the pixels are stored from the top.
+	w, h := r.dst.framebufferSize()
+	v.invW = 1 / pos.v[3]
+	v.x = (pos.v[0]*v.invW + 1) / 2 * float64(w)
+	v.y = (pos.v[1]*v.invW + 1) / 2 * float64(h)
+	v.z = pos.v[2] * v.invW
+
+	r.vertexOutputs[index] = v
+	return v
+}
+
+func (r *rasterizer) drawRegion(region image.Rectangle, indices []uint32) {
+	w, h := r.dst.framebufferSize()
+	clip := region.Intersect(image.Rect(0, 0, w, h))
+	if clip.Empty() {
+		return
+	}
+
+	if r.fillRule == graphicsdriver.FillAll {
+		r.stencil = r.stencil[:0]
+	} else {
+		n := clip.Dx() * clip.Dy()
+		if cap(r.stencil) < n {
+			r.stencil = make([]byte, n)
+		} else {
+			r.stencil = r.stencil[:n]
+			for i := range r.stencil {
+				r.stencil[i] = 0
+			}
+		}
+		for i := 0; i+2 < len(indices); i += 3 {
+			r.rasterizeTriangle(indices[i:i+3], clip, r.updateStencil)
+		}
+	}
+
+	for i := 0; i+2 < len(indices); i += 3 {
+		r.rasterizeTriangle(indices[i:i+3], clip, r.shadePixel)
+	}
+}
+
+// edge returns a value that is proportional to the signed distance from the edge (ax, ay)-(bx, by) to (px, py).
+func edge(ax, ay, bx, by, px, py float64) float64 {
+	return (bx-ax)*(py-ay) - (by-ay)*(px-ax)
+}
+
+// isTopLeft reports whether the edge (ax, ay)-(bx, by) of a triangle with a positive area is a top or left edge.
+func isTopLeft(ax, ay, bx, by float64) bool {
+	return (ay == by && bx > ax) || by < ay
+}
+
+func (r *rasterizer) rasterizeTriangle(indices []uint32, clip image.Rectangle, f func(x, y int, clip image.Rectangle, vs [3]*vertexOutput, weights [3]float64, front bool)) {
+	vs := [3]*vertexOutput{
+		r.vertexOutput(indices[0]),
+		r.vertexOutput(indices[1]),
+		r.vertexOutput(indices[2]),
+	}
+
+	area := edge(vs[0].x, vs[0].y, vs[1].x, vs[1].y, vs[2].x, vs[2].y)
+	if area == 0 || math.IsNaN(area) || math.IsInf(area, 0) {
+		return
+	}
+	front := area > 0
+
+	// Reorder the vertices so that the area is positive.
+	order := [3]int{0, 1, 2}
+	if !front {
+		order = [3]int{0, 2, 1}
+		area = -area
+	}
+	p0, p1, p2 := vs[order[0]], vs[order[1]], vs[order[2]]
+
+	minX := math.Floor(math.Min(p0.x, math.Min(p1.x, p2.x)))
+	minY := math.Floor(math.Min(p0.y, math.Min(p1.y, p2.y)))
+	maxX := math.Ceil(math.Max(p0.x, math.Max(p1.x, p2.x)))
+	maxY := math.Ceil(math.Max(p0.y, math.Max(p1.y, p2.y)))
+	x0 := int(math.Max(minX, float64(clip.Min.X)))
+	y0 := int(math.Max(minY, float64(clip.Min.Y)))
+	x1 := int(math.Min(maxX, float64(clip.Max.X)))
+	y1 := int(math.Min(maxY, float64(clip.Max.Y)))
+
+	tl0 := isTopLeft(p1.x, p1.y, p2.x, p2.y)
+	tl1 := isTopLeft(p2.x, p2.y, p0.x, p0.y)
+	tl2 := isTopLeft(p0.x, p0.y, p1.x, p1.y)
+
+	for y := y0; y < y1; y++ {
+		cy := float64(y) + 0.5
+		for x := x0; x < x1; x++ {
+			cx := float64(x) + 0.5
+			w0 := edge(p1.x, p1.y, p2.x, p2.y, cx, cy)
+			w1 := edge(p2.x, p2.y, p0.x, p0.y, cx, cy)
+			w2 := edge(p0.x, p0.y, p1.x, p1.y, cx, cy)
+			if w0 < 0 || w1 < 0 || w2 < 0 {
+				continue
+			}
+			if (w0 == 0 && !tl0) || (w1 == 0 && !tl1) || (w2 == 0 && !tl2) {
+				continue
+			}
+
+			var weights [3]float64
+			weights[order[0]] = w0 / area
+			weights[order[1]] = w1 / area
+			weights[order[2]] = w2 / area
+			f(x, y, clip, vs, weights, front)
+		}
+	}
+}
+
+func (r *rasterizer) updateStencil(x, y int, clip image.Rectangle, vs [3]*vertexOutput, weights [3]float64, front bool) {
+	i := (y-clip.Min.Y)*clip.Dx() + (x - clip.Min.X)
+	switch r.fillRule {
+	case graphicsdriver.NonZero:
+		if front {
+			r.stencil[i]++
+		} else {
+			r.stencil[i]--
+		}
+	case graphicsdriver.EvenOdd:
+		r.stencil[i] ^= 0xff
+	}
+}
+
+func (r *rasterizer) shadePixel(x, y int, clip image.Rectangle, vs [3]*vertexOutput, weights [3]float64, front bool) {
+	if len(r.stencil) > 0 && r.stencil[(y-clip.Min.Y)*clip.Dx()+(x-clip.Min.X)] == 0 {
+		return
+	}
+
+	w, _ := r.dst.framebufferSize()
+	p := r.dst.pixels[4*(y*w+x) : 4*(y*w+x)+4]
+
+	// The result doesn't depend on the shader when clearing. Skip running the shader for performance.
+	if r.blend == graphicsdriver.BlendClear {
+		p[0], p[1], p[2], p[3] = 0, 0, 0, 0
+		return
+	}
+
+	// Interpolate the varying values with perspective correction.
+	var pw [3]float64
+	var sum float64
+	for i, v := range vs {
+		pw[i] = weights[i] * v.invW
+		sum += pw[i]
+	}
+	for i := range pw {
+		pw[i] /= sum
+	}
+	for i := range r.varyings {
+		v := value{typ: vs[0].varyings[i].typ}
+		for j := 0; j < v.count(); j++ {
+			v.v[j] = pw[0]*vs[0].varyings[i].v[j] + pw[1]*vs[1].varyings[i].v[j] + pw[2]*vs[2].varyings[i].v[j]
+		}
+		r.varyings[i] = v
+	}
+
+	fragCoord := value{typ: shaderir.Vec4}
+	fragCoord.v[0] = float64(x) + 0.5
+	fragCoord.v[1] = float64(y) + 0.5
+	fragCoord.v[2] = weights[0]*vs[0].z + weights[1]*vs[1].z + weights[2]*vs[2].z
+	fragCoord.v[3] = weights[0]*vs[0].invW + weights[1]*vs[1].invW + weights[2]*vs[2].invW
+
+	clr, ok := r.shader.interpreter.runFragment(fragCoord, r.varyings)
+	if !ok {
+		return
+	}
+	blendPixel(p, &clr, r.blend)
+}
+
+func clamp01(x float64) float64 {
+	if !(x > 0) {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
+
+func blendFactor(f graphicsdriver.BlendFactor, src, dst *[4]float64, c int) float64 {
+	switch f {
+	case graphicsdriver.BlendFactorZero:
+		return 0
+	case graphicsdriver.BlendFactorOne:
+		return 1
+	case graphicsdriver.BlendFactorSourceColor:
+		return src[c]
+	case graphicsdriver.BlendFactorOneMinusSourceColor:
+		return 1 - src[c]
+	case graphicsdriver.BlendFactorSourceAlpha:
+		return src[3]
+	case graphicsdriver.BlendFactorOneMinusSourceAlpha:
+		return 1 - src[3]
+	case graphicsdriver.BlendFactorDestinationColor:
+		return dst[c]
+	case graphicsdriver.BlendFactorOneMinusDestinationColor:
+		return 1 - dst[c]
+	case graphicsdriver.BlendFactorDestinationAlpha:
+		return dst[3]
+	case graphicsdriver.BlendFactorOneMinusDestinationAlpha:
+		return 1 - dst[3]
+	case graphicsdriver.BlendFactorSourceAlphaSaturated:
+		if c == 3 {
+			return 1
+		}
+		return math.Min(src[3], 1-dst[3])
+	default:
+		return 0
+	}
+}
+
+// blendPixel blends the color clr onto the premultiplied-alpha pixel p, in the same way as GPUs do with 8-bit color buffers.
+func blendPixel(p []byte, clr *value, blend graphicsdriver.Blend) {
+	var src, dst [4]float64
+	for c := 0; c < 4; c++ {
+		src[c] = clamp01(clr.v[c])
+		dst[c] = float64(p[c]) / 0xff
+	}
+
+	for c := 0; c < 4; c++ {
+		sf, df, op := blend.BlendFactorSourceRGB, blend.BlendFactorDestinationRGB, blend.BlendOperationRGB
+		if c == 3 {
+			sf, df, op = blend.BlendFactorSourceAlpha, blend.BlendFactorDestinationAlpha, blend.BlendOperationAlpha
+		}
+		s := src[c] * blendFactor(sf, &src, &dst, c)
+		d := dst[c] * blendFactor(df, &src, &dst, c)
+
+		var x float64
+		switch op {
+		case graphicsdriver.BlendOperationAdd:
+			x = s + d
+		case graphicsdriver.BlendOperationSubtract:
+			x = s - d
+		case graphicsdriver.BlendOperationReverseSubtract:
+			x = d - s
+		case graphicsdriver.BlendOperationMin:
+			x = math.Min(src[c], dst[c])
+		case graphicsdriver.BlendOperationMax:
+			x = math.Max(src[c], dst[c])
+		}
+		p[c] = byte(math.Floor(clamp01(x)*0xff + 0.5))
+	}
+}
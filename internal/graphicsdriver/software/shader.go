@@ -0,0 +1,91 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+// Shader is a shader program that is executed by the interpreter.
+type Shader struct {
+	id       graphicsdriver.ShaderID
+	graphics *Graphics
+
+	ir          *shaderir.Program
+	interpreter *interpreter
+}
+
+func newShader(id graphicsdriver.ShaderID, graphics *Graphics, program *shaderir.Program) (*Shader, error) {
+	if program.VertexFunc.Block == nil || program.FragmentFunc.Block == nil {
+		return nil, fmt.Errorf("software: the shader program must have both vertex and fragment entry points")
+	}
+	return &Shader{
+		id:          id,
+		graphics:    graphics,
+		ir:          program,
+		interpreter: newInterpreter(program),
+	}, nil
+}
+
+func (s *Shader) ID() graphicsdriver.ShaderID {
+	return s.id
+}
+
+func (s *Shader) Dispose() {
+	s.graphics.removeShader(s)
+}
+
+// setUniforms converts the uniform values in uint32 to the values for the interpreter.
+func (s *Shader) setUniforms(uniforms []uint32) {
+	in := s.interpreter
+	if len(in.uniforms) != len(s.ir.Uniforms) {
+		in.uniforms = make([]value, len(s.ir.Uniforms))
+	}
+	var idx int
+	for i := range s.ir.Uniforms {
+		t := &s.ir.Uniforms[i]
+		n := t.Uint32Count()
+		in.uniforms[i] = uniformValue(t, uniforms[idx:idx+n])
+		idx += n
+	}
+}
+
+func uniformValue(t *shaderir.Type, u []uint32) value {
+	if t.Main == shaderir.Array {
+		v := value{
+			typ:   shaderir.Array,
+			elems: make([]value, t.Length),
+		}
+		n := t.Sub[0].Uint32Count()
+		for i := range v.elems {
+			v.elems[i] = uniformValue(&t.Sub[0], u[i*n:(i+1)*n])
+		}
+		return v
+	}
+
+	v := value{typ: t.Main}
+	for i, x := range u {
+		if elementType(t.Main) == shaderir.Int {
+			v.v[i] = float64(int32(x))
+		} else {
+			v.v[i] = float64(math.Float32frombits(x))
+		}
+	}
+	return v
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+// value is a value of a shader program at runtime.
+//
+// Scalars, vectors and matrices are stored in v. Matrices are column-major.
+// Integers and booleans are also stored as float64 values, which can represent int32 values exactly.
+type value struct {
+	typ   shaderir.BasicType
+	v     [16]float64
+	elems []value
+	tex   int
+}
+
+func componentCount(t shaderir.BasicType) int {
+	switch t {
+	case shaderir.Bool, shaderir.Int, shaderir.Float:
+		return 1
+	case shaderir.Vec2, shaderir.IVec2:
+		return 2
+	case shaderir.Vec3, shaderir.IVec3:
+		return 3
+	case shaderir.Vec4, shaderir.IVec4, shaderir.Mat2:
+		return 4
+	case shaderir.Mat3:
+		return 9
+	case shaderir.Mat4:
+		return 16
+	default:
+		return 0
+	}
+}
+
+// matrixSize returns the number of columns (and rows) of a matrix type.
+func matrixSize(t shaderir.BasicType) int {
+	switch t {
+	case shaderir.Mat2:
+		return 2
+	case shaderir.Mat3:
+		return 3
+	case shaderir.Mat4:
+		return 4
+	default:
+		return 0
+	}
+}
+
+func isMatrix(t shaderir.BasicType) bool {
+	return matrixSize(t) > 0
+}
+
+// elementType returns the scalar type of a scalar, vector or matrix type.
+func elementType(t shaderir.BasicType) shaderir.BasicType {
+	switch t {
+	case shaderir.Int, shaderir.IVec2, shaderir.IVec3, shaderir.IVec4:
+		return shaderir.Int
+	case shaderir.Bool:
+		return shaderir.Bool
+	default:
+		return shaderir.Float
+	}
+}
+
+// vectorType returns the type of a vector whose elements are elem and the number of the elements is n.
+// If n is 1, vectorType returns the scalar type.
+func vectorType(elem shaderir.BasicType, n int) shaderir.BasicType {
+	if elem == shaderir.Int {
+		switch n {
+		case 1:
+			return shaderir.Int
+		case 2:
+			return shaderir.IVec2
+		case 3:
+			return shaderir.IVec3
+		case 4:
+			return shaderir.IVec4
+		}
+	}
+	if elem == shaderir.Bool && n == 1 {
+		return shaderir.Bool
+	}
+	switch n {
+	case 1:
+		return shaderir.Float
+	case 2:
+		return shaderir.Vec2
+	case 3:
+		return shaderir.Vec3
+	case 4:
+		return shaderir.Vec4
+	}
+	panic(fmt.Sprintf("software: unexpected vector size: %d", n))
+}
+
+func zeroValue(t *shaderir.Type) value {
+	switch t.Main {
+	case shaderir.Array:
+		v := value{
+			typ:   shaderir.Array,
+			elems: make([]value, t.Length),
+		}
+		for i := range v.elems {
+			v.elems[i] = zeroValue(&t.Sub[0])
+		}
+		return v
+	default:
+		return value{typ: t.Main}
+	}
+}
+
+func scalarValue(t shaderir.BasicType, x float64) value {
+	v := value{typ: t}
+	v.v[0] = x
+	return v
+}
+
+func boolValue(b bool) value {
+	if b {
+		return scalarValue(shaderir.Bool, 1)
+	}
+	return scalarValue(shaderir.Bool, 0)
+}
+
+func (v *value) bool() bool {
+	return v.v[0] != 0
+}
+
+func (v *value) count() int {
+	return componentCount(v.typ)
+}
+
+// at returns the i-th component. A scalar is broadcast to any index.
+func (v *value) at(i int) float64 {
+	if v.count() == 1 {
+		return v.v[0]
+	}
+	return v.v[i]
+}
+
+// clone returns a deep copy of v.
+func (v value) clone() value {
+	if v.elems == nil {
+		return v
+	}
+	elems := make([]value, len(v.elems))
+	for i, e := range v.elems {
+		elems[i] = e.clone()
+	}
+	v.elems = elems
+	return v
+}
+
+// zero returns a zero value whose type is the same as v.
+func (v value) zero() value {
+	if v.elems == nil {
+		return value{typ: v.typ}
+	}
+	elems := make([]value, len(v.elems))
+	for i, e := range v.elems {
+		elems[i] = e.zero()
+	}
+	return value{
+		typ:   v.typ,
+		elems: elems,
+	}
+}
+
+// convert converts v to the type t.
+// This is used to adjust untyped constants to the types of variables, parameters and returned values.
+func (v value) convert(t shaderir.BasicType) value {
+	if v.typ == t || t == shaderir.None || t == shaderir.Array || v.typ == shaderir.Array {
+		return v
+	}
+	if componentCount(v.typ) != componentCount(t) {
+		return v
+	}
+	v.typ = t
+	if elementType(t) == shaderir.Int {
+		for i := 0; i < v.count(); i++ {
+			v.v[i] = truncateToInt32(v.v[i])
+		}
+	}
+	return v
+}
+
+func truncateToInt32(x float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0
+	}
+	return float64(int32(int64(x)))
+}
@@ -52,6 +52,36 @@ func New(width, height int, imageType atlas.ImageType) *Mipmap {
 	}
 }
 
+// NewFromNativeTexture creates a mipmap whose level 0 image wraps the given native texture.
+func NewFromNativeTexture(handle uintptr, width, height int) *Mipmap {
+	return &Mipmap{
+		width:     width,
+		height:    height,
+		orig:      buffered.NewImageFromNativeTexture(handle, width, height),
+		imageType: atlas.ImageTypeUnmanaged,
+	}
+}
+
+// NewFromCompressedData creates a mipmap whose level 0 image has the given GPU-compressed data.
+func NewFromCompressedData(format graphicsdriver.CompressedTextureFormat, data []byte, width, height int) *Mipmap {
+	return &Mipmap{
+		width:     width,
+		height:    height,
+		orig:      buffered.NewImageFromCompressedData(format, data, width, height),
+		imageType: atlas.ImageTypeUnmanaged,
+	}
+}
+
+// NewHighPrecision creates a mipmap whose level 0 image has 16-bit floating point numbers per channel.
+func NewHighPrecision(width, height int) *Mipmap {
+	return &Mipmap{
+		width:     width,
+		height:    height,
+		orig:      buffered.NewHighPrecisionImage(width, height),
+		imageType: atlas.ImageTypeUnmanaged,
+	}
+}
+
 func (m *Mipmap) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name string, blackbg bool) (string, error) {
 	return m.orig.DumpScreenshot(graphicsDriver, name, blackbg)
 }
@@ -61,10 +91,19 @@ func (m *Mipmap) WritePixels(pix []byte, region image.Rectangle) {
 	m.deallocateMipmaps()
 }
 
+func (m *Mipmap) ClearPixels(region image.Rectangle) {
+	m.orig.ClearPixels(region)
+	m.deallocateMipmaps()
+}
+
 func (m *Mipmap) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) error {
 	return m.orig.ReadPixels(graphicsDriver, pixels, region)
 }
 
+func (m *Mipmap) ReadPixelsWithoutCache(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) error {
+	return m.orig.ReadPixelsWithoutCache(graphicsDriver, pixels, region)
+}
+
 func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderImageCount]*Mipmap, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool) {
 	if len(indices) == 0 {
 		return
@@ -208,6 +247,11 @@ func (m *Mipmap) Deallocate() {
 	m.orig.Deallocate()
 }
 
+// SetDebugLabel sets a label to the level 0 image, which is shown in GPU debugging tools.
+func (m *Mipmap) SetDebugLabel(label string) {
+	m.orig.SetDebugLabel(label)
+}
+
 func (m *Mipmap) deallocateMipmaps() {
 	for _, img := range m.imgs {
 		if img != nil {
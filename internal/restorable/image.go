@@ -68,6 +68,69 @@ func NewImage(width, height int, imageType ImageType) *Image {
 	return i
 }
 
+// NewImageFromNativeTexture creates an image that wraps the given native texture.
+//
+// The returned image is not cleared and has the native texture's pixels.
+//
+// Note that Dispose is not called automatically.
+func NewImageFromNativeTexture(handle uintptr, width, height int) *Image {
+	if !graphicsDriverInitialized {
+		panic("restorable: graphics driver must be ready at NewImageFromNativeTexture but not")
+	}
+
+	i := &Image{
+		image:     graphicscommand.NewImageFromNativeTexture(handle, width, height),
+		width:     width,
+		height:    height,
+		imageType: ImageTypeRegular,
+	}
+	theImages.add(i)
+	return i
+}
+
+// NewImageFromCompressedData creates an image with the given GPU-compressed data.
+//
+// The returned image can be used only as a rendering source.
+//
+// Note that Dispose is not called automatically.
+func NewImageFromCompressedData(format graphicsdriver.CompressedTextureFormat, data []byte, width, height int) *Image {
+	if !graphicsDriverInitialized {
+		panic("restorable: graphics driver must be ready at NewImageFromCompressedData but not")
+	}
+
+	i := &Image{
+		image:     graphicscommand.NewImageFromCompressedData(format, data, width, height),
+		width:     width,
+		height:    height,
+		imageType: ImageTypeRegular,
+	}
+	theImages.add(i)
+	return i
+}
+
+// NewHighPrecisionImage creates an emtpy image with 16-bit floating point numbers per channel.
+//
+// The returned image is cleared.
+//
+// Note that Dispose is not called automatically.
+func NewHighPrecisionImage(width, height int) *Image {
+	if !graphicsDriverInitialized {
+		panic("restorable: graphics driver must be ready at NewHighPrecisionImage but not")
+	}
+
+	i := &Image{
+		image:     graphicscommand.NewHighPrecisionImage(width, height),
+		width:     width,
+		height:    height,
+		imageType: ImageTypeRegular,
+	}
+
+	iw, ih := i.image.InternalSize()
+	clearImage(i.image, image.Rect(0, 0, iw, ih))
+	theImages.add(i)
+	return i
+}
+
 // Extend extends the image by the given size.
 // Extend creates a new image with the given size and copies the pixels of the given source image.
 // Extend disposes itself after its call.
@@ -179,6 +242,11 @@ func (i *Image) Dispose() {
 	i.image = nil
 }
 
+// SetDebugLabel sets a label to the image, which is shown in GPU debugging tools.
+func (i *Image) SetDebugLabel(label string) {
+	i.image.SetDebugLabel(label)
+}
+
 func (i *Image) Dump(graphicsDriver graphicsdriver.Graphics, path string, blackbg bool, rect image.Rectangle) (string, error) {
 	return i.image.Dump(graphicsDriver, path, blackbg, rect)
 }
@@ -45,6 +45,11 @@ func SwapBuffers(graphicsDriver graphicsdriver.Graphics) error {
 	return nil
 }
 
+// Flush flushes the pending graphics commands without presenting the screen.
+func Flush(graphicsDriver graphicsdriver.Graphics, wait bool) error {
+	return graphicscommand.Flush(graphicsDriver, wait)
+}
+
 // DumpImages dumps all the current images to the specified directory.
 //
 // This is for testing usage.
@@ -75,7 +80,32 @@ func InitializeGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) error
 	return graphicscommand.InitializeGraphicsDriverState(graphicsDriver)
 }
 
+// PushDebugGroup pushes a debug group, which is shown in GPU debugging tools.
+func PushDebugGroup(name string) {
+	graphicscommand.PushDebugGroup(name)
+}
+
+// PopDebugGroup pops the current debug group.
+func PopDebugGroup() {
+	graphicscommand.PopDebugGroup()
+}
+
 // MaxImageSize returns the maximum size of an image.
 func MaxImageSize(graphicsDriver graphicsdriver.Graphics) int {
 	return graphicscommand.MaxImageSize(graphicsDriver)
 }
+
+// IsCompressedTextureFormatSupported reports whether an image can be created with data in the given compressed texture format.
+func IsCompressedTextureFormatSupported(graphicsDriver graphicsdriver.Graphics, format graphicsdriver.CompressedTextureFormat) bool {
+	return graphicscommand.IsCompressedTextureFormatSupported(graphicsDriver, format)
+}
+
+// MaxShaderImages returns the maximum number of source images of a shader.
+func MaxShaderImages(graphicsDriver graphicsdriver.Graphics) int {
+	return graphicscommand.MaxShaderImages(graphicsDriver)
+}
+
+// IsHighPrecisionImageSupported reports whether an image with 16-bit floating point numbers per channel can be created.
+func IsHighPrecisionImageSupported(graphicsDriver graphicsdriver.Graphics) bool {
+	return graphicscommand.IsHighPrecisionImageSupported(graphicsDriver)
+}
@@ -36,6 +36,8 @@ func canTruncateToFloat(v gconstant.Value) bool {
 
 var textureVariableRe = regexp.MustCompile(`\A__t(\d+)\z`)
 
+var imageSrcFuncRe = regexp.MustCompile(`\AimageSrc(\d+)(At|UnsafeAt|Origin|Size)\z`)
+
 func (cs *compileState) parseExpr(block *block, fname string, expr ast.Expr, markLocalVariableUsed bool) ([]shaderir.Expr, []shaderir.Type, []shaderir.Stmt, bool) {
 	switch e := expr.(type) {
 	case *ast.BasicLit:
@@ -722,6 +724,12 @@ func (cs *compileState) parseExpr(block *block, fname string, expr ast.Expr, mar
 				},
 			}, []shaderir.Type{{Main: shaderir.Bool}}, nil, true
 		}
+		if m := imageSrcFuncRe.FindStringSubmatch(e.Name); m != nil {
+			if i, _ := strconv.Atoi(m[1]); i >= cs.ir.TextureCount {
+				cs.addError(e.Pos(), fmt.Sprintf("%s is not available: only %d source images are supported", e.Name, cs.ir.TextureCount))
+				return nil, nil, nil, false
+			}
+		}
 		cs.addError(e.Pos(), fmt.Sprintf("unexpected identifier: %s", e.Name))
 
 	case *ast.ParenExpr:
@@ -15,14 +15,11 @@
 package shader
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
 	"go/ast"
 	gconstant "go/constant"
 	"go/parser"
 	"go/token"
-	"regexp"
 	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
@@ -184,23 +181,44 @@ func (p *ParseError) Error() string {
 	return strings.Join(p.errs, "\n")
 }
 
+// ParseFile parses a shader source.
+// The comments are kept so that the compiler directives can be parsed with ParseCompilerDirectives.
+func ParseFile(fs *token.FileSet, src []byte) (*ast.File, error) {
+	return parser.ParseFile(fs, "", src, parser.AllErrors|parser.ParseComments)
+}
+
 func Compile(src []byte, vertexEntry, fragmentEntry string, textureCount int) (*shaderir.Program, error) {
-	unit, err := ParseCompilerDirectives(src)
+	fs := token.NewFileSet()
+	f, err := ParseFile(fs, src)
 	if err != nil {
 		return nil, err
 	}
+	return CompileFiles(fs, []*ast.File{f}, vertexEntry, fragmentEntry, textureCount)
+}
 
-	fs := token.NewFileSet()
-	f, err := parser.ParseFile(fs, "", src, parser.AllErrors)
+// CompileFiles compiles the parsed shader files as one program.
+// The compiler directives are read from the first file.
+func CompileFiles(fs *token.FileSet, files []*ast.File, vertexEntry, fragmentEntry string, textureCount int) (*shaderir.Program, error) {
+	directives, err := ParseCompilerDirectives(fs, files[0])
 	if err != nil {
 		return nil, err
 	}
 
+	f := files[0]
+	if len(files) > 1 {
+		merged := *f
+		merged.Decls = append([]ast.Decl{}, f.Decls...)
+		for _, f := range files[1:] {
+			merged.Decls = append(merged.Decls, f.Decls...)
+		}
+		f = &merged
+	}
+
 	s := &compileState{
 		fs:            fs,
 		vertexEntry:   vertexEntry,
 		fragmentEntry: fragmentEntry,
-		unit:          unit,
+		unit:          directives.Unit,
 	}
 	s.global.ir = &shaderir.Block{}
 	s.parse(f)
@@ -218,37 +236,89 @@ func Compile(src []byte, vertexEntry, fragmentEntry string, textureCount int) (*
 	return &s.ir, nil
 }
 
-func ParseCompilerDirectives(src []byte) (shaderir.Unit, error) {
-	// TODO: Change the unit to pixels in v3 (#2645).
-	unit := shaderir.Texels
+// CompilerDirectives represents the compiler directives in a shader.
+type CompilerDirectives struct {
+	// Unit is the unit specified by //kage:unit.
+	Unit shaderir.Unit
 
-	// Go's whitespace is U+0020 (SP), U+0009 (\t), U+000d (\r), and U+000A (\n).
-	// See https://go.dev/ref/spec#Tokens
-	reUnit := regexp.MustCompile(`^[ \t\r\n]*//kage:unit\s+([^ \t\r\n]+)[ \t\r\n]*$`)
+	// Vertex reports whether //kage:vertex is specified.
+	Vertex bool
+}
+
+// ParseCompilerDirectives parses the compiler directives in f.
+// f must be parsed with fs by ParseFile.
+//
+// A compiler directive is a line comment on its own line outside of declarations.
+// A directive in a block comment is ignored.
+func ParseCompilerDirectives(fs *token.FileSet, f *ast.File) (CompilerDirectives, error) {
+	// TODO: Change the unit to pixels in v3 (#2645).
+	d := CompilerDirectives{
+		Unit: shaderir.Texels,
+	}
 	var unitParsed bool
 
-	buf := bytes.NewBuffer(src)
-	s := bufio.NewScanner(buf)
-	for s.Scan() {
-		m := reUnit.FindStringSubmatch(s.Text())
-		if m == nil {
-			continue
-		}
-		if unitParsed {
-			return 0, fmt.Errorf("shader: at most one //kage:unit can exist in a shader")
+	// The lines that have code outside of comments.
+	codeLines := map[int]struct{}{
+		fs.Position(f.Package).Line:    {},
+		fs.Position(f.Name.End()).Line: {},
+	}
+	for _, decl := range f.Decls {
+		codeLines[fs.Position(decl.Pos()).Line] = struct{}{}
+		codeLines[fs.Position(decl.End()).Line] = struct{}{}
+	}
+	inDecl := func(pos token.Pos) bool {
+		for _, decl := range f.Decls {
+			if decl.Pos() <= pos && pos < decl.End() {
+				return true
+			}
 		}
-		switch m[1] {
-		case "pixels":
-			unit = shaderir.Pixels
-		case "texels":
-			unit = shaderir.Texels
-		default:
-			return 0, fmt.Errorf("shader: invalid value for //kage:unit: %s", m[1])
+		return false
+	}
+
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, "//kage:") {
+				continue
+			}
+			if _, ok := codeLines[fs.Position(c.Pos()).Line]; ok || inDecl(c.Pos()) {
+				continue
+			}
+
+			// Go's whitespace is U+0020 (SP), U+0009 (\t), U+000d (\r), and U+000A (\n).
+			// See https://go.dev/ref/spec#Tokens
+			fields := strings.FieldsFunc(c.Text[len("//"):], func(r rune) bool {
+				return r == ' ' || r == '\t' || r == '\r' || r == '\n'
+			})
+			switch fields[0] {
+			case "kage:unit":
+				if unitParsed {
+					return CompilerDirectives{}, fmt.Errorf("shader: at most one //kage:unit can exist in a shader")
+				}
+				if len(fields) != 2 {
+					return CompilerDirectives{}, fmt.Errorf("shader: //kage:unit must have one value")
+				}
+				switch fields[1] {
+				case "pixels":
+					d.Unit = shaderir.Pixels
+				case "texels":
+					d.Unit = shaderir.Texels
+				default:
+					return CompilerDirectives{}, fmt.Errorf("shader: invalid value for //kage:unit: %s", fields[1])
+				}
+				unitParsed = true
+			case "kage:vertex":
+				if d.Vertex {
+					return CompilerDirectives{}, fmt.Errorf("shader: at most one //kage:vertex can exist in a shader")
+				}
+				if len(fields) != 1 {
+					return CompilerDirectives{}, fmt.Errorf("shader: //kage:vertex must not have a value")
+				}
+				d.Vertex = true
+			}
 		}
-		unitParsed = true
 	}
 
-	return unit, nil
+	return d, nil
 }
 
 func (s *compileState) addError(pos token.Pos, str string) {
@@ -843,7 +913,8 @@ func (cs *compileState) parseFunc(block *block, d *ast.FuncDecl) (function, bool
 		var hasReturn func(stmts []shaderir.Stmt) bool
 		hasReturn = func(stmts []shaderir.Stmt) bool {
 			for _, stmt := range stmts {
-				if stmt.Type == shaderir.Return {
+				// discard terminates the fragment shader, and the generated code always returns after discarding.
+				if stmt.Type == shaderir.Return || stmt.Type == shaderir.Discard {
 					return true
 				}
 				for _, b := range stmt.Blocks {
@@ -2041,14 +2041,21 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 `)); err != nil {
 		t.Error(err)
 	}
-	// discard without return doesn't work so far.
-	// TODO: Allow discard without return.
 	if _, err := compileToIR([]byte(`package main
 
 func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	discard()
 	return vec4(0)
 }
+`)); err != nil {
+		t.Error(err)
+	}
+	// discard without return.
+	if _, err := compileToIR([]byte(`package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	discard()
+}
 `)); err != nil {
 		t.Error(err)
 	}
@@ -3326,6 +3333,31 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 }`,
 			err: true,
 		},
+		{
+			src: `/*
+//kage:unit pixels
+*/
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return dstPos
+}`,
+			unit: shaderir.Texels,
+			err:  false,
+		},
+		{
+			src: `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	//kage:unit texels
+	return dstPos
+}`,
+			unit: shaderir.Pixels,
+			err:  false,
+		},
 		{
 			src: "\t    " + `//kage:unit pixels` + "    \t\r" + `
 package main
@@ -406,6 +406,35 @@ func (p *Program) ReachableFuncsFromBlock(block *Block) []*Func {
 	return funcs
 }
 
+// ReadsTexturesFromBlock reports whether the block or the functions called from the block read textures.
+func (p *Program) ReadsTexturesFromBlock(block *Block) bool {
+	indexToFunc := map[int]*Func{}
+	for _, f := range p.Funcs {
+		f := f
+		indexToFunc[f.Index] = &f
+	}
+
+	var reads bool
+	visited := map[int]struct{}{}
+	var f func(expr *Expr)
+	f = func(expr *Expr) {
+		switch expr.Type {
+		case BuiltinFuncExpr:
+			if expr.BuiltinFunc == TexelAt {
+				reads = true
+			}
+		case FunctionExpr:
+			if _, ok := visited[expr.Index]; ok {
+				return
+			}
+			visited[expr.Index] = struct{}{}
+			walkExprs(f, indexToFunc[expr.Index].Block)
+		}
+	}
+	walkExprs(f, block)
+	return reads
+}
+
 func walkExprs(f func(expr *Expr), block *Block) {
 	if block == nil {
 		return
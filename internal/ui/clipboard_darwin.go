@@ -0,0 +1,108 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios
+
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"unsafe"
+
+	"github.com/ebitengine/purego/objc"
+)
+
+var (
+	class_NSPasteboard     = objc.GetClass("NSPasteboard")
+	class_NSData           = objc.GetClass("NSData")
+	class_NSBitmapImageRep = objc.GetClass("NSBitmapImageRep")
+	class_NSDictionary     = objc.GetClass("NSDictionary")
+)
+
+var (
+	sel_generalPasteboard                 = objc.RegisterName("generalPasteboard")
+	sel_clearContents                     = objc.RegisterName("clearContents")
+	sel_dataForType                       = objc.RegisterName("dataForType:")
+	sel_setDataForType                    = objc.RegisterName("setData:forType:")
+	sel_dataWithBytesLength               = objc.RegisterName("dataWithBytes:length:")
+	sel_bytes                             = objc.RegisterName("bytes")
+	sel_length                            = objc.RegisterName("length")
+	sel_imageRepWithData                  = objc.RegisterName("imageRepWithData:")
+	sel_representationUsingTypeProperties = objc.RegisterName("representationUsingType:properties:")
+	sel_dictionary                        = objc.RegisterName("dictionary")
+)
+
+// _NSBitmapImageFileTypePNG is NSBitmapImageFileTypePNG to convert a bitmap to PNG data.
+const _NSBitmapImageFileTypePNG = 4
+
+const (
+	pasteboardTypePNG  = "public.png"
+	pasteboardTypeTIFF = "public.tiff"
+)
+
+func bytesFromNSData(data objc.ID) []byte {
+	n := int(data.Send(sel_length))
+	if n == 0 {
+		return nil
+	}
+	p := unsafe.Pointer(data.Send(sel_bytes))
+	b := make([]byte, n)
+	copy(b, unsafe.Slice((*byte)(p), n))
+	return b
+}
+
+func (u *UserInterface) clipboardImage() (image.Image, error) {
+	pb := objc.ID(class_NSPasteboard).Send(sel_generalPasteboard)
+
+	data := pb.Send(sel_dataForType, nsStringFromString(pasteboardTypePNG))
+	if data == 0 {
+		// Screenshots and many applications put TIFF data. Convert it to PNG.
+		tiff := pb.Send(sel_dataForType, nsStringFromString(pasteboardTypeTIFF))
+		if tiff == 0 {
+			return nil, nil
+		}
+		rep := objc.ID(class_NSBitmapImageRep).Send(sel_imageRepWithData, tiff)
+		if rep == 0 {
+			return nil, nil
+		}
+		data = rep.Send(sel_representationUsingTypeProperties, _NSBitmapImageFileTypePNG, objc.ID(class_NSDictionary).Send(sel_dictionary))
+		if data == 0 {
+			return nil, nil
+		}
+	}
+
+	// The data is put by another application and might be broken. Treat it as no image.
+	img, err := png.Decode(bytes.NewReader(bytesFromNSData(data)))
+	if err != nil {
+		return nil, nil
+	}
+	return img, nil
+}
+
+func (u *UserInterface) setClipboardImage(img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return fmt.Errorf("ui: encoding the clipboard image failed: %w", err)
+	}
+	b := buf.Bytes()
+
+	data := objc.ID(class_NSData).Send(sel_dataWithBytesLength, unsafe.Pointer(&b[0]), len(b))
+	pb := objc.ID(class_NSPasteboard).Send(sel_generalPasteboard)
+	pb.Send(sel_clearContents)
+	pb.Send(sel_setDataForType, data, nsStringFromString(pasteboardTypePNG))
+	return nil
+}
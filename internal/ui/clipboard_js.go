@@ -0,0 +1,80 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"image"
+	"syscall/js"
+)
+
+var (
+	// navigator.clipboard is available only in secure contexts.
+	jsClipboard                 = js.Global().Get("navigator").Get("clipboard")
+	jsClipboardReadTextCh       chan string
+	jsClipboardReadTextResolved js.Func
+	jsClipboardReadTextRejected js.Func
+	jsClipboardWriteTextIgnored js.Func
+)
+
+func init() {
+	if !jsClipboard.Truthy() {
+		return
+	}
+
+	jsClipboardReadTextCh = make(chan string, 1)
+	jsClipboardReadTextResolved = js.FuncOf(func(this js.Value, args []js.Value) any {
+		jsClipboardReadTextCh <- args[0].String()
+		return nil
+	})
+	// readText is rejected when the permission is denied or the document is not focused.
+	jsClipboardReadTextRejected = js.FuncOf(func(this js.Value, args []js.Value) any {
+		jsClipboardReadTextCh <- ""
+		return nil
+	})
+	jsClipboardWriteTextIgnored = js.FuncOf(func(this js.Value, args []js.Value) any {
+		return nil
+	})
+}
+
+func (u *UserInterface) ClipboardText() string {
+	if !u.isRunning() {
+		return ""
+	}
+	if !jsClipboard.Truthy() {
+		return ""
+	}
+	jsClipboard.Call("readText").Call("then", jsClipboardReadTextResolved, jsClipboardReadTextRejected)
+	return <-jsClipboardReadTextCh
+}
+
+func (u *UserInterface) SetClipboardText(text string) {
+	if !u.isRunning() {
+		return
+	}
+	if !jsClipboard.Truthy() {
+		return
+	}
+	// writeText is asynchronous. Ignore a rejection, e.g. when the document is not focused.
+	jsClipboard.Call("writeText", text).Call("catch", jsClipboardWriteTextIgnored)
+}
+
+func (u *UserInterface) ClipboardImage() image.Image {
+	// TODO: Implement this with navigator.clipboard.read.
+	return nil
+}
+
+func (u *UserInterface) SetClipboardImage(img image.Image) {
+	// TODO: Implement this with navigator.clipboard.write.
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
+	"github.com/hajimehoshi/ebiten/v2/internal/microsoftgdk"
+)
+
+const (
+	_BI_RGB       = 0
+	_BI_BITFIELDS = 3
+
+	bitmapInfoHeaderSize = 40
+)
+
+func (u *UserInterface) clipboardImage() (image.Image, error) {
+	if microsoftgdk.IsXbox() {
+		return nil, nil
+	}
+
+	data, err := glfw.GetClipboardDIB()
+	if err != nil {
+		// A platform error means the clipboard is temporarily unavailable, e.g. another application opens it.
+		if errors.Is(err, glfw.PlatformError) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	// The data is put by another application and might be broken. Treat it as no image.
+	img, err := decodeDIB(data)
+	if err != nil {
+		return nil, nil
+	}
+	return img, nil
+}
+
+// decodeDIB decodes a packed device-independent bitmap, i.e. a BITMAPINFO followed by pixels.
+// decodeDIB returns nil if the format is not supported.
+func decodeDIB(data []byte) (image.Image, error) {
+	if len(data) < bitmapInfoHeaderSize {
+		return nil, errors.New("ui: the clipboard bitmap is too short")
+	}
+	headerSize := int(binary.LittleEndian.Uint32(data[0:4]))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:8])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:12])))
+	bitCount := int(binary.LittleEndian.Uint16(data[14:16]))
+	compression := binary.LittleEndian.Uint32(data[16:20])
+	colorsUsed := int(binary.LittleEndian.Uint32(data[32:36]))
+
+	if bitCount != 24 && bitCount != 32 {
+		return nil, nil
+	}
+	if compression != _BI_RGB && compression != _BI_BITFIELDS {
+		return nil, nil
+	}
+
+	offset := headerSize + colorsUsed*4
+	if compression == _BI_BITFIELDS && headerSize == bitmapInfoHeaderSize {
+		// The three color masks follow BITMAPINFOHEADER. Assume they are the standard BGR masks.
+		offset += 12
+	}
+
+	bottomUp := height > 0
+	if !bottomUp {
+		height = -height
+	}
+	stride := (width*bitCount/8 + 3) &^ 3
+	if width <= 0 || height <= 0 || offset+stride*height > len(data) {
+		return nil, errors.New("ui: the clipboard bitmap is broken")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	hasAlpha := false
+	for j := 0; j < height; j++ {
+		row := j
+		if bottomUp {
+			row = height - 1 - j
+		}
+		src := data[offset+row*stride:]
+		dst := img.Pix[j*img.Stride:]
+		for i := 0; i < width; i++ {
+			s := src[i*bitCount/8:]
+			dst[4*i] = s[2]
+			dst[4*i+1] = s[1]
+			dst[4*i+2] = s[0]
+			dst[4*i+3] = 0xff
+			if bitCount == 32 {
+				dst[4*i+3] = s[3]
+				if s[3] != 0 {
+					hasAlpha = true
+				}
+			}
+		}
+	}
+
+	// Many applications put 32-bit bitmaps with the alpha channel unused. Treat them as opaque.
+	if bitCount == 32 && !hasAlpha {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+	}
+	return img, nil
+}
+
+func encodeDIB(img image.Image) []byte {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+
+	src, ok := img.(*image.NRGBA)
+	if !ok || src.Rect.Min != (image.Point{}) {
+		src = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	}
+
+	data := make([]byte, bitmapInfoHeaderSize+4*width*height)
+	binary.LittleEndian.PutUint32(data[0:4], bitmapInfoHeaderSize)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(width))
+	binary.LittleEndian.PutUint32(data[8:12], uint32(height))
+	binary.LittleEndian.PutUint16(data[12:14], 1)
+	binary.LittleEndian.PutUint16(data[14:16], 32)
+	binary.LittleEndian.PutUint32(data[16:20], _BI_RGB)
+	binary.LittleEndian.PutUint32(data[20:24], uint32(4*width*height))
+
+	// Write the rows from the bottom to the top, as a bitmap with a positive height is bottom-up.
+	pix := data[bitmapInfoHeaderSize:]
+	for j := 0; j < height; j++ {
+		s := src.Pix[j*src.Stride:]
+		d := pix[(height-1-j)*4*width:]
+		for i := 0; i < width; i++ {
+			d[4*i] = s[4*i+2]
+			d[4*i+1] = s[4*i+1]
+			d[4*i+2] = s[4*i]
+			d[4*i+3] = s[4*i+3]
+		}
+	}
+	return data
+}
+
+func (u *UserInterface) setClipboardImage(img image.Image) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	if err := glfw.SetClipboardDIB(encodeDIB(img)); err != nil {
+		if errors.Is(err, glfw.PlatformError) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -15,11 +15,13 @@
 package ui
 
 import (
+	"image"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/debug"
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 )
@@ -32,17 +34,28 @@ var (
 type Game interface {
 	NewOffscreenImage(width, height int) *Image
 	NewScreenImage(width, height int) *Image
-	Layout(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
+	Layout(outsideWidth, outsideHeight float64, deviceScaleFactor float64) (screenWidth, screenHeight float64)
 	UpdateInputState(fn func(*InputState))
+	SetUpdatePaused(paused bool)
+	SetFocused(focused bool)
 	Update() error
 	DrawOffscreen() error
-	DrawFinalScreen(scale, offsetX, offsetY float64)
+	DrawFinalScreen(scale, offsetX, offsetY float64, region image.Rectangle)
 }
 
+// maxSkipCount is the number of frames to keep rendering the final screen after the offscreen is changed.
+// This is for the swapped framebuffers that might have the contents of the last frames.
+const maxSkipCount = 3
+
 type context struct {
 	game Game
 
 	updateCalled bool
+	updatePaused bool
+
+	// focused is the last focus state. focused is valid only when focusInitialized is true.
+	focused          bool
+	focusInitialized bool
 
 	offscreen *Image
 	screen    *Image
@@ -54,7 +67,17 @@ type context struct {
 
 	isOffscreenModified bool
 
+	// updatedSinceLastDraw reports whether Update is called since the last Draw.
+	updatedSinceLastDraw bool
+
+	// drawnOffscreen is the offscreen used at the last Draw.
+	drawnOffscreen *Image
+
 	skipCount int
+
+	screenDirtyRects   [maxSkipCount]image.Rectangle
+	presentedScreen    *Image
+	presentedOffscreen *Image
 }
 
 func newContext(game Game) *context {
@@ -118,26 +141,54 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		return err
 	}
 
+	// Notify the focus change. The initial state is not notified.
+	if focused := ui.IsFocused(); !c.focusInitialized {
+		c.focused = focused
+		c.focusInitialized = true
+	} else if focused != c.focused {
+		c.focused = focused
+		c.game.SetFocused(focused)
+	}
+
+	// Skip Update while the game is paused. Draw is still called.
+	// The clock is still updated so that the game doesn't catch up the skipped ticks when the game is resumed.
+	if paused := ui.shouldSkipUpdate(); paused != c.updatePaused {
+		c.updatePaused = paused
+		c.game.SetUpdatePaused(paused)
+	}
+
 	// Ensure that Update is called once before Draw so that Update can be used for initialization.
+	forceUpdate := !c.updateCalled
 	if !c.updateCalled && updateCount == 0 {
 		updateCount = 1
-		c.updateCalled = true
 	}
 	debug.Logf("Update count per frame: %d\n", updateCount)
 
 	// Update the game.
 	for i := 0; i < updateCount; i++ {
 		// Read the input state and use it for one tick to give a consistent result for one tick (#2496, #2501).
+		// The input state is read even while the game is paused so that the input during the pause is not delivered
+		// as stale input after the game is resumed.
 		c.game.UpdateInputState(func(inputState *InputState) {
 			ui.readInputState(inputState)
+			gamepad.ReadPresses(ui.IsInputBufferingEnabled())
 		})
 
+		// The hooks are run even while the game is paused, as they manage the internal states like audio players.
 		if err := hook.RunBeforeUpdateHooks(); err != nil {
 			return err
 		}
+
+		// While the game is paused, only Update is skipped.
+		if c.updatePaused && !forceUpdate {
+			continue
+		}
+		forceUpdate = false
+		c.updateCalled = true
 		if err := c.game.Update(); err != nil {
 			return err
 		}
+		c.updatedSinceLastDraw = true
 
 		// Catch the error that happened at (*Image).At.
 		if err := ui.error(); err != nil {
@@ -156,6 +207,9 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		return err
 	}
 
+	// Flush the buffers of the images every frame so that the images that are no longer used can be released.
+	ui.flushImagesWithBuffers()
+
 	return nil
 }
 
@@ -168,12 +222,19 @@ func (c *context) newOffscreenImage(w, h int) *Image {
 }
 
 func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInterface, forceDraw bool) error {
-	if (c.offscreen.imageType == atlas.ImageTypeVolatile) != ui.IsScreenClearedEveryFrame() {
-		w, h := c.offscreen.width, c.offscreen.height
-		c.offscreen.Deallocate()
-		c.offscreen = c.newOffscreenImage(w, h)
+	// A volatile offscreen cannot preserve its content across frames. Recreate the offscreen when the screen is no longer cleared.
+	// The opposite is not needed as a non-volatile offscreen can also be cleared every frame.
+	// Then, toggling SetScreenClearedEveryFrame frame by frame doesn't recreate the offscreen every time.
+	if c.offscreen.imageType == atlas.ImageTypeVolatile && !ui.IsScreenClearedEveryFrame() {
+		c.offscreen = c.newOffscreenImage(c.offscreen.width, c.offscreen.height)
 	}
 
+	// Draw doesn't have to render anything when the offscreen keeps the last content and the game state is not updated.
+	// Then the offscreen is not modified and the frame can be skipped.
+	ui.setScreenRedrawNeeded(forceDraw || c.updatedSinceLastDraw || ui.IsScreenClearedEveryFrame() || c.offscreen != c.drawnOffscreen)
+	c.updatedSinceLastDraw = false
+	c.drawnOffscreen = c.offscreen
+
 	// isOffscreenModified is updated when an offscreen's modifyCallback.
 	c.isOffscreenModified = false
 
@@ -188,8 +249,6 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 		return err
 	}
 
-	const maxSkipCount = 3
-
 	if !forceDraw && !c.isOffscreenModified {
 		if c.skipCount < maxSkipCount {
 			c.skipCount++
@@ -198,13 +257,20 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 		c.skipCount = 0
 	}
 
-	if c.skipCount < maxSkipCount {
-		if graphicsDriver.NeedsClearingScreen() {
+	region, partial := c.screenRegionToPresent(ui, forceDraw)
+	if partial && region.Empty() || !partial && c.skipCount >= maxSkipCount {
+		return nil
+	}
+
+	{
+		// When only a part of the screen is rendered, the part is cleared at DrawFinalScreen.
+		if region == image.Rect(0, 0, c.screen.width, c.screen.height) && graphicsDriver.NeedsClearingScreen() {
 			// This clear is needed for fullscreen mode or some mobile platforms (#622).
 			c.screen.clear()
 		}
 
-		c.game.DrawFinalScreen(c.screenScaleAndOffsets())
+		scale, offsetX, offsetY := c.screenScaleAndOffsets()
+		c.game.DrawFinalScreen(scale, offsetX, offsetY, region)
 
 		// The final screen is never used as the rendering source.
 		// Flush its buffer here just in case.
@@ -214,8 +280,53 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 	return nil
 }
 
+// screenRegionToPresent returns the region of the screen to be rendered in this frame.
+// partial reports whether only the region is rendered with the dirty rectangles.
+func (c *context) screenRegionToPresent(ui *UserInterface, forceDraw bool) (region image.Rectangle, partial bool) {
+	full := image.Rect(0, 0, c.screen.width, c.screen.height)
+
+	// Take the dirty rectangles anyway so that the rectangles are not carried over to the next frame.
+	dirty := ui.takeScreenDirtyRect()
+
+	// The dirty rectangles are meaningful only when the offscreen is preserved.
+	if !ui.IsScreenDirtyRectsEnabled() || ui.IsScreenClearedEveryFrame() {
+		c.screenDirtyRects = [maxSkipCount]image.Rectangle{}
+		return full, false
+	}
+
+	if forceDraw || c.screen != c.presentedScreen || c.offscreen != c.presentedOffscreen {
+		c.presentedScreen = c.screen
+		c.presentedOffscreen = c.offscreen
+		dirty = full
+	} else {
+		dirty = c.offscreenRectToScreenRect(dirty).Intersect(full)
+	}
+
+	// The screen framebuffers are swapped, and the contents of the last frames might remain in the current framebuffer.
+	// Render the dirty rectangles of the last frames too, in the same way as skipCount.
+	copy(c.screenDirtyRects[1:], c.screenDirtyRects[:maxSkipCount-1])
+	c.screenDirtyRects[0] = dirty
+	for _, r := range c.screenDirtyRects {
+		region = region.Union(r)
+	}
+	return region, true
+}
+
+func (c *context) offscreenRectToScreenRect(rect image.Rectangle) image.Rectangle {
+	if rect.Empty() {
+		return image.Rectangle{}
+	}
+	s, ox, oy := c.screenScaleAndOffsets()
+	// Expand the region by one pixel as a filter can refer to the neighbor pixels.
+	x0 := int(math.Floor(float64(rect.Min.X)*s+ox)) - 1
+	y0 := int(math.Floor(float64(rect.Min.Y)*s+oy)) - 1
+	x1 := int(math.Ceil(float64(rect.Max.X)*s+ox)) + 1
+	y1 := int(math.Ceil(float64(rect.Max.Y)*s+oy)) + 1
+	return image.Rect(x0, y0, x1, y1)
+}
+
 func (c *context) layoutGame(outsideWidth, outsideHeight float64, deviceScaleFactor float64) (int, int) {
-	owf, ohf := c.game.Layout(outsideWidth, outsideHeight)
+	owf, ohf := c.game.Layout(outsideWidth, outsideHeight, deviceScaleFactor)
 	if owf <= 0 || ohf <= 0 {
 		panic("ui: Layout must return positive numbers")
 	}
@@ -259,6 +370,15 @@ func (c *context) clientPositionToLogicalPosition(x, y float64, deviceScaleFacto
 	return (x*deviceScaleFactor - ox) / s, (y*deviceScaleFactor - oy) / s
 }
 
+// clientLengthToLogicalLength converts a length in the client, e.g. a touch radius, to a length in logical pixels.
+func (c *context) clientLengthToLogicalLength(l float64, deviceScaleFactor float64) float64 {
+	s, _, _ := c.screenScaleAndOffsets()
+	if s == 0 {
+		return math.NaN()
+	}
+	return l * deviceScaleFactor / s
+}
+
 func (c *context) logicalPositionToClientPosition(x, y float64, deviceScaleFactor float64) (float64, float64) {
 	s, ox, oy := c.screenScaleAndOffsets()
 	return (x*s + ox) / deviceScaleFactor, (y*s + oy) / deviceScaleFactor
@@ -0,0 +1,49 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"errors"
+)
+
+type FileDialogFilter struct {
+	Name       string
+	Extensions []string
+}
+
+type FileDialogOptions struct {
+	Title     string
+	Directory string
+	FileName  string
+	Filters   []FileDialogFilter
+}
+
+var errFileDialogNotSupported = errors.New("ui: file dialogs are not supported on this platform")
+
+// ShowFileDialog shows a native file dialog and returns the chosen path.
+// If save is true, a dialog to save a file is shown. Otherwise, a dialog to open a file is shown.
+// ShowFileDialog returns an empty string without an error if the dialog is canceled.
+func (u *UserInterface) ShowFileDialog(options *FileDialogOptions, save bool) (string, error) {
+	if !u.isRunning() {
+		return "", errors.New("ui: file dialogs are available only while the game is running")
+	}
+
+	var path string
+	var err error
+	u.RunOnMainThread(func() {
+		path, err = u.showFileDialog(options, save)
+	})
+	return path, err
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios
+
+package ui
+
+import (
+	"strings"
+	"unsafe"
+
+	"github.com/ebitengine/purego/objc"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/cocoa"
+)
+
+var (
+	class_NSOpenPanel    = objc.GetClass("NSOpenPanel")
+	class_NSSavePanel    = objc.GetClass("NSSavePanel")
+	class_NSURL          = objc.GetClass("NSURL")
+	class_NSMutableArray = objc.GetClass("NSMutableArray")
+)
+
+var (
+	sel_openPanel               = objc.RegisterName("openPanel")
+	sel_savePanel               = objc.RegisterName("savePanel")
+	sel_setTitle                = objc.RegisterName("setTitle:")
+	sel_setCanChooseFiles       = objc.RegisterName("setCanChooseFiles:")
+	sel_setCanChooseDirectories = objc.RegisterName("setCanChooseDirectories:")
+	sel_setAllowsMultipleSelect = objc.RegisterName("setAllowsMultipleSelection:")
+	sel_setDirectoryURL         = objc.RegisterName("setDirectoryURL:")
+	sel_setNameFieldStringValue = objc.RegisterName("setNameFieldStringValue:")
+	sel_setAllowedFileTypes     = objc.RegisterName("setAllowedFileTypes:")
+	sel_fileURLWithPath         = objc.RegisterName("fileURLWithPath:")
+	sel_array                   = objc.RegisterName("array")
+	sel_addObject               = objc.RegisterName("addObject:")
+	sel_runModal                = objc.RegisterName("runModal")
+	sel_URL                     = objc.RegisterName("URL")
+	sel_path                    = objc.RegisterName("path")
+	sel_UTF8String              = objc.RegisterName("UTF8String")
+)
+
+// _NSModalResponseOK is the return value of runModal when the user chose a file.
+const _NSModalResponseOK = 1
+
+func nsStringFromString(str string) objc.ID {
+	return cocoa.NSString_alloc().InitWithUTF8String(str).ID
+}
+
+// stringFromNSString converts an NSString to a string.
+// Unlike cocoa.NSString.String, this counts bytes of the UTF-8 string, so that non-ASCII paths are kept.
+func stringFromNSString(str objc.ID) string {
+	if str == 0 {
+		return ""
+	}
+	p := unsafe.Pointer(str.Send(sel_UTF8String))
+	if p == nil {
+		return ""
+	}
+	var n int
+	for *(*byte)(unsafe.Add(p, n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice((*byte)(p), n))
+}
+
+func (u *UserInterface) showFileDialog(options *FileDialogOptions, save bool) (string, error) {
+	if options == nil {
+		options = &FileDialogOptions{}
+	}
+
+	var panel objc.ID
+	if save {
+		panel = objc.ID(class_NSSavePanel).Send(sel_savePanel)
+	} else {
+		panel = objc.ID(class_NSOpenPanel).Send(sel_openPanel)
+		panel.Send(sel_setCanChooseFiles, true)
+		panel.Send(sel_setCanChooseDirectories, false)
+		panel.Send(sel_setAllowsMultipleSelect, false)
+	}
+
+	if options.Title != "" {
+		panel.Send(sel_setTitle, nsStringFromString(options.Title))
+	}
+	if options.Directory != "" {
+		panel.Send(sel_setDirectoryURL, objc.ID(class_NSURL).Send(sel_fileURLWithPath, nsStringFromString(options.Directory)))
+	}
+	if save && options.FileName != "" {
+		panel.Send(sel_setNameFieldStringValue, nsStringFromString(options.FileName))
+	}
+	if len(options.Filters) > 0 {
+		types := objc.ID(class_NSMutableArray).Send(sel_array)
+		for _, f := range options.Filters {
+			for _, ext := range f.Extensions {
+				types.Send(sel_addObject, nsStringFromString(strings.TrimPrefix(ext, ".")))
+			}
+		}
+		panel.Send(sel_setAllowedFileTypes, types)
+	}
+
+	if objc.Send[int](panel, sel_runModal) != _NSModalResponseOK {
+		return "", nil
+	}
+	return stringFromNSString(panel.Send(sel_URL).Send(sel_path)), nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/microsoftgdk"
+)
+
+const (
+	_OFN_OVERWRITEPROMPT = 0x00000002
+	_OFN_NOCHANGEDIR     = 0x00000008
+	_OFN_PATHMUSTEXIST   = 0x00000800
+	_OFN_FILEMUSTEXIST   = 0x00001000
+	_OFN_EXPLORER        = 0x00080000
+)
+
+type _OPENFILENAMEW struct {
+	lStructSize       uint32
+	hwndOwner         windows.HWND
+	hInstance         windows.Handle
+	lpstrFilter       *uint16
+	lpstrCustomFilter *uint16
+	nMaxCustFilter    uint32
+	nFilterIndex      uint32
+	lpstrFile         *uint16
+	nMaxFile          uint32
+	lpstrFileTitle    *uint16
+	nMaxFileTitle     uint32
+	lpstrInitialDir   *uint16
+	lpstrTitle        *uint16
+	Flags             uint32
+	nFileOffset       uint16
+	nFileExtension    uint16
+	lpstrDefExt       *uint16
+	lCustData         uintptr
+	lpfnHook          uintptr
+	lpTemplateName    *uint16
+	pvReserved        unsafe.Pointer
+	dwReserved        uint32
+	FlagsEx           uint32
+}
+
+var (
+	comdlg32 = windows.NewLazySystemDLL("comdlg32.dll")
+
+	procGetOpenFileNameW     = comdlg32.NewProc("GetOpenFileNameW")
+	procGetSaveFileNameW     = comdlg32.NewProc("GetSaveFileNameW")
+	procCommDlgExtendedError = comdlg32.NewProc("CommDlgExtendedError")
+)
+
+// utf16Zeros converts items to a UTF-16 sequence where each item is terminated by a zero.
+// The sequence is terminated by an additional zero.
+func utf16Zeros(items []string) ([]uint16, error) {
+	var r []uint16
+	for _, item := range items {
+		s, err := windows.UTF16FromString(item)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, s...)
+	}
+	return append(r, 0), nil
+}
+
+func (u *UserInterface) showFileDialog(options *FileDialogOptions, save bool) (string, error) {
+	if microsoftgdk.IsXbox() {
+		return "", errFileDialogNotSupported
+	}
+
+	if options == nil {
+		options = &FileDialogOptions{}
+	}
+
+	hwnd, err := u.nativeWindow()
+	if err != nil {
+		return "", err
+	}
+
+	// 32767 is the maximum length of an extended-length path.
+	file := make([]uint16, 32768)
+	if options.FileName != "" {
+		name, err := windows.UTF16FromString(options.FileName)
+		if err != nil {
+			return "", err
+		}
+		copy(file[:len(file)-1], name)
+	}
+
+	ofn := _OPENFILENAMEW{
+		hwndOwner: windows.HWND(hwnd),
+		lpstrFile: &file[0],
+		nMaxFile:  uint32(len(file)),
+		Flags:     _OFN_EXPLORER | _OFN_NOCHANGEDIR | _OFN_PATHMUSTEXIST,
+	}
+	ofn.lStructSize = uint32(unsafe.Sizeof(ofn))
+	if save {
+		ofn.Flags |= _OFN_OVERWRITEPROMPT
+	} else {
+		ofn.Flags |= _OFN_FILEMUSTEXIST
+	}
+
+	var filter []uint16
+	if len(options.Filters) > 0 {
+		var items []string
+		for _, f := range options.Filters {
+			patterns := make([]string, 0, len(f.Extensions))
+			for _, ext := range f.Extensions {
+				patterns = append(patterns, "*."+strings.TrimPrefix(ext, "."))
+			}
+			items = append(items, f.Name, strings.Join(patterns, ";"))
+		}
+		f, err := utf16Zeros(items)
+		if err != nil {
+			return "", err
+		}
+		filter = f
+		ofn.lpstrFilter = &filter[0]
+		ofn.nFilterIndex = 1
+	}
+
+	var title []uint16
+	if options.Title != "" {
+		t, err := windows.UTF16FromString(options.Title)
+		if err != nil {
+			return "", err
+		}
+		title = t
+		ofn.lpstrTitle = &title[0]
+	}
+
+	var dir []uint16
+	if options.Directory != "" {
+		d, err := windows.UTF16FromString(options.Directory)
+		if err != nil {
+			return "", err
+		}
+		dir = d
+		ofn.lpstrInitialDir = &dir[0]
+	}
+
+	proc := procGetOpenFileNameW
+	if save {
+		proc = procGetSaveFileNameW
+	}
+	r, _, _ := proc.Call(uintptr(unsafe.Pointer(&ofn)))
+	runtime.KeepAlive(file)
+	runtime.KeepAlive(filter)
+	runtime.KeepAlive(title)
+	runtime.KeepAlive(dir)
+	if int32(r) == 0 {
+		// CommDlgExtendedError returns 0 when the user canceled the dialog.
+		if e, _, _ := procCommDlgExtendedError.Call(); e != 0 {
+			return "", fmt.Errorf("ui: %s failed: error code: %d", proc.Name, e)
+		}
+		return "", nil
+	}
+	return windows.UTF16ToString(file), nil
+}
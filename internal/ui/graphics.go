@@ -19,6 +19,7 @@ import (
 	"os"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/software"
 )
 
 type graphicsDriverCreator interface {
@@ -50,6 +51,8 @@ func newGraphicsDriver(creator graphicsDriverCreator, graphicsLibrary GraphicsLi
 			graphicsLibrary = GraphicsLibraryMetal
 		case "playstation5":
 			graphicsLibrary = GraphicsLibraryPlayStation5
+		case "software":
+			graphicsLibrary = GraphicsLibrarySoftware
 		default:
 			return nil, 0, fmt.Errorf("ui: an unsupported graphics library is specified by the environment variable: %s", env)
 		}
@@ -89,6 +92,9 @@ func newGraphicsDriver(creator graphicsDriverCreator, graphicsLibrary GraphicsLi
 			return nil, 0, err
 		}
 		return g, GraphicsLibraryPlayStation5, nil
+	case GraphicsLibrarySoftware:
+		// The software renderer doesn't depend on the platform.
+		return software.NewGraphics(), GraphicsLibrarySoftware, nil
 	default:
 		return nil, 0, fmt.Errorf("ui: an unsupported graphics library is specified: %d", graphicsLibrary)
 	}
@@ -107,6 +113,7 @@ const (
 	GraphicsLibraryDirectX
 	GraphicsLibraryMetal
 	GraphicsLibraryPlayStation5
+	GraphicsLibrarySoftware
 )
 
 func (g GraphicsLibrary) String() string {
@@ -123,6 +130,8 @@ func (g GraphicsLibrary) String() string {
 		return "Metal"
 	case GraphicsLibraryPlayStation5:
 		return "PlayStation 5"
+	case GraphicsLibrarySoftware:
+		return "Software"
 	default:
 		return fmt.Sprintf("GraphicsLibrary(%d)", g)
 	}
@@ -15,6 +15,8 @@
 package ui
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"image"
 	"math"
@@ -51,6 +53,20 @@ type Image struct {
 	// bigOffscreenBuffer is a double-sized offscreen for anti-alias rendering.
 	bigOffscreenBuffer *bigOffscreenImage
 
+	// antialias reports whether all the rendering onto the image is done with anti-alias.
+	antialias bool
+
+	// compressed reports whether the image is created with GPU-compressed data.
+	// A compressed image can be used only as a rendering source.
+	compressed bool
+
+	// highPrecision reports whether the image has 16-bit floating point numbers per channel.
+	// The dots buffer is not used for a high-precision image.
+	highPrecision bool
+
+	// buffered reports whether the image is registered as an image that might have buffered rendering commands.
+	buffered bool
+
 	// modifyCallback is a callback called when DrawTriangles or WritePixels is called.
 	// modifyCallback is useful to detect whether the image is manipulated or not after a certain time.
 	modifyCallback func()
@@ -69,6 +85,114 @@ func (u *UserInterface) NewImage(width, height int, imageType atlas.ImageType) *
 	}
 }
 
+// NewImageFromNativeTexture creates an image that wraps the given native texture of the current graphics library.
+func (u *UserInterface) NewImageFromNativeTexture(handle uintptr, width, height int) (*Image, error) {
+	if u.graphicsDriver == nil {
+		return nil, errors.New("ui: the graphics library is not initialized yet")
+	}
+	if _, ok := u.graphicsDriver.(graphicsdriver.NativeTextureImporter); !ok {
+		return nil, fmt.Errorf("ui: the graphics library %s cannot import a native texture", u.GraphicsLibrary())
+	}
+	if handle == 0 {
+		return nil, errors.New("ui: the native texture handle must not be 0")
+	}
+	if s := u.MaxImageSize(); width > s || height > s {
+		return nil, fmt.Errorf("ui: the native texture size (%d, %d) must be less than or equal to %d", width, height, s)
+	}
+	return &Image{
+		ui:        u,
+		mipmap:    mipmap.NewFromNativeTexture(handle, width, height),
+		width:     width,
+		height:    height,
+		imageType: atlas.ImageTypeUnmanaged,
+		lastBlend: graphicsdriver.BlendSourceOver,
+	}, nil
+}
+
+// IsCompressedTextureFormatSupported reports whether an image can be created with data in the given compressed texture format.
+// IsCompressedTextureFormatSupported returns false if the graphics library is not initialized yet.
+func (u *UserInterface) IsCompressedTextureFormatSupported(format graphicsdriver.CompressedTextureFormat) bool {
+	if u.graphicsDriver == nil {
+		return false
+	}
+	if !format.IsValid() {
+		return false
+	}
+	return atlas.IsCompressedTextureFormatSupported(u.graphicsDriver, format)
+}
+
+// NewImageFromCompressedData creates an image with the given GPU-compressed data.
+func (u *UserInterface) NewImageFromCompressedData(format graphicsdriver.CompressedTextureFormat, data []byte, width, height int) (*Image, error) {
+	if u.graphicsDriver == nil {
+		return nil, errors.New("ui: the graphics library is not initialized yet")
+	}
+	if !format.IsValid() {
+		return nil, fmt.Errorf("ui: invalid compressed texture format: %d", format)
+	}
+	if !atlas.IsCompressedTextureFormatSupported(u.graphicsDriver, format) {
+		return nil, fmt.Errorf("ui: the graphics library %s doesn't support the compressed texture format %d", u.GraphicsLibrary(), format)
+	}
+	if got, want := len(data), format.DataSize(width, height); got != want {
+		return nil, fmt.Errorf("ui: len(data) must be %d but %d", want, got)
+	}
+	if s := u.MaxImageSize(); width > s || height > s {
+		return nil, fmt.Errorf("ui: the image size (%d, %d) must be less than or equal to %d", width, height, s)
+	}
+
+	// Copy the data as the data is used asynchronously.
+	d := make([]byte, len(data))
+	copy(d, data)
+	return &Image{
+		ui:         u,
+		mipmap:     mipmap.NewFromCompressedData(format, d, width, height),
+		width:      width,
+		height:     height,
+		imageType:  atlas.ImageTypeUnmanaged,
+		compressed: true,
+		lastBlend:  graphicsdriver.BlendSourceOver,
+	}, nil
+}
+
+// IsHighPrecisionImageSupported reports whether an image with 16-bit floating point numbers per channel can be created.
+// IsHighPrecisionImageSupported returns false if the graphics library is not initialized yet.
+func (u *UserInterface) IsHighPrecisionImageSupported() bool {
+	if u.graphicsDriver == nil {
+		return false
+	}
+	return atlas.IsHighPrecisionImageSupported(u.graphicsDriver)
+}
+
+// NewHighPrecisionImage creates an image with 16-bit floating point numbers per channel.
+func (u *UserInterface) NewHighPrecisionImage(width, height int) (*Image, error) {
+	if u.graphicsDriver == nil {
+		return nil, errors.New("ui: the graphics library is not initialized yet")
+	}
+	if !atlas.IsHighPrecisionImageSupported(u.graphicsDriver) {
+		return nil, fmt.Errorf("ui: the graphics library %s doesn't support high-precision images", u.GraphicsLibrary())
+	}
+	if s := u.MaxImageSize(); width > s || height > s {
+		return nil, fmt.Errorf("ui: the image size (%d, %d) must be less than or equal to %d", width, height, s)
+	}
+	return u.newHighPrecisionImage(width, height), nil
+}
+
+func (u *UserInterface) newHighPrecisionImage(width, height int) *Image {
+	return &Image{
+		ui:            u,
+		mipmap:        mipmap.NewHighPrecision(width, height),
+		width:         width,
+		height:        height,
+		imageType:     atlas.ImageTypeUnmanaged,
+		highPrecision: true,
+		lastBlend:     graphicsdriver.BlendSourceOver,
+	}
+}
+
+// IsHighPrecision reports whether the image has 16-bit floating point numbers per channel.
+func (i *Image) IsHighPrecision() bool {
+	return i.highPrecision
+}
+
 func (i *Image) Deallocate() {
 	if i.mipmap == nil {
 		return
@@ -78,9 +202,53 @@ func (i *Image) Deallocate() {
 	}
 	i.mipmap.Deallocate()
 	i.dotsBuffer = nil
+	// After deallocation, the image is a new cleared image and no longer has the compressed data.
+	i.compressed = false
+	if i.buffered {
+		i.buffered = false
+		i.ui.removeImageWithBuffer(i)
+	}
+}
+
+// SetDebugLabel sets a label to the image, which is shown in GPU debugging tools.
+func (i *Image) SetDebugLabel(label string) {
+	if i.mipmap == nil {
+		return
+	}
+	i.mipmap.SetDebugLabel(label)
+}
+
+// PushDebugGroup pushes a debug group, which is shown in GPU debugging tools.
+func (u *UserInterface) PushDebugGroup(name string) {
+	atlas.PushDebugGroup(name)
+}
+
+// PopDebugGroup pops the current debug group.
+func (u *UserInterface) PopDebugGroup() {
+	atlas.PopDebugGroup()
+}
+
+// SetAntiAlias sets whether all the rendering onto the image is done with anti-alias,
+// as if antialias is always true at DrawTriangles.
+func (i *Image) SetAntiAlias(antialias bool) {
+	if i.antialias == antialias {
+		return
+	}
+	if !antialias {
+		i.flushBigOffscreenBufferIfNeeded()
+	}
+	i.antialias = antialias
 }
 
 func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, antialias bool) {
+	i.drawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, antialias || i.antialias)
+}
+
+// drawTriangles draws the triangles without taking i.antialias into account.
+func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, antialias bool) {
+	if i.compressed {
+		panic("ui: a compressed image cannot be a rendering destination")
+	}
 	if i.modifyCallback != nil {
 		i.modifyCallback()
 	}
@@ -105,6 +273,7 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 		}
 
 		i.bigOffscreenBuffer.drawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, false)
+		i.markBuffered()
 		return
 	}
 
@@ -123,6 +292,13 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 }
 
 func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
+	if i.compressed {
+		panic("ui: WritePixels cannot be called on a compressed image")
+	}
+	if i.highPrecision {
+		i.WritePixelsHighPrecision(bytesToFloat16s(pix), region)
+		return
+	}
 	if i.modifyCallback != nil {
 		i.modifyCallback()
 	}
@@ -138,6 +314,7 @@ func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
 		var clr [4]byte
 		copy(clr[:], pix)
 		i.dotsBuffer[region.Min] = clr
+		i.markBuffered()
 
 		if len(i.dotsBuffer) >= 10000 {
 			i.flushDotsBufferIfNeeded()
@@ -149,7 +326,40 @@ func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
 	i.mipmap.WritePixels(pix, region)
 }
 
+// WritePixelsHighPrecision replaces the pixels at the specified region of a high-precision image.
+// pix must be premultiplied-alpha RGBA values, each of which is a 16-bit floating point number in little endian.
+func (i *Image) WritePixelsHighPrecision(pix []byte, region image.Rectangle) {
+	if !i.highPrecision {
+		panic("ui: WritePixelsHighPrecision cannot be called on an image that is not high-precision")
+	}
+	if i.modifyCallback != nil {
+		i.modifyCallback()
+	}
+
+	i.flushBufferIfNeeded()
+	i.mipmap.WritePixels(pix, region)
+}
+
 func (i *Image) ReadPixels(pixels []byte, region image.Rectangle) {
+	i.readPixels(pixels, region, true)
+}
+
+// ReadPixelsWithoutCache reads the pixels at the specified region without caching the pixels of the whole image.
+func (i *Image) ReadPixelsWithoutCache(pixels []byte, region image.Rectangle) {
+	i.readPixels(pixels, region, false)
+}
+
+func (i *Image) readPixels(pixels []byte, region image.Rectangle, cache bool) {
+	if i.compressed {
+		panic("ui: pixels of a compressed image cannot be read")
+	}
+	if i.highPrecision {
+		pix := make([]byte, 8*region.Dx()*region.Dy())
+		i.readPixelsHighPrecision(pix, region, cache)
+		float16sToBytes(pixels, pix)
+		return
+	}
+
 	// Check the error existence and avoid unnecessary calls.
 	if i.ui.error() != nil {
 		return
@@ -168,7 +378,33 @@ func (i *Image) ReadPixels(pixels []byte, region image.Rectangle) {
 		i.flushDotsBufferIfNeeded()
 	}
 
-	if err := i.ui.readPixels(i.mipmap, pixels, region); err != nil {
+	if err := i.ui.readPixels(i.mipmap, pixels, region, cache); err != nil {
+		if panicOnErrorOnReadingPixels {
+			panic(err)
+		}
+		i.ui.setError(err)
+	}
+}
+
+// ReadPixelsHighPrecision reads the pixels at the specified region of a high-precision image.
+// The format of pixels is the same as WritePixelsHighPrecision.
+func (i *Image) ReadPixelsHighPrecision(pixels []byte, region image.Rectangle) {
+	i.readPixelsHighPrecision(pixels, region, true)
+}
+
+func (i *Image) readPixelsHighPrecision(pixels []byte, region image.Rectangle, cache bool) {
+	if !i.highPrecision {
+		panic("ui: ReadPixelsHighPrecision cannot be called on an image that is not high-precision")
+	}
+
+	// Check the error existence and avoid unnecessary calls.
+	if i.ui.error() != nil {
+		return
+	}
+
+	i.flushBigOffscreenBufferIfNeeded()
+
+	if err := i.ui.readPixels(i.mipmap, pixels, region, cache); err != nil {
 		if panicOnErrorOnReadingPixels {
 			panic(err)
 		}
@@ -176,6 +412,30 @@ func (i *Image) ReadPixels(pixels []byte, region image.Rectangle) {
 	}
 }
 
+// bytesToFloat16s converts 8-bit values to 16-bit floating point numbers in little endian.
+func bytesToFloat16s(pix []byte) []byte {
+	dst := make([]byte, 2*len(pix))
+	for i, v := range pix {
+		binary.LittleEndian.PutUint16(dst[2*i:], graphics.Float32ToFloat16(float32(v)/0xff))
+	}
+	return dst
+}
+
+// float16sToBytes converts 16-bit floating point numbers in little endian to 8-bit values.
+// The values are clamped to [0, 1].
+func float16sToBytes(dst []byte, pix []byte) {
+	for i := range dst {
+		v := graphics.Float16ToFloat32(binary.LittleEndian.Uint16(pix[2*i:]))
+		if !(v > 0) {
+			v = 0
+		}
+		if v > 1 {
+			v = 1
+		}
+		dst[i] = byte(v*0xff + 0.5)
+	}
+}
+
 func (i *Image) DumpScreenshot(name string, blackbg bool) (string, error) {
 	i.flushBufferIfNeeded()
 	return i.ui.dumpScreenshot(i.mipmap, name, blackbg)
@@ -185,6 +445,18 @@ func (i *Image) flushBufferIfNeeded() {
 	// The buffers are exclusive and the order should not matter.
 	i.flushDotsBufferIfNeeded()
 	i.flushBigOffscreenBufferIfNeeded()
+	if i.buffered {
+		i.buffered = false
+		i.ui.removeImageWithBuffer(i)
+	}
+}
+
+func (i *Image) markBuffered() {
+	if i.buffered {
+		return
+	}
+	i.buffered = true
+	i.ui.addImageWithBuffer(i)
 }
 
 func (i *Image) flushDotsBufferIfNeeded() {
@@ -267,7 +539,21 @@ func (u *UserInterface) DumpImages(dir string) (string, error) {
 }
 
 func (i *Image) clear() {
-	i.Fill(0, 0, 0, 0, image.Rect(0, 0, i.width, i.height))
+	i.Clear(image.Rect(0, 0, i.width, i.height))
+}
+
+// Clear clears the pixels at the specified region.
+// Clear is cheaper than Fill with a transparent color, as Clear doesn't need a source image or a color.
+func (i *Image) Clear(region image.Rectangle) {
+	if i.compressed {
+		panic("ui: a compressed image cannot be cleared")
+	}
+	if i.modifyCallback != nil {
+		i.modifyCallback()
+	}
+
+	i.flushBufferIfNeeded()
+	i.mipmap.ClearPixels(region)
 }
 
 func (i *Image) Fill(r, g, b, a float32, region image.Rectangle) {
@@ -290,7 +576,8 @@ func (i *Image) Fill(r, g, b, a float32, region image.Rectangle) {
 		blend = graphicsdriver.BlendSourceOver
 	}
 	// i.lastBlend is updated in DrawTriangles.
-	i.DrawTriangles(srcs, i.tmpVerticesForFill, is, blend, region, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll, true, false)
+	// Filling an axis-aligned rectangle doesn't need anti-alias even when i.antialias is true.
+	i.drawTriangles(srcs, i.tmpVerticesForFill, is, blend, region, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll, true, false)
 }
 
 type bigOffscreenImage struct {
@@ -342,7 +629,12 @@ func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderImageCount]*Image
 	}
 
 	if i.image == nil {
-		i.image = i.ui.NewImage(i.region.Dx()*bigOffscreenScale, i.region.Dy()*bigOffscreenScale, i.imageType)
+		if i.orig.highPrecision {
+			// Keep the precision of the original image.
+			i.image = i.ui.newHighPrecisionImage(i.region.Dx()*bigOffscreenScale, i.region.Dy()*bigOffscreenScale)
+		} else {
+			i.image = i.ui.NewImage(i.region.Dx()*bigOffscreenScale, i.region.Dy()*bigOffscreenScale, i.imageType)
+		}
 	}
 
 	// Copy the current rendering result to get the correct blending result.
@@ -407,7 +699,7 @@ func (i *bigOffscreenImage) flush() {
 	if i.blend != graphicsdriver.BlendSourceOver {
 		blend = graphicsdriver.BlendCopy
 	}
-	i.orig.DrawTriangles(srcs, i.tmpVerticesForFlushing, is, blend, dstRegion, [graphics.ShaderImageCount]image.Rectangle{}, LinearFilterShader, nil, graphicsdriver.FillAll, true, false)
+	i.orig.drawTriangles(srcs, i.tmpVerticesForFlushing, is, blend, dstRegion, [graphics.ShaderImageCount]image.Rectangle{}, LinearFilterShader, nil, graphicsdriver.FillAll, true, false)
 
 	i.image.clear()
 	i.dirty = false
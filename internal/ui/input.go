@@ -16,6 +16,7 @@ package ui
 
 import (
 	"io/fs"
+	"time"
 	"unicode"
 )
 
@@ -36,28 +37,71 @@ type Touch struct {
 	ID TouchID
 	X  int
 	Y  int
+
+	// Force is the touch pressure, usually in [0, 1]. Force is -1 if the pressure is unknown.
+	Force float64
+
+	// Radius is the contact radius in logical pixels. Radius is -1 if the radius is unknown.
+	Radius float64
+
+	// Time is the time of the latest event that started or changed the touch.
+	Time time.Time
 }
 
 type InputState struct {
 	KeyPressed         [KeyMax + 1]bool
+	KeyRepeated        [KeyMax + 1]bool
 	MouseButtonPressed [MouseButtonMax + 1]bool
 	CursorX            float64
 	CursorY            float64
 	WheelX             float64
 	WheelY             float64
+	WheelPrecise       bool
 	Touches            []Touch
 	Runes              []rune
 	WindowBeingClosed  bool
 	DroppedFiles       fs.FS
+
+	// KeyEventTime and MouseButtonEventTime are the times of the latest press or release events.
+	KeyEventTime         [KeyMax + 1]time.Time
+	MouseButtonEventTime [MouseButtonMax + 1]time.Time
+
+	// KeyPressCount and MouseButtonPressCount are the numbers of presses since the last read.
+	KeyPressCount         [KeyMax + 1]int
+	MouseButtonPressCount [MouseButtonMax + 1]int
+
+	// keyPressedSinceRead and mouseButtonPressedSinceRead record presses that happened after the last read.
+	// As the input is sampled more often than the game ticks, a quick tap might be released before the next tick.
+	// If the input buffering is enabled, these buffered presses let such a tap be observed as pressed for one tick.
+	keyPressedSinceRead         [KeyMax + 1]bool
+	mouseButtonPressedSinceRead [MouseButtonMax + 1]bool
+
+	keyPressCountSinceRead         [KeyMax + 1]int
+	mouseButtonPressCountSinceRead [MouseButtonMax + 1]int
+
+	// prevTouches is the touches before the current update. This is used to keep the times of unchanged touches.
+	prevTouches []Touch
 }
 
-func (i *InputState) copyAndReset(dst *InputState) {
-	dst.KeyPressed = i.KeyPressed
-	dst.MouseButtonPressed = i.MouseButtonPressed
+// copyAndReset copies the input state to dst and resets the deltas.
+// If buffered is true, the keys and the mouse buttons pressed since the last read are treated as pressed.
+func (i *InputState) copyAndReset(dst *InputState, buffered bool) {
+	for k := range dst.KeyPressed {
+		dst.KeyPressed[k] = i.KeyPressed[k] || (buffered && i.keyPressedSinceRead[k])
+	}
+	dst.KeyRepeated = i.KeyRepeated
+	dst.KeyEventTime = i.KeyEventTime
+	dst.MouseButtonEventTime = i.MouseButtonEventTime
+	dst.KeyPressCount = i.keyPressCountSinceRead
+	dst.MouseButtonPressCount = i.mouseButtonPressCountSinceRead
+	for b := range dst.MouseButtonPressed {
+		dst.MouseButtonPressed[b] = i.MouseButtonPressed[b] || (buffered && i.mouseButtonPressedSinceRead[b])
+	}
 	dst.CursorX = i.CursorX
 	dst.CursorY = i.CursorY
 	dst.WheelX = i.WheelX
 	dst.WheelY = i.WheelY
+	dst.WheelPrecise = i.WheelPrecise
 	dst.Touches = append(dst.Touches[:0], i.Touches...)
 	dst.Runes = append(dst.Runes[:0], i.Runes...)
 	dst.WindowBeingClosed = i.WindowBeingClosed
@@ -66,13 +110,89 @@ func (i *InputState) copyAndReset(dst *InputState) {
 	// Reset the members that are updated by deltas, rather than absolute values.
 	i.WheelX = 0
 	i.WheelY = 0
+	i.WheelPrecise = false
 	i.Runes = i.Runes[:0]
+	i.KeyRepeated = [KeyMax + 1]bool{}
+	i.keyPressedSinceRead = [KeyMax + 1]bool{}
+	i.mouseButtonPressedSinceRead = [MouseButtonMax + 1]bool{}
+	i.keyPressCountSinceRead = [KeyMax + 1]int{}
+	i.mouseButtonPressCountSinceRead = [MouseButtonMax + 1]int{}
 
 	// Reset the members that are never reset until they are explicitly done.
 	i.WindowBeingClosed = false
 	i.DroppedFiles = nil
 }
 
+// setKeyPressed updates the key state. t is the time of the event.
+// A press is recorded when the key state changes from released to pressed.
+func (i *InputState) setKeyPressed(key Key, pressed bool, t time.Time) {
+	if pressed && !i.KeyPressed[key] {
+		i.addKeyPress(key)
+	}
+	i.updateKeyPressed(key, pressed, t)
+}
+
+// updateKeyPressed updates the key state without recording a press.
+// This is used when presses are recorded by addKeyPress separately, e.g. at event callbacks.
+func (i *InputState) updateKeyPressed(key Key, pressed bool, t time.Time) {
+	if i.KeyPressed[key] != pressed {
+		i.KeyEventTime[key] = t
+	}
+	i.KeyPressed[key] = pressed
+}
+
+// addKeyPress records a press of the key, even if the key is released before the next read.
+func (i *InputState) addKeyPress(key Key) {
+	i.keyPressedSinceRead[key] = true
+	i.keyPressCountSinceRead[key]++
+}
+
+// setMouseButtonPressed updates the mouse button state. t is the time of the event.
+// A press is recorded when the mouse button state changes from released to pressed.
+func (i *InputState) setMouseButtonPressed(button MouseButton, pressed bool, t time.Time) {
+	if pressed && !i.MouseButtonPressed[button] {
+		i.addMouseButtonPress(button)
+	}
+	i.updateMouseButtonPressed(button, pressed, t)
+}
+
+// updateMouseButtonPressed updates the mouse button state without recording a press.
+// This is used when presses are recorded by addMouseButtonPress separately, e.g. at event callbacks.
+func (i *InputState) updateMouseButtonPressed(button MouseButton, pressed bool, t time.Time) {
+	if i.MouseButtonPressed[button] != pressed {
+		i.MouseButtonEventTime[button] = t
+	}
+	i.MouseButtonPressed[button] = pressed
+}
+
+// addMouseButtonPress records a press of the mouse button, even if the button is released before the next read.
+func (i *InputState) addMouseButtonPress(button MouseButton) {
+	i.mouseButtonPressedSinceRead[button] = true
+	i.mouseButtonPressCountSinceRead[button]++
+}
+
+// resetTouches resets the touches before appending the current touches by appendTouch.
+func (i *InputState) resetTouches() {
+	i.prevTouches = append(i.prevTouches[:0], i.Touches...)
+	i.Touches = i.Touches[:0]
+}
+
+// appendTouch appends a touch. t is the time of the event.
+// If the touch is not changed from the previous one, the previous time is kept.
+func (i *InputState) appendTouch(touch Touch, t time.Time) {
+	touch.Time = t
+	for _, p := range i.prevTouches {
+		if p.ID != touch.ID {
+			continue
+		}
+		if p.X == touch.X && p.Y == touch.Y && p.Force == touch.Force && p.Radius == touch.Radius {
+			touch.Time = p.Time
+		}
+		break
+	}
+	i.Touches = append(i.Touches, touch)
+}
+
 func (i *InputState) appendRune(r rune) {
 	if !unicode.IsPrint(r) {
 		return
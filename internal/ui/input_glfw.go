@@ -18,6 +18,7 @@ package ui
 
 import (
 	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
@@ -32,6 +33,46 @@ var glfwMouseButtonToMouseButton = map[glfw.MouseButton]MouseButton{
 }
 
 func (u *UserInterface) registerInputCallbacks() error {
+	if _, err := u.window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		if action != glfw.Press && action != glfw.Repeat {
+			return
+		}
+
+		// As this function is called from GLFW callbacks, the current thread is main.
+		u.m.Lock()
+		defer u.m.Unlock()
+		for uk, gk := range uiKeyToGLFWKey {
+			if gk != key {
+				continue
+			}
+			if action == glfw.Press {
+				// Record the press even if the key is released before the next polling.
+				u.inputState.addKeyPress(uk)
+				continue
+			}
+			u.inputState.KeyRepeated[uk] = true
+		}
+	}); err != nil {
+		return err
+	}
+
+	if _, err := u.window.SetMouseButtonCallback(func(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+		if action != glfw.Press {
+			return
+		}
+		ub, ok := glfwMouseButtonToMouseButton[button]
+		if !ok {
+			return
+		}
+
+		// As this function is called from GLFW callbacks, the current thread is main.
+		u.m.Lock()
+		defer u.m.Unlock()
+		u.inputState.addMouseButtonPress(ub)
+	}); err != nil {
+		return err
+	}
+
 	if _, err := u.window.SetCharModsCallback(func(w *glfw.Window, char rune, mods glfw.ModifierKey) {
 		// As this function is called from GLFW callbacks, the current thread is main.
 		u.m.Lock()
@@ -47,6 +88,9 @@ func (u *UserInterface) registerInputCallbacks() error {
 		defer u.m.Unlock()
 		u.inputState.WheelX += xoff
 		u.inputState.WheelY += yoff
+		if isScrollPrecise(xoff, yoff) {
+			u.inputState.WheelPrecise = true
+		}
 	}); err != nil {
 		return err
 	}
@@ -67,19 +111,23 @@ func (u *UserInterface) updateInputStateImpl() error {
 	u.m.Lock()
 	defer u.m.Unlock()
 
+	// GLFW doesn't provide event times. Use the current time as the states are polled just now.
+	now := time.Now()
 	for uk, gk := range uiKeyToGLFWKey {
 		s, err := u.window.GetKey(gk)
 		if err != nil {
 			return err
 		}
-		u.inputState.KeyPressed[uk] = s == glfw.Press
+		// Presses are recorded at the key callback.
+		u.inputState.updateKeyPressed(uk, s == glfw.Press, now)
 	}
 	for gb, ub := range glfwMouseButtonToMouseButton {
 		s, err := u.window.GetMouseButton(gb)
 		if err != nil {
 			return err
 		}
-		u.inputState.MouseButtonPressed[ub] = s == glfw.Press
+		// Presses are recorded at the mouse button callback.
+		u.inputState.updateMouseButtonPressed(ub, s == glfw.Press, now)
 	}
 
 	m, err := u.currentMonitor()
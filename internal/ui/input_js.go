@@ -17,6 +17,7 @@ package ui
 import (
 	"math"
 	"syscall/js"
+	"time"
 	"unicode"
 )
 
@@ -32,10 +33,30 @@ var (
 	stringTouchmove  = js.ValueOf("touchmove")
 )
 
+var performance = js.Global().Get("performance")
+
 type touchInClient struct {
-	id TouchID
-	x  float64
-	y  float64
+	id     TouchID
+	x      float64
+	y      float64
+	force  float64
+	radius float64
+	time   time.Time
+}
+
+// eventTime returns the time when the event e happened.
+func eventTime(e js.Value) time.Time {
+	now := time.Now()
+	ts := e.Get("timeStamp")
+	if ts.Type() != js.TypeNumber || !performance.Truthy() {
+		return now
+	}
+	// Event.timeStamp is a DOMHighResTimeStamp, which is relative to the same time origin as performance.now().
+	d := performance.Call("now").Float() - ts.Float()
+	if d < 0 {
+		return now
+	}
+	return now.Add(-time.Duration(d * float64(time.Millisecond)))
 }
 
 func jsKeyToID(key js.Value) Key {
@@ -57,28 +78,31 @@ var codeToMouseButton = map[int]MouseButton{
 	4: MouseButton4,
 }
 
-func (u *UserInterface) keyDown(code js.Value) {
+func (u *UserInterface) keyDown(code js.Value, repeat bool, t time.Time) {
 	id := jsKeyToID(code)
 	if id < 0 {
 		return
 	}
-	u.inputState.KeyPressed[id] = true
+	u.inputState.setKeyPressed(id, true, t)
+	if repeat {
+		u.inputState.KeyRepeated[id] = true
+	}
 }
 
-func (u *UserInterface) keyUp(code js.Value) {
+func (u *UserInterface) keyUp(code js.Value, t time.Time) {
 	id := jsKeyToID(code)
 	if id < 0 {
 		return
 	}
-	u.inputState.KeyPressed[id] = false
+	u.inputState.setKeyPressed(id, false, t)
 }
 
-func (u *UserInterface) mouseDown(code int) {
-	u.inputState.MouseButtonPressed[codeToMouseButton[code]] = true
+func (u *UserInterface) mouseDown(code int, t time.Time) {
+	u.inputState.setMouseButtonPressed(codeToMouseButton[code], true, t)
 }
 
-func (u *UserInterface) mouseUp(code int) {
-	u.inputState.MouseButtonPressed[codeToMouseButton[code]] = false
+func (u *UserInterface) mouseUp(code int, t time.Time) {
+	u.inputState.setMouseButtonPressed(codeToMouseButton[code], false, t)
 }
 
 func (u *UserInterface) updateInputFromEvent(e js.Value) error {
@@ -91,14 +115,14 @@ func (u *UserInterface) updateInputFromEvent(e js.Value) error {
 				u.inputState.appendRune(r)
 			}
 		}
-		u.keyDown(e.Get("code"))
+		u.keyDown(e.Get("code"), e.Get("repeat").Truthy(), eventTime(e))
 	case t.Equal(stringKeyup):
-		u.keyUp(e.Get("code"))
+		u.keyUp(e.Get("code"), eventTime(e))
 	case t.Equal(stringMousedown):
-		u.mouseDown(e.Get("button").Int())
+		u.mouseDown(e.Get("button").Int(), eventTime(e))
 		u.setMouseCursorFromEvent(e)
 	case t.Equal(stringMouseup):
-		u.mouseUp(e.Get("button").Int())
+		u.mouseUp(e.Get("button").Int(), eventTime(e))
 		u.setMouseCursorFromEvent(e)
 	case t.Equal(stringMousemove):
 		u.setMouseCursorFromEvent(e)
@@ -140,13 +164,25 @@ func (u *UserInterface) recoverCursorPosition() {
 func (u *UserInterface) updateTouchesFromEvent(e js.Value) {
 	u.touchesInClient = u.touchesInClient[:0]
 
+	now := eventTime(e)
 	touches := e.Get("targetTouches")
 	for i := 0; i < touches.Length(); i++ {
 		t := touches.Call("item", i)
+		force := -1.0
+		if f := t.Get("force"); f.Type() == js.TypeNumber {
+			force = f.Float()
+		}
+		radius := -1.0
+		if rx, ry := t.Get("radiusX"), t.Get("radiusY"); rx.Type() == js.TypeNumber && ry.Type() == js.TypeNumber {
+			radius = (rx.Float() + ry.Float()) / 2
+		}
 		u.touchesInClient = append(u.touchesInClient, touchInClient{
-			id: TouchID(t.Get("identifier").Int()),
-			x:  t.Get("clientX").Float(),
-			y:  t.Get("clientY").Float(),
+			id:     TouchID(t.Get("identifier").Int()),
+			x:      t.Get("clientX").Float(),
+			y:      t.Get("clientY").Float(),
+			force:  force,
+			radius: radius,
+			time:   now,
 		})
 	}
 }
@@ -272,14 +308,20 @@ func (u *UserInterface) updateInputState() error {
 		u.inputState.CursorY = cy
 	}
 
-	u.inputState.Touches = u.inputState.Touches[:0]
+	u.inputState.resetTouches()
 	for _, t := range u.touchesInClient {
 		x, y := u.context.clientPositionToLogicalPosition(t.x, t.y, s)
-		u.inputState.Touches = append(u.inputState.Touches, Touch{
-			ID: t.id,
-			X:  int(x),
-			Y:  int(y),
-		})
+		r := t.radius
+		if r >= 0 {
+			r = u.context.clientLengthToLogicalLength(r, s)
+		}
+		u.inputState.appendTouch(Touch{
+			ID:     t.id,
+			X:      int(x),
+			Y:      int(y),
+			Force:  t.force,
+			Radius: r,
+		}, t.time)
 	}
 
 	return nil
@@ -16,6 +16,10 @@
 
 package ui
 
+import (
+	"time"
+)
+
 type TouchForInput struct {
 	ID TouchID
 
@@ -24,15 +28,22 @@ type TouchForInput struct {
 
 	// Y is in device-independent pixels.
 	Y float64
+
+	// Force is the touch pressure, usually in [0, 1]. Force is -1 if the pressure is unknown.
+	Force float64
+
+	// Radius is in device-independent pixels. Radius is -1 if the radius is unknown.
+	Radius float64
 }
 
 func (u *UserInterface) updateInputStateFromOutside(keys map[Key]struct{}, runes []rune, touches []TouchForInput) {
 	u.m.Lock()
 	defer u.m.Unlock()
 
+	now := time.Now()
 	for k := range u.inputState.KeyPressed {
 		_, ok := keys[Key(k)]
-		u.inputState.KeyPressed[k] = ok
+		u.inputState.setKeyPressed(Key(k), ok, now)
 	}
 
 	u.inputState.Runes = append(u.inputState.Runes, runes...)
@@ -41,6 +52,7 @@ func (u *UserInterface) updateInputStateFromOutside(keys map[Key]struct{}, runes
 	for _, t := range touches {
 		u.touches = append(u.touches, t)
 	}
+	u.touchesTime = now
 }
 
 func (u *UserInterface) updateInputState() error {
@@ -49,14 +61,20 @@ func (u *UserInterface) updateInputState() error {
 
 	s := u.DeviceScaleFactor()
 
-	u.inputState.Touches = u.inputState.Touches[:0]
+	u.inputState.resetTouches()
 	for _, t := range u.touches {
 		x, y := u.context.clientPositionToLogicalPosition(t.X, t.Y, s)
-		u.inputState.Touches = append(u.inputState.Touches, Touch{
-			ID: t.ID,
-			X:  int(x),
-			Y:  int(y),
-		})
+		r := t.Radius
+		if r >= 0 {
+			r = u.context.clientLengthToLogicalLength(r, s)
+		}
+		u.inputState.appendTouch(Touch{
+			ID:     t.ID,
+			X:      int(x),
+			Y:      int(y),
+			Force:  t.Force,
+			Radius: r,
+		}, u.touchesTime)
 	}
 	return nil
 }
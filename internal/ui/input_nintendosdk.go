@@ -26,6 +26,8 @@ package ui
 import "C"
 
 import (
+	"time"
+
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 )
 
@@ -58,14 +60,17 @@ func (u *UserInterface) updateInputStateImpl() error {
 	u.m.Lock()
 	defer u.m.Unlock()
 
-	u.inputState.Touches = u.inputState.Touches[:0]
+	now := time.Now()
+	u.inputState.resetTouches()
 	for _, t := range u.nativeTouches {
 		x, y := u.context.clientPositionToLogicalPosition(float64(t.x), float64(t.y), deviceScaleFactor)
-		u.inputState.Touches = append(u.inputState.Touches, Touch{
-			ID: TouchID(t.id),
-			X:  int(x),
-			Y:  int(y),
-		})
+		u.inputState.appendTouch(Touch{
+			ID:     TouchID(t.id),
+			X:      int(x),
+			Y:      int(y),
+			Force:  -1,
+			Radius: -1,
+		}, now)
 	}
 
 	return nil
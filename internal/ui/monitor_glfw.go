@@ -40,12 +40,39 @@ func (m *Monitor) Name() string {
 	return m.name
 }
 
+// Bounds returns the monitor's bounds in device-independent pixels.
+func (m *Monitor) Bounds() image.Rectangle {
+	b := m.boundsInGLFWPixels
+	return image.Rect(
+		int(dipFromGLFWPixel(float64(b.Min.X), m)),
+		int(dipFromGLFWPixel(float64(b.Min.Y), m)),
+		int(dipFromGLFWPixel(float64(b.Max.X), m)),
+		int(dipFromGLFWPixel(float64(b.Max.Y), m)))
+}
+
+// DeviceScaleFactor returns the monitor's device scale factor.
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return m.deviceScaleFactor()
+}
+
+// RefreshRate returns the monitor's refresh rate in Hz of the current video mode.
+// RefreshRate returns 0 if the refresh rate is unknown.
+func (m *Monitor) RefreshRate() float64 {
+	if m == nil || m.videoMode == nil {
+		return 0
+	}
+	return float64(m.videoMode.RefreshRate)
+}
+
 func (m *Monitor) deviceScaleFactor() float64 {
 	// It is rare, but monitor can be nil when glfw.GetPrimaryMonitor returns nil.
 	// In this case, return 1 as a tentative scale (#1878).
 	if m == nil {
 		return 1
 	}
+	if theUI.isDeviceScaleFactorDisabled() {
+		return 1
+	}
 	return m.contentScale
 }
 
@@ -113,6 +140,21 @@ func (m *monitors) monitorFromPosition(x, y int) *Monitor {
 	return nil
 }
 
+// monitorFromPositionInDIP returns a monitor for the given position (x, y),
+// or returns nil if monitor is not found.
+// The position is in the same coordinate as (*Monitor).Bounds.
+func (m *monitors) monitorFromPositionInDIP(x, y int) *Monitor {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	for _, m := range m.monitors {
+		if image.Pt(x, y).In(m.Bounds()) {
+			return m
+		}
+	}
+	return nil
+}
+
 // update must be called from the main thread.
 func (m *monitors) update() error {
 	glfwMonitors, err := glfw.GetMonitors()
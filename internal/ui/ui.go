@@ -21,6 +21,7 @@ import (
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 	"github.com/hajimehoshi/ebiten/v2/internal/mipmap"
 	"github.com/hajimehoshi/ebiten/v2/internal/thread"
 )
@@ -59,6 +60,8 @@ const (
 	CursorShapeNWSEResize
 	CursorShapeMove
 	CursorShapeNotAllowed
+	CursorShapeGrab
+	CursorShapeGrabbing
 )
 
 type WindowResizingMode int
@@ -69,17 +72,46 @@ const (
 	WindowResizingModeEnabled
 )
 
+type WindowHitTestResult int
+
+const (
+	WindowHitTestResultClient WindowHitTestResult = iota
+	WindowHitTestResultCaption
+	WindowHitTestResultResizeLeft
+	WindowHitTestResultResizeRight
+	WindowHitTestResultResizeTop
+	WindowHitTestResultResizeBottom
+	WindowHitTestResultResizeTopLeft
+	WindowHitTestResultResizeTopRight
+	WindowHitTestResultResizeBottomLeft
+	WindowHitTestResultResizeBottomRight
+)
+
 type UserInterface struct {
 	err  error
 	errM sync.Mutex
 
 	isScreenClearedEveryFrame int32
 	graphicsLibrary           int32
+	srgbFramebuffer           int32
 	running                   int32
 	terminated                int32
+	updatePaused              int32
+	updateSkippedOnUnfocused  int32
+	screenDirtyRectsEnabled   int32
+	screenRedrawNeeded        int32
+	inputBufferingEnabled     int32
+
+	// screenDirtyRect is the union of the dirty rectangles added in the current frame.
+	screenDirtyRect  image.Rectangle
+	screenDirtyRectM sync.Mutex
 
 	whiteImage *Image
 
+	// imagesWithBuffers is a set of images that might have buffered rendering commands.
+	imagesWithBuffers  map[*Image]struct{}
+	imagesWithBuffersM sync.Mutex
+
 	mainThread thread.Thread
 
 	userInterfaceImpl
@@ -124,7 +156,10 @@ func newUserInterface() (*UserInterface, error) {
 	return u, nil
 }
 
-func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region image.Rectangle) error {
+func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region image.Rectangle, cache bool) error {
+	if !cache {
+		return mipmap.ReadPixelsWithoutCache(u.graphicsDriver, pixels, region)
+	}
 	return mipmap.ReadPixels(u.graphicsDriver, pixels, region)
 }
 
@@ -132,6 +167,75 @@ func (u *UserInterface) dumpScreenshot(mipmap *mipmap.Mipmap, name string, black
 	return mipmap.DumpScreenshot(u.graphicsDriver, name, blackbg)
 }
 
+func (u *UserInterface) addImageWithBuffer(img *Image) {
+	u.imagesWithBuffersM.Lock()
+	defer u.imagesWithBuffersM.Unlock()
+	if u.imagesWithBuffers == nil {
+		u.imagesWithBuffers = map[*Image]struct{}{}
+	}
+	u.imagesWithBuffers[img] = struct{}{}
+}
+
+func (u *UserInterface) removeImageWithBuffer(img *Image) {
+	u.imagesWithBuffersM.Lock()
+	defer u.imagesWithBuffersM.Unlock()
+	delete(u.imagesWithBuffers, img)
+}
+
+// FlushGraphics flushes all the pending rendering commands and sends them to the graphics library.
+// If wait is true, FlushGraphics waits until the commands are sent.
+func (u *UserInterface) FlushGraphics(wait bool) {
+	// Check the error existence and avoid unnecessary calls.
+	if u.error() != nil {
+		return
+	}
+	if u.graphicsDriver == nil {
+		return
+	}
+
+	u.flushImagesWithBuffers()
+	if err := atlas.Flush(u.graphicsDriver, wait); err != nil {
+		u.setError(err)
+	}
+}
+
+// flushImagesWithBuffers flushes the buffers of all the images that might have buffered rendering commands.
+//
+// As the set of such images holds the images, flushImagesWithBuffers must be called every frame.
+// Otherwise, an image that has buffers and is no longer used would never be released.
+func (u *UserInterface) flushImagesWithBuffers() {
+	u.imagesWithBuffersM.Lock()
+	imgs := make([]*Image, 0, len(u.imagesWithBuffers))
+	for img := range u.imagesWithBuffers {
+		imgs = append(imgs, img)
+	}
+	u.imagesWithBuffersM.Unlock()
+
+	for _, img := range imgs {
+		img.flushBufferIfNeeded()
+	}
+}
+
+// MaxImageSize returns the maximum size of an image the current graphics library supports.
+// MaxImageSize returns 0 if the graphics library is not initialized yet.
+func (u *UserInterface) MaxImageSize() int {
+	if u.graphicsDriver == nil {
+		return 0
+	}
+	return atlas.MaxImageSize(u.graphicsDriver)
+}
+
+// MaxShaderImages returns the maximum number of source images of a shader the current graphics library supports.
+// MaxShaderImages returns graphics.ShaderImageCount if the graphics library is not initialized yet.
+func (u *UserInterface) MaxShaderImages() int {
+	return atlas.MaxShaderImages()
+}
+
+// ReleaseUnusedGraphicsResources disposes the graphics resources that are no longer referenced.
+func (u *UserInterface) ReleaseUnusedGraphicsResources() {
+	atlas.ReleaseUnusedResources()
+}
+
 func (u *UserInterface) dumpImages(dir string) (string, error) {
 	return atlas.DumpImages(u.graphicsDriver, dir)
 }
@@ -142,6 +246,9 @@ type RunOptions struct {
 	ScreenTransparent bool
 	SkipTaskbar       bool
 	SingleThread      bool
+	LinearBlending    bool
+
+	DeviceScaleFactorDisabled bool
 }
 
 // InitialWindowPosition returns the position for centering the given second width/height pair within the first width/height pair.
@@ -175,6 +282,92 @@ func (u *UserInterface) SetScreenClearedEveryFrame(cleared bool) {
 	atomic.StoreInt32(&u.isScreenClearedEveryFrame, v)
 }
 
+func (u *UserInterface) IsScreenDirtyRectsEnabled() bool {
+	return atomic.LoadInt32(&u.screenDirtyRectsEnabled) != 0
+}
+
+func (u *UserInterface) SetScreenDirtyRectsEnabled(enabled bool) {
+	v := int32(0)
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&u.screenDirtyRectsEnabled, v)
+}
+
+func (u *UserInterface) IsScreenRedrawNeeded() bool {
+	return atomic.LoadInt32(&u.screenRedrawNeeded) != 0
+}
+
+func (u *UserInterface) setScreenRedrawNeeded(needed bool) {
+	v := int32(0)
+	if needed {
+		v = 1
+	}
+	atomic.StoreInt32(&u.screenRedrawNeeded, v)
+}
+
+func (u *UserInterface) AddScreenDirtyRect(rect image.Rectangle) {
+	u.screenDirtyRectM.Lock()
+	defer u.screenDirtyRectM.Unlock()
+	u.screenDirtyRect = u.screenDirtyRect.Union(rect)
+}
+
+// takeScreenDirtyRect returns the union of the dirty rectangles added since the last call, and resets it.
+func (u *UserInterface) takeScreenDirtyRect() image.Rectangle {
+	u.screenDirtyRectM.Lock()
+	defer u.screenDirtyRectM.Unlock()
+	r := u.screenDirtyRect
+	u.screenDirtyRect = image.Rectangle{}
+	return r
+}
+
+func (u *UserInterface) IsUpdatePaused() bool {
+	return atomic.LoadInt32(&u.updatePaused) != 0
+}
+
+func (u *UserInterface) SetUpdatePaused(paused bool) {
+	v := int32(0)
+	if paused {
+		v = 1
+	}
+	atomic.StoreInt32(&u.updatePaused, v)
+}
+
+func (u *UserInterface) IsInputBufferingEnabled() bool {
+	return atomic.LoadInt32(&u.inputBufferingEnabled) != 0
+}
+
+func (u *UserInterface) SetInputBufferingEnabled(enabled bool) {
+	v := int32(0)
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&u.inputBufferingEnabled, v)
+}
+
+func (u *UserInterface) IsUpdateOnUnfocused() bool {
+	return atomic.LoadInt32(&u.updateSkippedOnUnfocused) == 0
+}
+
+func (u *UserInterface) SetUpdateOnUnfocused(update bool) {
+	v := int32(0)
+	if !update {
+		v = 1
+	}
+	atomic.StoreInt32(&u.updateSkippedOnUnfocused, v)
+}
+
+// shouldSkipUpdate reports whether updating the game should be skipped, either by the explicit pause or by losing focus.
+func (u *UserInterface) shouldSkipUpdate() bool {
+	if u.IsUpdatePaused() {
+		return true
+	}
+	if !u.IsUpdateOnUnfocused() && !u.IsFocused() {
+		return true
+	}
+	return false
+}
+
 func (u *UserInterface) setGraphicsLibrary(library GraphicsLibrary) {
 	atomic.StoreInt32(&u.graphicsLibrary, int32(library))
 }
@@ -183,6 +376,34 @@ func (u *UserInterface) GraphicsLibrary() GraphicsLibrary {
 	return GraphicsLibrary(atomic.LoadInt32(&u.graphicsLibrary))
 }
 
+// enableSRGBIfAvailable makes the graphics driver treat the textures and the screen as sRGB if possible.
+// enableSRGBIfAvailable must be called before any images are created.
+func (u *UserInterface) enableSRGBIfAvailable() {
+	s, ok := u.graphicsDriver.(graphicsdriver.SRGBEnabler)
+	if !ok {
+		return
+	}
+	if !s.EnableSRGB() {
+		return
+	}
+	atomic.StoreInt32(&u.srgbFramebuffer, 1)
+}
+
+// IsSRGBFramebuffer reports whether the textures and the screen are treated as sRGB.
+func (u *UserInterface) IsSRGBFramebuffer() bool {
+	return atomic.LoadInt32(&u.srgbFramebuffer) != 0
+}
+
+// RunOnMainThread calls f on the main thread and blocks until f finishes.
+// If the main thread is not available, e.g. before the game runs or on mobiles, f is called on the current goroutine.
+func (u *UserInterface) RunOnMainThread(f func()) {
+	if u.mainThread == nil || !u.isRunning() {
+		f()
+		return
+	}
+	u.mainThread.Call(f)
+}
+
 func (u *UserInterface) isRunning() bool {
 	return atomic.LoadInt32(&u.running) != 0 && !u.isTerminated()
 }
@@ -211,10 +211,19 @@ func dipFromGLFWPixel(x float64, monitor *Monitor) float64 {
 	// Thus, the conversion functions are unnecessary,
 	// however we still need the deviceScaleFactor internally
 	// so we can create and maintain a HiDPI frame buffer.
+	//
+	// If the device scale factor is disabled, a device-independent pixel is a device pixel.
+	// Then, convert the coordinate from points to device pixels.
+	if theUI.isDeviceScaleFactorDisabled() && monitor != nil {
+		return x * monitor.contentScale
+	}
 	return x
 }
 
 func dipToGLFWPixel(x float64, monitor *Monitor) float64 {
+	if theUI.isDeviceScaleFactorDisabled() && monitor != nil {
+		return x / monitor.contentScale
+	}
 	return x
 }
 
@@ -222,6 +231,17 @@ func (u *UserInterface) adjustWindowPosition(x, y int, monitor *Monitor) (int, i
 	return x, y
 }
 
+// isScrollPrecise reports whether the current scroll event comes from a precise scrolling device like a trackpad.
+// isScrollPrecise must be called from a GLFW scroll callback.
+func isScrollPrecise(xoff, yoff float64) bool {
+	// The scroll callback is called while the scroll event is being dispatched.
+	e := cocoa.NSApplication_sharedApplication().CurrentEvent()
+	if e.ID == 0 {
+		return false
+	}
+	return e.HasPreciseScrollingDeltas()
+}
+
 var (
 	class_NSCursor = objc.GetClass("NSCursor")
 	class_NSEvent  = objc.GetClass("NSEvent")
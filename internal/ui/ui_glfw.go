@@ -24,6 +24,7 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/file"
@@ -60,6 +61,9 @@ type userInterfaceImpl struct {
 	maxWindowWidthInDIP  int
 	maxWindowHeightInDIP int
 
+	windowAspectRatioNumer int
+	windowAspectRatioDenom int
+
 	runnableOnUnfocused  bool
 	fpsMode              FPSModeType
 	iconImages           []image.Image
@@ -69,6 +73,27 @@ type userInterfaceImpl struct {
 
 	lastDeviceScaleFactor float64
 
+	// deviceScaleFactorDisabled reports whether the device scale factor is treated as 1 regardless of the monitors.
+	// deviceScaleFactorDisabled must be accessed atomically.
+	deviceScaleFactorDisabled int32
+
+	// customCursor is a cursor created by SetCursorImage.
+	// customCursor must be accessed from the main thread.
+	customCursor *glfw.Cursor
+
+	// windowHitTest is a function set by SetWindowHitTest.
+	// windowHitTest is protected by m.
+	windowHitTest func(x, y int) WindowHitTestResult
+
+	// windowResizeCallback is a function set by SetWindowResizeCallback.
+	// windowResizeCallback is protected by m.
+	windowResizeCallback func(width, height int)
+
+	// lastNotifiedWindowWidthInDIP and lastNotifiedWindowHeightInDIP are the size last given to windowResizeCallback.
+	// These must be accessed from the main thread.
+	lastNotifiedWindowWidthInDIP  int
+	lastNotifiedWindowHeightInDIP int
+
 	initMonitor                *Monitor
 	initFullscreen             bool
 	initCursorMode             CursorMode
@@ -80,6 +105,7 @@ type userInterfaceImpl struct {
 	initWindowFloating         bool
 	initWindowMaximized        bool
 	initWindowMousePassthrough bool
+	initWindowOpacity          float64
 
 	// bufferOnceSwapped must be accessed from the main thread.
 	bufferOnceSwapped bool
@@ -91,6 +117,12 @@ type userInterfaceImpl struct {
 
 	fpsModeInited bool
 
+	// fpsModeToApply is the FPS mode set while the game is running.
+	// This is applied at the next frame on the main thread.
+	// fpsModeToApply is protected by m.
+	fpsModeToApply     FPSModeType
+	fpsModeToBeApplied bool
+
 	inputState   InputState
 	iwindow      glfwWindow
 	savedCursorX float64
@@ -101,6 +133,7 @@ type userInterfaceImpl struct {
 	framebufferSizeCallback        glfw.FramebufferSizeCallback
 	defaultFramebufferSizeCallback glfw.FramebufferSizeCallback
 	dropCallback                   glfw.DropCallback
+	hitTestCallback                glfw.HitTestCallback
 	framebufferSizeCallbackCh      chan struct{}
 
 	darwinInitOnce        sync.Once
@@ -128,12 +161,15 @@ func (u *UserInterface) init() error {
 		minWindowHeightInDIP:     glfw.DontCare,
 		maxWindowWidthInDIP:      glfw.DontCare,
 		maxWindowHeightInDIP:     glfw.DontCare,
+		windowAspectRatioNumer:   glfw.DontCare,
+		windowAspectRatioDenom:   glfw.DontCare,
 		initCursorMode:           CursorModeVisible,
 		initWindowDecorated:      true,
 		initWindowPositionXInDIP: invalidPos,
 		initWindowPositionYInDIP: invalidPos,
 		initWindowWidthInDIP:     640,
 		initWindowHeightInDIP:    480,
+		initWindowOpacity:        1,
 		origWindowPosX:           invalidPos,
 		origWindowPosY:           invalidPos,
 		savedCursorX:             math.NaN(),
@@ -242,6 +278,18 @@ func (u *UserInterface) initializeGLFW() error {
 	}
 	glfwSystemCursors[CursorShapeNotAllowed] = c
 
+	c, err = glfw.CreateStandardCursor(glfw.GrabCursor)
+	if err != nil {
+		return err
+	}
+	glfwSystemCursors[CursorShapeGrab] = c
+
+	c, err = glfw.CreateStandardCursor(glfw.GrabbingCursor)
+	if err != nil {
+		return err
+	}
+	glfwSystemCursors[CursorShapeGrabbing] = c
+
 	return nil
 }
 
@@ -373,6 +421,32 @@ func (u *UserInterface) setWindowSizeLimitsInDIP(minw, minh, maxw, maxh int) boo
 	return true
 }
 
+func (u *UserInterface) getWindowAspectRatio() (numer, denom int) {
+	if microsoftgdk.IsXbox() {
+		return glfw.DontCare, glfw.DontCare
+	}
+
+	u.m.RLock()
+	defer u.m.RUnlock()
+	return u.windowAspectRatioNumer, u.windowAspectRatioDenom
+}
+
+func (u *UserInterface) setWindowAspectRatio(numer, denom int) bool {
+	if microsoftgdk.IsXbox() {
+		// Do nothing. The size is always fixed.
+		return false
+	}
+
+	u.m.Lock()
+	defer u.m.Unlock()
+	if u.windowAspectRatioNumer == numer && u.windowAspectRatioDenom == denom {
+		return false
+	}
+	u.windowAspectRatioNumer = numer
+	u.windowAspectRatioDenom = denom
+	return true
+}
+
 func (u *UserInterface) isWindowMaximizable() bool {
 	_, _, maxw, maxh := u.getWindowSizeLimitsInDIP()
 	return maxw == glfw.DontCare && maxh == glfw.DontCare
@@ -432,6 +506,12 @@ func (u *UserInterface) setInitWindowDecorated(decorated bool) {
 	u.m.Unlock()
 }
 
+// isDeviceScaleFactorDisabled reports whether the device scale factor is treated as 1.
+// isDeviceScaleFactorDisabled is concurrent-safe.
+func (u *UserInterface) isDeviceScaleFactorDisabled() bool {
+	return atomic.LoadInt32(&u.deviceScaleFactorDisabled) != 0
+}
+
 func (u *UserInterface) isRunnableOnUnfocused() bool {
 	u.m.RLock()
 	v := u.runnableOnUnfocused
@@ -557,6 +637,18 @@ func (u *UserInterface) setInitWindowMousePassthrough(enabled bool) {
 	u.initWindowMousePassthrough = enabled
 }
 
+func (u *UserInterface) initWindowOpacityValue() float64 {
+	u.m.RLock()
+	defer u.m.RUnlock()
+	return u.initWindowOpacity
+}
+
+func (u *UserInterface) setInitWindowOpacity(opacity float64) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.initWindowOpacity = opacity
+}
+
 func (u *UserInterface) isWindowClosingHandled() bool {
 	u.m.RLock()
 	v := u.windowClosingHandled
@@ -705,6 +797,9 @@ func (u *UserInterface) IsRunnableOnUnfocused() bool {
 func (u *UserInterface) FPSMode() FPSModeType {
 	u.m.Lock()
 	defer u.m.Unlock()
+	if u.fpsModeToBeApplied {
+		return u.fpsModeToApply
+	}
 	return u.fpsMode
 }
 
@@ -719,19 +814,32 @@ func (u *UserInterface) SetFPSMode(mode FPSModeType) {
 		return
 	}
 
-	u.mainThread.Call(func() {
-		if u.isTerminated() {
-			return
-		}
-		if !u.fpsModeInited {
-			u.fpsMode = mode
-			return
-		}
-		if err := u.setFPSMode(mode); err != nil {
-			u.setError(err)
-			return
-		}
-	})
+	// Do not wait for the main thread, which might be blocked by swapping buffers.
+	// The mode is applied at the next frame.
+	u.m.Lock()
+	u.fpsModeToApply = mode
+	u.fpsModeToBeApplied = true
+	u.m.Unlock()
+
+	// Wake up the main thread in case it is waiting for events with FPSModeVsyncOffMinimum.
+	// PostEmptyEvent is concurrent safe.
+	if err := glfw.PostEmptyEvent(); err != nil {
+		u.setError(err)
+		return
+	}
+}
+
+// applyFPSModeIfNeeded must be called from the main thread.
+func (u *UserInterface) applyFPSModeIfNeeded() error {
+	u.m.Lock()
+	mode, ok := u.fpsModeToApply, u.fpsModeToBeApplied
+	u.fpsModeToBeApplied = false
+	u.m.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return u.setFPSMode(mode)
 }
 
 func (u *UserInterface) ScheduleFrame() {
@@ -798,7 +906,7 @@ func (u *UserInterface) SetCursorMode(mode CursorMode) {
 			return
 		}
 		if mode == CursorModeVisible {
-			if err := u.window.SetCursor(glfwSystemCursors[u.getCursorShape()]); err != nil {
+			if err := u.window.SetCursor(u.currentGLFWCursor()); err != nil {
 				u.setError(err)
 				return
 			}
@@ -816,7 +924,29 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	}
 
 	old := u.setCursorShape(shape)
-	if old == shape {
+	if !u.isRunning() {
+		return
+	}
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		if old == shape && u.customCursor == nil {
+			return
+		}
+		if err := u.destroyCustomCursor(); err != nil {
+			u.setError(err)
+			return
+		}
+		if err := u.window.SetCursor(glfwSystemCursors[shape]); err != nil {
+			u.setError(err)
+			return
+		}
+	})
+}
+
+func (u *UserInterface) SetCursorImage(img image.Image, hotspotX, hotspotY int) {
+	if u.isTerminated() {
 		return
 	}
 	if !u.isRunning() {
@@ -826,11 +956,136 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 		if u.isTerminated() {
 			return
 		}
-		if err := u.window.SetCursor(glfwSystemCursors[shape]); err != nil {
+		if err := u.destroyCustomCursor(); err != nil {
+			u.setError(err)
+			return
+		}
+		if img != nil {
+			c, err := glfw.CreateCursor(img, hotspotX, hotspotY)
+			if err != nil {
+				u.setError(err)
+				return
+			}
+			u.customCursor = c
+		}
+		if err := u.window.SetCursor(u.currentGLFWCursor()); err != nil {
+			u.setError(err)
+			return
+		}
+	})
+}
+
+func (u *UserInterface) ClipboardText() string {
+	if u.isTerminated() {
+		return ""
+	}
+	if !u.isRunning() {
+		return ""
+	}
+
+	var text string
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		t, err := glfw.GetClipboardString()
+		if err != nil {
+			// A platform error means the clipboard is temporarily unavailable, e.g. another application opens it.
+			if errors.Is(err, glfw.PlatformError) {
+				return
+			}
+			u.setError(err)
+			return
+		}
+		text = t
+	})
+	return text
+}
+
+func (u *UserInterface) SetClipboardText(text string) {
+	if u.isTerminated() {
+		return
+	}
+	if !u.isRunning() {
+		return
+	}
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		if err := glfw.SetClipboardString(text); err != nil {
+			if errors.Is(err, glfw.PlatformError) {
+				return
+			}
+			u.setError(err)
+			return
+		}
+	})
+}
+
+func (u *UserInterface) ClipboardImage() image.Image {
+	if u.isTerminated() {
+		return nil
+	}
+	if !u.isRunning() {
+		return nil
+	}
+
+	var img image.Image
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		i, err := u.clipboardImage()
+		if err != nil {
 			u.setError(err)
 			return
 		}
+		img = i
 	})
+	return img
+}
+
+func (u *UserInterface) SetClipboardImage(img image.Image) {
+	if u.isTerminated() {
+		return
+	}
+	if img == nil || img.Bounds().Empty() {
+		return
+	}
+	if !u.isRunning() {
+		return
+	}
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		if err := u.setClipboardImage(img); err != nil {
+			u.setError(err)
+			return
+		}
+	})
+}
+
+// currentGLFWCursor must be called from the main thread.
+func (u *UserInterface) currentGLFWCursor() *glfw.Cursor {
+	if u.customCursor != nil {
+		return u.customCursor
+	}
+	return glfwSystemCursors[u.getCursorShape()]
+}
+
+// destroyCustomCursor must be called from the main thread.
+func (u *UserInterface) destroyCustomCursor() error {
+	if u.customCursor == nil {
+		return nil
+	}
+	c := u.customCursor
+	u.customCursor = nil
+	if err := u.window.SetCursor(glfwSystemCursors[u.getCursorShape()]); err != nil {
+		return err
+	}
+	return c.Destroy()
 }
 
 func (u *UserInterface) DeviceScaleFactor() float64 {
@@ -1017,6 +1272,97 @@ func (u *UserInterface) registerDropCallback() error {
 	return nil
 }
 
+// registerWindowSizeCallback must be called from the main thread.
+func (u *UserInterface) registerWindowSizeCallback() error {
+	if u.sizeCallback == nil {
+		u.sizeCallback = func(_ *glfw.Window, width, height int) {
+			u.m.Lock()
+			f := u.windowResizeCallback
+			u.m.Unlock()
+			if f == nil {
+				return
+			}
+
+			// The size is zero when the window is iconified.
+			if width == 0 || height == 0 {
+				return
+			}
+
+			m, err := u.currentMonitor()
+			if err != nil {
+				u.setError(err)
+				return
+			}
+			w := int(dipFromGLFWPixel(float64(width), m))
+			h := int(dipFromGLFWPixel(float64(height), m))
+			if w == u.lastNotifiedWindowWidthInDIP && h == u.lastNotifiedWindowHeightInDIP {
+				return
+			}
+			u.lastNotifiedWindowWidthInDIP = w
+			u.lastNotifiedWindowHeightInDIP = h
+
+			// Call f without the lock, as f might call other functions that require the lock.
+			f(w, h)
+		}
+	}
+	if _, err := u.window.SetSizeCallback(u.sizeCallback); err != nil {
+		return err
+	}
+	return nil
+}
+
+// registerHitTestCallback must be called from the main thread.
+func (u *UserInterface) registerHitTestCallback() error {
+	if u.hitTestCallback == nil {
+		u.hitTestCallback = func(_ *glfw.Window, xpos int, ypos int) glfw.HitTestResult {
+			u.m.Lock()
+			f := u.windowHitTest
+			u.m.Unlock()
+			if f == nil {
+				return glfw.HitTestClient
+			}
+
+			m, err := u.currentMonitor()
+			if err != nil {
+				u.setError(err)
+				return glfw.HitTestClient
+			}
+			x := dipFromGLFWPixel(float64(xpos), m)
+			y := dipFromGLFWPixel(float64(ypos), m)
+			x, y = u.context.clientPositionToLogicalPosition(x, y, m.deviceScaleFactor())
+			if math.IsNaN(x) || math.IsNaN(y) {
+				return glfw.HitTestClient
+			}
+
+			switch f(int(math.Floor(x)), int(math.Floor(y))) {
+			case WindowHitTestResultCaption:
+				return glfw.HitTestCaption
+			case WindowHitTestResultResizeLeft:
+				return glfw.HitTestResizeLeft
+			case WindowHitTestResultResizeRight:
+				return glfw.HitTestResizeRight
+			case WindowHitTestResultResizeTop:
+				return glfw.HitTestResizeTop
+			case WindowHitTestResultResizeBottom:
+				return glfw.HitTestResizeBottom
+			case WindowHitTestResultResizeTopLeft:
+				return glfw.HitTestResizeTopLeft
+			case WindowHitTestResultResizeTopRight:
+				return glfw.HitTestResizeTopRight
+			case WindowHitTestResultResizeBottomLeft:
+				return glfw.HitTestResizeBottomLeft
+			case WindowHitTestResultResizeBottomRight:
+				return glfw.HitTestResizeBottomRight
+			}
+			return glfw.HitTestClient
+		}
+	}
+	if _, err := u.window.SetHitTestCallback(u.hitTestCallback); err != nil {
+		return err
+	}
+	return nil
+}
+
 // waitForFramebufferSizeCallback waits for GLFW's FramebufferSize callback.
 // f is a process executed after registering the callback.
 // If the callback is not invoked for a while, waitForFramebufferSizeCallback times out and return.
@@ -1074,6 +1420,10 @@ event:
 }
 
 func (u *UserInterface) initOnMainThread(options *RunOptions) error {
+	if options.DeviceScaleFactorDisabled {
+		atomic.StoreInt32(&u.deviceScaleFactorDisabled, 1)
+	}
+
 	if err := glfw.WindowHint(glfw.AutoIconify, glfw.False); err != nil {
 		return err
 	}
@@ -1111,6 +1461,9 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 	u.graphicsDriver = g
 	u.setGraphicsLibrary(lib)
 	u.graphicsDriver.SetTransparent(options.ScreenTransparent)
+	if options.LinearBlending {
+		u.enableSRGBIfAvailable()
+	}
 
 	// internal/glfw is customized and the default client API is NoAPI, not OpenGLAPI.
 	// Then, glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI) doesn't have to be called.
@@ -1171,6 +1524,12 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 		return err
 	}
 
+	if opacity := u.initWindowOpacityValue(); opacity != 1 {
+		if err := u.setWindowOpacity(opacity); err != nil {
+			return err
+		}
+	}
+
 	if options.SkipTaskbar {
 		// Ignore the error.
 		_ = u.skipTaskbar()
@@ -1207,6 +1566,12 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 	if err := u.registerDropCallback(); err != nil {
 		return err
 	}
+	if err := u.registerHitTestCallback(); err != nil {
+		return err
+	}
+	if err := u.registerWindowSizeCallback(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1347,6 +1712,9 @@ func (u *UserInterface) update() (float64, float64, error) {
 			return 0, 0, err
 		}
 	}
+	if err := u.applyFPSModeIfNeeded(); err != nil {
+		return 0, 0, err
+	}
 
 	if u.fpsMode != FPSModeVsyncOffMinimum {
 		// TODO: Updating the input can be skipped when clock.Update returns 0 (#1367).
@@ -1507,12 +1875,17 @@ func (u *UserInterface) updateIconIfNeeded() error {
 		newImgs = make([]image.Image, len(imgs))
 	}
 	for i, img := range imgs {
-		// TODO: If img is not *ebiten.Image, this converting is not necessary.
-		// However, this package cannot refer *ebiten.Image due to the package
-		// dependencies.
-
 		b := img.Bounds()
 		rgba := image.NewRGBA(b)
+
+		// This package cannot refer *ebiten.Image due to the package dependencies.
+		// Read the pixels at once if possible, as At for *ebiten.Image is slow.
+		if r, ok := img.(interface{ ReadPixels(pixels []byte) }); ok {
+			r.ReadPixels(rgba.Pix)
+			newImgs[i] = rgba
+			continue
+		}
+
 		for j := b.Min.Y; j < b.Max.Y; j++ {
 			for i := b.Min.X; i < b.Max.X; i++ {
 				rgba.Set(i, j, img.At(i, j))
@@ -1573,6 +1946,12 @@ func (u *UserInterface) updateWindowSizeLimits() error {
 		return err
 	}
 
+	// The aspect ratio doesn't depend on the device scale factor.
+	numer, denom := u.getWindowAspectRatio()
+	if err := u.window.SetAspectRatio(numer, denom); err != nil {
+		return err
+	}
+
 	// The window size limit affects the resizing mode, especially on macOS (#2260).
 	if err := u.setWindowResizingModeForOS(u.windowResizingMode); err != nil {
 		return err
@@ -1586,7 +1965,13 @@ func (u *UserInterface) updateWindowSizeLimits() error {
 //
 // disableWindowSizeLimits must be called from the main thread.
 func (u *UserInterface) disableWindowSizeLimits() error {
-	return u.window.SetSizeLimits(glfw.DontCare, glfw.DontCare, glfw.DontCare, glfw.DontCare)
+	if err := u.window.SetSizeLimits(glfw.DontCare, glfw.DontCare, glfw.DontCare, glfw.DontCare); err != nil {
+		return err
+	}
+	if err := u.window.SetAspectRatio(glfw.DontCare, glfw.DontCare); err != nil {
+		return err
+	}
+	return nil
 }
 
 // adjustWindowSizeBasedOnSizeLimitsInDIP adjust the size based on the window size limits.
@@ -1874,7 +2259,7 @@ func (u *UserInterface) currentMonitor() (*Monitor, error) {
 func (u *UserInterface) readInputState(inputState *InputState) {
 	u.m.Lock()
 	defer u.m.Unlock()
-	u.inputState.copyAndReset(inputState)
+	u.inputState.copyAndReset(inputState, u.IsInputBufferingEnabled())
 }
 
 func (u *UserInterface) Window() Window {
@@ -2107,6 +2492,37 @@ func (u *UserInterface) isWindowMaximized() (bool, error) {
 	return a == glfw.True && !n, nil
 }
 
+// windowPositionInDIP returns the window position relative to the current monitor, and the current monitor.
+// In fullscreen mode, windowPositionInDIP returns the original window position.
+//
+// windowPositionInDIP must be called from the main thread.
+func (u *UserInterface) windowPositionInDIP() (int, int, *Monitor, error) {
+	f, err := u.isFullscreen()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	var wx, wy int
+	if f {
+		wx, wy = u.origWindowPos()
+	} else {
+		x, y, err := u.window.GetPos()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		wx, wy = x, y
+	}
+	m, err := u.currentMonitor()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	wx -= m.boundsInGLFWPixels.Min.X
+	wy -= m.boundsInGLFWPixels.Min.Y
+	xf := dipFromGLFWPixel(float64(wx), m)
+	yf := dipFromGLFWPixel(float64(wy), m)
+	return int(xf), int(yf), m, nil
+}
+
 func (u *UserInterface) origWindowPos() (int, int) {
 	return u.origWindowPosX, u.origWindowPosY
 }
@@ -2132,10 +2548,18 @@ func (u *UserInterface) setWindowMousePassthrough(enabled bool) error {
 	return nil
 }
 
-func IsScreenTransparentAvailable() bool {
-	return true
+// setWindowOpacity must be called from the main thread.
+func (u *UserInterface) setWindowOpacity(opacity float64) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	if err := u.window.SetOpacity(float32(opacity)); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (u *UserInterface) RunOnMainThread(f func()) {
-	u.mainThread.Call(f)
+func IsScreenTransparentAvailable() bool {
+	return true
 }
@@ -15,8 +15,12 @@
 package ui
 
 import (
+	"bytes"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"image"
+	"image/png"
 	"math"
 	"sync"
 	"syscall/js"
@@ -81,6 +85,10 @@ func driverCursorShapeToCSSCursor(cursor CursorShape) string {
 		return "move"
 	case CursorShapeNotAllowed:
 		return "not-allowed"
+	case CursorShapeGrab:
+		return "grab"
+	case CursorShapeGrabbing:
+		return "grabbing"
 	}
 	return "auto"
 }
@@ -95,6 +103,7 @@ type userInterfaceImpl struct {
 	cursorPrevMode      CursorMode
 	captureCursorLater  bool
 	cursorShape         CursorShape
+	cursorImageCSS      string
 	onceUpdateCalled    bool
 	lastCaptureExitTime time.Time
 
@@ -235,7 +244,7 @@ func (u *UserInterface) setCursorMode(mode CursorMode) {
 	u.cursorMode = mode
 	switch mode {
 	case CursorModeVisible:
-		canvas.Get("style").Set("cursor", driverCursorShapeToCSSCursor(u.cursorShape))
+		canvas.Get("style").Set("cursor", u.cssCursor())
 	case CursorModeHidden:
 		canvas.Get("style").Set("cursor", stringNone)
 	case CursorModeCaptured:
@@ -261,16 +270,44 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	if !canvas.Truthy() {
 		return
 	}
-	if u.cursorShape == shape {
+	if u.cursorShape == shape && u.cursorImageCSS == "" {
 		return
 	}
 
 	u.cursorShape = shape
+	u.cursorImageCSS = ""
 	if u.cursorMode == CursorModeVisible {
-		canvas.Get("style").Set("cursor", driverCursorShapeToCSSCursor(u.cursorShape))
+		canvas.Get("style").Set("cursor", u.cssCursor())
 	}
 }
 
+func (u *UserInterface) SetCursorImage(img image.Image, hotspotX, hotspotY int) {
+	if !canvas.Truthy() {
+		return
+	}
+
+	u.cursorImageCSS = ""
+	if img != nil {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			u.setError(err)
+			return
+		}
+		u.cursorImageCSS = fmt.Sprintf("url(data:image/png;base64,%s) %d %d", base64.StdEncoding.EncodeToString(buf.Bytes()), hotspotX, hotspotY)
+	}
+	if u.cursorMode == CursorModeVisible {
+		canvas.Get("style").Set("cursor", u.cssCursor())
+	}
+}
+
+func (u *UserInterface) cssCursor() string {
+	if u.cursorImageCSS != "" {
+		// A fallback cursor is required after a URL.
+		return u.cursorImageCSS + ", " + driverCursorShapeToCSSCursor(u.cursorShape)
+	}
+	return driverCursorShapeToCSSCursor(u.cursorShape)
+}
+
 func (u *UserInterface) DeviceScaleFactor() float64 {
 	if u.deviceScaleFactor != 0 {
 		return u.deviceScaleFactor
@@ -779,7 +816,7 @@ func (u *UserInterface) updateScreenSize() {
 }
 
 func (u *UserInterface) readInputState(inputState *InputState) {
-	u.inputState.copyAndReset(inputState)
+	u.inputState.copyAndReset(inputState, u.IsInputBufferingEnabled())
 	u.keyboardLayoutMap = js.Value{}
 }
 
@@ -802,6 +839,15 @@ func (m *Monitor) Name() string {
 	return ""
 }
 
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return Get().DeviceScaleFactor()
+}
+
+func (m *Monitor) RefreshRate() float64 {
+	// TODO: Implement this.
+	return 0
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -19,6 +19,7 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"image"
 	"runtime"
 
 	"github.com/jezek/xgb"
@@ -134,6 +135,12 @@ func (u *UserInterface) adjustWindowPosition(x, y int, monitor *Monitor) (int, i
 	return x, y
 }
 
+// isScrollPrecise reports whether the current scroll event comes from a precise scrolling device like a trackpad.
+func isScrollPrecise(xoff, yoff float64) bool {
+	// On X11, a scroll is always notified as a discrete button event.
+	return false
+}
+
 func initialMonitorByOS() (*Monitor, error) {
 	xconn, err := xgb.NewConn()
 	if err != nil {
@@ -163,6 +170,16 @@ func (u *UserInterface) nativeWindow() (uintptr, error) {
 	return 0, nil
 }
 
+func (u *UserInterface) clipboardImage() (image.Image, error) {
+	// TODO: Implement this.
+	return nil, nil
+}
+
+func (u *UserInterface) setClipboardImage(img image.Image) error {
+	// TODO: Implement this.
+	return nil
+}
+
 func (u *UserInterface) isNativeFullscreen() (bool, error) {
 	return false, nil
 }
@@ -24,6 +24,7 @@ import (
 	"runtime/debug"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
@@ -101,6 +102,9 @@ type userInterfaceImpl struct {
 	inputState InputState
 	touches    []TouchForInput
 
+	// touchesTime is the time when touches are updated.
+	touchesTime time.Time
+
 	fpsMode         int32
 	renderRequester RenderRequester
 
@@ -221,6 +225,10 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	// Do nothing
 }
 
+func (u *UserInterface) SetCursorImage(img image.Image, hotspotX, hotspotY int) {
+	// Do nothing
+}
+
 func (u *UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -268,7 +276,7 @@ func (u *UserInterface) DeviceScaleFactor() float64 {
 func (u *UserInterface) readInputState(inputState *InputState) {
 	u.m.Lock()
 	defer u.m.Unlock()
-	u.inputState.copyAndReset(inputState)
+	u.inputState.copyAndReset(inputState, u.IsInputBufferingEnabled())
 }
 
 func (u *UserInterface) Window() Window {
@@ -288,6 +296,15 @@ func (m *Monitor) Name() string {
 	return ""
 }
 
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return Get().DeviceScaleFactor()
+}
+
+func (m *Monitor) RefreshRate() float64 {
+	// TODO: Implement this.
+	return 0
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -116,7 +116,7 @@ func (*UserInterface) ScreenSizeInFullscreen() (int, int) {
 func (u *UserInterface) readInputState(inputState *InputState) {
 	u.m.Lock()
 	defer u.m.Unlock()
-	u.inputState.copyAndReset(inputState)
+	u.inputState.copyAndReset(inputState, u.IsInputBufferingEnabled())
 }
 
 func (*UserInterface) CursorMode() CursorMode {
@@ -133,6 +133,9 @@ func (*UserInterface) CursorShape() CursorShape {
 func (*UserInterface) SetCursorShape(shape CursorShape) {
 }
 
+func (*UserInterface) SetCursorImage(img image.Image, hotspotX, hotspotY int) {
+}
+
 func (*UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -178,6 +181,15 @@ func (m *Monitor) Name() string {
 	return ""
 }
 
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return Get().DeviceScaleFactor()
+}
+
+func (m *Monitor) RefreshRate() float64 {
+	// TODO: Implement this.
+	return 0
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -119,6 +119,9 @@ func (*UserInterface) CursorShape() CursorShape {
 func (*UserInterface) SetCursorShape(shape CursorShape) {
 }
 
+func (*UserInterface) SetCursorImage(img image.Image, hotspotX, hotspotY int) {
+}
+
 func (*UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -164,6 +167,15 @@ func (m *Monitor) Name() string {
 	return ""
 }
 
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return Get().DeviceScaleFactor()
+}
+
+func (m *Monitor) RefreshRate() float64 {
+	// TODO: Implement this.
+	return 0
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -17,6 +17,7 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"math"
 	"runtime"
 	"syscall"
 
@@ -132,6 +133,13 @@ func (u *UserInterface) adjustWindowPosition(x, y int, monitor *Monitor) (int, i
 	return x, y
 }
 
+// isScrollPrecise reports whether the current scroll event comes from a precise scrolling device like a trackpad.
+func isScrollPrecise(xoff, yoff float64) bool {
+	// A wheel of a regular mouse notifies a multiple of WHEEL_DELTA, which is an integer offset.
+	// A precision touchpad notifies smaller deltas.
+	return xoff != math.Trunc(xoff) || yoff != math.Trunc(yoff)
+}
+
 func initialMonitorByOS() (*Monitor, error) {
 	if microsoftgdk.IsXbox() {
 		return theMonitors.primaryMonitor(), nil
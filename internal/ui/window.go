@@ -26,10 +26,14 @@ type Window interface {
 	SetMonitor(*Monitor)
 	Position() (int, int)
 	SetPosition(x, y int)
+	PositionInVirtualScreen() (int, int)
+	SetPositionInVirtualScreen(x, y int)
 	Size() (int, int)
 	SetSize(width, height int)
 	SizeLimits() (minw, minh, maxw, maxh int)
 	SetSizeLimits(minw, minh, maxw, maxh int)
+	AspectRatio() (numer, denom int)
+	SetAspectRatio(numer, denom int)
 	IsFloating() bool
 	SetFloating(floating bool)
 	Maximize()
@@ -43,6 +47,10 @@ type Window interface {
 	IsClosingHandled() bool
 	SetMousePassthrough(enabled bool)
 	IsMousePassthrough() bool
+	SetHitTest(f func(x, y int) WindowHitTestResult)
+	SetResizeCallback(f func(width, height int))
+	Opacity() float64
+	SetOpacity(opacity float64)
 }
 
 type nullWindow struct{}
@@ -71,6 +79,13 @@ func (*nullWindow) Position() (int, int) {
 func (*nullWindow) SetPosition(x, y int) {
 }
 
+func (*nullWindow) PositionInVirtualScreen() (int, int) {
+	return 0, 0
+}
+
+func (*nullWindow) SetPositionInVirtualScreen(x, y int) {
+}
+
 func (*nullWindow) Size() (int, int) {
 	return 0, 0
 }
@@ -85,6 +100,13 @@ func (*nullWindow) SizeLimits() (minw, minh, maxw, maxh int) {
 func (*nullWindow) SetSizeLimits(minw, minh, maxw, maxh int) {
 }
 
+func (*nullWindow) AspectRatio() (numer, denom int) {
+	return -1, -1
+}
+
+func (*nullWindow) SetAspectRatio(numer, denom int) {
+}
+
 func (*nullWindow) IsFloating() bool {
 	return false
 }
@@ -128,3 +150,16 @@ func (*nullWindow) SetMousePassthrough(enabled bool) {
 func (*nullWindow) IsMousePassthrough() bool {
 	return false
 }
+
+func (*nullWindow) SetHitTest(f func(x, y int) WindowHitTestResult) {
+}
+
+func (*nullWindow) SetResizeCallback(f func(width, height int)) {
+}
+
+func (*nullWindow) Opacity() float64 {
+	return 1
+}
+
+func (*nullWindow) SetOpacity(opacity float64) {
+}
@@ -298,33 +298,12 @@ func (w *glfwWindow) Position() (int, int) {
 		if w.ui.isTerminated() {
 			return
 		}
-		f, err := w.ui.isFullscreen()
+		wx, wy, _, err := w.ui.windowPositionInDIP()
 		if err != nil {
 			w.ui.setError(err)
 			return
 		}
-
-		var wx, wy int
-		if f {
-			wx, wy = w.ui.origWindowPos()
-		} else {
-			x, y, err := w.ui.window.GetPos()
-			if err != nil {
-				w.ui.setError(err)
-				return
-			}
-			wx, wy = x, y
-		}
-		m, err := w.ui.currentMonitor()
-		if err != nil {
-			w.ui.setError(err)
-			return
-		}
-		wx -= m.boundsInGLFWPixels.Min.X
-		wy -= m.boundsInGLFWPixels.Min.Y
-		xf := dipFromGLFWPixel(float64(wx), m)
-		yf := dipFromGLFWPixel(float64(wy), m)
-		x, y = int(xf), int(yf)
+		x, y = wx, wy
 	})
 	return x, y
 }
@@ -353,6 +332,65 @@ func (w *glfwWindow) SetPosition(x, y int) {
 	})
 }
 
+func (w *glfwWindow) PositionInVirtualScreen() (int, int) {
+	if w.ui.isTerminated() {
+		return 0, 0
+	}
+	if !w.ui.isRunning() {
+		panic("ui: WindowPositionInVirtualScreen can't be called before the main loop starts")
+	}
+	var x, y int
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		wx, wy, m, err := w.ui.windowPositionInDIP()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		b := m.Bounds()
+		x, y = b.Min.X+wx, b.Min.Y+wy
+	})
+	return x, y
+}
+
+func (w *glfwWindow) SetPositionInVirtualScreen(x, y int) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.isRunning() {
+		m := theMonitors.monitorFromPositionInDIP(x, y)
+		if m == nil {
+			m = theMonitors.primaryMonitor()
+		}
+		b := m.Bounds()
+		w.ui.setInitMonitor(m)
+		w.ui.setInitWindowPositionInDIP(x-b.Min.X, y-b.Min.Y)
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		m := theMonitors.monitorFromPositionInDIP(x, y)
+		if m == nil {
+			// The position is out of any monitors. Use the current monitor and let the position be adjusted.
+			var err error
+			m, err = w.ui.currentMonitor()
+			if err != nil {
+				w.ui.setError(err)
+				return
+			}
+		}
+		b := m.Bounds()
+		if err := w.ui.setWindowPositionInDIP(x-b.Min.X, y-b.Min.Y, m); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
 func (w *glfwWindow) Size() (int, int) {
 	if w.ui.isTerminated() {
 		return 0, 0
@@ -427,6 +465,32 @@ func (w *glfwWindow) SetSizeLimits(minw, minh, maxw, maxh int) {
 	})
 }
 
+func (w *glfwWindow) AspectRatio() (numer, denom int) {
+	return w.ui.getWindowAspectRatio()
+}
+
+func (w *glfwWindow) SetAspectRatio(numer, denom int) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.setWindowAspectRatio(numer, denom) {
+		return
+	}
+	if !w.ui.isRunning() {
+		return
+	}
+
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		if err := w.ui.updateWindowSizeLimits(); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
 func (w *glfwWindow) SetIcon(iconImages []image.Image) {
 	if w.ui.isTerminated() {
 		return
@@ -484,6 +548,59 @@ func (w *glfwWindow) SetMousePassthrough(enabled bool) {
 	})
 }
 
+func (w *glfwWindow) SetHitTest(f func(x, y int) WindowHitTestResult) {
+	w.ui.m.Lock()
+	defer w.ui.m.Unlock()
+	w.ui.windowHitTest = f
+}
+
+func (w *glfwWindow) SetResizeCallback(f func(width, height int)) {
+	w.ui.m.Lock()
+	defer w.ui.m.Unlock()
+	w.ui.windowResizeCallback = f
+}
+
+func (w *glfwWindow) Opacity() float64 {
+	if w.ui.isTerminated() {
+		return 1
+	}
+	if !w.ui.isRunning() {
+		return w.ui.initWindowOpacityValue()
+	}
+	v := 1.0
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		o, err := w.ui.window.GetOpacity()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		v = float64(o)
+	})
+	return v
+}
+
+func (w *glfwWindow) SetOpacity(opacity float64) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.isRunning() {
+		w.ui.setInitWindowOpacity(opacity)
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		if err := w.ui.setWindowOpacity(opacity); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
 func (w *glfwWindow) IsMousePassthrough() bool {
 	if w.ui.isTerminated() {
 		return false
@@ -15,6 +15,8 @@
 package ebiten
 
 import (
+	"image"
+
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -27,6 +29,19 @@ func (m *MonitorType) Name() string {
 	return (*ui.Monitor)(m).Name()
 }
 
+// Bounds returns the monitor's bounds in device-independent pixels.
+// The origin is the top-left corner of the primary monitor.
+//
+// On browsers and mobiles, Bounds might return an empty rectangle.
+func (m *MonitorType) Bounds() image.Rectangle {
+	return (*ui.Monitor)(m).Bounds()
+}
+
+// DeviceScaleFactor returns the monitor's device scale factor.
+func (m *MonitorType) DeviceScaleFactor() float64 {
+	return (*ui.Monitor)(m).DeviceScaleFactor()
+}
+
 // Monitor returns the current monitor.
 func Monitor() *MonitorType {
 	m := ui.Get().Monitor()
@@ -36,7 +51,26 @@ func Monitor() *MonitorType {
 	return (*MonitorType)(m)
 }
 
+// MonitorRefreshRate returns the refresh rate of the current monitor in Hz.
+//
+// With FPSModeVsyncOn, this is the actual target frame rate.
+// With a variable refresh rate display, this is the peak refresh rate.
+//
+// MonitorRefreshRate returns 0 if the refresh rate is unknown, e.g. on browsers and mobiles, or before the game starts.
+//
+// MonitorRefreshRate is concurrent-safe.
+func MonitorRefreshRate() float64 {
+	m := ui.Get().Monitor()
+	if m == nil {
+		return 0
+	}
+	return m.RefreshRate()
+}
+
 // SetMonitor sets the monitor that the window should be on. This can be called before or after Run.
+//
+// If the window is in fullscreen mode, the window becomes fullscreen on the new monitor.
+// Use AppendMonitors to enumerate the monitors.
 func SetMonitor(monitor *MonitorType) {
 	ui.Get().Window().SetMonitor((*ui.Monitor)(monitor))
 }
@@ -0,0 +1,144 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+)
+
+// NineSliceInsets represents the sizes of the borders of a nine-slice image in pixels.
+type NineSliceInsets struct {
+	Left   int
+	Top    int
+	Right  int
+	Bottom int
+}
+
+// DrawNineSliceOptions represents options for DrawNineSlice.
+type DrawNineSliceOptions struct {
+	// GeoM is a geometry matrix applied after the source image is laid out in the destination rectangle.
+	// The default (zero) value is identity.
+	GeoM GeoM
+
+	// ColorScale is a scale of color in the premultiplied-alpha format, which works in the same way as DrawImageOptions.ColorScale.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is FilterNearest.
+	Filter Filter
+}
+
+// DrawNineSlice draws the source image src onto dst so that src fits dstRect, without distorting the corners of src.
+//
+// src is sliced into nine regions by insets.
+// The four corners are drawn without scaling, the four edges are stretched along one axis,
+// and the center is stretched along both axes.
+// If dstRect is smaller than the corners, the corners are shrunk proportionally.
+//
+// insets are in pixels of src, and dstRect is in the coordinate of dst before options.GeoM is applied.
+// All the regions are drawn with one DrawTriangles call.
+//
+// options can be nil.
+//
+// If an inset is negative, or the sum of the insets along an axis exceeds the size of src, DrawNineSlice panics.
+func DrawNineSlice(dst, src *Image, insets NineSliceInsets, dstRect image.Rectangle, options *DrawNineSliceOptions) {
+	sb := src.Bounds()
+	if insets.Left < 0 || insets.Top < 0 || insets.Right < 0 || insets.Bottom < 0 {
+		panic(fmt.Sprintf("ebiten: insets at DrawNineSlice must not be negative but %+v", insets))
+	}
+	if insets.Left+insets.Right > sb.Dx() || insets.Top+insets.Bottom > sb.Dy() {
+		panic(fmt.Sprintf("ebiten: insets %+v at DrawNineSlice exceed the source size %v", insets, sb.Size()))
+	}
+	if dstRect.Empty() {
+		return
+	}
+
+	if options == nil {
+		options = &DrawNineSliceOptions{}
+	}
+
+	sxs := [4]float32{
+		float32(sb.Min.X),
+		float32(sb.Min.X + insets.Left),
+		float32(sb.Max.X - insets.Right),
+		float32(sb.Max.X),
+	}
+	sys := [4]float32{
+		float32(sb.Min.Y),
+		float32(sb.Min.Y + insets.Top),
+		float32(sb.Max.Y - insets.Bottom),
+		float32(sb.Max.Y),
+	}
+	dxs := nineSliceDestinationPositions(dstRect.Min.X, dstRect.Max.X, insets.Left, insets.Right)
+	dys := nineSliceDestinationPositions(dstRect.Min.Y, dstRect.Max.Y, insets.Top, insets.Bottom)
+
+	cr, cg, cb, ca := options.ColorScale.elements()
+
+	// The vertices are a 4x4 grid, and the regions are the 3x3 quads between them.
+	var vs [16]Vertex
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			x, y := options.GeoM.Apply(dxs[i], dys[j])
+			vs[4*j+i] = Vertex{
+				DstX:   float32(x),
+				DstY:   float32(y),
+				SrcX:   sxs[i],
+				SrcY:   sys[j],
+				ColorR: cr,
+				ColorG: cg,
+				ColorB: cb,
+				ColorA: ca,
+			}
+		}
+	}
+	is := make([]uint32, 0, 6*9)
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			idx := uint32(4*j + i)
+			is = append(is, idx, idx+1, idx+4, idx+1, idx+4, idx+5)
+		}
+	}
+
+	op := &DrawTrianglesOptions{}
+	op.ColorScaleMode = ColorScaleModePremultipliedAlpha
+	op.Blend = options.Blend
+	op.Filter = options.Filter
+	dst.DrawTriangles32(vs[:], is, src, op)
+}
+
+// nineSliceDestinationPositions returns the positions of the slice boundaries along an axis.
+func nineSliceDestinationPositions(start, end int, inset0, inset1 int) [4]float64 {
+	size := float64(end - start)
+	i0 := float64(inset0)
+	i1 := float64(inset1)
+	if i0+i1 > size {
+		// Shrink the corners to fit the destination.
+		s := size / (i0 + i1)
+		i0 *= s
+		i1 *= s
+	}
+	return [4]float64{
+		float64(start),
+		float64(start) + i0,
+		float64(end) - i1,
+		float64(end),
+	}
+}
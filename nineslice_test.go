@@ -0,0 +1,93 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDrawNineSlice(t *testing.T) {
+	const (
+		sw, sh = 6, 6
+		inset  = 2
+	)
+
+	region := func(v, size, inset0, inset1 int) int {
+		if v < inset0 {
+			return 0
+		}
+		if v >= size-inset1 {
+			return 2
+		}
+		return 1
+	}
+	regionColor := func(rx, ry int) color.RGBA {
+		return color.RGBA{R: byte(0x40 * (rx + 1)), G: byte(0x40 * (ry + 1)), A: 0xff}
+	}
+
+	src := ebiten.NewImage(sw, sh)
+	pix := make([]byte, 4*sw*sh)
+	for j := 0; j < sh; j++ {
+		for i := 0; i < sw; i++ {
+			clr := regionColor(region(i, sw, inset, inset), region(j, sh, inset, inset))
+			idx := 4 * (i + j*sw)
+			pix[idx] = clr.R
+			pix[idx+1] = clr.G
+			pix[idx+2] = clr.B
+			pix[idx+3] = clr.A
+		}
+	}
+	src.WritePixels(pix)
+
+	const dw, dh = 16, 12
+	dst := ebiten.NewImage(dw, dh)
+	dstRect := image.Rect(1, 1, 15, 11)
+	ebiten.DrawNineSlice(dst, src, ebiten.NineSliceInsets{Left: inset, Top: inset, Right: inset, Bottom: inset}, dstRect, nil)
+
+	for j := 0; j < dh; j++ {
+		for i := 0; i < dw; i++ {
+			got := dst.At(i, j)
+			var want color.RGBA
+			if image.Pt(i, j).In(dstRect) {
+				want = regionColor(region(i-dstRect.Min.X, dstRect.Dx(), inset, inset), region(j-dstRect.Min.Y, dstRect.Dy(), inset, inset))
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawNineSliceSmallDestination(t *testing.T) {
+	src := ebiten.NewImage(4, 4)
+	src.SubImage(image.Rect(0, 0, 2, 4)).(*ebiten.Image).Fill(color.RGBA{R: 0xff, A: 0xff})
+	src.SubImage(image.Rect(2, 0, 4, 4)).(*ebiten.Image).Fill(color.RGBA{B: 0xff, A: 0xff})
+
+	// The destination is narrower than the sum of the left and right insets, so the corners are shrunk.
+	dst := ebiten.NewImage(2, 4)
+	ebiten.DrawNineSlice(dst, src, ebiten.NineSliceInsets{Left: 2, Right: 2}, image.Rect(0, 0, 2, 4), nil)
+	for j := 0; j < 4; j++ {
+		if got, want := dst.At(0, j), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+			t.Errorf("dst.At(0, %d): got: %v, want: %v", j, got, want)
+		}
+		if got, want := dst.At(1, j), (color.RGBA{B: 0xff, A: 0xff}); got != want {
+			t.Errorf("dst.At(1, %d): got: %v, want: %v", j, got, want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// palettedImageMaxColors is the maximum number of colors in a palette.
+const palettedImageMaxColors = 256
+
+// The index image has an index in the red channel.
+// The palette image is a 256x1 image and has premultiplied alpha colors.
+// The palette's position is calculated relatively to the index image's origin,
+// as a source position for imageSrc1UnsafeAt is translated from the 0th image's region to the 1st image's region.
+const palettedImageShaderSrc = `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	index := floor(imageSrc0UnsafeAt(srcPos).r*255 + 0.5)
+	return imageSrc1UnsafeAt(imageSrc0Origin() + vec2(index+0.5, 0.5)) * color
+}
+`
+
+var (
+	palettedImageShader     *Shader
+	palettedImageShaderOnce sync.Once
+)
+
+// PalettedImage represents an image of 8-bit color indices with a palette.
+//
+// The indices and the palette are stored in separate textures.
+// Changing the palette doesn't require updating the indices, so swapping palettes is cheap.
+//
+// PalettedImage must not be copied by value.
+type PalettedImage struct {
+	indices *Image
+	palette *Image
+
+	// pixels is a temporary buffer to write pixels.
+	pixels []byte
+}
+
+// NewPalettedImage returns an empty paletted image.
+// All the indices are 0, and all the colors in the palette are transparent.
+//
+// If width or height is less than 1 or more than device-dependent maximum size, NewPalettedImage panics.
+func NewPalettedImage(width, height int) *PalettedImage {
+	return &PalettedImage{
+		indices: NewImage(width, height),
+		palette: NewImage(palettedImageMaxColors, 1),
+	}
+}
+
+// NewPalettedImageFromImage creates a new paletted image with the given image.Paletted's indices and palette.
+//
+// The returned image's upper-left position is always (0, 0). The source's bounds are not respected.
+func NewPalettedImageFromImage(source *image.Paletted) *PalettedImage {
+	b := source.Bounds()
+	p := NewPalettedImage(b.Dx(), b.Dy())
+	indices := make([]byte, b.Dx()*b.Dy())
+	for j := 0; j < b.Dy(); j++ {
+		copy(indices[j*b.Dx():(j+1)*b.Dx()], source.Pix[source.PixOffset(b.Min.X, b.Min.Y+j):])
+	}
+	p.WriteIndices(indices)
+	p.SetPalette(source.Palette)
+	return p
+}
+
+// Bounds returns the bounds of the image.
+func (p *PalettedImage) Bounds() image.Rectangle {
+	return p.indices.Bounds()
+}
+
+// WriteIndices replaces the color indices of the image.
+//
+// The given indices represent the indices of the pixels in row-major order.
+// len(indices) must be width * height of the image. WriteIndices panics otherwise.
+func (p *PalettedImage) WriteIndices(indices []byte) {
+	b := p.indices.Bounds()
+	if len(indices) != b.Dx()*b.Dy() {
+		panic(fmt.Sprintf("ebiten: len(indices) must be %d but %d at WriteIndices", b.Dx()*b.Dy(), len(indices)))
+	}
+
+	if cap(p.pixels) < 4*len(indices) {
+		p.pixels = make([]byte, 4*len(indices))
+	}
+	p.pixels = p.pixels[:4*len(indices)]
+	for i, idx := range indices {
+		p.pixels[4*i] = idx
+		p.pixels[4*i+1] = 0
+		p.pixels[4*i+2] = 0
+		p.pixels[4*i+3] = 0xff
+	}
+	p.indices.WritePixels(p.pixels)
+}
+
+// SetPalette replaces the palette of the image.
+//
+// The palette can have 256 colors at maximum. SetPalette panics otherwise.
+// Indices without corresponding colors are rendered as transparent.
+//
+// SetPalette doesn't update the indices, and is much cheaper than updating the pixels of an RGBA image.
+func (p *PalettedImage) SetPalette(palette color.Palette) {
+	if len(palette) > palettedImageMaxColors {
+		panic(fmt.Sprintf("ebiten: the number of colors in a palette must be less than or equal to %d but %d at SetPalette", palettedImageMaxColors, len(palette)))
+	}
+
+	var pix [4 * palettedImageMaxColors]byte
+	for i, c := range palette {
+		r, g, b, a := c.RGBA()
+		pix[4*i] = byte(r >> 8)
+		pix[4*i+1] = byte(g >> 8)
+		pix[4*i+2] = byte(b >> 8)
+		pix[4*i+3] = byte(a >> 8)
+	}
+	p.palette.WritePixels(pix[:])
+}
+
+// Dispose disposes the image data.
+// After disposing, most of the functions do nothing, and DrawPalettedImage with this image panics.
+func (p *PalettedImage) Dispose() {
+	p.indices.Dispose()
+	p.palette.Dispose()
+}
+
+func (p *PalettedImage) isDisposed() bool {
+	return p.indices.isDisposed()
+}
+
+// DrawPalettedImageOptions represents options for DrawPalettedImage.
+type DrawPalettedImageOptions struct {
+	// GeoM is a geometry matrix to draw.
+	// The default (zero) value is identity, which draws the image at (0, 0).
+	GeoM GeoM
+
+	// ColorScale is a scale of colors.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+}
+
+// DrawPalettedImage draws the given paletted image on the image i.
+//
+// Each pixel is rendered with the color in the palette at the pixel's index.
+// The image is always rendered with the nearest filter.
+//
+// When the image i is disposed, DrawPalettedImage does nothing.
+// When the given image img is disposed, DrawPalettedImage panics.
+func (i *Image) DrawPalettedImage(img *PalettedImage, options *DrawPalettedImageOptions) {
+	i.copyCheck()
+
+	if img.isDisposed() {
+		panic("ebiten: the given image to DrawPalettedImage must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	if options == nil {
+		options = &DrawPalettedImageOptions{}
+	}
+
+	palettedImageShaderOnce.Do(func() {
+		palettedImageShader = mustCompileInternalShader(palettedImageShaderSrc)
+	})
+	shader := palettedImageShader
+
+	geoM := options.GeoM
+	if offsetX, offsetY := i.adjustPosition(0, 0); offsetX != 0 || offsetY != 0 {
+		geoM.Translate(float64(offsetX), float64(offsetY))
+	}
+	a, b, c, d, tx, ty := geoM.elements32()
+
+	bounds := img.indices.Bounds()
+	sx0, sy0 := img.indices.adjustPosition(bounds.Min.X, bounds.Min.Y)
+	sx1, sy1 := img.indices.adjustPosition(bounds.Max.X, bounds.Max.Y)
+	cr, cg, cb, ca := options.ColorScale.elements()
+	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
+	graphics.QuadVertices(vs, float32(sx0), float32(sy0), float32(sx1), float32(sy1), a, b, c, d, tx, ty, cr, cg, cb, ca)
+	is := graphics.QuadIndices()
+
+	srcs := [graphics.ShaderImageCount]*ui.Image{img.indices.image, img.palette.image}
+	srcRegions := [graphics.ShaderImageCount]image.Rectangle{img.indices.adjustedBounds(), img.palette.adjustedBounds()}
+
+	i.tmpUniforms = i.tmpUniforms[:0]
+	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, nil)
+
+	i.image.DrawTriangles(srcs, vs, is, options.Blend.internalBlend(), i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, true, false)
+}
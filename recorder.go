@@ -0,0 +1,195 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// RecordingFrame represents a frame delivered by a recording started by StartRecording.
+type RecordingFrame struct {
+	// Pixels is the pixels of the frame in the premultiplied-alpha RGBA format.
+	// The length is 4*Width*Height.
+	//
+	// Pixels is valid only during the callback call, and is reused for the next frames.
+	// Copy Pixels if the pixels are used after the callback returns.
+	Pixels []byte
+
+	// Width and Height are the size of the frame in pixels, which is the screen size given to Game.Draw.
+	// The size might be changed in the middle of a recording, e.g. when the window is resized and Layout returns a different size.
+	Width  int
+	Height int
+
+	// Index is the index of the frame, starting from 0.
+	Index int
+
+	// Timestamp is the time of the frame from the start of the recording.
+	// Timestamp is always exactly Index/FPS seconds.
+	Timestamp time.Duration
+}
+
+// RecordingOptions represents options for StartRecording.
+type RecordingOptions struct {
+	// FPS is the number of frames per second of the recording.
+	//
+	// The default (zero) value is 60.
+	FPS int
+}
+
+// StartRecording starts recording the screen given to Game.Draw at a fixed rate.
+//
+// callback is called with the frames in the order of the timestamps.
+// The frames are delivered at a constant rate based on the actual time:
+// if the game draws slower than the rate, the same pixels are delivered multiple times with incremented timestamps,
+// and if the game draws faster than the rate, some rendering results are not delivered.
+// Then, the frames can be fed to a video encoder as they are.
+//
+// callback is called on the same goroutine as Game.Draw, right after Game.Draw.
+// As the pixels are read from GPU every time callback is called, a recording might slow down a game.
+// If callback returns an error, the recording stops and RunGame returns the error.
+//
+// The pixels are what Game.Draw renders, and don't include the effects of FinalScreenDrawer or the letterbox bars.
+//
+// StartRecording returns an error if callback is nil or a recording is already running.
+//
+// StartRecording is concurrent-safe.
+func StartRecording(callback func(frame *RecordingFrame) error, options *RecordingOptions) error {
+	fps := 60
+	if options != nil && options.FPS > 0 {
+		fps = options.FPS
+	}
+	return theRecorder.start(callback, fps)
+}
+
+// StopRecording stops the current recording.
+//
+// StopRecording does nothing if no recording is running.
+//
+// StopRecording is concurrent-safe.
+func StopRecording() {
+	theRecorder.stop()
+}
+
+// IsRecording reports whether a recording started by StartRecording is running.
+//
+// IsRecording is concurrent-safe.
+func IsRecording() bool {
+	theRecorder.m.Lock()
+	defer theRecorder.m.Unlock()
+	return theRecorder.callback != nil
+}
+
+type recorder struct {
+	callback  func(frame *RecordingFrame) error
+	fps       int
+	startTime time.Time
+	nextIndex int
+
+	// generation is incremented every time a recording starts.
+	generation int
+
+	// frame is used only on the goroutine of Game.Draw.
+	frame RecordingFrame
+
+	m sync.Mutex
+}
+
+var theRecorder recorder
+
+func (r *recorder) start(callback func(frame *RecordingFrame) error, fps int) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if callback == nil {
+		return errors.New("ebiten: the callback must not be nil")
+	}
+	if r.callback != nil {
+		return errors.New("ebiten: a recording is already running")
+	}
+	r.callback = callback
+	r.fps = fps
+	// The start time is determined at the first frame.
+	r.startTime = time.Time{}
+	r.nextIndex = 0
+	r.generation++
+	return nil
+}
+
+func (r *recorder) stop() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.callback = nil
+}
+
+func (r *recorder) isRecording(generation int) bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.callback != nil && r.generation == generation
+}
+
+// record delivers the frames whose timestamps have come with the pixels of the given screen.
+func (r *recorder) record(screen *Image) error {
+	r.m.Lock()
+	callback := r.callback
+	fps := r.fps
+	generation := r.generation
+	var first, last int
+	if callback != nil {
+		now := time.Now()
+		if r.startTime.IsZero() {
+			r.startTime = now
+		}
+		first = r.nextIndex
+		// last is the index of the latest frame whose timestamp is not after now.
+		last = int(now.Sub(r.startTime) * time.Duration(fps) / time.Second)
+		if first <= last {
+			r.nextIndex = last + 1
+		}
+	}
+	r.m.Unlock()
+
+	if callback == nil || first > last {
+		return nil
+	}
+
+	// Call the callback without the lock so that the callback can call StopRecording.
+	b := screen.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if len(r.frame.Pixels) != 4*w*h {
+		r.frame.Pixels = make([]byte, 4*w*h)
+	}
+	screen.ReadPixels(r.frame.Pixels)
+	r.frame.Width = w
+	r.frame.Height = h
+
+	for idx := first; idx <= last; idx++ {
+		r.frame.Index = idx
+		r.frame.Timestamp = time.Duration(idx) * time.Second / time.Duration(fps)
+		if err := callback(&r.frame); err != nil {
+			r.m.Lock()
+			if r.generation == generation {
+				r.callback = nil
+			}
+			r.m.Unlock()
+			return err
+		}
+		if !r.isRecording(generation) {
+			return nil
+		}
+	}
+	return nil
+}
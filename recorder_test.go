@@ -0,0 +1,53 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestRecordingStartStop(t *testing.T) {
+	defer ebiten.StopRecording()
+
+	if err := ebiten.StartRecording(nil, nil); err == nil {
+		t.Errorf("StartRecording with a nil callback must return an error")
+	}
+	if ebiten.IsRecording() {
+		t.Errorf("IsRecording must return false before StartRecording")
+	}
+
+	f := func(frame *ebiten.RecordingFrame) error {
+		return nil
+	}
+	if err := ebiten.StartRecording(f, &ebiten.RecordingOptions{FPS: 30}); err != nil {
+		t.Fatal(err)
+	}
+	if !ebiten.IsRecording() {
+		t.Errorf("IsRecording must return true after StartRecording")
+	}
+	if err := ebiten.StartRecording(f, nil); err == nil {
+		t.Errorf("StartRecording during a recording must return an error")
+	}
+
+	ebiten.StopRecording()
+	if ebiten.IsRecording() {
+		t.Errorf("IsRecording must return false after StopRecording")
+	}
+	if err := ebiten.StartRecording(f, nil); err != nil {
+		t.Errorf("StartRecording after StopRecording must succeed: %v", err)
+	}
+}
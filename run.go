@@ -19,7 +19,9 @@ import (
 	"image"
 	"image/color"
 	"io/fs"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -82,6 +84,7 @@ type Game interface {
 	// adjusted with the given outside size.
 	//
 	// If the game implements the interface LayoutFer, Layout is never called and LayoutF is called instead.
+	// If the game implements the interface DevicePixelLayouter, Layout is never called and LayoutDevicePixels is called instead.
 	Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int)
 }
 
@@ -93,6 +96,21 @@ type LayoutFer interface {
 	LayoutF(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 }
 
+// DevicePixelLayouter is an interface for the device-pixel version of Game.Layout.
+type DevicePixelLayouter interface {
+	// LayoutDevicePixels accepts a native outside size in device pixels, which is the actual framebuffer size,
+	// and the device scale factor, and returns the game's logical screen size.
+	//
+	// The outside size in device-independent pixels is the outside size divided by deviceScaleFactor.
+	// If LayoutDevicePixels returns the given outside size as it is, the game screen has the same size as the framebuffer
+	// and is rendered without implicit scaling.
+	// In this case, the positions like CursorPosition and TouchPosition are also in device pixels.
+	//
+	// If the game implements this interface, neither Layout nor LayoutF is called and LayoutDevicePixels is called instead.
+	// If the game implements both DevicePixelLayouter and LayoutFer, LayoutDevicePixels takes priority.
+	LayoutDevicePixels(outsideWidth, outsideHeight float64, deviceScaleFactor float64) (screenWidth, screenHeight float64)
+}
+
 // FinalScreen represents the final screen image.
 // FinalScreen implements a part of Image functions.
 type FinalScreen interface {
@@ -124,6 +142,28 @@ type FinalScreenDrawer interface {
 	DrawFinalScreen(screen FinalScreen, offscreen *Image, geoM GeoM)
 }
 
+// PauseListener is an interface for a game to be notified when updating the game is paused or resumed.
+type PauseListener interface {
+	// OnPause is called when updating the game is paused by SetUpdatePaused or by losing focus with SetUpdateOnUnfocused(false).
+	// OnPause is called before Draw is called in the frame.
+	OnPause()
+
+	// OnResume is called when updating the game is resumed.
+	// OnResume is called before Update is called in the frame.
+	OnResume()
+}
+
+// FocusListener is an interface for a game to be notified when the game gains or loses focus.
+type FocusListener interface {
+	// OnFocusChanged is called when the focus state of the game changes.
+	// focused is the new state, which is the same as the result of IsFocused.
+	// OnFocusChanged is called before Update is called in the frame.
+	// OnFocusChanged is not called for the initial state.
+	//
+	// OnFocusChanged is useful e.g. to mute audio when the game is in the background.
+	OnFocusChanged(focused bool)
+}
+
 // DefaultTPS represents a default ticks per second, that represents how many times game updating happens in a second.
 const DefaultTPS = clock.DefaultTPS
 
@@ -155,6 +195,10 @@ var (
 // SetScreenClearedEveryFrame enables or disables the clearing of the screen at the beginning of each frame.
 // The default value is true and the screen is cleared each frame by default.
 //
+// SetScreenClearedEveryFrame can be called at any time, even every frame, e.g. to clear the screen only in some frames for trail effects.
+// When the clearing is disabled, the content of the screen in the last frame is kept.
+// While the clearing is disabled, you can clear the screen by yourself with (*Image).Clear in Draw.
+//
 // SetScreenClearedEveryFrame is concurrent-safe.
 func SetScreenClearedEveryFrame(cleared bool) {
 	ui.Get().SetScreenClearedEveryFrame(cleared)
@@ -167,6 +211,85 @@ func IsScreenClearedEveryFrame() bool {
 	return ui.Get().IsScreenClearedEveryFrame()
 }
 
+// IsScreenRedrawNeeded reports whether Game.Draw needs to render the screen in the current frame.
+//
+// IsScreenRedrawNeeded returns false when the screen keeps the content of the last frame and Update has not been called since the last Draw,
+// i.e. the screen is not cleared every frame, the screen image is not recreated, and the window doesn't require redrawing.
+// In this case, Draw can return immediately without rendering anything, and the content of the last frame is kept on the screen.
+// This is useful to skip expensive rendering e.g. in applications that call SetScreenClearedEveryFrame(false).
+//
+// IsScreenRedrawNeeded is valid only in Draw.
+//
+// IsScreenRedrawNeeded is concurrent-safe.
+func IsScreenRedrawNeeded() bool {
+	return ui.Get().IsScreenRedrawNeeded()
+}
+
+// SetScreenFillColor sets the color to fill the letterbox or pillarbox bars of the screen.
+//
+// When the aspect ratio of the game screen doesn't match with the window's, the screen has bars outside of the game screen.
+// The bars are filled with the color instead of black.
+// If clr is nil or transparent, the bars are not filled.
+// The default value is nil.
+//
+// The bars are not rendered with the game's Draw, and are not affected by SetScreenClearedEveryFrame.
+// If the game implements FinalScreenDrawer, the bars are filled before DrawFinalScreen is called.
+//
+// SetScreenFillColor is concurrent-safe.
+func SetScreenFillColor(clr color.Color) {
+	setScreenFillColor(clr)
+}
+
+// SetScreenFillImage sets the image to be tiled in the letterbox or pillarbox bars of the screen.
+//
+// The image is tiled from the upper-left corner of the window in the same scale as the game screen,
+// over the color specified by SetScreenFillColor.
+// If img is nil, no image is tiled.
+// The default value is nil.
+//
+// SetScreenFillImage is concurrent-safe.
+func SetScreenFillImage(img *Image) {
+	setScreenFillImage(img)
+}
+
+// SetScreenDirtyRectsEnabled enables or disables the dirty rectangles of the screen.
+// The default value is false.
+//
+// When the dirty rectangles are enabled, only the regions registered with AddScreenDirtyRect in each frame
+// are rendered to the final screen, instead of the whole screen.
+// This can reduce GPU usage for applications that update only small parts of the screen, like editors or UI tools.
+//
+// The dirty rectangles are used only when SetScreenClearedEveryFrame(false) is called,
+// as the offscreen must keep the contents of the last frames.
+// Otherwise, the whole screen is rendered regardless of the dirty rectangles.
+// The whole screen is also rendered when the screen is resized.
+//
+// SetScreenDirtyRectsEnabled is concurrent-safe.
+func SetScreenDirtyRectsEnabled(enabled bool) {
+	ui.Get().SetScreenDirtyRectsEnabled(enabled)
+}
+
+// IsScreenDirtyRectsEnabled reports whether the dirty rectangles of the screen are enabled.
+//
+// IsScreenDirtyRectsEnabled is concurrent-safe.
+func IsScreenDirtyRectsEnabled() bool {
+	return ui.Get().IsScreenDirtyRectsEnabled()
+}
+
+// AddScreenDirtyRect registers the region modified in the current frame as a dirty rectangle.
+// rect is in the coordinates of the screen image passed to Draw.
+//
+// AddScreenDirtyRect should be called in Draw.
+// The registered rectangles are reset after each frame.
+// If no rectangles are registered in a frame, nothing new is rendered to the final screen in the frame.
+//
+// AddScreenDirtyRect does nothing effectively unless SetScreenDirtyRectsEnabled(true) and SetScreenClearedEveryFrame(false) are called.
+//
+// AddScreenDirtyRect is concurrent-safe.
+func AddScreenDirtyRect(rect image.Rectangle) {
+	ui.Get().AddScreenDirtyRect(rect)
+}
+
 // SetScreenFilterEnabled enables/disables the use of the "screen" filter Ebitengine uses.
 //
 // The "screen" filter is a box filter from game to display resolution.
@@ -267,6 +390,45 @@ type RunGameOptions struct {
 	//
 	// The default (zero) value is false, which means that the single thread mode is disabled.
 	SingleThread bool
+
+	// LinearBlending indicates whether the textures and the screen are treated as in the sRGB color space.
+	// With LinearBlending, the graphics library converts colors to the linear color space when reading textures,
+	// and converts them back to sRGB when writing. Then, blending and filtering are done in the linear color space,
+	// which avoids dark edges of alpha gradients.
+	//
+	// Pixels given to WritePixels and returned by ReadPixels are still sRGB-encoded values.
+	// On the other hand, ColorScale, ColorM and Kage shaders operate on the linear values.
+	// Native textures given to NewImageFromNativeTexture must be in an sRGB format.
+	//
+	// LinearBlending is valid on desktops with OpenGL (not OpenGL ES), Metal, or DirectX 11.
+	// Otherwise, LinearBlending is ignored.
+	// GraphicsLibraryInfo().SRGBFramebuffer reports whether LinearBlending is actually in effect.
+	//
+	// The default (zero) value is false, which means that blending is done in the stored color space.
+	LinearBlending bool
+
+	// ScreenSupersampling indicates whether all the rendering onto the screen image given to Draw is done with 2x2 supersampling.
+	// This is useful to smooth the edges of rotated images and vector graphics without specifying anti-alias for each rendering.
+	//
+	// ScreenSupersampling works in the same way as DrawTrianglesOptions.AntiAlias on all the platforms.
+	// ScreenSupersampling might affect performance, as the rendering is done onto an internal double-sized image.
+	//
+	// The default (zero) value is false.
+	ScreenSupersampling bool
+
+	// DisableDeviceScaleFactor indicates whether the device scale factor is treated as 1 regardless of the monitors.
+	// With DisableDeviceScaleFactor, a device-independent pixel is the same as a device pixel.
+	// Then, the outside size given to Layout, the window size, the window position, the monitor bounds,
+	// and the cursor position are all in device pixels, and DeviceScaleFactor returns 1.
+	//
+	// This is useful when a game wants to render pixel-perfect graphics without any scaling.
+	// On the other hand, the window looks smaller on a high-DPI display, as the window size is not scaled.
+	//
+	// DisableDeviceScaleFactor is valid only on desktops.
+	// Otherwise, DisableDeviceScaleFactor is ignored.
+	//
+	// The default (zero) value is false, which means that the device scale factor is applied.
+	DisableDeviceScaleFactor bool
 }
 
 // RunGameWithOptions starts the main loop and runs the game with the specified options.
@@ -314,7 +476,7 @@ func RunGameWithOptions(game Game, options *RunGameOptions) error {
 	} else {
 		atomic.StoreInt32(&screenTransparent, 0)
 	}
-	g := newGameForUI(game, op.ScreenTransparent)
+	g := newGameForUI(game, op.ScreenTransparent, options != nil && options.ScreenSupersampling)
 
 	if err := ui.Get().Run(g, op); err != nil {
 		if errors.Is(err, Termination) {
@@ -393,12 +555,39 @@ func CursorShape() CursorShapeType {
 // SetCursorShape sets the cursor shape.
 //
 // If the platform doesn't implement the given shape, the default cursor shape is used.
+// As an exception, CursorShapeGrab and CursorShapeGrabbing fall back to a move cursor on platforms without hand cursors like Windows.
 //
 // SetCursorShape is concurrent-safe.
 func SetCursorShape(shape CursorShapeType) {
 	ui.Get().SetCursorShape(shape)
 }
 
+// SetCursorImage sets a custom cursor image with the hotspot (hotspotX, hotspotY).
+// The hotspot is the position in the image that represents the cursor position, relative to the image's upper-left corner.
+//
+// The cursor image is a hardware cursor created from img's pixels, so the cursor doesn't have latency unlike
+// an image drawn at CursorPosition.
+// Modifying img after SetCursorImage doesn't affect the cursor.
+//
+// If img is nil, the cursor returns to the current cursor shape.
+// Calling SetCursorShape also removes the custom cursor image.
+//
+// As SetCursorImage reads img's pixels, SetCursorImage can't be called before the main loop (ebiten.RunGame) starts.
+//
+// SetCursorImage does nothing on mobiles.
+//
+// SetCursorImage is concurrent-safe.
+func SetCursorImage(img *Image, hotspotX, hotspotY int) {
+	if img == nil {
+		ui.Get().SetCursorImage(nil, 0, 0)
+		return
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	img.ReadPixels(rgba.Pix)
+	ui.Get().SetCursorImage(rgba, hotspotX, hotspotY)
+}
+
 // IsFullscreen reports whether the current mode is fullscreen or not.
 //
 // IsFullscreen always returns false on mobiles.
@@ -433,7 +622,7 @@ func SetFullscreen(fullscreen bool) {
 // IsFocused returns a boolean value indicating whether
 // the game is in focus or in the foreground.
 //
-// IsFocused will only return true if IsRunnableOnUnfocused is false.
+// To be notified when the focus state changes, implement FocusListener in the game.
 //
 // IsFocused is concurrent-safe.
 func IsFocused() bool {
@@ -463,6 +652,70 @@ func SetRunnableOnUnfocused(runnableOnUnfocused bool) {
 	ui.Get().SetRunnableOnUnfocused(runnableOnUnfocused)
 }
 
+// IsUpdatePaused reports whether updating the game is paused by SetUpdatePaused.
+//
+// IsUpdatePaused is concurrent-safe.
+func IsUpdatePaused() bool {
+	return ui.Get().IsUpdatePaused()
+}
+
+// SetUpdatePaused pauses or resumes updating the game.
+//
+// While updating the game is paused, Update is not called but Draw is still called.
+// The game's time doesn't advance while the game is paused, and the skipped ticks are not caught up when the game is resumed.
+// The input is still consumed every tick while the game is paused, so the input during the pause is not delivered to Update
+// after the game is resumed.
+// If the game implements PauseListener, OnPause and OnResume are called when the state changes.
+//
+// The initial state is false.
+//
+// SetUpdatePaused is concurrent-safe.
+func SetUpdatePaused(paused bool) {
+	ui.Get().SetUpdatePaused(paused)
+}
+
+// IsUpdateOnUnfocused reports whether the game is updated even when the game is not in focus.
+//
+// IsUpdateOnUnfocused is concurrent-safe.
+func IsUpdateOnUnfocused() bool {
+	return ui.Get().IsUpdateOnUnfocused()
+}
+
+// SetUpdateOnUnfocused sets the state if the game is updated even when the game is not in focus.
+//
+// If the given value is false, Update is not called while the game is not in focus, but Draw is still called.
+// This works in the same way as SetUpdatePaused, and OnPause and OnResume of PauseListener are called when the focus changes.
+// The initial state is true.
+//
+// Unlike SetRunnableOnUnfocused(false), which stops the whole game loop, this keeps drawing the game in background.
+// SetUpdateOnUnfocused has an effect only when the game loop is running, i.e. IsRunnableOnUnfocused is true or the game is in focus.
+//
+// SetUpdateOnUnfocused is concurrent-safe.
+func SetUpdateOnUnfocused(update bool) {
+	ui.Get().SetUpdateOnUnfocused(update)
+}
+
+// IsInputBufferingEnabled reports whether the input buffering is enabled.
+//
+// IsInputBufferingEnabled is concurrent-safe.
+func IsInputBufferingEnabled() bool {
+	return ui.Get().IsInputBufferingEnabled()
+}
+
+// SetInputBufferingEnabled sets the state if the presses between two ticks are buffered.
+//
+// The input is sampled more often than the game ticks when possible, e.g. every frame when TPS is lower than the
+// display's refresh rate. If the input buffering is enabled, a key, a mouse button, or a gamepad button pressed and
+// released between two ticks is treated as pressed for one tick, so that a quick tap is not missed.
+// Otherwise, only the state at the tick is reported.
+//
+// The initial state is false.
+//
+// SetInputBufferingEnabled is concurrent-safe.
+func SetInputBufferingEnabled(enabled bool) {
+	ui.Get().SetInputBufferingEnabled(enabled)
+}
+
 // DeviceScaleFactor returns a device scale factor value of the current monitor which the window belongs to.
 //
 // DeviceScaleFactor returns a meaningful value on high-DPI display environment,
@@ -489,6 +742,9 @@ func IsVsyncEnabled() bool {
 
 // SetVsyncEnabled sets a boolean value indicating whether
 // the game uses the display's vsync.
+//
+// SetVsyncEnabled doesn't block and can be called every frame, e.g. to disable vsync only during a loading screen.
+// The change takes effect from the next frame.
 func SetVsyncEnabled(enabled bool) {
 	if enabled {
 		ui.Get().SetFPSMode(ui.FPSModeVsyncOn)
@@ -543,6 +799,21 @@ func FPSMode() FPSModeType {
 	return ui.Get().FPSMode()
 }
 
+// RunOnMainThread calls f on the OS main thread and blocks until f finishes.
+//
+// Some platform APIs, e.g. Cocoa on macOS, must be called on the main thread.
+// RunOnMainThread is useful to call such APIs from Update or Draw via Cgo or other native bindings.
+//
+// RunOnMainThread is available only on desktops while the game is running.
+// Otherwise, f is called on the current goroutine.
+//
+// Do not call RunOnMainThread from f, or a deadlock might happen.
+//
+// RunOnMainThread is concurrent-safe.
+func RunOnMainThread(f func()) {
+	ui.Get().RunOnMainThread(f)
+}
+
 // SetFPSMode sets the FPS mode.
 // The default FPS mode is FPSModeVsyncOn.
 //
@@ -570,6 +841,21 @@ func TPS() int {
 	return clock.TPS()
 }
 
+// Tick returns the number of times Update has been called and completed.
+// During the first Update, Tick returns 0, and during the n-th Update, Tick returns n-1.
+//
+// Tick is incremented exactly once per Update call, and is independent from the system clock.
+// Then, Tick is useful for deterministic simulations like network lockstep.
+//
+// Tick never skips values. Instead, Tick might fall behind the system clock:
+// when the game time is too far behind the system clock, Ebitengine gives up catching up and the given-up ticks are not counted (see TickStats.SkippedTicks).
+// While Update is not called, e.g. the game is paused by SetUpdatePaused, Tick is not incremented either.
+//
+// Tick is concurrent-safe.
+func Tick() int64 {
+	return atomic.LoadInt64(&tick)
+}
+
 // MaxTPS returns the current maximum TPS.
 //
 // Deprecated: as of v2.4. Use TPS instead.
@@ -587,6 +873,122 @@ func ActualTPS() float64 {
 	return clock.ActualTPS()
 }
 
+// TickStats represents statistics of ticks, i.e. calls of Update, in the last one-second window.
+type TickStats struct {
+	// Ticks is the number of ticks executed.
+	Ticks int
+
+	// SkippedTicks is the number of ticks skipped.
+	// When the game time is too far behind the system clock, e.g. due to a slow Update,
+	// Ebitengine gives up catching up and syncs the game time with the system clock.
+	// The ticks given up are counted as skipped ticks.
+	SkippedTicks int
+
+	// CatchUpTicks is the number of extra ticks executed in frames that call Update more than once to catch up with the system clock.
+	CatchUpTicks int
+
+	// Backlog is the duration by which the game time is behind the system clock.
+	// Backlog is the current value and is not a statistic of the last one-second window.
+	// Backlog growing steadily indicates that Update cannot keep up with TPS.
+	Backlog time.Duration
+}
+
+// TPSStats returns the statistics of ticks in the last one-second window.
+//
+// This value is for measurement and/or debug, and your game logic should not rely on this value.
+//
+// If TPS is SyncWithFPS, SkippedTicks, CatchUpTicks, and Backlog are always 0.
+//
+// TPSStats is concurrent-safe.
+func TPSStats() TickStats {
+	s := clock.CurrentStats()
+	return TickStats{
+		Ticks:        s.Ticks,
+		SkippedTicks: s.SkippedTicks,
+		CatchUpTicks: s.CatchUpTicks,
+		Backlog:      s.Backlog,
+	}
+}
+
+// InterpolationAlpha returns the fractional progress in [0, 1) from the last tick to the next tick.
+//
+// InterpolationAlpha is useful to interpolate rendering positions in Draw when TPS is lower than FPS:
+// render an object at prev + (current - prev) * alpha, where prev and current are the positions at the last two ticks.
+// The value is updated once per frame before Update is called, so the same value is returned during a frame.
+//
+// If TPS is SyncWithFPS, InterpolationAlpha always returns 0.
+//
+// InterpolationAlpha is concurrent-safe.
+func InterpolationAlpha() float64 {
+	return clock.InterpolationAlpha()
+}
+
+var (
+	frameBudgetCallback   func(over bool, avgFrameTime time.Duration)
+	frameBudgetLastWindow int
+	frameBudgetM          sync.Mutex
+)
+
+// SetFrameBudgetCallback sets a callback function that is invoked every second with the statistics of frames
+// in the last one-second window.
+//
+// over reports whether the frames are over the budget, i.e., the average frame time is more than 5% longer than
+// the target frame time.
+// With FPSModeVsyncOn, the target frame time is the interval of the monitor's refresh, or 1/60 [s] if the refresh rate is unknown.
+// Otherwise, the target frame time is 1 / TPS, or 1/60 [s] if TPS is SyncWithFPS.
+// avgFrameTime is the average duration between frames.
+//
+// The callback is useful to implement dynamic resolution or effect scaling.
+// The callback is invoked on the same goroutine as Update and Draw, right before Draw is called.
+//
+// If f is nil, the callback is removed.
+//
+// SetFrameBudgetCallback is concurrent-safe.
+func SetFrameBudgetCallback(f func(over bool, avgFrameTime time.Duration)) {
+	frameBudgetM.Lock()
+	defer frameBudgetM.Unlock()
+	frameBudgetCallback = f
+}
+
+// runFrameBudgetCallbackIfNeeded invokes the frame budget callback if the statistics of frames are updated.
+func runFrameBudgetCallbackIfNeeded() {
+	frameBudgetM.Lock()
+	f := frameBudgetCallback
+	s := clock.CurrentFrameStats()
+	updated := s.Window != frameBudgetLastWindow
+	frameBudgetLastWindow = s.Window
+	frameBudgetM.Unlock()
+
+	if f == nil || !updated || s.Frames == 0 {
+		return
+	}
+
+	target := targetFrameTime()
+	if target <= 0 {
+		return
+	}
+	f(s.AverageFrameTime*20 > target*21, s.AverageFrameTime)
+}
+
+// targetFrameTime returns the expected interval between frames.
+func targetFrameTime() time.Duration {
+	if FPSMode() == FPSModeVsyncOn {
+		if r := MonitorRefreshRate(); r > 0 {
+			return time.Duration(float64(time.Second) / r)
+		}
+		return time.Second / DefaultTPS
+	}
+
+	tps := clock.TPS()
+	if tps == SyncWithFPS {
+		tps = DefaultTPS
+	}
+	if tps <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(tps)
+}
+
 // CurrentTPS returns the current TPS (ticks per second),
 // that represents how many times Update function is called in a second.
 //
@@ -687,6 +1089,9 @@ func toUIRunOptions(options *RunGameOptions) *ui.RunOptions {
 		ScreenTransparent: options.ScreenTransparent,
 		SkipTaskbar:       options.SkipTaskbar,
 		SingleThread:      options.SingleThread,
+		LinearBlending:    options.LinearBlending,
+
+		DeviceScaleFactorDisabled: options.DisableDeviceScaleFactor,
 	}
 }
 
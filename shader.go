@@ -16,6 +16,7 @@ package ebiten
 
 import (
 	"fmt"
+	"image"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
@@ -36,6 +37,41 @@ type Shader struct {
 //
 // If the compilation fails, NewShader returns an error.
 //
+// A Kage program must have a fragment entry point Fragment.
+// A Kage program can also have an optional vertex entry point Vertex with the //kage:vertex directive and this signature:
+//
+//	//kage:vertex
+//
+//	func Vertex(dstPos vec2, srcPos vec2, color vec4) (vec2, vec2, vec4)
+//
+// Without the directive, a function named Vertex is a regular function.
+// The arguments are the attributes of a vertex (DstX/DstY, SrcX/SrcY, and ColorR/ColorG/ColorB/ColorA of Vertex),
+// and Vertex returns the attributes passed to Fragment after the interpolation.
+// dstPos is in pixels on the destination image, and srcPos is in the shader's unit.
+// Vertex can refer to uniform variables, so Vertex is useful to displace vertices on GPU, e.g. for waving grass and water.
+// Vertex must not read source images since some platforms don't support it, and NewShader returns an error in this case.
+// The parts moved outside of the destination region are not rendered.
+// With a fill rule other than FillAll, Vertex should not move a vertex by more than 1 pixel,
+// or the rendering result might be unexpected.
+//
+// A Kage program can call these functions of the standard library without defining them:
+//
+//	// hsvToRGB and rgbToHSV convert a color between RGB and HSV. All the components are in [0, 1].
+//	func hsvToRGB(c vec3) vec3
+//	func rgbToHSV(c vec3) vec3
+//
+//	// valueNoise returns a value noise in [0, 1], and perlinNoise returns a Perlin noise in roughly [-1, 1].
+//	func valueNoise(p vec2) float
+//	func perlinNoise(p vec2) float
+//
+//	// rotate2D returns a matrix to rotate a vector by angle in radians, in the same direction as GeoM.Rotate.
+//	func rotate2D(angle float) mat2
+//
+//	// sdRoundedRect returns the signed distance from p to a rounded rectangle centered at the origin.
+//	func sdRoundedRect(p vec2, halfSize vec2, radius float) float
+//
+// If a Kage program defines a function of the same name, the program's function is used instead.
+//
 // For the details about the shader, see https://ebitengine.org/en/documents/shader.html.
 func NewShader(src []byte) (*Shader, error) {
 	ir, err := graphics.CompileShader(src)
@@ -77,6 +113,34 @@ func (s *Shader) Deallocate() {
 	s.shader.Deallocate()
 }
 
+// Precompile compiles the shader for the GPU in advance, so that the first rendering with the shader doesn't cause a hitch.
+//
+// Precompile renders with the shader onto a small internal image, as some graphics drivers like OpenGL's
+// finish compiling a shader only when the shader is used for rendering.
+// The actual compilation happens when the rendering commands are flushed, typically at the end of the current frame.
+//
+// Precompile is useful to move the cost of the shader compilation to e.g. a loading screen.
+// After Deallocate is called, the shader needs to be compiled again.
+//
+// If the shader is disposed, Precompile does nothing.
+func (s *Shader) Precompile() {
+	if s.isDisposed() {
+		return
+	}
+	shaderPrecompileImageOnce.Do(func() {
+		shaderPrecompileImage = NewImageWithOptions(image.Rect(0, 0, 1, 1), &NewImageOptions{
+			Unmanaged: true,
+		})
+	})
+	shaderPrecompileImage.DrawRectShader(1, 1, s, nil)
+}
+
+var (
+	// shaderPrecompileImage is a destination image for Shader.Precompile.
+	shaderPrecompileImage     *Image
+	shaderPrecompileImageOnce sync.Once
+)
+
 func (s *Shader) appendUniforms(dst []uint32, uniforms map[string]any) []uint32 {
 	return s.shader.AppendUniforms(dst, uniforms)
 }
@@ -99,7 +163,7 @@ func builtinShader(filter builtinshader.Filter, address builtinshader.Address, u
 	}
 
 	var shader *Shader
-	if address == builtinshader.AddressUnsafe && !useColorM {
+	if address == builtinshader.AddressUnsafe && !useColorM && filter != builtinshader.FilterAnisotropic {
 		switch filter {
 		case builtinshader.FilterNearest:
 			shader = &Shader{shader: ui.NearestFilterShader}
@@ -56,6 +56,46 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	}
 }
 
+func TestShaderPrecompile(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+var Color vec4
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return Color
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Precompile()
+
+	// Precompiling must not affect the later rendering.
+	dst := ebiten.NewImage(w, h)
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Uniforms = map[string]any{
+		"Color": []float32{0, 1, 0, 1},
+	}
+	dst.DrawRectShader(w, h, s, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{G: 0xff, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	s.Deallocate()
+	s.Precompile()
+}
+
 func TestShaderFillWithDrawImage(t *testing.T) {
 	const w, h = 16, 16
 
@@ -1405,6 +1445,61 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	}
 }
 
+func TestShaderDrawRectColorScaleModeStraightAlpha(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		mode    ebiten.ColorScaleMode
+		modeSet bool
+		want    color.RGBA
+	}{
+		{
+			name: "default",
+			want: color.RGBA{R: 0xff, G: 0x80, B: 0x40, A: 0x80},
+		},
+		{
+			name:    "premultiplied alpha",
+			mode:    ebiten.ColorScaleModePremultipliedAlpha,
+			modeSet: true,
+			want:    color.RGBA{R: 0xff, G: 0x80, B: 0x40, A: 0x80},
+		},
+		{
+			name:    "straight alpha",
+			mode:    ebiten.ColorScaleModeStraightAlpha,
+			modeSet: true,
+			want:    color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0x80},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dst := ebiten.NewImage(w, h)
+			op := &ebiten.DrawRectShaderOptions{}
+			op.ColorScale.Scale(1, 0.5, 0.25, 0.5)
+			op.ColorScaleMode = tc.mode
+			op.ColorScaleModeSet = tc.modeSet
+			op.Blend = ebiten.BlendCopy
+			dst.DrawRectShader(w, h, s, op)
+
+			if got := dst.At(0, 0).(color.RGBA); !sameColors(got, tc.want, 1) {
+				t.Errorf("dst.At(0, 0): got: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestShaderUniformInt(t *testing.T) {
 	const ints = `//kage:unit pixels
 
@@ -0,0 +1,111 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+)
+
+// Snapshot represents a copy of an image's pixels taken by (*Image).Snapshot.
+//
+// The pixels of a snapshot are kept on GPU, and are never read back to CPU.
+type Snapshot struct {
+	image *Image
+}
+
+// Snapshot copies the current pixels of the image i into a new snapshot, and returns it.
+//
+// The copy is done on GPU without a round trip to CPU, so Snapshot is much faster than ReadPixels.
+// Snapshot is useful e.g. to implement undo in editors.
+// To keep an undo history, keep snapshots in a slice and call Deallocate for the snapshots that are no longer needed.
+//
+// If the image is a sub-image, only the region of the sub-image is copied.
+//
+// When the image is disposed, Snapshot panics.
+func (i *Image) Snapshot() *Snapshot {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		panic("ebiten: the image to Snapshot must not be disposed")
+	}
+
+	b := i.Bounds()
+	img := NewImage(b.Dx(), b.Dy())
+	op := &DrawImageOptions{}
+	op.Blend = BlendCopy
+	img.DrawImage(i, op)
+	return &Snapshot{
+		image: img,
+	}
+}
+
+// Restore replaces the pixels of the image i with the pixels of the snapshot.
+//
+// The copy is done on GPU without a round trip to CPU.
+// The snapshot is still available after Restore, so the same snapshot can be restored multiple times.
+//
+// The image's size must be the same as the size of the image when the snapshot was taken.
+// Otherwise, Restore panics.
+// The snapshot can be restored to an image other than the original one as long as the sizes are the same.
+//
+// When the image is disposed, Restore does nothing.
+// When the snapshot is deallocated, Restore panics.
+func (i *Image) Restore(snapshot *Snapshot) {
+	i.copyCheck()
+
+	if snapshot.image == nil {
+		panic("ebiten: the snapshot to Restore must not be deallocated")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	b := i.Bounds()
+	sb := snapshot.image.Bounds()
+	if b.Dx() != sb.Dx() || b.Dy() != sb.Dy() {
+		panic(fmt.Sprintf("ebiten: the image size (%d, %d) must be the same as the snapshot size (%d, %d)", b.Dx(), b.Dy(), sb.Dx(), sb.Dy()))
+	}
+
+	op := &DrawImageOptions{}
+	op.GeoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+	op.Blend = BlendCopy
+	i.DrawImage(snapshot.image, op)
+}
+
+// Bounds returns the bounds of the snapshot.
+// The upper-left corner is always (0, 0).
+//
+// If the snapshot is deallocated, Bounds returns an empty rectangle.
+func (s *Snapshot) Bounds() image.Rectangle {
+	if s.image == nil {
+		return image.Rectangle{}
+	}
+	return s.image.Bounds()
+}
+
+// Deallocate deallocates the internal state of the snapshot.
+// After Deallocate is called, the snapshot is no longer available.
+//
+// Usually, you don't have to call Deallocate since the internal state is automatically released by GC.
+//
+// If the snapshot is already deallocated, Deallocate does nothing.
+func (s *Snapshot) Deallocate() {
+	if s.image == nil {
+		return
+	}
+	s.image.Deallocate()
+	s.image = nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+)
+
+// SpriteBatch accumulates sprites sharing the same source image, and draws them with one DrawTriangles call.
+//
+// The accumulated sprites are retained after Draw, so a SpriteBatch can be drawn multiple times.
+// Call Reset to remove the sprites.
+type SpriteBatch struct {
+	source *Image
+
+	vertices []Vertex
+	indices  []uint32
+}
+
+// NewSpriteBatch creates a new SpriteBatch with the source image.
+func NewSpriteBatch(source *Image) *SpriteBatch {
+	return &SpriteBatch{
+		source: source,
+	}
+}
+
+// Add adds a sprite of the region srcRect of the source image.
+// srcRect is in the source image's coordinate, which is the same as the rectangle for SubImage.
+//
+// geoM is the transform of the sprite, where the upper-left corner of srcRect is the origin.
+// colorScale is the color scale of the sprite.
+//
+// Add is useful especially when many sprites share the same source image, e.g. a texture atlas.
+func (s *SpriteBatch) Add(srcRect image.Rectangle, geoM GeoM, colorScale ColorScale) {
+	sx0, sy0 := float32(srcRect.Min.X), float32(srcRect.Min.Y)
+	sx1, sy1 := float32(srcRect.Max.X), float32(srcRect.Max.Y)
+	w, h := float64(srcRect.Dx()), float64(srcRect.Dy())
+	cr, cg, cb, ca := colorScale.elements()
+
+	idx := uint32(len(s.vertices))
+	for _, p := range [4][4]float64{
+		{0, 0, float64(sx0), float64(sy0)},
+		{w, 0, float64(sx1), float64(sy0)},
+		{0, h, float64(sx0), float64(sy1)},
+		{w, h, float64(sx1), float64(sy1)},
+	} {
+		dx, dy := geoM.Apply(p[0], p[1])
+		s.vertices = append(s.vertices, Vertex{
+			DstX:   float32(dx),
+			DstY:   float32(dy),
+			SrcX:   float32(p[2]),
+			SrcY:   float32(p[3]),
+			ColorR: cr,
+			ColorG: cg,
+			ColorB: cb,
+			ColorA: ca,
+		})
+	}
+	s.indices = append(s.indices, idx, idx+1, idx+2, idx+1, idx+2, idx+3)
+}
+
+// Len returns the number of the accumulated sprites.
+func (s *SpriteBatch) Len() int {
+	return len(s.vertices) / 4
+}
+
+// Reset removes all the accumulated sprites.
+func (s *SpriteBatch) Reset() {
+	s.vertices = s.vertices[:0]
+	s.indices = s.indices[:0]
+}
+
+// Draw draws the accumulated sprites onto dst in the order of Add calls.
+//
+// options can be nil. options.GeoM is applied to all the sprites after their own transforms.
+// options.ColorScaleMode is ignored, as the color scales given at Add are treated as premultiplied-alpha scales like ColorScale.
+func (s *SpriteBatch) Draw(dst *Image, options *DrawTrianglesOptions) {
+	if len(s.indices) == 0 {
+		return
+	}
+
+	var op DrawTrianglesOptions
+	if options != nil {
+		op = *options
+	}
+	op.ColorScaleMode = ColorScaleModePremultipliedAlpha
+	dst.DrawTriangles32(s.vertices, s.indices, s.source, &op)
+}
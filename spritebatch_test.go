@@ -0,0 +1,80 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestSpriteBatch(t *testing.T) {
+	const w, h = 16, 16
+
+	src := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			pix[idx] = byte(i * 0x10)
+			pix[idx+1] = byte(j * 0x10)
+			pix[idx+2] = 0x80
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	dst0 := ebiten.NewImage(64, 64)
+	dst1 := ebiten.NewImage(64, 64)
+
+	b := ebiten.NewSpriteBatch(src)
+	for i, r := range []image.Rectangle{
+		image.Rect(0, 0, 8, 8),
+		image.Rect(8, 0, 16, 8),
+		image.Rect(4, 4, 12, 16),
+	} {
+		var geoM ebiten.GeoM
+		geoM.Translate(float64(i*20), float64(i*10))
+		var cs ebiten.ColorScale
+		cs.ScaleWithColor(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80})
+		b.Add(r, geoM, cs)
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM = geoM
+		op.ColorScale = cs
+		dst1.DrawImage(src.SubImage(r).(*ebiten.Image), op)
+	}
+	if got, want := b.Len(), 3; got != want {
+		t.Errorf("Len(): got: %d, want: %d", got, want)
+	}
+	b.Draw(dst0, nil)
+
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			got := dst0.At(i, j)
+			want := dst1.At(i, j)
+			if got != want {
+				t.Errorf("dst0.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	b.Reset()
+	if got, want := b.Len(), 0; got != want {
+		t.Errorf("Len(): got: %d, want: %d", got, want)
+	}
+}
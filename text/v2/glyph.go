@@ -15,7 +15,9 @@
 package text
 
 import (
+	"container/list"
 	"math"
+	"runtime"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -37,29 +39,158 @@ func init() {
 	})
 }
 
+// GlyphCacheStats represents the statistics of the glyph image cache shared by all the faces.
+type GlyphCacheStats struct {
+	// GlyphCount is the number of the cached glyph images.
+	GlyphCount int
+
+	// ByteSize is the total size of the cached glyph images in bytes, calculated as 4 bytes per pixel.
+	// The actual GPU memory usage might be different as glyph images are packed into texture atlases.
+	ByteSize int64
+}
+
+// SetGlyphCacheLimit sets the maximum size of the glyph image cache in bytes.
+// The size of a glyph image is calculated as 4 bytes per pixel.
+//
+// The glyph image cache is shared by all the faces.
+// When the total size exceeds the limit, the least recently used glyph images are evicted and deallocated.
+// The glyph image being rendered is never evicted, so the total size might exceed the limit temporarily when the limit is very small.
+//
+// If bytes is 0 or negative, the cache size is not limited.
+// Even without a limit, glyph images that are not used for a while are removed when there are many glyph images.
+// The default value is 0.
+//
+// SetGlyphCacheLimit is concurrent-safe.
+func SetGlyphCacheLimit(bytes int64) {
+	m := &theGlyphImageCacheManager
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.limit = bytes
+	m.evict(nil)
+}
+
+// ReadGlyphCacheStats reads the statistics of the glyph image cache.
+//
+// ReadGlyphCacheStats is concurrent-safe.
+func ReadGlyphCacheStats(stats *GlyphCacheStats) {
+	m := &theGlyphImageCacheManager
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	stats.GlyphCount = m.lru.Len()
+	stats.ByteSize = m.byteSize
+}
+
 type glyphImageCacheEntry struct {
 	image *ebiten.Image
 	atime int64
+
+	byteSize int64
+	elem     *list.Element
+	remove   func()
+}
+
+// glyphImageCacheManager tracks the glyph images of all the glyphImageCache objects to bound the total size.
+type glyphImageCacheManager struct {
+	// lru is a list of *glyphImageCacheEntry with non-nil images.
+	// The front is the most recently used one.
+	lru list.List
+
+	byteSize int64
+	limit    int64
+
+	// m protects the manager and all the glyphImageCache objects.
+	// A single mutex is used so that an eviction can remove an entry from any cache without a deadlock.
+	m sync.Mutex
+}
+
+var theGlyphImageCacheManager glyphImageCacheManager
+
+func (m *glyphImageCacheManager) add(e *glyphImageCacheEntry) {
+	e.elem = m.lru.PushFront(e)
+	m.byteSize += e.byteSize
+}
+
+func (m *glyphImageCacheManager) touch(e *glyphImageCacheEntry) {
+	if e.elem == nil {
+		return
+	}
+	m.lru.MoveToFront(e.elem)
+}
+
+func (m *glyphImageCacheManager) remove(e *glyphImageCacheEntry) {
+	if e.elem == nil {
+		return
+	}
+	m.lru.Remove(e.elem)
+	e.elem = nil
+	m.byteSize -= e.byteSize
+}
+
+// evict evicts the least recently used entries until the total size fits with the limit.
+// keep is never evicted.
+func (m *glyphImageCacheManager) evict(keep *glyphImageCacheEntry) {
+	if m.limit <= 0 {
+		return
+	}
+	for m.byteSize > m.limit {
+		elem := m.lru.Back()
+		if elem == nil {
+			return
+		}
+		e := elem.Value.(*glyphImageCacheEntry)
+		if e == keep {
+			return
+		}
+		m.remove(e)
+		e.remove()
+		e.image.Deallocate()
+	}
+}
+
+// glyphImageCacheToken is finalized when its glyphImageCache is no longer used.
+//
+// The manager's list refers to the cache map but not to glyphImageCache nor its owner face,
+// so the finalizer of the token can remove the entries from the manager.
+type glyphImageCacheToken struct {
+	removeAll func()
 }
 
 type glyphImageCache[Key comparable] struct {
 	cache map[Key]*glyphImageCacheEntry
-	m     sync.Mutex
+	token *glyphImageCacheToken
 }
 
 func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *ebiten.Image) *ebiten.Image {
-	g.m.Lock()
-	defer g.m.Unlock()
+	m := &theGlyphImageCacheManager
+	m.m.Lock()
+	defer m.m.Unlock()
 
 	e, ok := g.cache[key]
 	if ok {
 		e.atime = now()
+		m.touch(e)
 		return e.image
 	}
 
 	if g.cache == nil {
-		g.cache = map[Key]*glyphImageCacheEntry{}
+		c := map[Key]*glyphImageCacheEntry{}
+		g.cache = c
+		g.token = &glyphImageCacheToken{
+			removeAll: func() {
+				m.m.Lock()
+				defer m.m.Unlock()
+				for _, e := range c {
+					m.remove(e)
+				}
+			},
+		}
+		runtime.SetFinalizer(g.token, func(token *glyphImageCacheToken) {
+			token.removeAll()
+		})
 	}
+	c := g.cache
 
 	img := create()
 	e = &glyphImageCacheEntry{
@@ -67,6 +198,13 @@ func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *eb
 	}
 	if img != nil {
 		e.atime = now()
+		b := img.Bounds()
+		e.byteSize = 4 * int64(b.Dx()) * int64(b.Dy())
+		// Don't refer to g here so that the token can be finalized.
+		e.remove = func() {
+			delete(c, key)
+		}
+		m.add(e)
 	} else {
 		// If the glyph image is nil, the entry doesn't have to be removed.
 		// Keep this until the face is GCed.
@@ -87,9 +225,12 @@ func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *eb
 			if e.atime >= now()-60 {
 				continue
 			}
+			m.remove(e)
 			delete(g.cache, key)
 		}
 	}
 
+	m.evict(e)
+
 	return img
 }
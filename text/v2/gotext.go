@@ -37,6 +37,11 @@ var _ Face = (*GoTextFace)(nil)
 // GoTextFace is a Face implementation for go-text's font.Face (github.com/go-text/typesetting).
 // With a GoTextFace, shaping.HarfBuzzShaper is always used as a shaper internally.
 // GoTextFace includes the source and various options.
+//
+// Color bitmap glyphs like emojis in CBDT/CBLC or sbix tables are rendered with their own colors.
+// The colors are still multiplied by DrawOptions.ColorScale, so use the default (white) color to render them as they are.
+// Color glyphs in COLR tables are not supported yet, and are rendered from their outlines as monochrome glyphs.
+// AppendVectorPath doesn't include color bitmap glyphs.
 type GoTextFace struct {
 	// Source is the font face source.
 	Source *GoTextFaceSource
@@ -304,7 +309,12 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 		Y: float64ToFixed26_6(originY),
 	}
 	_, gs := g.Source.shape(line, g)
+	horizontal := g.direction().isHorizontal()
 	for _, glyph := range gs {
+		a := -glyph.shapingGlyph.YAdvance
+		if horizontal {
+			a = glyph.shapingGlyph.XAdvance
+		}
 		img, imgX, imgY := g.glyphImage(glyph, origin.Add(fixed.Point26_6{
 			X: glyph.shapingGlyph.XOffset,
 			Y: -glyph.shapingGlyph.YOffset,
@@ -318,6 +328,9 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 			Image:             img,
 			X:                 float64(imgX),
 			Y:                 float64(imgY),
+			OriginX:           fixed26_6ToFloat64(origin.X),
+			OriginY:           fixed26_6ToFloat64(origin.Y),
+			Advance:           fixed26_6ToFloat64(a),
 		})
 		origin = origin.Add(fixed.Point26_6{
 			X: glyph.shapingGlyph.XAdvance,
@@ -338,6 +351,18 @@ func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Im
 	}
 
 	b := glyph.bounds
+	if glyph.bitmap != nil {
+		// A bitmap is not rendered at subpixel positions.
+		key := goTextGlyphImageCacheKey{
+			gid:        glyph.shapingGlyph.GlyphID,
+			variations: g.ensureVariationsString(),
+		}
+		img := g.Source.getOrCreateGlyphImage(g, key, func() *ebiten.Image {
+			return bitmapToImage(glyph.bitmap, b)
+		})
+		return img, (origin.X + b.Min.X).Floor(), (origin.Y + b.Min.Y).Floor()
+	}
+
 	subpixelOffset := fixed.Point26_6{
 		X: (origin.X + b.Min.X) & ((1 << 6) - 1),
 		Y: (origin.Y + b.Min.Y) & ((1 << 6) - 1),
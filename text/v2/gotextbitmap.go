@@ -0,0 +1,57 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/go-text/typesetting/opentype/api"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// bitmapToImage decodes a color bitmap glyph and scales it to the glyph bounds.
+//
+// bitmapToImage returns nil if the bitmap cannot be decoded.
+func bitmapToImage(bitmap *api.GlyphBitmap, glyphBounds fixed.Rectangle26_6) *ebiten.Image {
+	w, h := (glyphBounds.Max.X - glyphBounds.Min.X).Ceil(), (glyphBounds.Max.Y - glyphBounds.Min.Y).Ceil()
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+
+	var src image.Image
+	var err error
+	switch bitmap.Format {
+	case api.PNG:
+		src, err = png.Decode(bytes.NewReader(bitmap.Data))
+	case api.JPG:
+		src, err = jpeg.Decode(bytes.NewReader(bitmap.Data))
+	default:
+		return nil
+	}
+	if err != nil {
+		return nil
+	}
+
+	// Bitmaps are usually larger than the rendering size, then scale them on CPU with a bilinear filter.
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.BiLinear.Scale(dst, dst.Bounds(), src, src.Bounds(), xdraw.Src, nil)
+	return ebiten.NewImageFromImage(dst)
+}
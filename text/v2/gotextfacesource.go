@@ -46,6 +46,9 @@ type glyph struct {
 	endIndex       int
 	scaledSegments []api.Segment
 	bounds         fixed.Rectangle26_6
+
+	// bitmap is a color bitmap of the glyph like an emoji, or nil if the glyph is rendered from its outline.
+	bitmap *api.GlyphBitmap
 }
 
 type goTextOutputCacheValue struct {
@@ -119,6 +122,8 @@ func NewGoTextFaceSource(source io.Reader) (*GoTextFaceSource, error) {
 }
 
 // NewGoTextFaceSourcesFromCollection parses an OpenType or TrueType font collection and returns a slice of GoTextFaceSource objects.
+// The order of the slice is the same as the faces in the collection.
+// Use Metadata().PostScriptName to find a face by its PostScript name.
 func NewGoTextFaceSourcesFromCollection(source io.Reader) ([]*GoTextFaceSource, error) {
 	src, err := toFontResource(source)
 	if err != nil {
@@ -216,7 +221,10 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 
 		for _, gl := range out.Glyphs {
 			gl := gl
+			scale := float32(g.scale(fixed26_6ToFloat64(out.Size)))
 			var segs []api.Segment
+			var bitmap *api.GlyphBitmap
+			var bitmapBounds fixed.Rectangle26_6
 			switch data := g.f.GlyphData(gl.GlyphID).(type) {
 			case api.GlyphOutline:
 				if out.Direction.IsSideways() {
@@ -226,13 +234,16 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 			case api.GlyphSVG:
 				segs = data.Outline.Segments
 			case api.GlyphBitmap:
-				if data.Outline != nil {
+				// Prefer a color bitmap to the outline, as the outline of a color glyph is usually just a fallback.
+				if b, ok := g.colorBitmapBounds(gl.GlyphID, data, scale); ok {
+					bitmap = &data
+					bitmapBounds = b
+				} else if data.Outline != nil {
 					segs = data.Outline.Segments
 				}
 			}
 
 			scaledSegs := make([]api.Segment, len(segs))
-			scale := float32(g.scale(fixed26_6ToFloat64(out.Size)))
 			for i, seg := range segs {
 				scaledSegs[i] = seg
 				for j := range seg.Args {
@@ -241,12 +252,17 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 				}
 			}
 
+			bounds := segmentsToBounds(scaledSegs)
+			if bitmap != nil {
+				bounds = bitmapBounds
+			}
 			gs = append(gs, glyph{
 				shapingGlyph:   &gl,
 				startIndex:     indices[gl.ClusterIndex],
 				endIndex:       indices[gl.ClusterIndex+gl.RuneCount],
 				scaledSegments: scaledSegs,
-				bounds:         segmentsToBounds(scaledSegs),
+				bounds:         bounds,
+				bitmap:         bitmap,
 			})
 		}
 	}
@@ -278,6 +294,33 @@ func (g *GoTextFaceSource) scale(size float64) float64 {
 	return size / float64(g.f.Upem())
 }
 
+// colorBitmapBounds returns the bounds of the given bitmap glyph in pixels.
+// colorBitmapBounds returns false if the bitmap is not a color image that can be rendered.
+func (g *GoTextFaceSource) colorBitmapBounds(gid api.GID, data api.GlyphBitmap, scale float32) (fixed.Rectangle26_6, bool) {
+	// TIFF is very rare in fonts and is not supported for now.
+	if data.Format != api.PNG && data.Format != api.JPG {
+		return fixed.Rectangle26_6{}, false
+	}
+	if data.Width == 0 || data.Height == 0 {
+		return fixed.Rectangle26_6{}, false
+	}
+	// The extents are in font units, and the Y axis points upward.
+	e, ok := g.f.GlyphExtents(gid)
+	if !ok {
+		return fixed.Rectangle26_6{}, false
+	}
+	return fixed.Rectangle26_6{
+		Min: fixed.Point26_6{
+			X: float32ToFixed26_6(e.XBearing * scale),
+			Y: float32ToFixed26_6(-e.YBearing * scale),
+		},
+		Max: fixed.Point26_6{
+			X: float32ToFixed26_6((e.XBearing + e.Width) * scale),
+			Y: float32ToFixed26_6(-(e.YBearing + e.Height) * scale),
+		},
+	}, true
+}
+
 func (g *GoTextFaceSource) getOrCreateGlyphImage(goTextFace *GoTextFace, key goTextGlyphImageCacheKey, create func() *ebiten.Image) *ebiten.Image {
 	if g.glyphImageCache == nil {
 		g.glyphImageCache = map[float64]*glyphImageCache[goTextGlyphImageCacheKey]{}
@@ -15,6 +15,7 @@
 package text
 
 import (
+	"math"
 	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -61,12 +62,101 @@ type LayoutOptions struct {
 	// and the horizontal direction for a vertical-direction face.
 	// The meaning of the start and the end depends on the face direction.
 	SecondaryAlign Align
+
+	// LineLength is a length of the rendering region in the primary direction in pixels.
+	//
+	// If LineLength is positive, each line is aligned by PrimaryAlign within the range from 0 to LineLength
+	// in the primary direction, e.g. from the left edge (0) to the right edge (LineLength) for a horizontal-direction face.
+	// If LineLength is 0, each line is aligned around the origin.
+	LineLength float64
+
+	// TabStops is a list of tab stop positions in pixels in ascending order.
+	// A position is a distance from the start of a line in the primary direction.
+	//
+	// If TabStops or TabWidth is specified, a '\t' tab character advances the following text to the next tab stop.
+	// Otherwise, a '\t' is treated as a regular character.
+	TabStops []float64
+
+	// TabWidth is an interval of tab stops in pixels after the last position of TabStops.
+	// If TabStops is empty, tab stops are placed at every TabWidth from the start of a line.
+	//
+	// If TabWidth is 0, a '\t' after the last tab stop is treated as a regular character.
+	TabWidth float64
+}
+
+func (o *LayoutOptions) isTabStopEnabled() bool {
+	return len(o.TabStops) > 0 || o.TabWidth > 0
+}
+
+// nextTabStop returns the first tab stop after the given position.
+// nextTabStop returns false if there is no such tab stop.
+func (o *LayoutOptions) nextTabStop(position float64) (float64, bool) {
+	for _, s := range o.TabStops {
+		if s > position {
+			return s, true
+		}
+	}
+	if o.TabWidth <= 0 {
+		return 0, false
+	}
+	var base float64
+	if len(o.TabStops) > 0 {
+		base = o.TabStops[len(o.TabStops)-1]
+	}
+	return base + (math.Floor((position-base)/o.TabWidth)+1)*o.TabWidth, true
+}
+
+// lineSegment is a part of a line separated by tab characters.
+type lineSegment struct {
+	text        string
+	indexOffset int
+
+	// position is a distance from the start of the line in the primary direction.
+	position float64
+	advance  float64
+}
+
+// appendLineSegments appends the segments of the given line, and returns the slice and the line's advance.
+func appendLineSegments(segments []lineSegment, line string, indexOffset int, face Face, options *LayoutOptions) ([]lineSegment, float64) {
+	if !options.isTabStopEnabled() {
+		a := face.advance(line)
+		return append(segments, lineSegment{
+			text:        line,
+			indexOffset: indexOffset,
+			advance:     a,
+		}), a
+	}
+
+	var position float64
+	for t := line; ; {
+		seg, rest, found := strings.Cut(t, "\t")
+		a := face.advance(seg)
+		segments = append(segments, lineSegment{
+			text:        seg,
+			indexOffset: indexOffset,
+			position:    position,
+			advance:     a,
+		})
+		position += a
+		if !found {
+			break
+		}
+		if s, ok := options.nextTabStop(position); ok {
+			position = s
+		} else {
+			position += face.advance("\t")
+		}
+		t = rest
+		indexOffset += len(seg) + 1
+	}
+	return segments, position
 }
 
 // Draw draws a given text on a given destination image dst.
 // face is the font for text rendering.
 //
 // The '\n' newline character puts the following text on the next line.
+// If tab stops are specified in LayoutOptions, the '\t' tab character advances the following text to the next tab stop.
 //
 // Glyphs used for rendering are cached in least-recently-used way.
 // Then old glyphs might be evicted from the cache.
@@ -130,11 +220,102 @@ func AppendGlyphs(glyphs []Glyph, text string, face Face, options *LayoutOptions
 	return appendGlyphs(glyphs, text, face, 0, 0, options)
 }
 
+// CaretPosition returns the position of a caret put before the byte index indexInBytes in text.
+// The returned position is on the baseline, and is in the same coordinate system as Glyph's OriginX and OriginY
+// returned by AppendGlyphs with the same arguments.
+//
+// indexInBytes is clamped to the range [0, len(text)].
+// If indexInBytes is at a line break or at the end of the text, the position at the end of the line is returned.
+// If indexInBytes is in the middle of a glyph cluster, e.g. between a base character and a combining mark
+// shaped into one cluster, the position before the cluster is returned.
+// For a right-to-left face, the position before a cluster is the cluster's right edge.
+//
+// For the details of options, see Draw function.
+//
+// CaretPosition is concurrent-safe.
+func CaretPosition(text string, indexInBytes int, face Face, options *LayoutOptions) (x, y float64) {
+	if indexInBytes < 0 {
+		indexInBytes = 0
+	}
+	if indexInBytes > len(text) {
+		indexInBytes = len(text)
+	}
+
+	var found bool
+	forEachLine(text, face, options, func(line string, indexOffset int, originX, originY float64) {
+		if found {
+			return
+		}
+		if indexInBytes < indexOffset || indexInBytes > indexOffset+len(line) {
+			return
+		}
+		found = true
+		x, y = caretPositionInLine(line, indexInBytes, indexOffset, face, originX, originY)
+	})
+	return
+}
+
+func caretPositionInLine(line string, indexInBytes int, indexOffset int, face Face, originX, originY float64) (float64, float64) {
+	d := face.direction()
+	glyphs := face.appendGlyphsForLine(nil, line, indexOffset, originX, originY)
+
+	// Find the cluster that includes indexInBytes or that is the first one after indexInBytes.
+	start := -1
+	for _, g := range glyphs {
+		if g.EndIndexInBytes <= indexInBytes {
+			continue
+		}
+		if start == -1 || g.StartIndexInBytes < start {
+			start = g.StartIndexInBytes
+		}
+	}
+
+	// There is no cluster after indexInBytes. Return the end of the line.
+	if start == -1 {
+		a := face.advance(line)
+		switch d {
+		case DirectionLeftToRight:
+			return originX + a, originY
+		case DirectionRightToLeft:
+			return originX, originY
+		default:
+			return originX, originY + a
+		}
+	}
+
+	var x, y float64
+	var initialized bool
+	for _, g := range glyphs {
+		if g.StartIndexInBytes != start {
+			continue
+		}
+		switch d {
+		case DirectionLeftToRight:
+			if !initialized || g.OriginX < x {
+				x, y = g.OriginX, g.OriginY
+			}
+		case DirectionRightToLeft:
+			if !initialized || g.OriginX+g.Advance > x {
+				x, y = g.OriginX+g.Advance, g.OriginY
+			}
+		default:
+			if !initialized || g.OriginY < y {
+				x, y = g.OriginX, g.OriginY
+			}
+		}
+		initialized = true
+	}
+	return x, y
+}
+
 // AppndVectorPath appends a vector path for glyphs to the given path.
 //
 // AppendVectorPath works only when the face is *GoTextFace or a composite face using *GoTextFace so far.
 // For other types, AppendVectorPath does nothing.
 func AppendVectorPath(path *vector.Path, text string, face Face, options *LayoutOptions) {
+	if text == "" {
+		return
+	}
 	forEachLine(text, face, options, func(line string, indexOffset int, originX, originY float64) {
 		face.appendVectorPathForLine(path, line, originX, originY)
 	})
@@ -145,6 +326,9 @@ func AppendVectorPath(path *vector.Path, text string, face Face, options *Layout
 // appendGlyphs assumes the text is rendered with the position (x, y).
 // (x, y) might affect the subpixel rendering results.
 func appendGlyphs(glyphs []Glyph, text string, face Face, x, y float64, options *LayoutOptions) []Glyph {
+	if text == "" {
+		return glyphs
+	}
 	forEachLine(text, face, options, func(line string, indexOffset int, originX, originY float64) {
 		glyphs = face.appendGlyphsForLine(glyphs, line, indexOffset, originX+x, originY+y)
 	})
@@ -152,23 +336,26 @@ func appendGlyphs(glyphs []Glyph, text string, face Face, x, y float64, options
 }
 
 // forEachLine interates lines.
+// An empty text is treated as one empty line.
 func forEachLine(text string, face Face, options *LayoutOptions, f func(text string, indexOffset int, originX, originY float64)) {
-	if text == "" {
-		return
-	}
-
 	if options == nil {
 		options = &LayoutOptions{}
 	}
 
-	// Calculate the advances for each line.
+	// Calculate the advances and the segments for each line.
 	var advances []float64
+	var segments []lineSegment
+	// segmentEnds[i] is the end index of the i-th line's segments.
+	var segmentEnds []int
 	var longestAdvance float64
 	var lineCount int
+	var indexOffset int
 	for t := text; ; {
 		lineCount++
 		line, rest, found := strings.Cut(t, "\n")
-		a := face.advance(line)
+		var a float64
+		segments, a = appendLineSegments(segments, line, indexOffset, face, options)
+		segmentEnds = append(segmentEnds, len(segments))
 		advances = append(advances, a)
 		if longestAdvance < a {
 			longestAdvance = a
@@ -177,6 +364,7 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 			break
 		}
 		t = rest
+		indexOffset += len(line) + 1
 	}
 
 	d := face.direction()
@@ -228,12 +416,16 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 		}
 	}
 
-	var indexOffset int
-	var originX, originY float64
-	var i int
-	for t := text; ; {
-		line, rest, found := strings.Cut(t, "\n")
+	// l is the length of the rendering region in the primary direction.
+	// If l is 0, lines are aligned around the origin.
+	l := options.LineLength
+	if l < 0 {
+		l = 0
+	}
 
+	var originX, originY float64
+	var segmentStart int
+	for i := 0; i < lineCount; i++ {
 		// Adjust the origin position based on the primary alignments.
 		switch d {
 		case DirectionLeftToRight, DirectionRightToLeft:
@@ -241,29 +433,39 @@ func forEachLine(text string, face Face, options *LayoutOptions, f func(text str
 			case horizontalAlignLeft:
 				originX = 0
 			case horizontalAlignCenter:
-				originX = -advances[i] / 2
+				originX = (l - advances[i]) / 2
 			case horizontalAlignRight:
-				originX = -advances[i]
+				originX = l - advances[i]
 			}
 		case DirectionTopToBottomAndLeftToRight, DirectionTopToBottomAndRightToLeft:
 			switch v {
 			case verticalAlignTop:
 				originY = 0
 			case verticalAlignCenter:
-				originY = -advances[i] / 2
+				originY = (l - advances[i]) / 2
 			case verticalAlignBottom:
-				originY = -advances[i]
+				originY = l - advances[i]
 			}
 		}
 
-		f(line, indexOffset, originX+offsetX, originY+offsetY)
+		for _, seg := range segments[segmentStart:segmentEnds[i]] {
+			x, y := originX, originY
+			switch d {
+			case DirectionLeftToRight:
+				x += seg.position
+			case DirectionRightToLeft:
+				// The start of a line is the right edge.
+				x += advances[i] - seg.position - seg.advance
+			default:
+				y += seg.position
+			}
+			f(seg.text, seg.indexOffset, x+offsetX, y+offsetY)
+		}
+		segmentStart = segmentEnds[i]
 
-		if !found {
+		if i == lineCount-1 {
 			break
 		}
-		t = rest
-		indexOffset += len(line) + 1
-		i++
 
 		// Advance the origin position in the secondary direction.
 		switch face.direction() {
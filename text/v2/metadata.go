@@ -17,6 +17,7 @@ package text
 import (
 	"github.com/go-text/typesetting/opentype/api/metadata"
 	"github.com/go-text/typesetting/opentype/loader"
+	"github.com/go-text/typesetting/opentype/tables"
 )
 
 // Metadata represents a font face's metadata.
@@ -25,15 +26,29 @@ type Metadata struct {
 	Style   Style
 	Weight  Weight
 	Stretch Stretch
+
+	// PostScriptName is the PostScript name of the font face, e.g. "HelveticaNeue-Bold".
+	// PostScriptName is useful to find a face in a font collection.
+	PostScriptName string
 }
 
+// namePostScript is the name ID of the PostScript name in the 'name' table.
+const namePostScript tables.NameID = 6
+
 func metadataFromLoader(l *loader.Loader) Metadata {
-	f, a, _ := metadata.Describe(l, nil)
+	f, a, buf := metadata.Describe(l, nil)
+	var psName string
+	if buf, err := l.RawTableTo(loader.MustNewTag("name"), buf); err == nil {
+		if names, _, err := tables.ParseName(buf); err == nil {
+			psName = names.Name(namePostScript)
+		}
+	}
 	return Metadata{
-		Family:  f,
-		Style:   Style(a.Style),
-		Weight:  Weight(a.Weight),
-		Stretch: Stretch(a.Stretch),
+		Family:         f,
+		Style:          Style(a.Style),
+		Weight:         Weight(a.Weight),
+		Stretch:        Stretch(a.Stretch),
+		PostScriptName: psName,
 	}
 }
 
@@ -26,6 +26,12 @@ var _ Face = (*MultiFace)(nil)
 // MultiFace is a Face that consists of multiple Face objects.
 // The face in the first index is used in the highest priority, and the last the lowest priority.
 //
+// MultiFace works as a font fallback chain: for each rune, the first face that has a glyph for the rune is used.
+// If no face has a glyph for the rune, the last face is used.
+// This is useful to render text mixing scripts that a single font doesn't cover, e.g. Latin, CJK, and emoji.
+//
+// The metrics of MultiFace are the maximum values of the faces' metrics, so the line height is consistent across the faces.
+//
 // There is a known issue: if the writing directions of the faces don't agree, the rendering result might be messed up.
 type MultiFace struct {
 	faces []Face
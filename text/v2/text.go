@@ -138,6 +138,21 @@ type Glyph struct {
 	// The position is determined in a sequence of characters given at AppendGlyphs.
 	// The position's origin is the first character's origin position.
 	Y float64
+
+	// OriginX is the X position of the origin of this glyph, i.e. the pen position before this glyph is rendered.
+	// The glyph image might be rendered with an offset from the origin position.
+	// The position's origin is the first character's origin position.
+	OriginX float64
+
+	// OriginY is the Y position of the origin of this glyph, i.e. the pen position before this glyph is rendered.
+	// The glyph image might be rendered with an offset from the origin position.
+	// The position's origin is the first character's origin position.
+	OriginY float64
+
+	// Advance is the distance by which the pen position advances after rendering this glyph in the face's primary direction.
+	// For a right-to-left face, glyphs are arranged in the visual order and the pen position still advances rightward.
+	// Advance can be 0, e.g. for a zero-width combining mark.
+	Advance float64
 }
 
 // Advance returns the advanced distance from the origin position when rendering the given text with the given face.
@@ -186,18 +201,38 @@ func (d Direction) isHorizontal() bool {
 // With a horizontal direction face, the width is the longest line's advance, and the height is the total of line heights.
 // With a vertical direction face, the width and the height are calculated in an opposite manner.
 //
+// Measure doesn't treat tab stops. Use MeasureWithLayoutOptions to measure a text in the same layout as Draw.
+//
 // Measure is concurrent-safe.
 func Measure(text string, face Face, lineSpacingInPixels float64) (width, height float64) {
+	return MeasureWithLayoutOptions(text, face, &LayoutOptions{
+		LineSpacing: lineSpacingInPixels,
+	})
+}
+
+// MeasureWithLayoutOptions measures the boundary size of the text laid out with the given options.
+//
+// MeasureWithLayoutOptions works in the same way as Measure, but the tab stops are treated as Draw does,
+// and the boundary's length in the primary direction is at least options.LineLength.
+// The alignments don't affect the size.
+//
+// MeasureWithLayoutOptions is concurrent-safe.
+func MeasureWithLayoutOptions(text string, face Face, options *LayoutOptions) (width, height float64) {
 	if text == "" {
 		return 0, 0
 	}
+	if options == nil {
+		options = &LayoutOptions{}
+	}
 
 	var primary float64
 	var lineCount int
+	var segments []lineSegment
 	for t := text; ; {
 		lineCount++
 		line, rest, found := strings.Cut(t, "\n")
-		a := face.advance(line)
+		var a float64
+		segments, a = appendLineSegments(segments[:0], line, 0, face, options)
 		if primary < a {
 			primary = a
 		}
@@ -206,14 +241,17 @@ func Measure(text string, face Face, lineSpacingInPixels float64) (width, height
 		}
 		t = rest
 	}
+	if primary < options.LineLength {
+		primary = options.LineLength
+	}
 
 	m := face.Metrics()
 
 	if face.direction().isHorizontal() {
-		secondary := float64(lineCount-1)*lineSpacingInPixels + m.HAscent + m.HDescent
+		secondary := float64(lineCount-1)*options.LineSpacing + m.HAscent + m.HDescent
 		return primary, secondary
 	}
-	secondary := float64(lineCount-1)*lineSpacingInPixels + m.VAscent + m.VDescent
+	secondary := float64(lineCount-1)*options.LineSpacing + m.VAscent + m.VDescent
 	return secondary, primary
 }
 
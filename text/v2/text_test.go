@@ -15,6 +15,7 @@
 package text_test
 
 import (
+	"bytes"
 	"image"
 	"image/color"
 	"regexp"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/hajimehoshi/bitmapfont/v3"
 	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
 	"golang.org/x/image/math/fixed"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -356,3 +358,181 @@ func TestConvertToFloat(t *testing.T) {
 		}
 	}
 }
+
+func TestCaretPosition(t *testing.T) {
+	const sampleText = "ab\ncde"
+
+	f := text.NewStdFace(bitmapfont.Face)
+	op := &text.LayoutOptions{
+		LineSpacing: 16,
+	}
+	glyphs := text.AppendGlyphs(nil, sampleText, f, op)
+	if got, want := len(glyphs), 5; got != want {
+		t.Fatalf("len(glyphs): got: %d, want: %d", got, want)
+	}
+
+	for _, g := range glyphs {
+		x, y := text.CaretPosition(sampleText, g.StartIndexInBytes, f, op)
+		if x != g.OriginX || y != g.OriginY {
+			t.Errorf("CaretPosition(%d): got: (%f, %f), want: (%f, %f)", g.StartIndexInBytes, x, y, g.OriginX, g.OriginY)
+		}
+		if g.Advance <= 0 {
+			t.Errorf("glyphs[%d].Advance: got: %f, want: > 0", g.StartIndexInBytes, g.Advance)
+		}
+	}
+
+	// A caret at a line break or at the end of the text is at the end of the line.
+	for _, tc := range []struct {
+		index int
+		glyph text.Glyph
+	}{
+		{index: 2, glyph: glyphs[1]},
+		{index: len(sampleText), glyph: glyphs[4]},
+		{index: len(sampleText) + 1, glyph: glyphs[4]},
+	} {
+		x, y := text.CaretPosition(sampleText, tc.index, f, op)
+		if wantX, wantY := tc.glyph.OriginX+tc.glyph.Advance, tc.glyph.OriginY; x != wantX || y != wantY {
+			t.Errorf("CaretPosition(%d): got: (%f, %f), want: (%f, %f)", tc.index, x, y, wantX, wantY)
+		}
+	}
+
+	// A caret in an empty text is at the origin.
+	if x, y := text.CaretPosition("", 0, f, op); x != glyphs[0].OriginX || y != glyphs[0].OriginY {
+		t.Errorf("CaretPosition for an empty text: got: (%f, %f), want: (%f, %f)", x, y, glyphs[0].OriginX, glyphs[0].OriginY)
+	}
+}
+
+func TestGoTextFaceSourcePostScriptName(t *testing.T) {
+	s, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Metadata().PostScriptName, "GoRegular"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestTabStops(t *testing.T) {
+	const sampleText = "a\tb\tc"
+
+	f := text.NewStdFace(bitmapfont.Face)
+	op := &text.LayoutOptions{
+		TabStops: []float64{40},
+		TabWidth: 30,
+	}
+	glyphs := text.AppendGlyphs(nil, sampleText, f, op)
+	if got, want := len(glyphs), 3; got != want {
+		t.Fatalf("len(glyphs): got: %d, want: %d", got, want)
+	}
+	for i, want := range []float64{0, 40, 70} {
+		if got := glyphs[i].OriginX; got != want {
+			t.Errorf("glyphs[%d].OriginX: got: %f, want: %f", i, got, want)
+		}
+	}
+
+	// A caret after a tab is at the tab stop.
+	if x, _ := text.CaretPosition(sampleText, 2, f, op); x != 40 {
+		t.Errorf("CaretPosition(2): got: %f, want: %f", x, 40.0)
+	}
+	// A caret before a tab is at the end of the previous text.
+	if x, _ := text.CaretPosition(sampleText, 1, f, op); x != glyphs[0].Advance {
+		t.Errorf("CaretPosition(1): got: %f, want: %f", x, glyphs[0].Advance)
+	}
+}
+
+func TestMeasureWithTabStops(t *testing.T) {
+	const sampleText = "a\tb\nc"
+
+	f := text.NewStdFace(bitmapfont.Face)
+	op := &text.LayoutOptions{
+		LineSpacing: 20,
+		TabStops:    []float64{40},
+	}
+	glyphs := text.AppendGlyphs(nil, sampleText, f, op)
+	if got, want := len(glyphs), 3; got != want {
+		t.Fatalf("len(glyphs): got: %d, want: %d", got, want)
+	}
+
+	w, h := text.MeasureWithLayoutOptions(sampleText, f, op)
+	if got, want := w, glyphs[1].OriginX+glyphs[1].Advance; got != want {
+		t.Errorf("width: got: %f, want: %f", got, want)
+	}
+	m := f.Metrics()
+	if got, want := h, 20+m.HAscent+m.HDescent; got != want {
+		t.Errorf("height: got: %f, want: %f", got, want)
+	}
+
+	// The width is at least LineLength.
+	op.LineLength = 100
+	if got, _ := text.MeasureWithLayoutOptions(sampleText, f, op); got != 100 {
+		t.Errorf("width with LineLength: got: %f, want: %f", got, 100.0)
+	}
+}
+
+func TestLineLength(t *testing.T) {
+	const sampleText = "ab"
+
+	f := text.NewStdFace(bitmapfont.Face)
+	for _, tc := range []struct {
+		align text.Align
+		want  float64
+	}{
+		{align: text.AlignStart, want: 0},
+		{align: text.AlignCenter, want: 50},
+		{align: text.AlignEnd, want: 100},
+	} {
+		op := &text.LayoutOptions{
+			PrimaryAlign: tc.align,
+			LineLength:   100,
+		}
+		glyphs := text.AppendGlyphs(nil, sampleText, f, op)
+		if got, want := len(glyphs), 2; got != want {
+			t.Fatalf("len(glyphs): got: %d, want: %d", got, want)
+		}
+		start := glyphs[0].OriginX
+		end := glyphs[1].OriginX + glyphs[1].Advance
+		var got float64
+		switch tc.align {
+		case text.AlignStart:
+			got = start
+		case text.AlignCenter:
+			got = (start + end) / 2
+		case text.AlignEnd:
+			got = end
+		}
+		if got != tc.want {
+			t.Errorf("align: %d: got: %f, want: %f", tc.align, got, tc.want)
+		}
+	}
+}
+
+func TestGlyphCacheLimit(t *testing.T) {
+	defer text.SetGlyphCacheLimit(0)
+
+	f := text.NewStdFace(bitmapfont.Face)
+	dst := ebiten.NewImage(100, 100)
+	text.Draw(dst, "abcdefg", f, nil)
+
+	var stats text.GlyphCacheStats
+	text.ReadGlyphCacheStats(&stats)
+	if stats.GlyphCount == 0 || stats.ByteSize == 0 {
+		t.Fatalf("got: %+v, want: non-zero stats", stats)
+	}
+
+	// The limit is applied immediately, and the least recently used glyphs are evicted.
+	text.SetGlyphCacheLimit(1)
+	text.ReadGlyphCacheStats(&stats)
+	if got, want := stats.GlyphCount, 0; got != want {
+		t.Errorf("GlyphCount: got: %d, want: %d", got, want)
+	}
+	if got, want := stats.ByteSize, int64(0); got != want {
+		t.Errorf("ByteSize: got: %d, want: %d", got, want)
+	}
+
+	// The glyph being rendered is kept even if the limit is exceeded.
+	text.Draw(dst, "abcdefg", f, nil)
+	text.ReadGlyphCacheStats(&stats)
+	if got, want := stats.GlyphCount, 1; got != want {
+		t.Errorf("GlyphCount: got: %d, want: %d", got, want)
+	}
+}
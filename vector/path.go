@@ -304,6 +304,12 @@ func (p *Path) ArcTo(x1, y1, x2, y2, radius float32) {
 
 // Arc adds an arc to the path.
 // (x, y) is the center of the arc.
+//
+// The angles are in radians, where 0 is the positive X direction.
+// As the Y axis points downward, Clockwise increases the angle.
+// The arc is tessellated adaptively, so the number of segments increases as the radius increases.
+//
+// Arc adds a line from the current position to the start point of the arc, if the path has a current position.
 func (p *Path) Arc(x, y, radius, startAngle, endAngle float32, dir Direction) {
 	// Adjust the angles.
 	var da float64
@@ -379,6 +385,17 @@ func (p *Path) Arc(x, y, radius, startAngle, endAngle float32, dir Direction) {
 	p.CubicTo(cx0, cy0, cx1, cy1, x1, y1)
 }
 
+// Pie adds a closed pie-slice shape to the path as a new subpath.
+// (x, y) is the center of the pie.
+//
+// The pie consists of a line from the center to the start point of the arc, the arc, and a line back to the center.
+// See Arc for the angles and the direction.
+func (p *Path) Pie(x, y, radius, startAngle, endAngle float32, dir Direction) {
+	p.MoveTo(x, y)
+	p.Arc(x, y, radius, startAngle, endAngle, dir)
+	p.Close()
+}
+
 // Close adds a new line from the last position of the current subpath to the first position of the current subpath,
 // and marks the current subpath closed.
 // Following operations for this path will start with a new subpath.
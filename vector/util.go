@@ -15,9 +15,11 @@
 package vector
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"math"
+	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -54,10 +56,43 @@ func drawVerticesForUtil(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr
 	dst.DrawTriangles(vs, is, whiteSubImage, op)
 }
 
+// lineShaderSrc is a shader to render an antialiased line.
+//
+// srcPos is a position in the line's local coordinate, where the origin is the center of the line,
+// the X axis is along the line, and the Y axis is across the line.
+// The coverage of a pixel is calculated as the overlap of the pixel box and the line rectangle in each axis.
+const lineShaderSrc = `//kage:unit pixels
+
+package main
+
+var HalfLength float
+var HalfWidth float
+
+func coverage(x float, halfSize float) float {
+	return max(min(x+0.5, halfSize)-max(x-0.5, -halfSize), 0)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color * coverage(srcPos.x, HalfLength) * coverage(srcPos.y, HalfWidth)
+}
+`
+
+var (
+	lineShader     *ebiten.Shader
+	lineShaderOnce sync.Once
+)
+
 // StrokeLine strokes a line (x0, y0)-(x1, y1) with the specified width and color.
 //
+// If antialias is true, the edges are rendered based on the pixel coverage, so even thin lines at arbitrary angles look smooth.
+//
 // clr has be to be a solid (non-transparent) color.
 func StrokeLine(dst *ebiten.Image, x0, y0, x1, y1 float32, strokeWidth float32, clr color.Color, antialias bool) {
+	if antialias {
+		strokeAntialiasedLine(dst, x0, y0, x1, y1, strokeWidth, clr)
+		return
+	}
+
 	var path Path
 	path.MoveTo(x0, y0)
 	path.LineTo(x1, y1)
@@ -68,6 +103,53 @@ func StrokeLine(dst *ebiten.Image, x0, y0, x1, y1 float32, strokeWidth float32,
 	drawVerticesForUtil(dst, vs, is, clr, antialias)
 }
 
+func strokeAntialiasedLine(dst *ebiten.Image, x0, y0, x1, y1 float32, strokeWidth float32, clr color.Color) {
+	lineShaderOnce.Do(func() {
+		s, err := ebiten.NewShader([]byte(lineShaderSrc))
+		if err != nil {
+			panic(fmt.Sprintf("vector: NewShader for the line shader failed: %v", err))
+		}
+		lineShader = s
+	})
+
+	dx, dy := x1-x0, y1-y0
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	// ux and uy are the unit vector along the line.
+	ux, uy := float32(1), float32(0)
+	if length > 0 {
+		ux, uy = dx/length, dy/length
+	}
+	hl := length / 2
+	hw := strokeWidth / 2
+	cx, cy := (x0+x1)/2, (y0+y1)/2
+
+	// Extend the quad by 1 pixel in each direction to cover the partially covered pixels.
+	el, ew := hl+1, hw+1
+
+	r, g, b, a := clr.RGBA()
+	var vs [4]ebiten.Vertex
+	for i, s := range [4][2]float32{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+		lx, ly := s[0]*el, s[1]*ew
+		vs[i] = ebiten.Vertex{
+			DstX:   cx + lx*ux - ly*uy,
+			DstY:   cy + lx*uy + ly*ux,
+			SrcX:   lx,
+			SrcY:   ly,
+			ColorR: float32(r) / 0xffff,
+			ColorG: float32(g) / 0xffff,
+			ColorB: float32(b) / 0xffff,
+			ColorA: float32(a) / 0xffff,
+		}
+	}
+
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Uniforms = map[string]any{
+		"HalfLength": hl,
+		"HalfWidth":  hw,
+	}
+	dst.DrawTrianglesShader(vs[:], []uint16{0, 1, 2, 1, 2, 3}, lineShader, op)
+}
+
 // DrawFilledRect fills a rectangle with the specified width and color.
 func DrawFilledRect(dst *ebiten.Image, x, y, width, height float32, clr color.Color, antialias bool) {
 	var path Path
@@ -16,6 +16,7 @@ package vector_test
 
 import (
 	"image/color"
+	"math"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -35,3 +36,52 @@ func TestLine0(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestLineAntialiasCoverage(t *testing.T) {
+	dst := ebiten.NewImage(16, 16)
+	vector.StrokeLine(dst, 0, 2.5, 16, 2.5, 1, color.White, true)
+	vector.StrokeLine(dst, 0, 8, 16, 8, 1, color.White, true)
+	for _, tc := range []struct {
+		y    int
+		want color.RGBA
+	}{
+		{y: 1, want: color.RGBA{}},
+		{y: 2, want: color.RGBA{0xff, 0xff, 0xff, 0xff}},
+		{y: 3, want: color.RGBA{}},
+		// The line at y=8 covers the half of the pixels at y=7 and y=8.
+		{y: 7, want: color.RGBA{0x80, 0x80, 0x80, 0x80}},
+		{y: 8, want: color.RGBA{0x80, 0x80, 0x80, 0x80}},
+	} {
+		if got := dst.At(8, tc.y); got != tc.want {
+			t.Errorf("dst.At(8, %d): got: %v, want: %v", tc.y, got, tc.want)
+		}
+	}
+}
+
+func TestPie(t *testing.T) {
+	dst := ebiten.NewImage(16, 16)
+
+	// Fill the bottom-right quarter of a circle.
+	var path vector.Path
+	path.Pie(8, 8, 6, 0, math.Pi/2, vector.Clockwise)
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	src := ebiten.NewImage(1, 1)
+	src.Fill(color.White)
+	op := &ebiten.DrawTrianglesOptions{}
+	op.FillRule = ebiten.NonZero
+	dst.DrawTriangles(vs, is, src, op)
+
+	for _, tc := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{x: 10, y: 10, want: color.RGBA{0xff, 0xff, 0xff, 0xff}},
+		{x: 5, y: 10, want: color.RGBA{}},
+		{x: 10, y: 5, want: color.RGBA{}},
+		{x: 5, y: 5, want: color.RGBA{}},
+	} {
+		if got := dst.At(tc.x, tc.y); got != tc.want {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
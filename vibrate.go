@@ -70,7 +70,8 @@ type VibrateGamepadOptions struct {
 
 // VibrateGamepad vibrates the specified gamepad with the specified options.
 //
-// VibrateGamepad works only on browsers and Nintendo Switch so far.
+// VibrateGamepad works only on browsers, Nintendo Switch, and Windows with XInput gamepads so far.
+// Use IsGamepadVibrationSupported to check whether the gamepad can vibrate.
 //
 // VibrateGamepad is concurrent-safe.
 func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
@@ -80,3 +81,98 @@ func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
 	}
 	g.Vibrate(options.Duration, options.StrongMagnitude, options.WeakMagnitude)
 }
+
+// IsGamepadVibrationSupported reports whether the specified gamepad supports vibration by VibrateGamepad.
+//
+// IsGamepadVibrationSupported returns false if the gamepad doesn't exist.
+//
+// IsGamepadVibrationSupported is concurrent-safe.
+func IsGamepadVibrationSupported(gamepadID GamepadID) bool {
+	g := gamepad.Get(gamepadID)
+	if g == nil {
+		return false
+	}
+	return g.IsVibrationSupported()
+}
+
+// SetGamepadLightColor sets the color of the light bar of the specified gamepad.
+// r, g, and b are the color components of the light.
+//
+// SetGamepadLightColor works only on macOS and Windows with DualShock 4 and DualSense controllers so far.
+// Use IsGamepadLightSupported to check whether the gamepad has a light that can be controlled.
+//
+// If the gamepad doesn't have a light, SetGamepadLightColor does nothing.
+//
+// SetGamepadLightColor is concurrent-safe.
+func SetGamepadLightColor(gamepadID GamepadID, r, g, b uint8) {
+	gp := gamepad.Get(gamepadID)
+	if gp == nil {
+		return
+	}
+	gp.SetLightColor(r, g, b)
+}
+
+// IsGamepadLightSupported reports whether the specified gamepad has a light that can be controlled by SetGamepadLightColor.
+//
+// IsGamepadLightSupported returns false if the gamepad doesn't exist.
+//
+// IsGamepadLightSupported is concurrent-safe.
+func IsGamepadLightSupported(gamepadID GamepadID) bool {
+	gp := gamepad.Get(gamepadID)
+	if gp == nil {
+		return false
+	}
+	return gp.IsLightSupported()
+}
+
+// IsGamepadMotionSupported reports whether the specified gamepad provides motion data by GamepadGyroscope and GamepadAccelerometer.
+//
+// Motion data is available only on macOS and Windows with DualShock 4 and DualSense controllers so far.
+// IsGamepadMotionSupported returns false until the first motion data is received from the gamepad.
+// With Bluetooth, a controller might not send motion data until the controller is requested to send full reports,
+// e.g. by SetGamepadLightColor.
+//
+// IsGamepadMotionSupported returns false if the gamepad doesn't exist.
+//
+// IsGamepadMotionSupported is concurrent-safe.
+func IsGamepadMotionSupported(gamepadID GamepadID) bool {
+	gp := gamepad.Get(gamepadID)
+	if gp == nil {
+		return false
+	}
+	return gp.IsMotionSupported()
+}
+
+// GamepadGyroscope returns the angular velocity of the specified gamepad in radians per second.
+//
+// The axes are in the gamepad's own coordinate, and the values are not calibrated.
+// A gamepad at rest might report small non-zero values.
+//
+// GamepadGyroscope returns (0, 0, 0) if the gamepad doesn't exist or doesn't support motion data.
+// Use IsGamepadMotionSupported to check whether motion data is available.
+//
+// GamepadGyroscope is concurrent-safe.
+func GamepadGyroscope(gamepadID GamepadID) (x, y, z float64) {
+	gp := gamepad.Get(gamepadID)
+	if gp == nil {
+		return 0, 0, 0
+	}
+	return gp.Gyroscope()
+}
+
+// GamepadAccelerometer returns the acceleration of the specified gamepad in meters per second squared.
+// The acceleration includes the gravity, so a gamepad at rest reports about 9.8 in the direction opposite to the gravity.
+//
+// The axes are in the gamepad's own coordinate, and the values are not calibrated.
+//
+// GamepadAccelerometer returns (0, 0, 0) if the gamepad doesn't exist or doesn't support motion data.
+// Use IsGamepadMotionSupported to check whether motion data is available.
+//
+// GamepadAccelerometer is concurrent-safe.
+func GamepadAccelerometer(gamepadID GamepadID) (x, y, z float64) {
+	gp := gamepad.Get(gamepadID)
+	if gp == nil {
+		return 0, 0, 0
+	}
+	return gp.Accelerometer()
+}
@@ -16,6 +16,7 @@ package ebiten
 
 import (
 	"image"
+	"math"
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -42,6 +43,49 @@ const (
 	WindowResizingModeEnabled WindowResizingModeType = ui.WindowResizingModeEnabled
 )
 
+// WindowHitTestResult represents how a position in the window is treated by the operating system.
+type WindowHitTestResult = ui.WindowHitTestResult
+
+// WindowHitTestResults
+const (
+	// WindowHitTestResultClient indicates a regular position in the window.
+	// Inputs at the position are sent to the game as usual.
+	WindowHitTestResultClient WindowHitTestResult = ui.WindowHitTestResultClient
+
+	// WindowHitTestResultCaption indicates a position treated as a title bar.
+	// A user can drag the window at the position.
+	WindowHitTestResultCaption WindowHitTestResult = ui.WindowHitTestResultCaption
+
+	// WindowHitTestResultResize* indicate positions treated as resizing borders or corners of the window.
+	// A user can resize the window at the position when the window is resizable.
+	WindowHitTestResultResizeLeft        WindowHitTestResult = ui.WindowHitTestResultResizeLeft
+	WindowHitTestResultResizeRight       WindowHitTestResult = ui.WindowHitTestResultResizeRight
+	WindowHitTestResultResizeTop         WindowHitTestResult = ui.WindowHitTestResultResizeTop
+	WindowHitTestResultResizeBottom      WindowHitTestResult = ui.WindowHitTestResultResizeBottom
+	WindowHitTestResultResizeTopLeft     WindowHitTestResult = ui.WindowHitTestResultResizeTopLeft
+	WindowHitTestResultResizeTopRight    WindowHitTestResult = ui.WindowHitTestResultResizeTopRight
+	WindowHitTestResultResizeBottomLeft  WindowHitTestResult = ui.WindowHitTestResultResizeBottomLeft
+	WindowHitTestResultResizeBottomRight WindowHitTestResult = ui.WindowHitTestResultResizeBottomRight
+)
+
+// SetWindowHitTest sets a function to determine how a position in the window is treated by the operating system.
+//
+// SetWindowHitTest is useful for an undecorated window with a custom title bar drawn by the game.
+// f is called with a position in the same coordinate as CursorPosition,
+// and returns e.g. WindowHitTestResultCaption to make the window draggable at the position.
+// If f is nil, which is the default, all the positions are treated as WindowHitTestResultClient.
+//
+// f is called from a different goroutine than the game's Update, and must be concurrent-safe.
+// f should return as soon as possible, as f is called whenever the cursor moves on the window.
+//
+// SetWindowHitTest works only on Windows so far.
+// On the other platforms, SetWindowHitTest does nothing.
+//
+// SetWindowHitTest is concurrent-safe.
+func SetWindowHitTest(f func(x, y int) WindowHitTestResult) {
+	ui.Get().Window().SetHitTest(f)
+}
+
 // IsWindowDecorated reports whether the window is decorated.
 //
 // IsWindowDecorated is concurrent-safe.
@@ -130,6 +174,24 @@ func SetWindowIcon(iconImages []image.Image) {
 	ui.Get().Window().SetIcon(iconImages)
 }
 
+// SetWindowIconFromEbitenImages sets the icon of the game window with Ebitengine images.
+//
+// SetWindowIconFromEbitenImages works in the same way as SetWindowIcon.
+// Specify multiple sizes like 16x16, 32x32, and 48x48 so that the size closest to the one desired by the system is selected.
+// The pixels are read from the images later in the main loop, so the images must not be deallocated until the icon is updated.
+// Sub-images are also available for icons.
+//
+// If len(iconImages) is 0, SetWindowIconFromEbitenImages reverts the icon to the default one.
+//
+// SetWindowIconFromEbitenImages is concurrent-safe.
+func SetWindowIconFromEbitenImages(iconImages []*Image) {
+	imgs := make([]image.Image, 0, len(iconImages))
+	for _, img := range iconImages {
+		imgs = append(imgs, img)
+	}
+	SetWindowIcon(imgs)
+}
+
 // WindowPosition returns the window position.
 // The origin position is the upper-left corner of the current monitor.
 // The unit is device-independent pixels.
@@ -159,6 +221,44 @@ func SetWindowPosition(x, y int) {
 	ui.Get().Window().SetPosition(x, y)
 }
 
+// WindowPositionInVirtualScreen returns the window position in the virtual screen coordinate.
+//
+// The virtual screen coordinate is the same coordinate as (*MonitorType).Bounds,
+// whose origin is the upper-left corner of the primary monitor.
+// Each monitor's region in the virtual screen is the one reported by (*MonitorType).Bounds,
+// and a position in a monitor is in the device-independent pixels of the monitor.
+// Then, the coordinate doesn't depend on the current monitor, unlike WindowPosition.
+//
+// WindowPositionInVirtualScreen is useful to save and restore the window placement with SetWindowPositionInVirtualScreen.
+//
+// WindowPositionInVirtualScreen panics if the main loop does not start yet.
+//
+// WindowPositionInVirtualScreen returns the original window position in fullscreen mode.
+//
+// WindowPositionInVirtualScreen returns (0, 0) if the platform is not a desktop.
+//
+// WindowPositionInVirtualScreen is concurrent-safe.
+func WindowPositionInVirtualScreen() (x, y int) {
+	return ui.Get().Window().PositionInVirtualScreen()
+}
+
+// SetWindowPositionInVirtualScreen sets the window position in the virtual screen coordinate.
+// See WindowPositionInVirtualScreen for the virtual screen coordinate.
+//
+// The window is moved to the monitor that includes the position.
+// If no monitor includes the position, the position is converted with the current monitor's device scale factor,
+// and might be adjusted so that the window is visible.
+//
+// SetWindowPositionInVirtualScreen sets the original window position in fullscreen mode.
+//
+// SetWindowPositionInVirtualScreen does nothing if the platform is not a desktop.
+//
+// SetWindowPositionInVirtualScreen is concurrent-safe.
+func SetWindowPositionInVirtualScreen(x, y int) {
+	atomic.StoreUint32(&windowPositionSetExplicitly, 1)
+	ui.Get().Window().SetPositionInVirtualScreen(x, y)
+}
+
 var (
 	windowPositionSetExplicitly uint32
 )
@@ -197,6 +297,26 @@ func SetWindowSize(width, height int) {
 	ui.Get().Window().SetSize(width, height)
 }
 
+// SetWindowResizeCallback sets a function called immediately when the window is resized on desktops.
+//
+// f is called with the new size of the window's client area in device-independent pixels, the same unit as WindowSize.
+// f is called also while the user is dragging the window border, then f is useful for e.g. debouncing expensive work for a new layout.
+// f is not called when the window is minimized, or when the size is not changed.
+// In fullscreen mode, f is called with the size of the fullscreen.
+// If f is nil, which is the default, nothing is called.
+//
+// f is called on the main thread while the window events are processed, and must be concurrent-safe.
+// f must not call the functions to query or modify the window like WindowSize, or they might block forever.
+// Use the given width and height instead.
+//
+// SetWindowResizeCallback works only on desktops.
+// On the other platforms, SetWindowResizeCallback does nothing.
+//
+// SetWindowResizeCallback is concurrent-safe.
+func SetWindowResizeCallback(f func(width, height int)) {
+	ui.Get().Window().SetResizeCallback(f)
+}
+
 // WindowSizeLimits returns the limitation of the window size on desktops.
 // A negative value indicates the size is not limited.
 //
@@ -213,6 +333,36 @@ func SetWindowSizeLimits(minw, minh, maxw, maxh int) {
 	ui.Get().Window().SetSizeLimits(minw, minh, maxw, maxh)
 }
 
+// WindowAspectRatio returns the aspect ratio limitation of the window on desktops.
+// Negative values indicate the aspect ratio is not limited.
+//
+// WindowAspectRatio is concurrent-safe.
+func WindowAspectRatio() (numer, denom int) {
+	return ui.Get().Window().AspectRatio()
+}
+
+// SetWindowAspectRatio sets the aspect ratio limitation of the window on desktops.
+// The ratio is numer:denom, e.g. SetWindowAspectRatio(16, 9) for 16:9.
+// While the user resizes the window, the window keeps the aspect ratio of the client area.
+//
+// If both numer and denom are negative, the aspect ratio is not limited. This is the default.
+// If one of numer and denom is zero, or only one of them is negative, SetWindowAspectRatio panics.
+//
+// SetWindowAspectRatio is useful with WindowResizingModeEnabled to keep the layout of the game usable.
+// The aspect ratio limitation works together with the size limits by SetWindowSizeLimits.
+//
+// SetWindowAspectRatio is concurrent-safe.
+func SetWindowAspectRatio(numer, denom int) {
+	if numer < 0 && denom < 0 {
+		ui.Get().Window().SetAspectRatio(-1, -1)
+		return
+	}
+	if numer <= 0 || denom <= 0 {
+		panic("ebiten: numer and denom must be both positive or both negative")
+	}
+	ui.Get().Window().SetAspectRatio(numer, denom)
+}
+
 // IsWindowFloating reports whether the window is always shown above all the other windows.
 //
 // IsWindowFloating returns false if the platform is not a desktop.
@@ -341,3 +491,40 @@ func SetWindowMousePassthrough(enabled bool) {
 func IsWindowMousePassthrough() bool {
 	return ui.Get().Window().IsMousePassthrough()
 }
+
+// SetWindowOpacity sets the opacity of the whole window including its decorations on desktops.
+// The default opacity is 1.
+//
+// opacity is clamped to [0, 1], where 0 is fully transparent and 1 is fully opaque.
+//
+// SetWindowOpacity is different from the screen transparency by RunGameOptions.ScreenTransparent,
+// which makes transparent pixels of the screen transparent.
+// SetWindowOpacity is useful e.g. to fade in or fade out the whole window.
+// Using both SetWindowOpacity and the screen transparency might not work on some platforms.
+//
+// SetWindowOpacity works only on desktops.
+// On Wayland, SetWindowOpacity does nothing.
+// SetWindowOpacity does nothing if the platform is not a desktop.
+//
+// SetWindowOpacity is concurrent-safe.
+func SetWindowOpacity(opacity float64) {
+	if math.IsNaN(opacity) {
+		return
+	}
+	if opacity < 0 {
+		opacity = 0
+	}
+	if opacity > 1 {
+		opacity = 1
+	}
+	ui.Get().Window().SetOpacity(opacity)
+}
+
+// WindowOpacity returns the opacity of the whole window on desktops.
+//
+// WindowOpacity always returns 1 if the platform is not a desktop or doesn't support the window opacity.
+//
+// WindowOpacity is concurrent-safe.
+func WindowOpacity() float64 {
+	return ui.Get().Window().Opacity()
+}